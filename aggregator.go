@@ -0,0 +1,216 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// AggregatorOptions are the options of the mAPI aggregator (see: Client.AggregatorHandler)
+type AggregatorOptions struct {
+	FeeCategory string // Category of the best quote (defaults to FeeCategoryMining)
+	FeeType     string // Type of the best quote (defaults to FeeTypeStandard)
+	MaxBodySize int64  // Max size of a submission (defaults to DefaultCallbackMaxBodySize)
+}
+
+// aggregatorEnvelope is the mAPI envelope of a response (the signed envelope of the miner, without custom fields)
+type aggregatorEnvelope struct {
+	Encoding  string  `json:"encoding"`
+	MimeType  string  `json:"mimetype"`
+	Payload   string  `json:"payload"`
+	PublicKey *string `json:"publicKey"`
+	Signature *string `json:"signature"`
+}
+
+// AggregatorHandler will return an http handler that exposes a single mAPI endpoint backed by all miners of the
+// client, so a wallet that supports one mAPI url gains the resilience of all miners:
+//   - GET /mapi/feeQuote: the best quote (see: CheapestMiners)
+//   - POST /mapi/tx: broadcasts the transaction to all miners (a success of any miner is returned)
+//   - GET /mapi/tx/{txid}: queries all miners (the most advanced status is returned, IE: mined)
+//
+// The responses are the signed envelopes of the miners (the publicKey is the key of the miner that responded),
+// submissions are accepted as JSON (see: Transaction) or as binary (application/octet-stream)
+func (c *Client) AggregatorHandler(options *AggregatorOptions) http.Handler {
+	if options == nil {
+		options = new(AggregatorOptions)
+	}
+	aggregator := *options
+	if len(aggregator.FeeCategory) == 0 {
+		aggregator.FeeCategory = FeeCategoryMining
+	}
+	if len(aggregator.FeeType) == 0 {
+		aggregator.FeeType = FeeTypeStandard
+	}
+	if aggregator.MaxBodySize <= 0 {
+		aggregator.MaxBodySize = DefaultCallbackMaxBodySize
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(routeFeeQuote, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeAggregatorError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		quotes, err := c.CheapestMiners(1, aggregator.FeeCategory, aggregator.FeeType)
+		if err != nil {
+			writeAggregatorError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+		writeAggregatorEnvelope(w, &quotes[0].JSONEnvelope)
+	})
+	mux.HandleFunc(routeSubmitTx, func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			writeAggregatorError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		tx, err := readAggregatorTx(req, aggregator.MaxBodySize)
+		if err != nil {
+			writeAggregatorError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		var response *SubmitTransactionResponse
+		if response, err = c.broadcastAll(req.Context(), tx); err != nil {
+			writeAggregatorError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+		writeAggregatorEnvelope(w, &response.JSONEnvelope)
+	})
+	mux.HandleFunc(routeQueryTx+"/", func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet {
+			writeAggregatorError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		response, err := c.queryAll(req.Context(), strings.TrimPrefix(req.URL.Path, routeQueryTx+"/"))
+		if err != nil {
+			writeAggregatorError(w, http.StatusBadGateway, err.Error())
+			return
+		}
+		writeAggregatorEnvelope(w, &response.JSONEnvelope)
+	})
+	return mux
+}
+
+// broadcastAll will submit the transaction to all miners and return the first success
+// (or the first rejection if no miner accepted the transaction, preferring a miner that already has it)
+//
+// A failed miner does not cancel the other submissions (the transaction is sent to every miner), the submissions
+// are cancelled with the context (IE: the client of the aggregator went away)
+func (c *Client) broadcastAll(ctx context.Context, tx *Transaction) (*SubmitTransactionResponse, error) {
+	responses := make([]*SubmitTransactionResponse, len(c.Miners))
+	errs := make([]error, len(c.Miners))
+
+	group, ctx := c.fanOut(ctx)
+	for index, miner := range c.Miners {
+		index, miner := index, miner
+		group.Go(func() error {
+			if errs[index] = c.checkSubmission(ctx, miner, tx); errs[index] == nil {
+				responses[index], errs[index] = c.submissionResult(submitTransaction(ctx, c, miner, tx), tx)
+			}
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	var rejected *SubmitTransactionResponse
+	for _, response := range responses {
+		if response == nil || response.Results == nil {
+			continue
+		} else if outcome := response.Outcome(); outcome == SubmitAccepted {
+			return response, nil
+		} else if rejected == nil || (outcome == SubmitAlreadyKnown && rejected.Outcome() != SubmitAlreadyKnown) {
+			rejected = response
+		}
+	}
+	if rejected != nil {
+		return rejected, nil
+	}
+	if err := firstError(errs...); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("failed submitting to all miners")
+}
+
+// queryAll will query the transaction on all miners and return the most advanced status
+// (mined with the most confirmations, then known, then the first failure)
+func (c *Client) queryAll(ctx context.Context, txID string) (*QueryTransactionResponse, error) {
+	responses := make([]*QueryTransactionResponse, len(c.Miners))
+	errs := make([]error, len(c.Miners))
+
+	group, ctx := c.fanOut(ctx)
+	for index, miner := range c.Miners {
+		index, miner := index, miner
+		group.Go(func() error {
+			responses[index], errs[index] = c.queryTransactionStatus(ctx, miner, txID)
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	var best *QueryTransactionResponse
+	for _, response := range responses {
+		if response != nil && response.Query != nil && (best == nil || queryRank(response.Query) > queryRank(best.Query)) {
+			best = response
+		}
+	}
+	if best == nil {
+		if err := firstError(errs...); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("failed querying all miners")
+	}
+	return best, nil
+}
+
+// queryRank will rank the status of a query (a higher rank is more advanced)
+func queryRank(query *QueryPayload) int64 {
+	if len(query.BlockHash) > 0 {
+		return 2 + query.Confirmations
+	} else if query.ReturnResult == ReturnResultSuccess {
+		return 1
+	}
+	return 0
+}
+
+// readAggregatorTx will read a submission (JSON or binary)
+func readAggregatorTx(req *http.Request, maxBodySize int64) (*Transaction, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxBodySize+1))
+	if err != nil {
+		return nil, err
+	} else if int64(len(body)) > maxBodySize {
+		return nil, ErrTxTooLarge
+	}
+
+	tx := new(Transaction)
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/octet-stream") {
+		tx.RawTx = hex.EncodeToString(body)
+	} else if err = json.Unmarshal(body, tx); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// writeAggregatorEnvelope will write the signed envelope of a miner
+func writeAggregatorEnvelope(w http.ResponseWriter, envelope *JSONEnvelope) {
+	response := &aggregatorEnvelope{
+		Encoding: envelope.Encoding,
+		MimeType: envelope.MimeType,
+		Payload:  envelope.Payload,
+	}
+	if len(envelope.PublicKey) > 0 {
+		response.PublicKey, response.Signature = &envelope.PublicKey, &envelope.Signature
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// writeAggregatorError will write an error response
+func writeAggregatorError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"status": status, "title": message})
+}
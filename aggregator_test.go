@@ -0,0 +1,68 @@
+package minercraft
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// mockHTTPAggregator for mocking requests (one miner rejects the submissions, one miner knows the tx was mined)
+type mockHTTPAggregator struct{}
+
+// Do is a mock http request
+func (m *mockHTTPAggregator) Do(req *http.Request) (*http.Response, error) {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/mapi/feeQuote"):
+		return (&mockHTTPValidBestQuote{}).Do(req)
+	case req.Method == http.MethodPost && strings.Contains(req.URL.Host, "taal"):
+		return (&mockHTTPRejectedSubmission{}).Do(req)
+	case req.Method == http.MethodPost:
+		return (&mockHTTPValidSubmission{}).Do(req)
+	case strings.Contains(req.URL.Host, "matterpool"):
+		return (&mockHTTPValidQuery{}).Do(req)
+	}
+	return (&mockHTTPBadQuery{}).Do(req)
+}
+
+// TestClient_AggregatorHandler tests the method AggregatorHandler()
+func TestClient_AggregatorHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := newTestClient(&mockHTTPAggregator{}).AggregatorHandler(nil)
+
+	var tests = []struct {
+		method   string
+		path     string
+		body     string
+		expected int
+		payload  string
+	}{
+		{http.MethodGet, "/mapi/feeQuote", "", http.StatusOK, `\"standard\",\"miningFee\":{\"satoshis\":400`},
+		{http.MethodPost, "/mapi/tx", `{"rawtx":"` + testRawTx + `"}`, http.StatusOK, `\"returnResult\":\"success\"`},
+		{http.MethodPost, "/mapi/tx", `{"rawtx":`, http.StatusBadRequest, ""},
+		{http.MethodGet, "/mapi/tx/" + testTx, "", http.StatusOK, `\"confirmations\":43733`},
+		{http.MethodDelete, "/mapi/feeQuote", "", http.StatusMethodNotAllowed, ""},
+	}
+	for _, test := range tests {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(test.method, test.path, strings.NewReader(test.body))
+		req.Header.Set("Content-Type", "application/json")
+		handler.ServeHTTP(recorder, req)
+		if recorder.Code != test.expected || !strings.Contains(recorder.Body.String(), test.payload) {
+			t.Errorf("%s Failed: [%s %s] inputted and [%d %s] expected, received: [%d %s]",
+				t.Name(), test.method, test.path, test.expected, test.payload, recorder.Code, recorder.Body.String())
+		}
+	}
+
+	// The envelope of the miner is returned (without custom fields)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/mapi/tx/"+testTx, nil))
+	var envelope map[string]interface{}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &envelope); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if _, ok := envelope["miner"]; ok || envelope["publicKey"] == nil {
+		t.Fatalf("expected the signed envelope of the miner, got: %v", envelope)
+	}
+}
@@ -0,0 +1,96 @@
+package minercraft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+Example callback from an ARC gateway, posted to the callback url of the transaction:
+
+{
+  "timestamp": "2023-03-28T09:42:03.134Z",
+  "txid": "6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0",
+  "txStatus": "MINED",
+  "blockHash": "0000000000000000050a09fe90b0e8542bba9e712edb8cc9349e61888fe45ac5",
+  "blockHeight": 612530,
+  "merklePath": "fe8a6a0c000c04fde80b0011774f01d26412f0d16ea3f0447be0b5ebec67b0782e321a7a01cbdf7f734e30"
+}
+*/
+
+// ARCCallback is a callback from an ARC gateway (status changes, merkle proofs and double spend attempts)
+//
+// Specs: https://bitcoin-sv.github.io/arc/api.html
+type ARCCallback struct {
+	BlockHash    string   `json:"blockHash"`
+	BlockHeight  int64    `json:"blockHeight"`
+	CompetingTxs []string `json:"competingTxs,omitempty"` // The txids of the double spend attempts
+	ExtraInfo    string   `json:"extraInfo,omitempty"`    // The reason of a rejection
+	MerklePath   string   `json:"merklePath,omitempty"`   // The merkle proof of a mined transaction (BUMP hex)
+	Timestamp    string   `json:"timestamp"`
+	TxID         string   `json:"txid"`
+	TxStatus     string   `json:"txStatus"`
+}
+
+// ParseARCCallback will parse a callback posted by an ARC gateway and send the notifications
+// (see: SubscribeNotifications), the miner is the gateway that received the transaction (optional)
+//
+// ARC callbacks are not signed, use a callback token to authenticate the gateway
+func (c *Client) ParseARCCallback(miner *Miner, body []byte) (*ARCCallback, error) {
+	callback, err := decodeARCCallback(body)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, notification := range callback.notifications(miner, NotificationSourceARCCallback) {
+		c.notifications.publish(notification, c.now())
+	}
+	return callback, nil
+}
+
+// decodeARCCallback will decode (and validate) the callback
+func decodeARCCallback(body []byte) (*ARCCallback, error) {
+	callback := new(ARCCallback)
+	if err := json.Unmarshal(body, callback); err != nil {
+		return nil, err
+	} else if !IsValidTxID(callback.TxID) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTxID, callback.TxID)
+	} else if len(callback.TxStatus) == 0 {
+		return nil, errors.New("failed getting callback status")
+	}
+	return callback, nil
+}
+
+// notifications will return the notifications of the callback received from the source
+// (a merkle path is also a merkle proof notification)
+func (a *ARCCallback) notifications(miner *Miner, source string) []*Notification {
+	notification := &Notification{
+		BlockHash: a.BlockHash, BlockHeight: a.BlockHeight, Miner: miner, Source: source, TxID: a.TxID,
+	}
+
+	// Double spend attempts (the competing transactions are the payload)
+	if strings.EqualFold(a.TxStatus, "DOUBLE_SPEND_ATTEMPTED") {
+		payload, _ := json.Marshal(map[string][]string{"competingTxs": a.CompetingTxs})
+		notification.Payload, notification.Type = string(payload), NotificationDoubleSpendAttempt
+		return []*Notification{notification}
+	}
+
+	switch parseStreamTxStatus(a.TxStatus) {
+	case TxStatusMempool:
+		notification.Type = NotificationMempool
+	case TxStatusMined:
+		notification.Type = NotificationMined
+		if len(a.MerklePath) > 0 {
+			proof := *notification
+			proof.Payload, proof.Type = a.MerklePath, NotificationMerkleProof
+			return []*Notification{notification, &proof}
+		}
+	case TxStatusRejected:
+		notification.Payload, notification.Type = a.ExtraInfo, NotificationRejected
+	default:
+		return nil
+	}
+	return []*Notification{notification}
+}
@@ -2,54 +2,28 @@ package minercraft
 
 import (
 	"context"
-	"sync"
+	"errors"
 )
 
 // BestQuote will check all known miners and compare rates, returning the best rate/quote
 //
-// Note: this might return different results each time if miners have the same rates as
-// it's a race condition on which results come back first
+// The first miner that fails cancels the other requests (early exit). Miners with the same
+// rates are resolved by the order of the miners of the client
 func (c *Client) BestQuote(feeCategory, feeType string) (*FeeQuoteResponse, error) {
 
 	// Best rate & quote
 	var bestRate uint64
 	var bestQuote FeeQuoteResponse
 
-	// The channel for the internal results
-	resultsChannel := make(chan *internalResult, len(c.Miners))
-
-	// Create a context
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	// Loop each miner (break into a Go routine for each quote request)
-	var wg sync.WaitGroup
-	for _, miner := range c.Miners {
-		wg.Add(1)
-		go func(ctx context.Context, wg *sync.WaitGroup, client *Client, miner *Miner, resultsChannel chan *internalResult) {
-			defer wg.Done()
-			resultsChannel <- getQuote(ctx, client, miner)
-		}(ctx, &wg, c, miner, resultsChannel)
+	// Get the quotes of all miners
+	quotes, err := c.fetchValidQuotes(context.Background())
+	if err != nil {
+		return nil, err
 	}
 
-	// Waiting for all requests to finish
-	wg.Wait()
-	close(resultsChannel)
-
-	// Loop the results of the channel
+	// Loop the quotes
 	var testRate uint64
-	for result := range resultsChannel {
-
-		// Check for error?
-		if result.Response.Error != nil {
-			return nil, result.Response.Error
-		}
-
-		// Parse the response
-		quote, err := result.parseQuote()
-		if err != nil {
-			return nil, err
-		}
+	for _, quote := range quotes {
 
 		// Get a test rate
 		if testRate, err = quote.Quote.CalculateFee(feeCategory, feeType, 1000); err != nil {
@@ -59,10 +33,95 @@ func (c *Client) BestQuote(feeCategory, feeType string) (*FeeQuoteResponse, erro
 		// Never set (or better)
 		if bestRate == 0 || testRate < bestRate {
 			bestRate = testRate
-			bestQuote = quote
+			bestQuote = *quote
 		}
 	}
 
 	// Return the best quote found
 	return &bestQuote, nil
 }
+
+// BestQuoteForTx will check all known miners and return the quote with the lowest total
+// fee for the given transaction size breakdown (standard and data bytes), and the fee itself
+//
+// Use TxSizeFromTx() or TxSizeFromHex() to get the size breakdown of a transaction
+func (c *Client) BestQuoteForTx(feeCategory string, txSize *TxSize) (*FeeQuoteResponse, uint64, error) {
+
+	// Make sure we have a tx size (before requesting any quotes)
+	if txSize == nil {
+		return nil, 0, errors.New("tx size was nil")
+	}
+
+	// Best fee & quote
+	var bestFee uint64
+	var bestQuote FeeQuoteResponse
+
+	// Get the quotes of all miners
+	quotes, err := c.fetchValidQuotes(context.Background())
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Loop the quotes
+	var testFee uint64
+	for _, quote := range quotes {
+
+		// Get the fee for this specific tx
+		if testFee, err = quote.Quote.CalculateTxFee(feeCategory, txSize); err != nil {
+			return nil, 0, err
+		}
+
+		// Never set (or better)
+		if bestFee == 0 || testFee < bestFee {
+			bestFee = testFee
+			bestQuote = *quote
+		}
+	}
+
+	// Return the best quote found
+	return &bestQuote, bestFee, nil
+}
+
+// fetchAllQuotes will fire a quote request to all miners and return the results (in the order of the miners)
+//
+// Failed requests do not cancel the other requests (partial results, see: fetchValidQuotes)
+func (c *Client) fetchAllQuotes(ctx context.Context) []*internalResult {
+	results := make([]*internalResult, len(c.Miners))
+	group, ctx := c.fanOut(ctx)
+	for index, miner := range c.Miners {
+		index, miner := index, miner
+		group.Go(func() error {
+			results[index] = getQuote(ctx, c, miner)
+			return nil
+		})
+	}
+	_ = group.Wait()
+	return results
+}
+
+// fetchValidQuotes will fire a quote request to all miners and return the parsed quotes (in the order of the miners)
+//
+// The first request that fails (or returns an invalid quote) cancels the other requests and its error is returned
+func (c *Client) fetchValidQuotes(ctx context.Context) ([]*FeeQuoteResponse, error) {
+	quotes := make([]*FeeQuoteResponse, len(c.Miners))
+	group, ctx := c.fanOut(ctx)
+	for index, miner := range c.Miners {
+		index, miner := index, miner
+		group.Go(func() error {
+			result := getQuote(ctx, c, miner)
+			if result.Response.Error != nil {
+				return result.Response.Error
+			}
+			quote, err := result.parseQuote()
+			if err != nil {
+				return err
+			}
+			quotes[index] = &quote
+			return nil
+		})
+	}
+	if err := group.Wait(); err != nil {
+		return nil, err
+	}
+	return quotes, nil
+}
@@ -267,3 +267,90 @@ func BenchmarkClient_BestQuote(b *testing.B) {
 		_, _ = client.BestQuote(FeeCategoryMining, FeeTypeData)
 	}
 }
+
+// TestClient_BestQuoteForTx tests the method BestQuoteForTx()
+func TestClient_BestQuoteForTx(t *testing.T) {
+	t.Parallel()
+
+	// Create the list of tests
+	var tests = []struct {
+		inputSize     *TxSize
+		expectedMiner string
+		expectedFee   uint64
+		expectedError bool
+	}{
+		{&TxSize{StandardBytes: 200, DataBytes: 10000}, MinerMempool, 4300, false},
+		{&TxSize{StandardBytes: 10000, DataBytes: 100}, MinerMatterpool, 4043, false},
+		{nil, "", 0, true},
+	}
+
+	// Create a client
+	client := newTestClient(&mockHTTPValidBestQuote{})
+
+	// Run tests
+	for _, test := range tests {
+		if response, fee, err := client.BestQuoteForTx(FeeCategoryMining, test.inputSize); err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%v] inputted and error not expected but got: %s", t.Name(), test.inputSize, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%v] inputted and error was expected", t.Name(), test.inputSize)
+		} else if err == nil && response.Miner.Name != test.expectedMiner {
+			t.Errorf("%s Failed: [%v] inputted and [%s] expected but got: %s", t.Name(), test.inputSize, test.expectedMiner, response.Miner.Name)
+		} else if err == nil && fee != test.expectedFee {
+			t.Errorf("%s Failed: [%v] inputted and [%d] expected but got: %d", t.Name(), test.inputSize, test.expectedFee, fee)
+		}
+	}
+}
+
+// TestClient_BestQuoteForTxHTTPError tests the method BestQuoteForTx()
+func TestClient_BestQuoteForTxHTTPError(t *testing.T) {
+	t.Parallel()
+
+	// Create a client
+	client := newTestClient(&mockHTTPError{})
+
+	// Create a req
+	response, _, err := client.BestQuoteForTx(FeeCategoryMining, &TxSize{StandardBytes: 250})
+	if err == nil {
+		t.Fatalf("error should have occurred")
+	} else if response != nil {
+		t.Fatalf("expected response to be nil")
+	}
+}
+
+// TestClient_BestQuoteForTxNilSize tests the method BestQuoteForTx()
+func TestClient_BestQuoteForTxNilSize(t *testing.T) {
+	t.Parallel()
+
+	// No requests are made for an invalid tx size
+	mock := &mockHTTPCountingQuote{}
+	client := newTestClient(mock)
+	if _, _, err := client.BestQuoteForTx(FeeCategoryMining, nil); err == nil {
+		t.Fatalf("error should have occurred")
+	} else if mock.count() != 0 {
+		t.Fatalf("expected %d requests, got %d", 0, mock.count())
+	}
+}
+
+// ExampleClient_BestQuoteForTx example using BestQuoteForTx()
+func ExampleClient_BestQuoteForTx() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidBestQuote{})
+
+	// Create a req
+	response, fee, err := client.BestQuoteForTx(FeeCategoryMining, &TxSize{StandardBytes: 200, DataBytes: 10000})
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("best quote for tx is from: %s with a fee of: %d", response.Miner.Name, fee)
+	// Output:best quote for tx is from: Mempool with a fee of: 4300
+}
+
+// BenchmarkClient_BestQuoteForTx benchmarks the method BestQuoteForTx()
+func BenchmarkClient_BestQuoteForTx(b *testing.B) {
+	client := newTestClient(&mockHTTPValidBestQuote{})
+	for i := 0; i < b.N; i++ {
+		_, _, _ = client.BestQuoteForTx(FeeCategoryMining, &TxSize{StandardBytes: 200, DataBytes: 10000})
+	}
+}
@@ -0,0 +1,30 @@
+package minercraft
+
+import (
+	"bytes"
+	"sync"
+)
+
+// maxPooledBufferSize is the max capacity of a buffer that is returned to the pool (larger buffers are released)
+const maxPooledBufferSize = 1 << 20
+
+// bufferPool is the pool of buffers for reading response bodies (see: readBody)
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// getBuffer will return an empty buffer from the pool
+func getBuffer() *bytes.Buffer {
+	return bufferPool.Get().(*bytes.Buffer)
+}
+
+// putBuffer will reset the buffer and return it to the pool (unless it grew too large)
+func putBuffer(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBufferSize {
+		return
+	}
+	buf.Reset()
+	bufferPool.Put(buf)
+}
@@ -0,0 +1,122 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+/*
+Example callback from a miner (merkle proof or double spend), posted to the callBackUrl of the transaction:
+
+{
+  "payload": "{\"apiVersion\":\"1.2.0\",\"timestamp\":\"2020-11-03T13:24:31.233647Z\",\"blockHash\":\"34bbc00697512058cb040e1c7bbba5d03a2e94270093eb28114747a6f7507d48\",\"blockHeight\":5,\"callbackTxId\":\"5df2bcf9a1af9a5c0c1c1e8a38d0fa4f6e2bd32ef0a4f1a2c6f6e1c0bd3f5c2a\",\"callbackReason\":\"merkleProof\",\"callbackPayload\":\"{...}\",\"minerId\":\"030d1fe5c1b560efe196ba40540ce9017c20daa9504c4c4cec6184fc702d9f274e\"}",
+  "signature": "3045022100...",
+  "publicKey": "030d1fe5c1b560efe196ba40540ce9017c20daa9504c4c4cec6184fc702d9f274e",
+  "encoding": "UTF-8",
+  "mimetype": "application/json"
+}
+*/
+
+const (
+	// CallbackReasonDoubleSpend is the callback reason for a double spend
+	CallbackReasonDoubleSpend = "doubleSpend"
+
+	// CallbackReasonDoubleSpendAttempt is the callback reason for a double spend attempt
+	CallbackReasonDoubleSpendAttempt = "doubleSpendAttempt"
+
+	// CallbackReasonMerkleProof is the callback reason for a merkle proof
+	CallbackReasonMerkleProof = "merkleProof"
+)
+
+// ErrUntrustedCallback is returned when a callback is not signed (or the signature is invalid), or the signing key
+// is not a known key of a miner (a forged callback, see: Miner.MinerID)
+var ErrUntrustedCallback = errors.New("callback is not signed by a trusted miner")
+
+// CallbackResponse is a callback from a miner (merkle proof or double spend notification)
+//
+// Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi/tree/v1.2-beta#callback-notifications
+type CallbackResponse struct {
+	JSONEnvelope
+	Callback *CallbackPayload `json:"callback"` // Custom field for unmarshalled payload data
+}
+
+// CallbackPayload is the unmarshalled version of the callback payload envelope
+type CallbackPayload struct {
+	APIVersion      string `json:"apiVersion"`
+	Timestamp       string `json:"timestamp"`
+	MinerID         string `json:"minerId"`
+	BlockHash       string `json:"blockHash"`
+	BlockHeight     uint64 `json:"blockHeight"`
+	CallbackTxID    string `json:"callbackTxId"`
+	CallbackReason  string `json:"callbackReason"`
+	CallbackPayload string `json:"callbackPayload"` // Merkle proof or double spend details (JSON)
+}
+
+// ParseCallback will parse (and validate the signature of) a callback posted by a miner,
+// fire the callback hooks (see: OnCallback) and send the notifications (see: SubscribeNotifications)
+//
+// Callbacks must be signed by a known key of a miner (the pinned minerId, a key rotated from it, or the last key
+// that signed a response of a miner without a pinned minerId), otherwise ErrUntrustedCallback is returned
+func (c *Client) ParseCallback(body []byte) (*CallbackResponse, error) {
+	response, err := c.parseCallback(body)
+	if err != nil {
+		return nil, err
+	}
+
+	c.hooks.fireCallback(response)
+	for _, notification := range callbackNotifications(response, NotificationSourceMAPICallback) {
+		c.notifications.publish(notification, c.now())
+	}
+	return response, nil
+}
+
+// parseCallback will parse (and validate the signature of) a callback, the miner is found using the signing key
+func (c *Client) parseCallback(body []byte) (*CallbackResponse, error) {
+
+	// Process the envelope (validates the signature)
+	response := new(CallbackResponse)
+	if err := response.process(c, nil, body); err != nil {
+		return nil, err
+	} else if err = response.decodePayload(&response.Callback); err != nil {
+		return nil, err
+	}
+
+	// Valid callback?
+	if response.Callback == nil || len(response.Callback.CallbackTxID) == 0 {
+		return nil, errors.New("failed getting callback payload")
+	}
+	response.checkPayloadMinerID(response.Callback.MinerID)
+
+	// Only accept callbacks signed by a miner
+	if !response.Validated {
+		return nil, fmt.Errorf("%w: invalid or missing signature", ErrUntrustedCallback)
+	} else if response.MinerIDMismatch {
+		return nil, fmt.Errorf("%w: signed by %s for minerId %s", ErrUntrustedCallback, response.PublicKey, response.Callback.MinerID)
+	} else if response.Miner = c.callbackMiner(response.PublicKey); response.Miner == nil {
+		return nil, fmt.Errorf("%w: unknown key %s", ErrUntrustedCallback, response.PublicKey)
+	}
+	response.Trusted = true
+
+	return response, nil
+}
+
+// callbackMiner will return the miner of the signing key of a callback (nil if the key is not a known key of a miner)
+//
+// The key of a miner with a pinned minerId is the minerId (or rotated from it), otherwise the last key
+// that signed a response of the miner (see: LastSeenMinerID)
+func (c *Client) callbackMiner(publicKey string) *Miner {
+	if len(publicKey) == 0 {
+		return nil
+	}
+	for _, miner := range c.Miners {
+		if len(miner.MinerID) > 0 {
+			if c.trustedMinerID(miner, publicKey) {
+				return miner
+			}
+		} else if strings.EqualFold(c.LastSeenMinerID(miner), publicKey) {
+			return miner
+		}
+	}
+	return nil
+}
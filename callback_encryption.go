@@ -0,0 +1,99 @@
+package minercraft
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/nacl/box"
+)
+
+// CallbackEncryptionSealedBox is the scheme of the callback encryption (libsodium sealed box: X25519 and
+// XSalsa20-Poly1305 with an ephemeral sender key)
+const CallbackEncryptionSealedBox = "libsodium sealed_box"
+
+// ErrInvalidCallbackEncryption is returned when a callback encryption header or an encrypted payload is invalid
+var ErrInvalidCallbackEncryption = errors.New("invalid callback encryption")
+
+// CallbackKeyPair is the key pair that receives encrypted callbacks (see: Transaction.CallBackEncryption)
+//
+// Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi#submit-transaction
+type CallbackKeyPair struct {
+	PrivateKey *[32]byte `json:"-"`
+	PublicKey  *[32]byte `json:"public_key"`
+}
+
+// GenerateCallbackKeyPair will return a new random key pair for encrypted callbacks
+func GenerateCallbackKeyPair() (*CallbackKeyPair, error) {
+	publicKey, privateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	return &CallbackKeyPair{PrivateKey: privateKey, PublicKey: publicKey}, nil
+}
+
+// EncryptionHeader will return the callBackEncryption of a submission (IE: "libsodium sealed_box:<base64 key>")
+func (k *CallbackKeyPair) EncryptionHeader() string {
+	return CallbackEncryptionSealedBox + ":" + base64.StdEncoding.EncodeToString(k.PublicKey[:])
+}
+
+// SetEncryption will set the callback encryption of the transaction to the public key
+func (k *CallbackKeyPair) SetEncryption(tx *Transaction) {
+	tx.CallBackEncryption = k.EncryptionHeader()
+}
+
+// Decrypt will open a sealed box encrypted to the public key (the raw box or the box encoded as base64)
+func (k *CallbackKeyPair) Decrypt(payload []byte) ([]byte, error) {
+	sealed := payload
+	if decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(payload))); err == nil {
+		sealed = decoded
+	}
+	message, ok := box.OpenAnonymous(nil, sealed, k.PublicKey, k.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: failed opening the sealed box", ErrInvalidCallbackEncryption)
+	}
+	return message, nil
+}
+
+// ParseEncryptedCallback will decrypt the callback using the key pair and parse it (see: ParseCallback)
+func (c *Client) ParseEncryptedCallback(keys *CallbackKeyPair, body []byte) (*CallbackResponse, error) {
+	if keys == nil {
+		return nil, errors.New("missing callback key pair")
+	}
+	message, err := keys.Decrypt(body)
+	if err != nil {
+		return nil, err
+	}
+	return c.ParseCallback(message)
+}
+
+// ParseCallbackEncryption will return the public key of a callback encryption header (see: EncryptionHeader)
+func ParseCallbackEncryption(header string) (*[32]byte, error) {
+	parts := strings.SplitN(header, ":", 2)
+	if len(parts) != 2 || !strings.EqualFold(strings.TrimSpace(parts[0]), CallbackEncryptionSealedBox) {
+		return nil, fmt.Errorf("%w: unsupported scheme: %s", ErrInvalidCallbackEncryption, parts[0])
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(parts[1]))
+	if err != nil || len(key) != 32 {
+		return nil, fmt.Errorf("%w: invalid public key", ErrInvalidCallbackEncryption)
+	}
+	publicKey := new([32]byte)
+	copy(publicKey[:], key)
+	return publicKey, nil
+}
+
+// EncryptCallback will encrypt the callback to the callback encryption header of the submission
+// (a sealed box encoded as base64, IE: a miner or a test)
+func EncryptCallback(header string, callback []byte) ([]byte, error) {
+	publicKey, err := ParseCallbackEncryption(header)
+	if err != nil {
+		return nil, err
+	}
+	var sealed []byte
+	if sealed, err = box.SealAnonymous(nil, callback, publicKey, rand.Reader); err != nil {
+		return nil, err
+	}
+	return []byte(base64.StdEncoding.EncodeToString(sealed)), nil
+}
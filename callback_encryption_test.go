@@ -0,0 +1,99 @@
+package minercraft
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestClient_ParseEncryptedCallback tests the method ParseEncryptedCallback()
+func TestClient_ParseEncryptedCallback(t *testing.T) {
+	t.Parallel()
+
+	keys, err := GenerateCallbackKeyPair()
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	tx := &Transaction{RawTx: testRawTx}
+	keys.SetEncryption(tx)
+	if !strings.HasPrefix(tx.CallBackEncryption, CallbackEncryptionSealedBox+":") {
+		t.Fatalf("expected the encryption header, got: %s", tx.CallBackEncryption)
+	}
+
+	// Encrypted by the miner
+	var encrypted []byte
+	if encrypted, err = EncryptCallback(tx.CallBackEncryption, []byte(testCallback)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	t.Run("decrypted and parsed", func(t *testing.T) {
+		client := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{}))
+		response, err := client.ParseEncryptedCallback(keys, encrypted)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Callback.CallbackTxID != testSubmittedTx {
+			t.Fatalf("expected the callback of %s, got: %s", testSubmittedTx, response.Callback.CallbackTxID)
+		}
+	})
+
+	t.Run("encrypted channel message", func(t *testing.T) {
+		client := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{}))
+		message := &SPVChannelMessage{
+			ContentType: "application/octet-stream",
+			Payload:     []byte(`"` + base64.StdEncoding.EncodeToString(encrypted) + `"`),
+		}
+		if notifications := client.channelNotifications(message, keys); len(notifications) != 2 {
+			t.Fatalf("expected 2 notifications, got: %d", len(notifications))
+		} else if notifications := client.channelNotifications(message, nil); notifications != nil {
+			t.Fatalf("expected no notifications without the keys")
+		}
+	})
+
+	t.Run("other key pair", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		otherKeys, _ := GenerateCallbackKeyPair()
+		if _, err := client.ParseEncryptedCallback(otherKeys, encrypted); !errors.Is(err, ErrInvalidCallbackEncryption) {
+			t.Fatalf("expected ErrInvalidCallbackEncryption, got: %v", err)
+		} else if _, err = client.ParseEncryptedCallback(nil, encrypted); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestParseCallbackEncryption tests the method ParseCallbackEncryption()
+func TestParseCallbackEncryption(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input         string
+		expectedError bool
+	}{
+		{"libsodium sealed_box:ld3tjZkbxHUBl1HuPqWNrTbxKNn3XvJw7VM8cj5g9Vk=", false},
+		{"LIBSODIUM SEALED_BOX: ld3tjZkbxHUBl1HuPqWNrTbxKNn3XvJw7VM8cj5g9Vk=", false},
+		{"libsodium sealed_box:ld3tjZkbxHUBl1HuPqWNrTbx", true},
+		{"libsodium sealed_box:not base64", true},
+		{"rsa:ld3tjZkbxHUBl1HuPqWNrTbxKNn3XvJw7VM8cj5g9Vk=", true},
+		{"ld3tjZkbxHUBl1HuPqWNrTbxKNn3XvJw7VM8cj5g9Vk=", true},
+		{"", true},
+	}
+	for _, test := range tests {
+		if key, err := ParseCallbackEncryption(test.input); err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] inputted and error not expected but got: %s", t.Name(), test.input, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] inputted and error was expected", t.Name(), test.input)
+		} else if err == nil && key == nil {
+			t.Errorf("%s Failed: [%s] inputted and a key was expected", t.Name(), test.input)
+		}
+	}
+}
+
+// ExampleCallbackKeyPair_EncryptionHeader example using EncryptionHeader()
+func ExampleCallbackKeyPair_EncryptionHeader() {
+	keys, _ := GenerateCallbackKeyPair()
+	encrypted, _ := EncryptCallback(keys.EncryptionHeader(), []byte(`{"callbackReason":"merkleProof"}`))
+	decrypted, _ := keys.Decrypt(encrypted)
+	fmt.Printf("decrypted: %s", decrypted)
+	// Output:decrypted: {"callbackReason":"merkleProof"}
+}
@@ -0,0 +1,157 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// DefaultCallbackMaxBodySize is the max size of a callback body (if not set)
+const DefaultCallbackMaxBodySize = 1 << 20
+
+// CallbackAckMode is when the callback handler acknowledges a callback to the miner
+type CallbackAckMode string
+
+const (
+
+	// CallbackAckDeferred acknowledges the callback after the handler returns (IE: persisted the payload),
+	// a handler error responds with a 500 so the miner delivers the callback again
+	CallbackAckDeferred CallbackAckMode = "deferred"
+
+	// CallbackAckImmediate acknowledges the callback once it is parsed, the handler is called after the
+	// response (errors of the handler are not seen by the miner)
+	CallbackAckImmediate CallbackAckMode = "immediate"
+)
+
+// ReceivedCallback is a callback received by the callback handler (a mAPI or an ARC callback)
+type ReceivedCallback struct {
+	ARC  *ARCCallback      `json:"arc,omitempty"`  // Set for an ARC callback
+	Body []byte            `json:"-"`              // The body as received (decrypted)
+	MAPI *CallbackResponse `json:"mapi,omitempty"` // Set for a mAPI callback
+}
+
+// TxID will return the txid of the callback
+func (r *ReceivedCallback) TxID() string {
+	if r.MAPI != nil && r.MAPI.Callback != nil {
+		return r.MAPI.Callback.CallbackTxID
+	} else if r.ARC != nil {
+		return r.ARC.TxID
+	}
+	return ""
+}
+
+// CallbackHandlerFunc handles a received callback (IE: persists the merkle proof)
+type CallbackHandlerFunc func(ctx context.Context, callback *ReceivedCallback) error
+
+// CallbackHandlerOptions are the options of the callback handler (see: Client.CallbackHandler)
+type CallbackHandlerOptions struct {
+	AckMode     CallbackAckMode     // When the callback is acknowledged (defaults to CallbackAckDeferred)
+	Handler     CallbackHandlerFunc // Called for each callback (optional, the notifications are always sent)
+	Keys        *CallbackKeyPair    // Decrypts encrypted callbacks (optional, see: CallbackKeyPair.SetEncryption)
+	MaxBodySize int64               // Max size of a callback body (defaults to DefaultCallbackMaxBodySize)
+}
+
+// callbackAck is the body of a response to a callback
+type callbackAck struct {
+	Message string `json:"message"`
+	Success bool   `json:"success"`
+}
+
+// CallbackHandler will return an http handler that receives the callbacks of miners (mAPI and ARC callbacks):
+// the callback is parsed (see: ParseCallback and ParseARCCallback), passed to the handler and acknowledged
+//
+// Responses (miners deliver a callback again until it is acknowledged):
+//   - 200: the callback was acknowledged
+//   - 400: the callback is invalid (a redelivery will not fix it, IE: an invalid signature, an untrusted signer
+//     or a body that can't be decrypted with the keys)
+//   - 405: the method is not POST
+//   - 413: the body is larger than the max body size
+//   - 500: the handler failed (deferred ack), the callback should be delivered again
+func (c *Client) CallbackHandler(options *CallbackHandlerOptions) http.Handler {
+	if options == nil {
+		options = new(CallbackHandlerOptions)
+	}
+	maxBodySize := options.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultCallbackMaxBodySize
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+
+		// Read the callback
+		if req.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeCallbackAck(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		body, err := ioutil.ReadAll(io.LimitReader(req.Body, maxBodySize+1))
+		if err != nil {
+			writeCallbackAck(w, http.StatusBadRequest, "failed reading the callback")
+			return
+		} else if int64(len(body)) > maxBodySize {
+			writeCallbackAck(w, http.StatusRequestEntityTooLarge, "callback is too large")
+			return
+		}
+
+		// Parse the callback
+		var callback *ReceivedCallback
+		if callback, err = c.receiveCallback(options.Keys, body); err != nil {
+			writeCallbackAck(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		// Handle the callback (before or after the acknowledgement)
+		if options.Handler == nil {
+			writeCallbackAck(w, http.StatusOK, "callback received")
+		} else if options.AckMode == CallbackAckImmediate {
+			writeCallbackAck(w, http.StatusOK, "callback received")
+			go func() {
+				_ = options.Handler(context.Background(), callback)
+			}()
+		} else if err = options.Handler(req.Context(), callback); err != nil {
+			writeCallbackAck(w, http.StatusInternalServerError, "failed handling the callback")
+		} else {
+			writeCallbackAck(w, http.StatusOK, "callback received")
+		}
+	})
+}
+
+// receiveCallback will decrypt (if the keys are set) and parse a mAPI or an ARC callback
+//
+// If the keys are set, callbacks that are not encrypted to the keys are rejected
+func (c *Client) receiveCallback(keys *CallbackKeyPair, body []byte) (*ReceivedCallback, error) {
+	if keys != nil {
+		decrypted, err := keys.Decrypt(body)
+		if err != nil {
+			return nil, errors.New("failed decrypting the callback")
+		}
+		body = decrypted
+	}
+	callback := &ReceivedCallback{Body: body}
+
+	// A mAPI callback is a JSON envelope (with a payload)
+	var envelope struct {
+		Payload *string `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, errors.New("invalid callback json")
+	} else if envelope.Payload != nil || !bytes.Contains(body, []byte(`"txStatus"`)) {
+		callback.MAPI, err = c.ParseCallback(body)
+		return callback, err
+	}
+
+	var err error
+	callback.ARC, err = c.ParseARCCallback(nil, body)
+	return callback, err
+}
+
+// writeCallbackAck will write the response to a callback
+func writeCallbackAck(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(&callbackAck{Message: message, Success: status == http.StatusOK})
+}
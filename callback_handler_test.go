@@ -0,0 +1,139 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// postCallback will post the body to the handler and return the response
+func postCallback(handler http.Handler, method, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(method, "/callback", strings.NewReader(body)))
+	return recorder
+}
+
+// TestClient_CallbackHandler tests the method CallbackHandler()
+func TestClient_CallbackHandler(t *testing.T) {
+	t.Parallel()
+
+	t.Run("status codes", func(t *testing.T) {
+		client := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{}))
+		handler := client.CallbackHandler(&CallbackHandlerOptions{MaxBodySize: 2048})
+
+		var tests = []struct {
+			method   string
+			body     string
+			expected int
+		}{
+			{http.MethodPost, testCallback, http.StatusOK},
+			{http.MethodPost, testARCMinedCallback, http.StatusOK},
+			{http.MethodGet, "", http.StatusMethodNotAllowed},
+			{http.MethodPost, `{"payload":`, http.StatusBadRequest},
+			{http.MethodPost, `{"payload":"{}","signature":null,"publicKey":null}`, http.StatusBadRequest},
+			{http.MethodPost, testUnsignedCallback, http.StatusBadRequest},
+			{http.MethodPost, `{"txid":"invalid","txStatus":"MINED"}`, http.StatusBadRequest},
+			{http.MethodPost, strings.Repeat(" ", 2049), http.StatusRequestEntityTooLarge},
+		}
+		for _, test := range tests {
+			if recorder := postCallback(handler, test.method, test.body); recorder.Code != test.expected {
+				t.Errorf("%s Failed: [%s] inputted and [%d] expected, received: [%d] %s",
+					t.Name(), test.method, test.expected, recorder.Code, recorder.Body.String())
+			} else if test.expected == http.StatusOK && !strings.Contains(recorder.Body.String(), `"success":true`) {
+				t.Errorf("%s Failed: expected a success body, received: %s", t.Name(), recorder.Body.String())
+			}
+		}
+	})
+
+	t.Run("deferred ack", func(t *testing.T) {
+		client := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{}))
+		var persisted []string
+		failing := true
+		handler := client.CallbackHandler(&CallbackHandlerOptions{
+			Handler: func(_ context.Context, callback *ReceivedCallback) error {
+				if failing {
+					return errors.New("database is down")
+				}
+				persisted = append(persisted, callback.TxID())
+				return nil
+			},
+		})
+
+		if recorder := postCallback(handler, http.MethodPost, testCallback); recorder.Code != http.StatusInternalServerError {
+			t.Fatalf("expected a redelivery, got: %d", recorder.Code)
+		}
+		failing = false
+		if recorder := postCallback(handler, http.MethodPost, testCallback); recorder.Code != http.StatusOK {
+			t.Fatalf("expected an ack, got: %d", recorder.Code)
+		} else if len(persisted) != 1 || persisted[0] != testSubmittedTx {
+			t.Fatalf("expected the callback to be persisted, got: %v", persisted)
+		}
+	})
+
+	t.Run("immediate ack", func(t *testing.T) {
+		client := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{}))
+		handled := make(chan *ReceivedCallback, 1)
+		handler := client.CallbackHandler(&CallbackHandlerOptions{
+			AckMode: CallbackAckImmediate,
+			Handler: func(_ context.Context, callback *ReceivedCallback) error {
+				handled <- callback
+				return errors.New("not seen by the miner")
+			},
+		})
+
+		if recorder := postCallback(handler, http.MethodPost, testARCMinedCallback); recorder.Code != http.StatusOK {
+			t.Fatalf("expected an ack, got: %d", recorder.Code)
+		}
+		select {
+		case callback := <-handled:
+			if callback.ARC == nil || callback.TxID() != testTx {
+				t.Fatalf("expected the ARC callback of %s", testTx)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("handler was not called")
+		}
+	})
+
+	t.Run("encrypted callbacks", func(t *testing.T) {
+		client := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{}))
+		keys, _ := GenerateCallbackKeyPair()
+		encrypted, _ := EncryptCallback(keys.EncryptionHeader(), []byte(testCallback))
+		handler := client.CallbackHandler(&CallbackHandlerOptions{Keys: keys})
+
+		if recorder := postCallback(handler, http.MethodPost, string(encrypted)); recorder.Code != http.StatusOK {
+			t.Fatalf("expected an ack, got: %d %s", recorder.Code, recorder.Body.String())
+		} else if recorder = postCallback(client.CallbackHandler(nil), http.MethodPost, string(encrypted)); !bytes.Contains(
+			recorder.Body.Bytes(), []byte(`"success":false`),
+		) {
+			t.Fatalf("expected a failure without the keys, got: %d", recorder.Code)
+		} else if recorder = postCallback(handler, http.MethodPost, testCallback); recorder.Code != http.StatusBadRequest {
+			t.Fatalf("expected a plaintext callback to be rejected, got: %d", recorder.Code)
+		}
+	})
+
+	t.Run("untrusted callbacks", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		var calls int
+		client.OnCallback(func(callback *CallbackResponse) {
+			calls++
+		})
+		handler := client.CallbackHandler(&CallbackHandlerOptions{Handler: func(context.Context, *ReceivedCallback) error {
+			calls++
+			return nil
+		}})
+
+		for _, body := range []string{testCallback, testUnsignedCallback} {
+			if recorder := postCallback(handler, http.MethodPost, body); recorder.Code != http.StatusBadRequest {
+				t.Fatalf("%s Failed: [%s] inputted and [%d] expected, received: [%d]", t.Name(), body, http.StatusBadRequest, recorder.Code)
+			}
+		}
+		if calls != 0 {
+			t.Fatalf("expected no hook or handler calls, got: %d", calls)
+		}
+	})
+}
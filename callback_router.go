@@ -0,0 +1,90 @@
+package minercraft
+
+import (
+	"context"
+	"strings"
+	"sync"
+)
+
+// CallbackRouter routes the received callbacks to the handler registered for the txid (IE: the tenant that
+// submitted the transaction), use the Route method as the handler of the callbacks (see: CallbackHandlerOptions)
+//
+// A handler of the txid is used first, then the handler of the longest matching txid prefix, then the default handler
+type CallbackRouter struct {
+	sync.RWMutex
+	fallback CallbackHandlerFunc
+	prefixes map[string]CallbackHandlerFunc
+	txs      map[string]CallbackHandlerFunc
+}
+
+// NewCallbackRouter will return a new callback router, the default handler receives the callbacks of the
+// transactions without a registered handler (optional, the callbacks are acknowledged if not set)
+func NewCallbackRouter(defaultHandler CallbackHandlerFunc) *CallbackRouter {
+	return &CallbackRouter{
+		fallback: defaultHandler,
+		prefixes: make(map[string]CallbackHandlerFunc),
+		txs:      make(map[string]CallbackHandlerFunc),
+	}
+}
+
+// Register will route the callbacks of the txid to the handler (replaces a registered handler of the txid)
+func (r *CallbackRouter) Register(txID string, handler CallbackHandlerFunc) {
+	r.Lock()
+	defer r.Unlock()
+	r.txs[strings.ToLower(txID)] = handler
+}
+
+// RegisterPrefix will route the callbacks of the txids that start with the prefix to the handler
+func (r *CallbackRouter) RegisterPrefix(prefix string, handler CallbackHandlerFunc) {
+	r.Lock()
+	defer r.Unlock()
+	r.prefixes[strings.ToLower(prefix)] = handler
+}
+
+// Unregister will remove the handler of the txid (IE: once the merkle proof is persisted)
+func (r *CallbackRouter) Unregister(txID string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.txs, strings.ToLower(txID))
+}
+
+// UnregisterPrefix will remove the handler of the prefix
+func (r *CallbackRouter) UnregisterPrefix(prefix string) {
+	r.Lock()
+	defer r.Unlock()
+	delete(r.prefixes, strings.ToLower(prefix))
+}
+
+// Route will pass the callback to the handler of its txid (see: CallbackHandlerFunc)
+func (r *CallbackRouter) Route(ctx context.Context, callback *ReceivedCallback) error {
+	if handler := r.handler(callback.TxID()); handler != nil {
+		return handler(ctx, callback)
+	}
+	return nil
+}
+
+// handler will return the handler of the txid (nil if no handler and no default handler)
+func (r *CallbackRouter) handler(txID string) CallbackHandlerFunc {
+	txID = strings.ToLower(txID)
+
+	r.RLock()
+	defer r.RUnlock()
+
+	// The handler of the txid
+	if handler, ok := r.txs[txID]; ok {
+		return handler
+	}
+
+	// The handler of the longest prefix
+	var matched string
+	var handler CallbackHandlerFunc
+	for prefix, prefixHandler := range r.prefixes {
+		if strings.HasPrefix(txID, prefix) && (handler == nil || len(prefix) > len(matched)) {
+			matched, handler = prefix, prefixHandler
+		}
+	}
+	if handler != nil {
+		return handler
+	}
+	return r.fallback
+}
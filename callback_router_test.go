@@ -0,0 +1,57 @@
+package minercraft
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// TestCallbackRouter_Route tests the method Route()
+func TestCallbackRouter_Route(t *testing.T) {
+	t.Parallel()
+
+	var routed []string
+	route := func(name string) CallbackHandlerFunc {
+		return func(_ context.Context, _ *ReceivedCallback) error {
+			routed = append(routed, name)
+			return nil
+		}
+	}
+
+	router := NewCallbackRouter(route("default"))
+	router.RegisterPrefix(testSubmittedTx[:2], route("short"))
+	router.RegisterPrefix(testSubmittedTx[:4], route("tenant"))
+	handler := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{})).CallbackHandler(&CallbackHandlerOptions{Handler: router.Route})
+
+	var tests = []struct {
+		register   bool
+		unregister bool
+		body       string
+		expected   string
+	}{
+		{false, false, testCallback, "tenant"},
+		{true, false, testCallback, "tx"},
+		{false, true, testCallback, "tenant"},
+		{false, false, testARCMinedCallback, "default"},
+	}
+	for _, test := range tests {
+		if test.register {
+			router.Register(testSubmittedTx, route("tx"))
+		} else if test.unregister {
+			router.Unregister(testSubmittedTx)
+		}
+		routed = nil
+		if recorder := postCallback(handler, http.MethodPost, test.body); recorder.Code != http.StatusOK {
+			t.Fatalf("expected an ack, got: %d", recorder.Code)
+		} else if len(routed) != 1 || routed[0] != test.expected {
+			t.Errorf("%s Failed: [%v] inputted and [%s] expected, received: [%v]",
+				t.Name(), test.register, test.expected, routed)
+		}
+	}
+
+	// No handler is acknowledged
+	router = NewCallbackRouter(nil)
+	if err := router.Route(context.Background(), &ReceivedCallback{}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+}
@@ -0,0 +1,161 @@
+package minercraft
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Defaults of the callback server (see: CallbackServerOptions)
+const (
+	DefaultCallbackServerAddr            = ":8080"
+	DefaultCallbackServerPath            = "/callback"
+	DefaultCallbackServerShutdownTimeout = 10 * time.Second
+)
+
+// ErrCallbackServerUnauthenticated is returned when serving callbacks without a token (see: CallbackServerOptions)
+var ErrCallbackServerUnauthenticated = errors.New("callback server requires a Token or TokenName (or AllowUnauthenticated)")
+
+// CallbackServerOptions are the options of the callback server (see: Client.NewCallbackServer)
+type CallbackServerOptions struct {
+	Addr                 string                  // Address to listen on (defaults to DefaultCallbackServerAddr)
+	AllowUnauthenticated bool                    // Accept callbacks without a bearer token (IE: behind an authenticating proxy)
+	Callbacks            *CallbackHandlerOptions // Options of the callback handler (see: CallbackHandler)
+	CertFile             string                  // TLS certificate file (optional, or set the certificates of TLSConfig)
+	KeyFile              string                  // TLS key file (with the CertFile)
+	Path                 string                  // Path of the callback url (defaults to DefaultCallbackServerPath)
+	ShutdownTimeout      time.Duration           // Time to finish the received callbacks when shutting down
+	TLSConfig            *tls.Config             // Serves TLS if set (or if the CertFile is set)
+	Token                string                  // Static bearer token of the callbacks (Token or TokenName is required)
+	TokenName            string                  // Name of the stored callback tokens (see: IssueCallbackToken)
+}
+
+// CallbackServer is a ready-to-run server that receives the callbacks of miners (see: CallbackHandler)
+//
+// Callbacks must have the bearer token that was sent as the callBackToken of the submission: the Token or a
+// valid stored token of the TokenName (see: ValidateCallbackToken). Without a token the server fails to start
+// (ErrCallbackServerUnauthenticated), unless AllowUnauthenticated is set (the callbacks are then not checked)
+type CallbackServer struct {
+	client  *Client
+	options CallbackServerOptions
+	server  *http.Server
+}
+
+// NewCallbackServer will return a new callback server (see: CallbackServer.ListenAndServe)
+func (c *Client) NewCallbackServer(options *CallbackServerOptions) *CallbackServer {
+	s := &CallbackServer{client: c}
+	if options != nil {
+		s.options = *options
+	}
+	if len(s.options.Addr) == 0 {
+		s.options.Addr = DefaultCallbackServerAddr
+	}
+	if len(s.options.Path) == 0 {
+		s.options.Path = DefaultCallbackServerPath
+	}
+	if s.options.ShutdownTimeout <= 0 {
+		s.options.ShutdownTimeout = DefaultCallbackServerShutdownTimeout
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle(s.options.Path, s.authorize(c.CallbackHandler(s.options.Callbacks)))
+	s.server = &http.Server{
+		Addr:              s.options.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig:         s.options.TLSConfig,
+	}
+	return s
+}
+
+// Handler will return the handler of the server (the callback path with the token check)
+func (s *CallbackServer) Handler() http.Handler {
+	return s.server.Handler
+}
+
+// ListenAndServe will listen on the address and serve the callbacks until the context is done,
+// then shut down gracefully (returns nil after a graceful shutdown)
+func (s *CallbackServer) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.options.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, listener)
+}
+
+// Serve will serve the callbacks on the listener until the context is done, then shut down gracefully
+func (s *CallbackServer) Serve(ctx context.Context, listener net.Listener) error {
+	if !s.authenticated() && !s.options.AllowUnauthenticated {
+		_ = listener.Close()
+		return ErrCallbackServerUnauthenticated
+	}
+
+	// Serve (TLS if configured)
+	served := make(chan error, 1)
+	go func() {
+		if s.options.TLSConfig != nil || len(s.options.CertFile) > 0 {
+			served <- s.server.ServeTLS(listener, s.options.CertFile, s.options.KeyFile)
+		} else {
+			served <- s.server.Serve(listener)
+		}
+	}()
+
+	// Wait for the context (or a failure)
+	select {
+	case err := <-served:
+		return err
+	case <-ctx.Done():
+	}
+
+	// Finish the received callbacks
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.options.ShutdownTimeout)
+	defer cancel()
+	if err := s.server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-served; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// authenticated will return true if a token is configured
+func (s *CallbackServer) authenticated() bool {
+	return len(s.options.Token) > 0 || len(s.options.TokenName) > 0
+}
+
+// authorize will reject callbacks without a valid bearer token (all callbacks if no token is configured,
+// unless AllowUnauthenticated is set)
+func (s *CallbackServer) authorize(next http.Handler) http.Handler {
+	if !s.authenticated() {
+		if s.options.AllowUnauthenticated {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			writeCallbackAck(w, http.StatusUnauthorized, ErrCallbackServerUnauthenticated.Error())
+		})
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		token := strings.TrimSpace(strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer "))
+		valid := len(token) > 0 && len(s.options.Token) > 0 &&
+			subtle.ConstantTimeCompare([]byte(token), []byte(s.options.Token)) == 1
+		if !valid && len(token) > 0 && len(s.options.TokenName) > 0 {
+			var err error
+			if valid, err = s.client.ValidateCallbackToken(req.Context(), s.options.TokenName, token); err != nil {
+				writeCallbackAck(w, http.StatusServiceUnavailable, "failed validating the token")
+				return
+			}
+		}
+		if !valid {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			writeCallbackAck(w, http.StatusUnauthorized, "invalid callback token")
+			return
+		}
+		next.ServeHTTP(w, req)
+	})
+}
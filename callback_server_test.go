@@ -0,0 +1,137 @@
+package minercraft
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// serveCallbacks will serve the callback server on a local listener and return its url
+func serveCallbacks(t *testing.T, server *CallbackServer) (string, func() error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- server.Serve(ctx, listener)
+	}()
+	return listener.Addr().String(), func() error {
+		cancel()
+		return <-served
+	}
+}
+
+// TestClient_NewCallbackServer tests the method NewCallbackServer()
+func TestClient_NewCallbackServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("bearer tokens", func(t *testing.T) {
+		client := addTestCallbackMiner(newTokenClient(t, &mockHTTPValidQuery{}))
+		issued, _ := client.IssueCallbackToken(context.Background(), "tenant")
+		handler := client.NewCallbackServer(&CallbackServerOptions{Token: "static", TokenName: "tenant"}).Handler()
+
+		var tests = []struct {
+			authorization string
+			expected      int
+		}{
+			{"Bearer static", http.StatusOK},
+			{issued.Header(), http.StatusOK},
+			{"Bearer wrong", http.StatusUnauthorized},
+			{"", http.StatusUnauthorized},
+		}
+		for _, test := range tests {
+			req := httptest.NewRequest(http.MethodPost, DefaultCallbackServerPath, strings.NewReader(testCallback))
+			req.Header.Set("Authorization", test.authorization)
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, req)
+			if recorder.Code != test.expected {
+				t.Errorf("%s Failed: [%s] inputted and [%d] expected, received: [%d]",
+					t.Name(), test.authorization, test.expected, recorder.Code)
+			}
+		}
+	})
+
+	t.Run("serve and shut down", func(t *testing.T) {
+		received := make(chan string, 1)
+		server := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{})).NewCallbackServer(&CallbackServerOptions{
+			Callbacks: &CallbackHandlerOptions{Handler: func(_ context.Context, callback *ReceivedCallback) error {
+				received <- callback.TxID()
+				return nil
+			}},
+			Path:  "/hooks/minercraft",
+			Token: "static",
+		})
+		addr, shutdown := serveCallbacks(t, server)
+
+		req, _ := http.NewRequest(http.MethodPost, "http://"+addr+"/hooks/minercraft", strings.NewReader(testCallback))
+		req.Header.Set("Authorization", "Bearer static")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		_ = res.Body.Close()
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected an ack, got: %d", res.StatusCode)
+		} else if txID := <-received; txID != testSubmittedTx {
+			t.Fatalf("expected the callback of %s, got: %s", testSubmittedTx, txID)
+		}
+
+		if err = shutdown(); err != nil {
+			t.Fatalf("expected a graceful shutdown, got: %s", err.Error())
+		} else if _, err = net.DialTimeout("tcp", addr, time.Second); err == nil {
+			t.Fatalf("expected the server to be closed")
+		}
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		server := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{})).NewCallbackServer(&CallbackServerOptions{
+			AllowUnauthenticated: true,
+			TLSConfig: &tls.Config{
+				Certificates: []tls.Certificate{*testClientCertificate(t)},
+				MinVersion:   tls.VersionTLS12,
+			},
+		})
+		addr, shutdown := serveCallbacks(t, server)
+		defer func() {
+			_ = shutdown()
+		}()
+
+		httpClient := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true, MinVersion: tls.VersionTLS12}, //nolint:gosec // self-signed test certificate
+		}}
+		res, err := httpClient.Post("https://"+addr+DefaultCallbackServerPath, "application/json", strings.NewReader(testCallback))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		_ = res.Body.Close()
+		if res.StatusCode != http.StatusOK || res.TLS == nil {
+			t.Fatalf("expected a TLS ack, got: %d", res.StatusCode)
+		}
+	})
+
+	t.Run("no token", func(t *testing.T) {
+		server := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{})).NewCallbackServer(nil)
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		if err = server.Serve(context.Background(), listener); !errors.Is(err, ErrCallbackServerUnauthenticated) {
+			t.Fatalf("expected ErrCallbackServerUnauthenticated, got: %v", err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, DefaultCallbackServerPath, strings.NewReader(testCallback))
+		recorder := httptest.NewRecorder()
+		server.Handler().ServeHTTP(recorder, req)
+		if recorder.Code != http.StatusUnauthorized {
+			t.Fatalf("expected the callbacks to be rejected, got: %d", recorder.Code)
+		}
+	})
+}
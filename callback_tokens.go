@@ -0,0 +1,219 @@
+package minercraft
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultTokenRotationGrace is how long a rotated token is still accepted (if no grace period is given),
+// long enough for the proofs of the transactions submitted with the previous token
+const DefaultTokenRotationGrace = 24 * time.Hour
+
+// callbackTokenBytes is the number of random bytes of an issued token
+const callbackTokenBytes = 32
+
+// CallbackToken is a token that authenticates callbacks (the callBackToken of a submission) or an SPV channel
+type CallbackToken struct {
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"` // Set when the token is rotated (zero for the current token)
+	ID        string    `json:"id,omitempty"`         // The id of an SPV channel token (see: SPVChannelToken)
+	Token     string    `json:"token"`
+}
+
+// Header will return the token as the authorization header miners send with a callback (IE: "Bearer <token>")
+func (t *CallbackToken) Header() string {
+	return "Bearer " + t.Token
+}
+
+// isExpired will return true if the token was rotated and the grace period is over
+func (t *CallbackToken) isExpired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && now.After(t.ExpiresAt)
+}
+
+// CallbackTokens are the tokens of a name (IE: a tenant or an SPV channel): the current token and the
+// rotated tokens that are still accepted (until they expire)
+type CallbackTokens struct {
+	Current  *CallbackToken   `json:"current"`
+	Name     string           `json:"name"`
+	Previous []*CallbackToken `json:"previous,omitempty"`
+}
+
+// tokenRegistry guards the stored tokens (read, modify and write)
+type tokenRegistry struct {
+	sync.Mutex
+}
+
+// IssueCallbackToken will return the current callback token of the name, issuing (and storing) a new random
+// token if the name has no token (see: SetStore)
+//
+// The tokens are stored as plain text (they are sent with each submission), use a private store
+func (c *Client) IssueCallbackToken(ctx context.Context, name string) (*CallbackToken, error) {
+	return c.updateTokens(ctx, name, func(tokens *CallbackTokens, now time.Time) error {
+		if tokens.Current != nil {
+			return nil
+		}
+		token, err := newCallbackToken()
+		tokens.Current = &CallbackToken{CreatedAt: now, Token: token}
+		return err
+	})
+}
+
+// RotateCallbackToken will issue a new current callback token for the name, the previous token is still accepted
+// for the grace period (DefaultTokenRotationGrace if not set), so proofs of transactions that were submitted with
+// the previous token are not lost
+func (c *Client) RotateCallbackToken(ctx context.Context, name string, grace time.Duration) (*CallbackToken, error) {
+	return c.updateTokens(ctx, name, func(tokens *CallbackTokens, now time.Time) error {
+		token, err := newCallbackToken()
+		if err != nil {
+			return err
+		}
+		tokens.rotate(&CallbackToken{CreatedAt: now, Token: token}, now, grace)
+		return nil
+	})
+}
+
+// ValidateCallbackToken will return true if the token (or the authorization header, IE: "Bearer <token>")
+// is the current token of the name or a rotated token that has not expired
+func (c *Client) ValidateCallbackToken(ctx context.Context, name, token string) (bool, error) {
+	tokens, err := c.CallbackTokens(ctx, name)
+	if err != nil || tokens == nil {
+		return false, err
+	}
+	token = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(token), "Bearer "))
+	now := c.now()
+	valid := false
+	for _, stored := range append([]*CallbackToken{tokens.Current}, tokens.Previous...) {
+		if stored != nil && !stored.isExpired(now) &&
+			subtle.ConstantTimeCompare([]byte(stored.Token), []byte(token)) == 1 {
+			valid = true
+		}
+	}
+	return valid, nil
+}
+
+// CallbackTokens will return the stored tokens of the name (nil if the name has no tokens)
+func (c *Client) CallbackTokens(ctx context.Context, name string) (*CallbackTokens, error) {
+	if c.store == nil {
+		return nil, ErrStoreRequired
+	}
+	data, err := c.store.Get(ctx, callbackTokensKey(name))
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	tokens := new(CallbackTokens)
+	if err = json.Unmarshal(data, tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// RevokeCallbackTokens will remove all tokens of the name (callbacks with the tokens are no longer accepted)
+func (c *Client) RevokeCallbackTokens(ctx context.Context, name string) error {
+	if c.store == nil {
+		return ErrStoreRequired
+	}
+	c.tokens.Lock()
+	defer c.tokens.Unlock()
+	return c.store.Delete(ctx, callbackTokensKey(name))
+}
+
+// RotateChannelToken will create a new API token of the channel (see: SPVChannelsClient.CreateToken) and store
+// it as the current token of the channel, the previous token is kept until the grace period is over
+// (DefaultTokenRotationGrace if not set) and can then be revoked on the server using its id
+func (s *SPVChannelsClient) RotateChannelToken(ctx context.Context, channelID, description string,
+	canRead, canWrite bool, grace time.Duration) (*CallbackToken, error) {
+
+	if s.client.store == nil {
+		return nil, ErrStoreRequired
+	}
+	created, err := s.CreateToken(ctx, channelID, description, canRead, canWrite)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.updateTokens(ctx, channelTokensName(channelID), func(tokens *CallbackTokens, now time.Time) error {
+		tokens.rotate(&CallbackToken{CreatedAt: now, ID: created.ID, Token: created.Token}, now, grace)
+		return nil
+	})
+}
+
+// ChannelTokens will return the stored tokens of the channel (see: RotateChannelToken)
+func (s *SPVChannelsClient) ChannelTokens(ctx context.Context, channelID string) (*CallbackTokens, error) {
+	return s.client.CallbackTokens(ctx, channelTokensName(channelID))
+}
+
+// rotate will replace the current token, the previous token expires after the grace period
+// (expired tokens are removed)
+func (t *CallbackTokens) rotate(token *CallbackToken, now time.Time, grace time.Duration) {
+	if grace <= 0 {
+		grace = DefaultTokenRotationGrace
+	}
+	previous := make([]*CallbackToken, 0, len(t.Previous)+1)
+	if t.Current != nil {
+		t.Current.ExpiresAt = now.Add(grace)
+		previous = append(previous, t.Current)
+	}
+	for _, rotated := range t.Previous {
+		if !rotated.isExpired(now) {
+			previous = append(previous, rotated)
+		}
+	}
+	t.Current, t.Previous = token, previous
+}
+
+// updateTokens will change the stored tokens of the name and return the current token
+func (c *Client) updateTokens(ctx context.Context, name string,
+	change func(tokens *CallbackTokens, now time.Time) error) (*CallbackToken, error) {
+
+	if c.store == nil {
+		return nil, ErrStoreRequired
+	} else if len(strings.TrimSpace(name)) == 0 {
+		return nil, errors.New("missing token name")
+	}
+
+	c.tokens.Lock()
+	defer c.tokens.Unlock()
+
+	tokens, err := c.CallbackTokens(ctx, name)
+	if err != nil {
+		return nil, err
+	} else if tokens == nil {
+		tokens = &CallbackTokens{Name: name}
+	}
+	if err = change(tokens, c.now().UTC()); err != nil {
+		return nil, err
+	}
+
+	var data []byte
+	if data, err = json.Marshal(tokens); err != nil {
+		return nil, err
+	} else if err = c.store.Set(ctx, callbackTokensKey(name), data, 0); err != nil {
+		return nil, err
+	}
+	return tokens.Current, nil
+}
+
+// newCallbackToken will return a new random token (hex)
+func newCallbackToken() (string, error) {
+	token := make([]byte, callbackTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// callbackTokensKey will return the store key of the tokens of the name
+func callbackTokensKey(name string) string {
+	return storeKey("tokens", name)
+}
+
+// channelTokensName will return the token name of an SPV channel
+func channelTokensName(channelID string) string {
+	return "channel:" + channelID
+}
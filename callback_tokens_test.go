@@ -0,0 +1,130 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// newTokenClient will return a test client with a file store
+func newTokenClient(t *testing.T, httpClient httpInterface) *Client {
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	client := newTestClient(httpClient)
+	client.SetStore(store)
+	return client
+}
+
+// TestClient_CallbackTokens tests the methods IssueCallbackToken(), RotateCallbackToken() and ValidateCallbackToken()
+func TestClient_CallbackTokens(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("no store", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		if _, err := client.IssueCallbackToken(ctx, "tenant"); !errors.Is(err, ErrStoreRequired) {
+			t.Fatalf("expected error: %v got: %v", ErrStoreRequired, err)
+		} else if _, err = client.ValidateCallbackToken(ctx, "tenant", "token"); !errors.Is(err, ErrStoreRequired) {
+			t.Fatalf("expected error: %v got: %v", ErrStoreRequired, err)
+		} else if err = client.RevokeCallbackTokens(ctx, "tenant"); !errors.Is(err, ErrStoreRequired) {
+			t.Fatalf("expected error: %v got: %v", ErrStoreRequired, err)
+		}
+	})
+
+	t.Run("issued once", func(t *testing.T) {
+		client := newTokenClient(t, &mockHTTPValidQuery{})
+		token, err := client.IssueCallbackToken(ctx, "tenant")
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if len(token.Token) != 2*callbackTokenBytes || token.Header() != "Bearer "+token.Token {
+			t.Fatalf("expected a random token, got: %s", token.Token)
+		}
+
+		var again *CallbackToken
+		if again, err = client.IssueCallbackToken(ctx, "tenant"); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if again.Token != token.Token {
+			t.Fatalf("expected the current token to be returned")
+		} else if _, err = client.IssueCallbackToken(ctx, " "); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("rotated with a grace period", func(t *testing.T) {
+		client := newTokenClient(t, &mockHTTPValidQuery{})
+		previous, _ := client.IssueCallbackToken(ctx, "tenant")
+		current, err := client.RotateCallbackToken(ctx, "tenant", time.Hour)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if current.Token == previous.Token {
+			t.Fatalf("expected a new token")
+		}
+
+		for _, header := range []string{current.Header(), previous.Header(), previous.Token} {
+			if valid, err := client.ValidateCallbackToken(ctx, "tenant", header); err != nil || !valid {
+				t.Fatalf("expected the token to be valid: %s %v", header, err)
+			}
+		}
+		if valid, _ := client.ValidateCallbackToken(ctx, "tenant", "Bearer wrong"); valid {
+			t.Fatalf("expected the token to be invalid")
+		} else if valid, _ = client.ValidateCallbackToken(ctx, "other", current.Token); valid {
+			t.Fatalf("expected the token of another name to be invalid")
+		}
+
+		// The grace period is over
+		tokens, _ := client.CallbackTokens(ctx, "tenant")
+		if len(tokens.Previous) != 1 || tokens.Previous[0].ExpiresAt.IsZero() {
+			t.Fatalf("expected 1 previous token with an expiration")
+		}
+		tokens.Previous[0].ExpiresAt = time.Now().Add(-time.Minute)
+		if _, err = client.updateTokens(ctx, "tenant", func(stored *CallbackTokens, _ time.Time) error {
+			stored.Previous = tokens.Previous
+			return nil
+		}); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if valid, _ := client.ValidateCallbackToken(ctx, "tenant", previous.Token); valid {
+			t.Fatalf("expected the expired token to be invalid")
+		}
+
+		// Expired tokens are removed on the next rotation
+		if _, err = client.RotateCallbackToken(ctx, "tenant", 0); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if tokens, _ = client.CallbackTokens(ctx, "tenant"); len(tokens.Previous) != 1 ||
+			tokens.Previous[0].Token != current.Token {
+			t.Fatalf("expected only the previous current token, got: %d", len(tokens.Previous))
+		}
+
+		// Revoked
+		if err = client.RevokeCallbackTokens(ctx, "tenant"); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if valid, _ := client.ValidateCallbackToken(ctx, "tenant", current.Token); valid {
+			t.Fatalf("expected the revoked token to be invalid")
+		}
+	})
+
+	t.Run("channel tokens", func(t *testing.T) {
+		client := newTokenClient(t, &mockHTTPSPVChannels{})
+		channels := client.SPVChannels(testChannelsAccount)
+		token, err := channels.RotateChannelToken(ctx, testChannelID, "Miner", false, true, 0)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if token.Token != testChannelToken || token.ID != "2" {
+			t.Fatalf("expected the token of the server, got: %+v", token)
+		}
+
+		var tokens *CallbackTokens
+		if tokens, err = channels.ChannelTokens(ctx, testChannelID); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if tokens.Current.Token != testChannelToken {
+			t.Fatalf("expected the stored token")
+		} else if _, err = newTestClient(&mockHTTPSPVChannels{}).SPVChannels(testChannelsAccount).RotateChannelToken(
+			ctx, testChannelID, "", true, true, 0,
+		); !errors.Is(err, ErrStoreRequired) {
+			t.Fatalf("expected error: %v got: %v", ErrStoreRequired, err)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/libsv/libsv/transaction"
+)
+
+// ErrChangeBelowDust is returned when the change of a transaction would be below the dust threshold after the fee
+var ErrChangeBelowDust = errors.New("change would be below the dust threshold")
+
+// AdjustChange will set the value of the change output so the transaction pays exactly the mining fee
+// of the quote, and return the fee
+//
+// The inputs must have their previous satoshis (IE: added using tx.From()). Inputs that are not signed yet are
+// counted as P2PKH inputs (see: P2PKHUnlockingScriptSize). The dust threshold uses the policies of the quote
+// (see: Policies.DustThreshold) or DustLimit, ErrChangeBelowDust is returned if the change would be below it
+func (f *FeePayload) AdjustChange(tx *transaction.Transaction, changeIndex int) (uint64, error) {
+
+	// Make sure we have a tx and a change output
+	if tx == nil {
+		return 0, errors.New("tx was nil")
+	} else if changeIndex < 0 || changeIndex >= len(tx.Outputs) {
+		return 0, fmt.Errorf("change output %d not found", changeIndex)
+	}
+
+	// Get the size (counting the unlocking scripts of the unsigned inputs)
+	txSize, err := TxSizeFromTx(tx)
+	if err != nil {
+		return 0, err
+	}
+	for _, in := range tx.Inputs {
+		if in.UnlockingScript == nil || len(*in.UnlockingScript) == 0 {
+			txSize.StandardBytes += P2PKHUnlockingScriptSize
+		}
+	}
+
+	// Calculate the fee
+	fee, err := f.CalculateTxFee(FeeCategoryMining, txSize)
+	if err != nil {
+		return 0, err
+	}
+
+	// Get the satoshis available for the change (inputs - other outputs)
+	var inputSatoshis uint64
+	for _, in := range tx.Inputs {
+		inputSatoshis += in.PreviousTxSatoshis
+	}
+	available := inputSatoshis
+	for index, out := range tx.Outputs {
+		if index == changeIndex {
+			continue
+		} else if out.Satoshis > available {
+			return 0, fmt.Errorf("outputs exceed the inputs (%d satoshis)", inputSatoshis)
+		}
+		available -= out.Satoshis
+	}
+
+	// Make sure the change is not dust
+	change := tx.Outputs[changeIndex]
+	dust := DustLimit
+	if f.Policies != nil {
+		dust = f.Policies.DustThreshold(uint64(len(change.ToBytes())))
+	}
+	if available < fee || available-fee < dust {
+		return 0, fmt.Errorf("%w: %d satoshis available for a fee of %d satoshis (dust: %d satoshis)",
+			ErrChangeBelowDust, available, fee, dust)
+	}
+
+	change.Satoshis = available - fee
+	return fee, nil
+}
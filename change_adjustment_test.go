@@ -0,0 +1,107 @@
+package minercraft
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/libsv/libsv/script"
+	"github.com/libsv/libsv/transaction"
+)
+
+// TestFeePayload_AdjustChange tests the method AdjustChange()
+func TestFeePayload_AdjustChange(t *testing.T) {
+	t.Parallel()
+
+	quote := testQuote(MinerTaal, 500).Quote
+
+	t.Run("pays the exact fee", func(t *testing.T) {
+		tx := testDataTx(t, []byte("hello"))
+		fee, err := quote.AdjustChange(tx, 0)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if tx.Outputs[0].Satoshis != 1000-fee {
+			t.Fatalf("expected change of %d, got: %d", 1000-fee, tx.Outputs[0].Satoshis)
+		}
+
+		// Sign (a P2PKH sized unlocking script) and check the fee
+		tx.Inputs[0].UnlockingScript = script.NewFromBytes(bytes.Repeat([]byte{0x01}, P2PKHUnlockingScriptSize))
+		if sufficient, shortfall, err := quote.IsTxFeeSufficient(tx, fee); err != nil || !sufficient {
+			t.Fatalf("expected the fee to be sufficient, got: %v %d %v", sufficient, shortfall, err)
+		} else if required, _ := quote.CalculateTxFee(FeeCategoryMining, mustTxSizeFromTx(t, tx)); required != fee {
+			t.Fatalf("expected the fee to be exactly %d, got: %d", required, fee)
+		}
+	})
+
+	t.Run("signed inputs", func(t *testing.T) {
+		tx := testDataTx(t, nil)
+		tx.Inputs[0].UnlockingScript = script.NewFromBytes(bytes.Repeat([]byte{0x01}, 10))
+		fee, err := quote.AdjustChange(tx, 0)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if required, _ := quote.CalculateTxFee(FeeCategoryMining, mustTxSizeFromTx(t, tx)); required != fee {
+			t.Fatalf("expected the fee to be exactly %d, got: %d", required, fee)
+		}
+	})
+
+	t.Run("change below dust", func(t *testing.T) {
+		tx := testDataTx(t, nil)
+		tx.Inputs[0].PreviousTxSatoshis = 600
+		if _, err := quote.AdjustChange(tx, 0); !errors.Is(err, ErrChangeBelowDust) {
+			t.Fatalf("expected %v, got: %v", ErrChangeBelowDust, err)
+		}
+
+		// The dust threshold of the policies
+		policyQuote := testQuote(MinerTaal, 500).Quote
+		policyQuote.Policies = &Policies{DustLimitFactor: 300, DustRelayFee: 150}
+		if _, err := policyQuote.AdjustChange(tx, 0); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		tx.Inputs[0].PreviousTxSatoshis = 150
+		if _, err := policyQuote.AdjustChange(tx, 0); !errors.Is(err, ErrChangeBelowDust) {
+			t.Fatalf("expected %v, got: %v", ErrChangeBelowDust, err)
+		}
+	})
+
+	t.Run("outputs exceed inputs", func(t *testing.T) {
+		tx := testDataTx(t, nil)
+		if err := tx.PayTo("1NTDHDz8hdmqyCxHATqgwT4u1SpERr7KXP", 2000); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		if _, err := quote.AdjustChange(tx, 0); err == nil || errors.Is(err, ErrChangeBelowDust) {
+			t.Fatalf("expected an error, got: %v", err)
+		}
+	})
+
+	t.Run("invalid change output", func(t *testing.T) {
+		if _, err := quote.AdjustChange(testDataTx(t, nil), 1); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = quote.AdjustChange(nil, 0); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// mustTxSizeFromTx will return the size breakdown of the tx (failing the test on error)
+func mustTxSizeFromTx(t *testing.T, tx *transaction.Transaction) *TxSize {
+	txSize, err := TxSizeFromTx(tx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return txSize
+}
+
+// ExampleFeePayload_AdjustChange example using AdjustChange()
+func ExampleFeePayload_AdjustChange() {
+	quote := testQuote(MinerTaal, 500).Quote
+	tx := testDataTx(&testing.T{}, nil) // One 1000 satoshi input and a change output (unsigned)
+	fee, err := quote.AdjustChange(tx, 0)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("fee: %d change: %d", fee, tx.Outputs[0].Satoshis)
+	// Output:fee: 96 change: 904
+}
@@ -0,0 +1,77 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"sort"
+)
+
+// CheapestMiners will check all known miners and return the (n) cheapest quotes for the given
+// fee category and type, sorted by rate (cheapest first). Each quote includes the miner (response.Miner)
+//
+// Miners that fail to return a valid quote are skipped, an error is only returned if no quotes are found
+func (c *Client) CheapestMiners(n int, feeCategory, feeType string) ([]*FeeQuoteResponse, error) {
+
+	// Make sure we have a valid number
+	if n <= 0 {
+		return nil, errors.New("number of miners must be greater than zero")
+	}
+
+	// Loop the results of all miners
+	type rankedQuote struct {
+		quote *FeeQuoteResponse
+		rate  uint64
+	}
+	var lastErr error
+	var ranked []*rankedQuote
+	for _, result := range c.fetchAllQuotes(context.Background()) {
+
+		// Check for error?
+		if result.Response.Error != nil {
+			lastErr = result.Response.Error
+			continue
+		}
+
+		// Parse the response
+		quote, err := result.parseQuote()
+		if err != nil {
+			lastErr = err
+			continue
+		} else if quote.Quote == nil {
+			lastErr = errors.New("failed getting quotes from: " + result.Miner.Name)
+			continue
+		}
+
+		// Get a test rate
+		var rate uint64
+		if rate, err = quote.Quote.CalculateFee(feeCategory, feeType, 1000); err != nil {
+			lastErr = err
+			continue
+		}
+
+		ranked = append(ranked, &rankedQuote{quote: &quote, rate: rate})
+	}
+
+	// No quotes found
+	if len(ranked) == 0 {
+		if lastErr == nil {
+			lastErr = errors.New("no quotes found")
+		}
+		return nil, lastErr
+	}
+
+	// Sort by rate (keeping the miner order for the same rates)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].rate < ranked[j].rate
+	})
+
+	// Return the top (n) quotes
+	if n > len(ranked) {
+		n = len(ranked)
+	}
+	quotes := make([]*FeeQuoteResponse, 0, n)
+	for _, r := range ranked[:n] {
+		quotes = append(quotes, r.quote)
+	}
+	return quotes, nil
+}
@@ -0,0 +1,128 @@
+package minercraft
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// mockHTTPPartialQuotes for mocking requests (only Taal returns a valid quote)
+type mockHTTPPartialQuotes struct{}
+
+// Do is a mock http request
+func (m *mockHTTPPartialQuotes) Do(req *http.Request) (*http.Response, error) {
+	if req != nil && strings.Contains(req.URL.String(), "taal") {
+		return (&mockHTTPValidBestQuote{}).Do(req)
+	}
+	return (&mockHTTPError{}).Do(req)
+}
+
+// TestClient_CheapestMiners tests the method CheapestMiners()
+func TestClient_CheapestMiners(t *testing.T) {
+	t.Parallel()
+
+	// Create the list of tests
+	var tests = []struct {
+		inputNumber    int
+		expectedMiners []string
+		expectedError  bool
+	}{
+		{1, []string{MinerMempool}, false},
+		{2, []string{MinerMempool, MinerMatterpool}, false},
+		{3, []string{MinerMempool, MinerMatterpool, MinerTaal}, false},
+		{10, []string{MinerMempool, MinerMatterpool, MinerTaal}, false},
+		{0, nil, true},
+		{-1, nil, true},
+	}
+
+	// Create a client
+	client := newTestClient(&mockHTTPValidBestQuote{})
+
+	// Run tests
+	for _, test := range tests {
+		quotes, err := client.CheapestMiners(test.inputNumber, FeeCategoryMining, FeeTypeData)
+		if err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%d] inputted and error not expected but got: %s", t.Name(), test.inputNumber, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%d] inputted and error was expected", t.Name(), test.inputNumber)
+		} else if len(quotes) != len(test.expectedMiners) {
+			t.Errorf("%s Failed: [%d] inputted and [%d] quotes expected but got: %d", t.Name(), test.inputNumber, len(test.expectedMiners), len(quotes))
+		} else {
+			for index, quote := range quotes {
+				if quote.Miner.Name != test.expectedMiners[index] {
+					t.Errorf("%s Failed: [%d] inputted and [%v] expected but got: %s at index %d", t.Name(), test.inputNumber, test.expectedMiners, quote.Miner.Name, index)
+				}
+			}
+		}
+	}
+}
+
+// TestClient_CheapestMinersPartialFailure tests the method CheapestMiners()
+func TestClient_CheapestMinersPartialFailure(t *testing.T) {
+	t.Parallel()
+
+	// Create a client
+	client := newTestClient(&mockHTTPPartialQuotes{})
+
+	// Failing miners are skipped
+	quotes, err := client.CheapestMiners(2, FeeCategoryMining, FeeTypeData)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(quotes) != 1 || quotes[0].Miner.Name != MinerTaal {
+		t.Fatalf("expected only a quote from %s", MinerTaal)
+	}
+}
+
+// TestClient_CheapestMinersHTTPError tests the method CheapestMiners()
+func TestClient_CheapestMinersHTTPError(t *testing.T) {
+	t.Parallel()
+
+	// Create a client
+	client := newTestClient(&mockHTTPError{})
+
+	// Create a req
+	quotes, err := client.CheapestMiners(2, FeeCategoryMining, FeeTypeData)
+	if err == nil {
+		t.Fatalf("error should have occurred")
+	} else if quotes != nil {
+		t.Fatalf("expected quotes to be nil")
+	}
+}
+
+// TestClient_CheapestMinersInvalidType tests the method CheapestMiners()
+func TestClient_CheapestMinersInvalidType(t *testing.T) {
+	t.Parallel()
+
+	// Create a client
+	client := newTestClient(&mockHTTPValidBestQuote{})
+
+	// Create a req
+	if _, err := client.CheapestMiners(2, FeeCategoryMining, "invalid"); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// ExampleClient_CheapestMiners example using CheapestMiners()
+func ExampleClient_CheapestMiners() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidBestQuote{})
+
+	// Get the two cheapest miners (primary and backup)
+	quotes, err := client.CheapestMiners(2, FeeCategoryMining, FeeTypeData)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("primary: %s backup: %s", quotes[0].Miner.Name, quotes[1].Miner.Name)
+	// Output:primary: Mempool backup: Matterpool
+}
+
+// BenchmarkClient_CheapestMiners benchmarks the method CheapestMiners()
+func BenchmarkClient_CheapestMiners(b *testing.B) {
+	client := newTestClient(&mockHTTPValidBestQuote{})
+	for i := 0; i < b.N; i++ {
+		_, _ = client.CheapestMiners(2, FeeCategoryMining, FeeTypeData)
+	}
+}
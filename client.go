@@ -1,10 +1,9 @@
 package minercraft
 
 import (
-	"encoding/json"
-	"errors"
+	"context"
+	"crypto/tls"
 	"fmt"
-	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -20,37 +19,67 @@ type httpInterface interface {
 
 // Client is the parent struct that contains the miner clients and list of miners to use
 type Client struct {
-	httpClient httpInterface  // Interface for all HTTP requests
-	Miners     []*Miner       // List of loaded miners
-	Options    *ClientOptions // Client options config
+	budgets         *requestBudgets      // Requests of each miner against its budget (see: Miner.HourlyBudget)
+	certificates    *clientCertificates  // Mutual TLS client certificates (by miner)
+	confirmations   *confirmationTracker // Time-to-confirmation of accepted transactions
+	endpoints       *endpointRegistry    // Registered custom endpoints
+	events          *eventBus            // Subscribers of miner events
+	feeAlerts       *feeAlerts           // Registered fee threshold alerts
+	health          *healthRegistry      // Last known health of each miner
+	hooks           *hooks               // Registered event hooks
+	httpClient      httpInterface        // Interface for all HTTP requests
+	journal         *journal             // Guards the index of journaled submissions (see: SubmitTransactionJournaled)
+	minerIDs        *minerIDRegistry     // Verified minerId key rotations
+	Miners          MinerSlice           // List of loaded miners
+	network         string               // Network of all miners (if set, see: WithNetwork)
+	notifications   *notificationHub     // Subscribers of transaction notifications
+	offline         *offlineQueue        // Submissions waiting for a miner to recover (see: SubmitTransactionAny)
+	Options         *ClientOptions       // Client options config
+	quoteCache      *quoteCache          // Cache of fee quotes (if enabled)
+	rateLimits      *rateLimiter         // Next allowed quote request of each miner (see: Miner.RateLimit)
+	registryVersion string               // Version of the miner registry that was loaded
+	store           Store                // Store for sharing cached quotes between instances (optional)
+	tokens          *tokenRegistry       // Guards the stored callback tokens (see: IssueCallbackToken)
+	streamClient    httpInterface        // HTTP client for streamed requests (no retries, a stream can't be replayed)
+	tenant          string               // Tenant of the requests (see: WithTenant)
+	tenants         *tenantRegistry      // Miner settings of each tenant (see: SetTenant)
+	transport       *http.Transport      // Shared transport of the HTTP clients (nil if a custom HTTP client was given)
+	transports      *minerTransports     // Transport of each miner, copied from the shared transport
+	usage           *usageTracker        // Requests sent to each miner by operation (see: UsageMetrics)
+	verifier        SignatureVerifier    // Verifier of response signatures (defaults to DERSignatureVerifier)
 }
 
 // AddMiner will add a new miner to the list of miners
 func (c *Client) AddMiner(miner Miner) error {
 
-	// Make sure we have the basic requirements
-	if len(miner.Name) == 0 {
-		return errors.New("missing miner name")
-	} else if len(miner.URL) == 0 {
-		return errors.New("missing miner url")
+	// Make sure we have a valid name and url (syntax and scheme)
+	if err := ValidateMiner(&miner); err != nil {
+		return err
 	}
 
 	// Check if a miner with that name already exists
-	existingMiner := c.MinerByName(miner.Name)
-	if existingMiner != nil {
-		return fmt.Errorf("miner %s already exists", miner.Name)
+	if existingMiner := c.MinerByName(miner.Name); existingMiner != nil {
+		return newDuplicateError(&miner, ErrDuplicateMinerName, miner.Name)
 	}
 
 	// Check if a miner with the minerID already exists
 	if len(miner.MinerID) > 0 {
-		if existingMiner = c.MinerByID(miner.MinerID); existingMiner != nil {
-			return fmt.Errorf("miner %s already exists", miner.MinerID)
+		if existingMiner := c.MinerByID(miner.MinerID); existingMiner != nil {
+			return newDuplicateError(&miner, ErrDuplicateMinerID, miner.MinerID)
 		}
 	}
 
-	// Remove any protocol(s)
-	miner.URL = strings.Replace(miner.URL, defaultProtocol, "", -1)
-	miner.URL = strings.Replace(miner.URL, "http://", "", -1)
+	// Make sure the miner is on the network of the client (if set)
+	if err := c.checkNetwork(&miner); err != nil {
+		return err
+	}
+
+	// Remove any protocol(s) and check if a miner with that url already exists
+	// (requests use https unless the miner's Scheme is set explicitly)
+	miner.URL, _ = normalizeMinerURL(&miner)
+	if existingMiner := c.minerByURL(miner.URL); existingMiner != nil {
+		return newDuplicateError(&miner, ErrDuplicateMinerURL, miner.URL)
+	}
 
 	// Append the new miner
 	c.Miners = append(c.Miners, &miner)
@@ -81,100 +110,212 @@ func (c *Client) MinerByID(minerID string) *Miner {
 func (c *Client) MinerUpdateToken(name, token string) {
 	if miner := c.MinerByName(name); miner != nil {
 		miner.Token = token
+		c.quoteCache.delete(miner) // Quotes requested with the previous token are no longer valid
 	}
 }
 
 // ClientOptions holds all the configuration for connection, dialer and transport
 type ClientOptions struct {
-	BackOffExponentFactor          float64       `json:"back_off_exponent_factor"`
-	BackOffInitialTimeout          time.Duration `json:"back_off_initial_timeout"`
-	BackOffMaximumJitterInterval   time.Duration `json:"back_off_maximum_jitter_interval"`
-	BackOffMaxTimeout              time.Duration `json:"back_off_max_timeout"`
-	DialerKeepAlive                time.Duration `json:"dialer_keep_alive"`
-	DialerTimeout                  time.Duration `json:"dialer_timeout"`
-	RequestRetryCount              int           `json:"request_retry_count"`
-	RequestTimeout                 time.Duration `json:"request_timeout"`
-	TransportExpectContinueTimeout time.Duration `json:"transport_expect_continue_timeout"`
-	TransportIdleTimeout           time.Duration `json:"transport_idle_timeout"`
-	TransportMaxIdleConnections    int           `json:"transport_max_idle_connections"`
-	TransportTLSHandshakeTimeout   time.Duration `json:"transport_tls_handshake_timeout"`
-	UserAgent                      string        `json:"user_agent"`
+	BackOffExponentFactor              float64       `json:"back_off_exponent_factor"`
+	BackOffInitialTimeout              time.Duration `json:"back_off_initial_timeout"`
+	BackOffMaximumJitterInterval       time.Duration `json:"back_off_maximum_jitter_interval"`
+	BackOffMaxTimeout                  time.Duration `json:"back_off_max_timeout"`
+	Clock                              Clock         `json:"-"`                 // Time source of expiry checks, caches and pollers (defaults to RealClock)
+	CompressMinSize                    int64         `json:"compress_min_size"` // Min submit body size (bytes) compressed for miners that support it (0 = disabled)
+	DialerKeepAlive                    time.Duration `json:"dialer_keep_alive"`
+	DialerTimeout                      time.Duration `json:"dialer_timeout"`
+	DNSCacheTTL                        time.Duration `json:"dns_cache_ttl"`      // Cache miner hostname lookups (overrides the record TTL, 0 = disabled)
+	MaxConcurrency                     int           `json:"max_concurrency"`    // Max requests in flight when fanning out to all miners (IE: BestQuote, 0 = no limit)
+	MaxEnvelopeSize                    int64         `json:"max_envelope_size"`  // Max response size (bytes), larger responses fail (0 = no limit)
+	MaxTxSize                          int64         `json:"max_tx_size"`        // Max raw tx size (bytes) checked before submitting (0 = no limit)
+	OfflineQueueSize                   int           `json:"offline_queue_size"` // Max submissions queued when all miners are unreachable (0 = disabled)
+	ParseMode                          ParseMode     `json:"parse_mode"`         // Strict rejects responses that deviate from the specs (defaults to ParseModeLenient)
+	Preflight                          bool          `json:"preflight"`          // Validate submissions locally before sending (see: WithPreflight)
+	QueryTimeout                       time.Duration `json:"query_timeout"`      // Timeout for querying a transaction
+	QueryWait                          time.Duration `json:"query_wait"`         // Long-poll wait of tx queries to miners that support it (see: Miner.LongPoll, 0 = disabled)
+	QueueOverBudget                    bool          `json:"queue_over_budget"`  // Wait for the request budget of a miner to reset instead of refusing (see: Miner.HourlyBudget)
+	QuoteCacheEnabled                  bool          `json:"quote_cache_enabled"`
+	QuoteFailureTTL                    time.Duration `json:"quote_failure_ttl"` // How long a failed quote request is cached (0 = disabled)
+	QuoteTimeout                       time.Duration `json:"quote_timeout"`     // Timeout for requesting a fee quote
+	RequestRetryCount                  int           `json:"request_retry_count"`
+	RequestTimeout                     time.Duration `json:"request_timeout"`      // Default timeout (if an operation timeout is not set)
+	StatusPollInterval                 time.Duration `json:"status_poll_interval"` // Interval of status polling when a miner has no status stream (see: SubscribeTxStatus)
+	StrictMinerID                      bool          `json:"strict_miner_id"`      // Fail responses signed with a new key without a valid rotation
+	SubmitTimeout                      time.Duration `json:"submit_timeout"`       // Timeout for submitting a transaction
+	TransportExpectContinueTimeout     time.Duration `json:"transport_expect_continue_timeout"`
+	TransportIdleTimeout               time.Duration `json:"transport_idle_timeout"`
+	TransportMaxIdleConnections        int           `json:"transport_max_idle_connections"`
+	TransportMaxIdleConnectionsPerHost int           `json:"transport_max_idle_connections_per_host"` // Idle connections kept to each miner
+	TransportTLSHandshakeTimeout       time.Duration `json:"transport_tls_handshake_timeout"`
+	UserAgent                          string        `json:"user_agent"`
+	ValidateMiners                     bool          `json:"validate_miners"`   // Validate all miners (reachability) in NewClient()
+	ValidatePayloads                   bool          `json:"validate_payloads"` // Attach the schema violations of the payloads to the responses (see: ValidatePayload)
 }
 
 // DefaultClientOptions will return an Options struct with the default settings.
 // Useful for starting with the default and then modifying as needed
 func DefaultClientOptions() (clientOptions *ClientOptions) {
 	return &ClientOptions{
-		BackOffExponentFactor:          2.0,
-		BackOffInitialTimeout:          2 * time.Millisecond,
-		BackOffMaximumJitterInterval:   2 * time.Millisecond,
-		BackOffMaxTimeout:              10 * time.Millisecond,
-		DialerKeepAlive:                20 * time.Second,
-		DialerTimeout:                  5 * time.Second,
-		RequestRetryCount:              2,
-		RequestTimeout:                 10 * time.Second,
-		TransportExpectContinueTimeout: 3 * time.Second,
-		TransportIdleTimeout:           20 * time.Second,
-		TransportMaxIdleConnections:    10,
-		TransportTLSHandshakeTimeout:   5 * time.Second,
-		UserAgent:                      defaultUserAgent,
+		BackOffExponentFactor:              2.0,
+		BackOffInitialTimeout:              2 * time.Millisecond,
+		BackOffMaximumJitterInterval:       2 * time.Millisecond,
+		BackOffMaxTimeout:                  10 * time.Millisecond,
+		Clock:                              RealClock(),
+		CompressMinSize:                    DefaultCompressMinSize,
+		DialerKeepAlive:                    20 * time.Second,
+		DialerTimeout:                      5 * time.Second,
+		DNSCacheTTL:                        0,
+		MaxConcurrency:                     DefaultMaxConcurrency,
+		MaxEnvelopeSize:                    DefaultMaxEnvelopeSize,
+		MaxTxSize:                          DefaultMaxTxSize,
+		OfflineQueueSize:                   0,
+		ParseMode:                          ParseModeLenient,
+		Preflight:                          false,
+		QueryTimeout:                       10 * time.Second,
+		QueryWait:                          0,
+		QueueOverBudget:                    false,
+		QuoteCacheEnabled:                  false,
+		QuoteFailureTTL:                    0,
+		QuoteTimeout:                       5 * time.Second,
+		RequestRetryCount:                  2,
+		RequestTimeout:                     10 * time.Second,
+		StatusPollInterval:                 10 * time.Second,
+		StrictMinerID:                      false,
+		SubmitTimeout:                      30 * time.Second,
+		TransportExpectContinueTimeout:     3 * time.Second,
+		TransportIdleTimeout:               20 * time.Second,
+		TransportMaxIdleConnections:        100,
+		TransportMaxIdleConnectionsPerHost: 10,
+		TransportTLSHandshakeTimeout:       5 * time.Second,
+		UserAgent:                          defaultUserAgent,
+		ValidateMiners:                     false,
+		ValidatePayloads:                   false,
+	}
+}
+
+// timeout will return the operation timeout (or the default RequestTimeout if not set)
+func (o *ClientOptions) timeout(operationTimeout time.Duration) time.Duration {
+	if operationTimeout > 0 {
+		return operationTimeout
+	}
+	return o.RequestTimeout
+}
+
+// minerTimeout will return the timeout for a request to the miner (the miner's timeout overrides the operation timeout)
+//
+// A tighter deadline on the caller's context is always kept
+func (c *Client) minerTimeout(miner *Miner, operationTimeout time.Duration) time.Duration {
+	if miner != nil && miner.Timeout > 0 {
+		return miner.Timeout
 	}
+	return c.Options.timeout(operationTimeout)
 }
 
 // NewClient creates a new client for requests
+//
+// If ValidateMiners is enabled, all miners are validated (reachability) using a fee quote
+// request and an error is returned if any miner fails, otherwise miners are validated lazily (on the first request).
+// Tokens are only caught if the miner rejects the quote request (miners can serve quotes without a token)
 func NewClient(clientOptions *ClientOptions, customHTTPClient *http.Client) (client *Client, err error) {
 
 	// Create the new client
 	client = createClient(clientOptions, customHTTPClient)
 
-	// Load all known miners
-	err = json.Unmarshal([]byte(KnownMiners), &client.Miners)
+	// Load all known miners (from the embedded registry)
+	var registry *MinerRegistry
+	if registry, err = DefaultMinerRegistry(); err != nil {
+		return
+	}
+	if err = validateMinerConfig(registry.Miners); err != nil {
+		return
+	}
+	client.Miners = registry.Miners
+	client.registryVersion = registry.Version
+
+	// Validate the miners now (eager) vs on the first request (lazy)
+	if client.Options.ValidateMiners {
+		err = client.validateMiners(context.Background())
+	}
 
 	return
 }
 
+// validateMiners will run a health check on all miners and return an error if any miner is unhealthy
+func (c *Client) validateMiners(ctx context.Context) error {
+	summary, _ := c.WarmUp(ctx)
+	var failures []string
+	for _, health := range summary.Miners {
+		if health.Healthy {
+			continue
+		} else if health.Miner == nil {
+			failures = append(failures, health.Error)
+		} else {
+			failures = append(failures, health.Miner.Name+": "+health.Error)
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("failed validating miners: %s", strings.Join(failures, ", "))
+	}
+	return nil
+}
+
 // createClient will make a new http client based on the options provided
 func createClient(options *ClientOptions, customHTTPClient *http.Client) (c *Client) {
 
 	// Create a client
 	c = new(Client)
-
-	// Is there a custom HTTP client to use?
-	if customHTTPClient != nil {
-		c.httpClient = customHTTPClient
-		return
-	}
+	c.budgets = newRequestBudgets()
+	c.certificates = newClientCertificates()
+	c.confirmations = newConfirmationTracker()
+	c.endpoints = newEndpointRegistry()
+	c.events = newEventBus()
+	c.feeAlerts = &feeAlerts{alerts: make(map[int]*feeAlert)}
+	c.health = newHealthRegistry()
+	c.hooks = new(hooks)
+	c.journal = new(journal)
+	c.minerIDs = newMinerIDRegistry()
+	c.notifications = newNotificationHub()
+	c.offline = new(offlineQueue)
+	c.quoteCache = newQuoteCache()
+	c.rateLimits = newRateLimiter()
+	c.tenants = newTenantRegistry()
+	c.tokens = new(tokenRegistry)
+	c.usage = newUsageTracker()
 
 	// Set options (either default or user modified)
 	if options == nil {
 		options = DefaultClientOptions()
 	}
+	c.Options = options
 
-	// dial is the net dialer for clientDefaultTransport
-	dial := &net.Dialer{KeepAlive: options.DialerKeepAlive, Timeout: options.DialerTimeout}
+	// Is there a custom HTTP client to use?
+	if customHTTPClient != nil {
+		c.httpClient = customHTTPClient
+		c.streamClient = customHTTPClient
+		return
+	}
 
-	// clientDefaultTransport is the default transport struct for the HTTP client
-	clientDefaultTransport := &http.Transport{
-		DialContext:           dial.DialContext,
-		ExpectContinueTimeout: options.TransportExpectContinueTimeout,
-		IdleConnTimeout:       options.TransportIdleTimeout,
-		MaxIdleConns:          options.TransportMaxIdleConnections,
-		Proxy:                 http.ProxyFromEnvironment,
-		TLSHandshakeTimeout:   options.TransportTLSHandshakeTimeout,
+	// clientDefaultTransport is the shared transport for all requests (see: NewTransport)
+	clientDefaultTransport := NewTransport(options)
+	clientDefaultTransport.TLSClientConfig = &tls.Config{
+		GetClientCertificate: c.certificates.getClientCertificate(nil), // Mutual TLS (see: SetClientCertificate)
+		MinVersion:           tls.VersionTLS12,
 	}
+	c.transport = clientDefaultTransport
+	c.transports = newMinerTransports(clientDefaultTransport, c.certificates)
 
-	// Set the options
-	c.Options = options
+	// Streamed requests skip the retries (the retrier buffers the entire body)
+	c.streamClient = &http.Client{Transport: c.transports}
 
 	// Determine the strategy for the http client
+	// (timeouts are applied to each request using the context, see: ClientOptions.QuoteTimeout)
 	if options.RequestRetryCount <= 0 {
 
 		// no retry enabled
 		c.httpClient = httpclient.NewClient(
 			httpclient.WithHTTPTimeout(options.RequestTimeout),
 			httpclient.WithHTTPClient(&http.Client{
-				Transport: clientDefaultTransport,
-				Timeout:   options.RequestTimeout,
+				Transport: c.transports,
 			}),
 		)
 		return
@@ -192,8 +333,7 @@ func createClient(options *ClientOptions, customHTTPClient *http.Client) (c *Cli
 			))),
 		httpclient.WithRetryCount(options.RequestRetryCount),
 		httpclient.WithHTTPClient(&http.Client{
-			Transport: clientDefaultTransport,
-			Timeout:   options.RequestTimeout,
+			Transport: c.transports,
 		}),
 	)
 
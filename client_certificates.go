@@ -0,0 +1,83 @@
+package minercraft
+
+import (
+	"crypto/tls"
+	"errors"
+	"strings"
+	"sync"
+)
+
+// ErrClientCertificatesUnsupported is returned when setting a client certificate on a client with a custom HTTP client
+// (configure the certificates on the custom transport instead)
+var ErrClientCertificatesUnsupported = errors.New("client certificates require the default transport")
+
+// clientCertificates is the mutual TLS client certificate of each miner (by name) and the default certificate
+type clientCertificates struct {
+	sync.RWMutex
+	defaultCert *tls.Certificate
+	miners      map[string]*tls.Certificate
+}
+
+// newClientCertificates will return a new registry without certificates
+func newClientCertificates() *clientCertificates {
+	return &clientCertificates{miners: make(map[string]*tls.Certificate)}
+}
+
+// SetClientCertificate will set the mutual TLS client certificate sent to the miner (by name)
+// or the default certificate sent to all other miners (if the name is empty), nil removes the certificate
+//
+// Certificates are only sent if the miner requests one (most miners don't)
+func (c *Client) SetClientCertificate(minerName string, cert *tls.Certificate) error {
+	if c.transport == nil {
+		return ErrClientCertificatesUnsupported
+	}
+
+	c.certificates.Lock()
+	defer c.certificates.Unlock()
+	if len(minerName) == 0 {
+		c.certificates.defaultCert = cert
+	} else if cert == nil {
+		delete(c.certificates.miners, strings.ToLower(minerName))
+	} else {
+		c.certificates.miners[strings.ToLower(minerName)] = cert
+	}
+
+	// Connections keep the certificate of their handshake
+	c.transports.closeIdleConnections()
+	return nil
+}
+
+// LoadClientCertificate will load the certificate and key (PEM files) and set it as the client certificate of the miner
+// (or the default certificate if the name is empty, see: SetClientCertificate)
+func (c *Client) LoadClientCertificate(minerName, certFile, keyFile string) error {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	return c.SetClientCertificate(minerName, &cert)
+}
+
+// clientCertificate will return the certificate of the miner (or the default certificate)
+func (r *clientCertificates) clientCertificate(miner *Miner) *tls.Certificate {
+	r.RLock()
+	defer r.RUnlock()
+	if miner != nil {
+		if cert, ok := r.miners[strings.ToLower(miner.Name)]; ok {
+			return cert
+		}
+	}
+	return r.defaultCert
+}
+
+// getClientCertificate will return the function returning the certificate of the miner for a handshake
+// (see: tls.Config.GetClientCertificate), a nil miner uses the default certificate
+//
+// An empty certificate is returned if none is set (the miner decides if the handshake continues)
+func (r *clientCertificates) getClientCertificate(miner *Miner) func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		if cert := r.clientCertificate(miner); cert != nil {
+			return cert, nil
+		}
+		return new(tls.Certificate), nil
+	}
+}
@@ -0,0 +1,104 @@
+package minercraft
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"io/ioutil"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// testClientCertificate will return a self-signed client certificate
+func testClientCertificate(t *testing.T) *tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	template := &x509.Certificate{
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		NotAfter:     time.Now().Add(time.Hour),
+		NotBefore:    time.Now().Add(-time.Hour),
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "minercraft"},
+	}
+	var der []byte
+	if der, err = x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return &tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// TestClient_SetClientCertificate tests the method SetClientCertificate()
+func TestClient_SetClientCertificate(t *testing.T) {
+	t.Parallel()
+
+	// The server requires a client certificate
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert, MinVersion: tls.VersionTLS12}
+	server.Config.ErrorLog = log.New(ioutil.Discard, "", 0) // Failed handshakes are expected
+	server.StartTLS()
+	defer server.Close()
+
+	options := DefaultClientOptions()
+	options.RequestRetryCount = 0
+	client, err := NewClient(options, nil)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	client.Transport().TLSClientConfig.RootCAs = server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	request := func(miner *Miner) *RequestResponse {
+		return httpRequest(context.Background(), client, &httpPayload{Method: http.MethodGet, Miner: miner, URL: server.URL})
+	}
+
+	t.Run("no certificate", func(t *testing.T) {
+		if response := request(&Miner{Name: "Other"}); response.Error == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("miner certificate", func(t *testing.T) {
+		if err = client.SetClientCertificate("Private", testClientCertificate(t)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		response := request(&Miner{Name: "private"})
+		if response.Error != nil {
+			t.Fatalf("error occurred: %s", response.Error.Error())
+		} else if string(response.BodyContents) != "minercraft" {
+			t.Fatalf("expected the client certificate, got: %s", response.BodyContents)
+		}
+	})
+
+	t.Run("remove certificate", func(t *testing.T) {
+		cert := testClientCertificate(t)
+		_ = client.SetClientCertificate("", cert)
+		_ = client.SetClientCertificate("Private", nil)
+		if got := client.certificates.clientCertificate(&Miner{Name: "Private"}); got != cert {
+			t.Fatalf("expected the default certificate")
+		}
+	})
+
+	t.Run("custom http client", func(t *testing.T) {
+		custom, _ := NewClient(nil, &http.Client{})
+		if err = custom.SetClientCertificate("", testClientCertificate(t)); err != ErrClientCertificatesUnsupported {
+			t.Fatalf("expected error: %v got: %v", ErrClientCertificatesUnsupported, err)
+		}
+	})
+
+	t.Run("invalid files", func(t *testing.T) {
+		if err = client.LoadClientCertificate("Private", "missing.crt", "missing.key"); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
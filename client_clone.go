@@ -0,0 +1,53 @@
+package minercraft
+
+// CloneOption will change a cloned client (see: Client.With)
+type CloneOption func(c *Client)
+
+// With will return a shallow clone of the client with different defaults (IE: per tenant)
+//
+// The clone shares the http clients (connection pools), caches, health, rate limits, request budgets, usage,
+// fee alerts, custom endpoints and store of the client. The list of miners and the options are copied,
+// so the clone can use a different miner subset or timeouts without changing the client.
+// Note: options used to create the http client (dialer, transport and retries) are not applied to the clone
+func (c *Client) With(options ...CloneOption) *Client {
+	clone := *c
+	clone.Miners = append(MinerSlice{}, c.Miners...)
+	clientOptions := *c.Options
+	clone.Options = &clientOptions
+	for _, option := range options {
+		option(&clone)
+	}
+	return &clone
+}
+
+// WithMiners will only keep the miners with the given names (case-insensitive)
+func WithMiners(names ...string) CloneOption {
+	return func(c *Client) {
+		miners := make(MinerSlice, 0, len(names))
+		for _, name := range names {
+			if miner := c.MinerByName(name); miner != nil {
+				miners = append(miners, miner)
+			}
+		}
+		c.Miners = miners
+	}
+}
+
+// WithOptions will change the (copied) options of the clone (IE: timeouts or quote caching)
+func WithOptions(change func(options *ClientOptions)) CloneOption {
+	return func(c *Client) {
+		change(c.Options)
+	}
+}
+
+// WithTenant will set the tenant of the requests of the clone (see: OnUsage)
+func WithTenant(tenant string) CloneOption {
+	return func(c *Client) {
+		c.tenant = tenant
+	}
+}
+
+// Tenant will return the tenant of the client (see: WithTenant)
+func (c *Client) Tenant() string {
+	return c.tenant
+}
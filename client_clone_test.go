@@ -0,0 +1,67 @@
+package minercraft
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestClient_With tests the method With()
+func TestClient_With(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCountingQuote{expiresIn: time.Minute}
+	client := newTestCachingClient(mock)
+	clone := client.With(
+		WithMiners(MinerTaal, "unknown", "MEMPOOL"),
+		WithOptions(func(options *ClientOptions) {
+			options.QuoteTimeout = time.Second
+		}),
+	)
+
+	// The miners and options are changed on the clone only
+	if len(clone.Miners) != 2 || clone.MinerByName(MinerMatterpool) != nil {
+		t.Fatalf("expected %d miners but got: %d", 2, len(clone.Miners))
+	} else if len(client.Miners) != 3 {
+		t.Fatalf("expected %d miners but got: %d", 3, len(client.Miners))
+	} else if clone.Options.QuoteTimeout != time.Second || client.Options.QuoteTimeout == time.Second {
+		t.Fatalf("expected the quote timeout to only change on the clone")
+	}
+
+	// Adding a miner to the clone does not change the client
+	if err := clone.AddMiner(Miner{Name: testMinerName, URL: testMinerURL}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if client.MinerByName(testMinerName) != nil {
+		t.Fatalf("expected the miner to only be added to the clone")
+	}
+
+	// The quote cache is shared
+	if _, err := clone.FeeQuote(clone.MinerByName(MinerTaal)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if _, err = client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if mock.count() != 1 {
+		t.Fatalf("expected %d requests, got %d", 1, mock.count())
+	}
+}
+
+// ExampleClient_With example using With()
+func ExampleClient_With() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPDefaultClient{})
+
+	// Create a tenant client that only uses Taal
+	tenant := client.With(WithMiners(MinerTaal), WithOptions(func(options *ClientOptions) {
+		options.SubmitTimeout = time.Minute
+	}))
+	fmt.Printf("miners: %d timeout: %s", len(tenant.Miners), tenant.Options.SubmitTimeout)
+	// Output:miners: 1 timeout: 1m0s
+}
+
+// BenchmarkClient_With benchmarks the method With()
+func BenchmarkClient_With(b *testing.B) {
+	client := newTestClient(&mockHTTPDefaultClient{})
+	for i := 0; i < b.N; i++ {
+		_ = client.With(WithMiners(MinerTaal))
+	}
+}
@@ -0,0 +1,112 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// clientStateVersion is the version of the client state snapshot
+const clientStateVersion = 1
+
+// ClientState is a snapshot of the client state (see: Export and Import), it can be serialized as JSON
+type ClientState struct {
+	Health          []*MinerHealth      `json:"health"`           // Last known health of each miner
+	Miners          []*Miner            `json:"miners"`           // List of loaded miners
+	Quotes          []*FeeQuoteResponse `json:"quotes"`           // Unexpired cached quotes (signed envelopes)
+	RegistryVersion string              `json:"registry_version"` // Version of the miner registry that was loaded
+	Version         int                 `json:"version"`          // Version of the snapshot
+}
+
+// Export will return a snapshot of the miners, the unexpired cached quotes and the health of the miners
+//
+// A restarting service can resume with the snapshot (see: Import) instead of cold-starting every miner
+func (c *Client) Export() *ClientState {
+	state := &ClientState{RegistryVersion: c.registryVersion, Version: clientStateVersion}
+	for _, miner := range c.Miners {
+		minerCopy := *miner
+		state.Miners = append(state.Miners, &minerCopy)
+		if cached := c.quoteCache.get(miner, c.now()); cached != nil {
+			state.Quotes = append(state.Quotes, cached.quote)
+		}
+		if health := c.MinerHealth(miner.Name); health != nil {
+			healthCopy := *health
+			healthCopy.Miner = &minerCopy
+			state.Health = append(state.Health, &healthCopy)
+		}
+	}
+	return state
+}
+
+// Import will restore a snapshot (see: Export), replacing the miners and restoring the cached quotes and health
+//
+// The miners are validated (see: ValidateMiner), the signature of each quote is validated again
+// and the quote is decoded from the signed payload. Expired quotes, and quotes or health
+// of unknown miners are skipped
+func (c *Client) Import(state *ClientState) error {
+
+	// Make sure we have a valid state
+	if state == nil {
+		return errors.New("state was nil")
+	} else if state.Version != clientStateVersion {
+		return fmt.Errorf("unsupported state version: %d", state.Version)
+	}
+
+	// Replace the miners (if any)
+	if len(state.Miners) > 0 {
+		miners := make(MinerSlice, 0, len(state.Miners))
+		for _, miner := range state.Miners {
+			if miner == nil {
+				return ErrNilMiner
+			}
+			minerCopy := *miner
+			miners = append(miners, &minerCopy)
+		}
+		if err := validateMinerConfig(miners); err != nil {
+			return err
+		}
+		c.Miners = miners
+		c.registryVersion = state.RegistryVersion
+	}
+
+	// Restore the quotes (decoded from the signed payload)
+	for _, quote := range state.Quotes {
+		if quote == nil || quote.Miner == nil {
+			continue
+		}
+		miner := c.MinerByName(quote.Miner.Name)
+		if miner == nil {
+			continue
+		}
+		restored := &FeeQuoteResponse{JSONEnvelope: JSONEnvelope{
+			Encoding:  quote.Encoding,
+			Miner:     miner,
+			MimeType:  quote.MimeType,
+			Payload:   quote.Payload,
+			PublicKey: quote.PublicKey,
+			Signature: quote.Signature,
+		}}
+		if err := restored.revalidate(c); err != nil {
+			continue
+		} else if err = restored.decodePayload(&restored.Quote); err != nil || restored.Quote == nil {
+			continue
+		}
+		restored.checkPayloadMinerID(restored.Quote.MinerID)
+		endpoint, _ := buildURL(miner, routeFeeQuote)
+		c.quoteCache.set(miner, restored, &RequestResponse{Method: http.MethodGet, StatusCode: http.StatusOK, URL: endpoint}, c.now())
+	}
+
+	// Restore the health
+	for _, health := range state.Health {
+		if health == nil || health.Miner == nil {
+			continue
+		}
+		if miner := c.MinerByName(health.Miner.Name); miner != nil {
+			healthCopy := *health
+			healthCopy.Miner = miner
+			c.health.set(&healthCopy)
+		}
+	}
+
+	return nil
+}
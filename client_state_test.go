@@ -0,0 +1,121 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestClient_ExportImport tests the methods Export() and Import()
+func TestClient_ExportImport(t *testing.T) {
+	t.Parallel()
+
+	// Warm up a client (custom miner, cached quote and health)
+	mockA := &mockHTTPCountingQuote{expiresIn: time.Minute}
+	clientA := newTestCachingClient(mockA)
+	if err := clientA.AddMiner(Miner{Name: testMinerName, URL: testMinerURL, Token: testMinerToken}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if _, err = clientA.FeeQuote(clientA.MinerByName(MinerTaal)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if health := clientA.HealthCheck(context.Background(), clientA.MinerByName(MinerMempool)); !health.Healthy {
+		t.Fatalf("expected a healthy miner but got: %s", health.Error)
+	}
+
+	// Export the state (as JSON)
+	state := clientA.Export()
+	if len(state.Miners) != len(clientA.Miners) || len(state.Quotes) != 2 || len(state.Health) != 1 {
+		t.Fatalf("unexpected state: %d miners %d quotes %d health", len(state.Miners), len(state.Quotes), len(state.Health))
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Import the state in a new client
+	var restored ClientState
+	if err = json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	mockB := &mockHTTPCountingQuote{expiresIn: time.Minute}
+	clientB := newTestCachingClient(mockB)
+	if err = clientB.Import(&restored); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// The miners, quotes and health are restored
+	if miner := clientB.MinerByName(testMinerName); miner == nil || miner.Token != testMinerToken {
+		t.Fatalf("expected the miner [%s] but got: %v", testMinerName, miner)
+	} else if clientB.RegistryVersion() != clientA.RegistryVersion() {
+		t.Fatalf("expected registry version [%s] but got: %s", clientA.RegistryVersion(), clientB.RegistryVersion())
+	} else if health := clientB.MinerHealth(MinerMempool); health == nil || !health.Healthy || health.Miner != clientB.MinerByName(MinerMempool) {
+		t.Fatalf("expected the health of [%s] but got: %v", MinerMempool, health)
+	}
+	for _, name := range []string{MinerTaal, MinerMempool} {
+		if response, err := clientB.FeeQuote(clientB.MinerByName(name)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Quote == nil || len(response.Quote.Fees) != 2 {
+			t.Fatalf("expected the restored quote but got: %v", response.Quote)
+		}
+	}
+	if mockB.count() != 0 {
+		t.Fatalf("expected %d requests, got %d", 0, mockB.count())
+	}
+
+	// The state is a copy
+	state.Miners[0].Token = "changed"
+	if clientA.Miners[0].Token == "changed" {
+		t.Fatalf("expected the exported miners to be copies")
+	}
+}
+
+// TestClient_ImportInvalid tests the method Import() with an invalid state
+func TestClient_ImportInvalid(t *testing.T) {
+	t.Parallel()
+
+	client := newTestCachingClient(&mockHTTPCountingQuote{expiresIn: time.Minute})
+	if err := client.Import(nil); err == nil {
+		t.Fatalf("error should have occurred")
+	} else if err = client.Import(&ClientState{Version: clientStateVersion + 1}); err == nil {
+		t.Fatalf("error should have occurred")
+	} else if err = client.Import(&ClientState{Version: clientStateVersion, Miners: []*Miner{nil}}); !errors.Is(err, ErrNilMiner) {
+		t.Fatalf("expected [%v] but got: %v", ErrNilMiner, err)
+	} else if err = client.Import(&ClientState{Version: clientStateVersion, Miners: []*Miner{
+		{Name: "Test", URL: "a.com"}, {Name: "test", URL: "b.com"},
+	}}); !errors.Is(err, ErrDuplicateMinerName) {
+		t.Fatalf("expected [%v] but got: %v", ErrDuplicateMinerName, err)
+	}
+
+	// Unknown miners and invalid quotes are skipped (the miners are kept)
+	if err := client.Import(&ClientState{Version: clientStateVersion, Quotes: []*FeeQuoteResponse{
+		nil,
+		{JSONEnvelope: JSONEnvelope{Miner: &Miner{Name: "unknown"}}},
+		{JSONEnvelope: JSONEnvelope{Miner: &Miner{Name: MinerTaal}, Payload: "invalid"}},
+	}, Health: []*MinerHealth{nil, {Miner: &Miner{Name: "unknown"}}}}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(client.Export().Quotes) != 0 || client.MinerByName(MinerTaal) == nil {
+		t.Fatalf("expected no quotes to be restored")
+	}
+}
+
+// ExampleClient_Export example using Export()
+func ExampleClient_Export() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestCachingClient(&mockHTTPCountingQuote{expiresIn: time.Minute})
+	if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	// Save the state (IE: before a restart) and restore it in a new client
+	state := client.Export()
+	restarted := newTestCachingClient(&mockHTTPCountingQuote{expiresIn: time.Minute})
+	if err := restarted.Import(state); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("miners: %d quotes: %d", len(restarted.Miners), len(restarted.Export().Quotes))
+	// Output:miners: 3 quotes: 1
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 )
@@ -46,6 +47,7 @@ func (m *mockHTTPDefaultClient) Do(req *http.Request) (*http.Response, error) {
 func newTestClient(httpClient httpInterface) *Client {
 	client, _ := NewClient(nil, nil)
 	client.httpClient = httpClient
+	client.streamClient = httpClient
 	return client
 }
 
@@ -164,6 +166,22 @@ func TestDefaultClientOptions(t *testing.T) {
 		t.Fatalf("expected value: %v got: %v", 5*time.Second, options.DialerTimeout)
 	}
 
+	if options.MaxTxSize != DefaultMaxTxSize {
+		t.Fatalf("expected value: %v got: %v", DefaultMaxTxSize, options.MaxTxSize)
+	}
+
+	if options.QueryTimeout != 10*time.Second {
+		t.Fatalf("expected value: %v got: %v", 10*time.Second, options.QueryTimeout)
+	}
+
+	if options.QuoteCacheEnabled {
+		t.Fatalf("expected value: %v got: %v", false, options.QuoteCacheEnabled)
+	}
+
+	if options.QuoteTimeout != 5*time.Second {
+		t.Fatalf("expected value: %v got: %v", 5*time.Second, options.QuoteTimeout)
+	}
+
 	if options.RequestRetryCount != 2 {
 		t.Fatalf("expected value: %v got: %v", 2, options.RequestRetryCount)
 	}
@@ -172,6 +190,10 @@ func TestDefaultClientOptions(t *testing.T) {
 		t.Fatalf("expected value: %v got: %v", 10*time.Second, options.RequestTimeout)
 	}
 
+	if options.SubmitTimeout != 30*time.Second {
+		t.Fatalf("expected value: %v got: %v", 30*time.Second, options.SubmitTimeout)
+	}
+
 	if options.TransportExpectContinueTimeout != 3*time.Second {
 		t.Fatalf("expected value: %v got: %v", 3*time.Second, options.TransportExpectContinueTimeout)
 	}
@@ -180,13 +202,21 @@ func TestDefaultClientOptions(t *testing.T) {
 		t.Fatalf("expected value: %v got: %v", 20*time.Second, options.TransportIdleTimeout)
 	}
 
-	if options.TransportMaxIdleConnections != 10 {
-		t.Fatalf("expected value: %v got: %v", 10, options.TransportMaxIdleConnections)
+	if options.TransportMaxIdleConnections != 100 {
+		t.Fatalf("expected value: %v got: %v", 100, options.TransportMaxIdleConnections)
+	}
+
+	if options.TransportMaxIdleConnectionsPerHost != 10 {
+		t.Fatalf("expected value: %v got: %v", 10, options.TransportMaxIdleConnectionsPerHost)
 	}
 
 	if options.TransportTLSHandshakeTimeout != 5*time.Second {
 		t.Fatalf("expected value: %v got: %v", 5*time.Second, options.TransportTLSHandshakeTimeout)
 	}
+
+	if options.ValidateMiners {
+		t.Fatalf("expected value: %v got: %v", false, options.ValidateMiners)
+	}
 }
 
 // ExampleDefaultClientOptions example using DefaultClientOptions()
@@ -489,3 +519,52 @@ func BenchmarkClient_MinerUpdateToken(b *testing.B) {
 		_ = client.MinerByName(MinerTaal)
 	}
 }
+
+// mockRoundTripper wraps a mock http interface as a http.RoundTripper (for use with a custom http.Client)
+type mockRoundTripper struct {
+	httpInterface
+}
+
+// RoundTrip is a mock http round trip
+func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	return m.Do(req)
+}
+
+// TestNewClient_ValidateMiners tests NewClient with eager miner validation
+func TestNewClient_ValidateMiners(t *testing.T) {
+	t.Parallel()
+
+	options := DefaultClientOptions()
+	options.ValidateMiners = true
+
+	t.Run("all miners valid", func(t *testing.T) {
+		client, err := NewClient(options, &http.Client{Transport: &mockRoundTripper{&mockHTTPValidBestQuote{}}})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if client.MinerHealth(MinerTaal) == nil {
+			t.Fatalf("expected miners to be health checked")
+		}
+	})
+
+	t.Run("invalid miners", func(t *testing.T) {
+		client, err := NewClient(options, &http.Client{Transport: &mockRoundTripper{&mockHTTPPartialQuotes{}}})
+		if err == nil {
+			t.Fatalf("error should have occurred")
+		} else if client == nil {
+			t.Fatalf("expected client to not be nil")
+		} else if !strings.Contains(err.Error(), MinerMempool) || !strings.Contains(err.Error(), MinerMatterpool) {
+			t.Fatalf("expected error to contain the invalid miners, got: %s", err.Error())
+		} else if strings.Contains(err.Error(), MinerTaal) {
+			t.Fatalf("expected error to not contain %s, got: %s", MinerTaal, err.Error())
+		}
+	})
+
+	t.Run("lazy validation (default)", func(t *testing.T) {
+		client, err := NewClient(nil, &http.Client{Transport: &mockRoundTripper{&mockHTTPError{}}})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if client.MinerHealth(MinerTaal) != nil {
+			t.Fatalf("expected miners to not be health checked")
+		}
+	})
+}
@@ -0,0 +1,90 @@
+package minercraft
+
+import "time"
+
+// Clock is the time source of the client: expiry checks, caches, rate limits and pollers use it
+// (IE: a fake clock to test quote expiry and retry schedules deterministically, see: ClientOptions.Clock)
+//
+// Note: the backoff between the retries of the default http client uses the real time
+type Clock interface {
+	NewTicker(d time.Duration) Ticker
+	NewTimer(d time.Duration) Timer
+	Now() time.Time
+}
+
+// Timer is a timer of a Clock (see: time.Timer)
+type Timer interface {
+	C() <-chan time.Time
+	Reset(d time.Duration) bool
+	Stop() bool
+}
+
+// Ticker is a ticker of a Clock (see: time.Ticker)
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// realClock is the Clock of the real time
+type realClock struct{}
+
+// realTimer is a Timer of the real time
+type realTimer struct {
+	*time.Timer
+}
+
+// realTicker is a Ticker of the real time
+type realTicker struct {
+	*time.Ticker
+}
+
+// RealClock will return the Clock of the real time (the default of the client)
+func RealClock() Clock {
+	return realClock{}
+}
+
+// NewTicker will return a new ticker (see: time.NewTicker)
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+// NewTimer will return a new timer (see: time.NewTimer)
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// Now will return the current time
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// C will return the channel of the timer
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
+
+// C will return the channel of the ticker
+func (t realTicker) C() <-chan time.Time {
+	return t.Ticker.C
+}
+
+// clock will return the clock of the options (the real time if not set, or the options are nil)
+func (o *ClientOptions) clock() Clock {
+	if o == nil || o.Clock == nil {
+		return realClock{}
+	}
+	return o.Clock
+}
+
+// clock will return the clock of the client (the real time if not set, or the client is nil)
+func (c *Client) clock() Clock {
+	if c == nil {
+		return realClock{}
+	}
+	return c.Options.clock()
+}
+
+// now will return the current time of the clock of the client
+func (c *Client) now() time.Time {
+	return c.clock().Now()
+}
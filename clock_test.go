@@ -0,0 +1,223 @@
+package minercraft
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when advanced
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// fakeTimer is a timer (or ticker if period > 0) of the fake clock
+type fakeTimer struct {
+	c      chan time.Time
+	clock  *fakeClock
+	fireAt time.Time
+	period time.Duration
+	active bool
+}
+
+// fakeTicker is a ticker of the fake clock
+type fakeTicker struct {
+	*fakeTimer
+}
+
+// Stop will stop the ticker
+func (t fakeTicker) Stop() {
+	t.fakeTimer.Stop()
+}
+
+// newFakeClock will return a fake clock at the given time
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+// Now will return the current time of the fake clock
+func (f *fakeClock) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTimer will return a timer firing once the clock is advanced by d
+func (f *fakeClock) NewTimer(d time.Duration) Timer {
+	return f.newTimer(d, 0)
+}
+
+// NewTicker will return a ticker firing every time the clock is advanced by d
+func (f *fakeClock) NewTicker(d time.Duration) Ticker {
+	return fakeTicker{f.newTimer(d, d)}
+}
+
+// newTimer will add a timer to the fake clock
+func (f *fakeClock) newTimer(d, period time.Duration) *fakeTimer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	timer := &fakeTimer{c: make(chan time.Time, 1), clock: f, fireAt: f.now.Add(d), period: period, active: true}
+	f.timers = append(f.timers, timer)
+	return timer
+}
+
+// Advance will move the fake clock forward and fire the due timers
+func (f *fakeClock) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+	for _, timer := range f.timers {
+		if !timer.active || f.now.Before(timer.fireAt) {
+			continue
+		}
+		select {
+		case timer.c <- f.now:
+		default:
+		}
+		if timer.period > 0 {
+			timer.fireAt = f.now.Add(timer.period)
+		} else {
+			timer.active = false
+		}
+	}
+}
+
+// C will return the channel of the timer
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+// Reset will change the timer to fire once the clock is advanced by d
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.active
+	t.active, t.fireAt = true, t.clock.now.Add(d)
+	return active
+}
+
+// Stop will stop the timer
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	active := t.active
+	t.active = false
+	return active
+}
+
+// TestRealClock tests the method RealClock()
+func TestRealClock(t *testing.T) {
+	t.Parallel()
+
+	clock := RealClock()
+	if now := clock.Now(); time.Since(now) > time.Second {
+		t.Fatalf("expected the current time, got %s", now)
+	}
+
+	timer := clock.NewTimer(time.Millisecond)
+	select {
+	case <-timer.C():
+	case <-time.After(time.Second):
+		t.Fatal("timer did not fire")
+	}
+
+	ticker := clock.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	select {
+	case <-ticker.C():
+	case <-time.After(time.Second):
+		t.Fatal("ticker did not fire")
+	}
+
+	var options *ClientOptions
+	if options.clock() == nil {
+		t.Fatal("expected the real clock for nil options")
+	}
+}
+
+// TestClient_Clock tests the quote cache expiry using ClientOptions.Clock
+func TestClient_Clock(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Now())
+	mock := &mockHTTPCountingQuote{expiresIn: 10 * time.Minute}
+	client := newTestCachingClient(mock)
+	client.Options.Clock = clock
+
+	if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Still cached before the quotes expire
+	clock.Advance(9 * time.Minute)
+	if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if mock.count() != len(client.Miners) {
+		t.Fatalf("expected %d quote requests, got %d", len(client.Miners), mock.count())
+	}
+
+	// Re-fetched after the quotes expire
+	clock.Advance(2 * time.Minute)
+	if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if mock.count() != 2*len(client.Miners) {
+		t.Fatalf("expected %d quote requests, got %d", 2*len(client.Miners), mock.count())
+	}
+}
+
+// TestRateLimiter_Clock tests the rate limit wait using a fake clock
+func TestRateLimiter_Clock(t *testing.T) {
+	t.Parallel()
+
+	clock := newFakeClock(time.Now())
+	limiter := newRateLimiter()
+	miner := &Miner{Name: "Test", RateLimit: time.Hour}
+	if err := limiter.wait(context.Background(), miner, clock); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- limiter.wait(context.Background(), miner, clock)
+	}()
+
+	// Advance until the waiting request is released (the timer may not be created yet)
+	for {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+			return
+		case <-time.After(time.Millisecond):
+			clock.Advance(time.Minute)
+		}
+	}
+}
+
+// TestFileStore_SetClock tests the method SetClock()
+func TestFileStore_SetClock(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	clock := newFakeClock(time.Now())
+	store.SetClock(clock)
+
+	if err = store.Set(context.Background(), "key", []byte("value"), time.Minute); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	if value, _ := store.Get(context.Background(), "key"); string(value) != "value" {
+		t.Fatalf("expected the value, got [%s]", value)
+	}
+
+	clock.Advance(time.Minute)
+	if value, _ := store.Get(context.Background(), "key"); value != nil {
+		t.Fatalf("expected an expired value, got [%s]", value)
+	}
+}
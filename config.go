@@ -10,17 +10,107 @@ const (
 
 	// defaultProtocol is used for url endpoints in requests
 	defaultProtocol = "https://"
+
+	// DefaultCompressMinSize is the default min size (bytes) of a submit body that is compressed (if the miner supports it)
+	DefaultCompressMinSize int64 = 64 * 1024
+
+	// DefaultMaxTxSize is the default max raw tx size in bytes (the default "maxtxsizepolicy" of a node after Genesis)
+	DefaultMaxTxSize int64 = 10000000
+
+	// DefaultResponseCacheMaxEntries is the default max number of responses in a ResponseCache
+	DefaultResponseCacheMaxEntries = 10000
+
+	// DustLimit is the smallest output value (satoshis) relayed by default (used for the minimum change in EstimateFee)
+	DustLimit uint64 = 546
+)
+
+const (
+	// CompressionGzip is the gzip request body compression (see: Miner.Compression)
+	CompressionGzip = "gzip"
+)
+
+const (
+	// SchemeHTTP is the (insecure) http url scheme (IE: local or testing miners)
+	SchemeHTTP = "http"
+
+	// SchemeHTTPS is the default url scheme for all miners
+	SchemeHTTPS = "https"
+)
+
+const (
+	// ReturnResultFailure is the returnResult of a failed request (IE: a rejected transaction)
+	ReturnResultFailure = "failure"
+
+	// ReturnResultSuccess is the returnResult of a successful request
+	ReturnResultSuccess = "success"
+
+	// queryInMempoolDescription is the (lowercase) resultDescription of a query for a transaction that is not yet in a block
+	queryInMempoolDescription = "in mempool but not yet in block"
 )
 
 const (
 	// routeFeeQuote is the route for getting a fee quote
 	routeFeeQuote = "/mapi/feeQuote"
 
-	// routeQueryTx is the route for querying a transaction
-	routeQueryTx = "/mapi/tx/"
+	// routePolicyQuote is the route for getting a policy quote
+	routePolicyQuote = "/mapi/policyQuote"
+
+	// routeQueryTx is the route for querying a transaction (the tx id is appended)
+	routeQueryTx = "/mapi/tx"
+
+	// queryWaitParam is the query parameter of the long-poll wait (seconds) of a tx query (see: Miner.LongPoll)
+	queryWaitParam = "wait"
 
 	// routeSubmitTx is the route for submit a transaction
 	routeSubmitTx = "/mapi/tx"
+
+	// routeSPVAccount is the route of an SPV channels account (the account id is appended)
+	routeSPVAccount = "/api/v1/account"
+
+	// routeSPVChannel is the route of an SPV channel (the channel id is appended)
+	routeSPVChannel = "/api/v1/channel"
+)
+
+const (
+	// opFalse is the OP_FALSE (OP_0) opcode
+	opFalse = 0x00
+
+	// opReturn is the OP_RETURN opcode (marks a data output)
+	opReturn = 0x6a
+
+	// opDup is the OP_DUP opcode
+	opDup = 0x76
+
+	// opEqualVerify is the OP_EQUALVERIFY opcode
+	opEqualVerify = 0x88
+
+	// opHash160 is the OP_HASH160 opcode
+	opHash160 = 0xa9
+
+	// opCheckSig is the OP_CHECKSIG opcode
+	opCheckSig = 0xac
+)
+
+// Transaction sizes (bytes) used for size estimates (see: EstimateP2PKHTxSize)
+const (
+	// P2PKHInputSize is the estimated size of a signed P2PKH input (outpoint, script and sequence)
+	P2PKHInputSize = 148
+
+	// P2PKHLockingScriptSize is the size of a P2PKH locking script
+	P2PKHLockingScriptSize = 25
+
+	// P2PKHOutputSize is the size of a P2PKH output (value, script length and script)
+	P2PKHOutputSize = 34
+
+	// P2PKHUnlockingScriptSize is the estimated size of a P2PKH unlocking script (a DER signature with the
+	// sighash flag and a compressed public key, each with a push opcode)
+	P2PKHUnlockingScriptSize = 107
+
+	// TxOverheadSize is the size of the version and lock time of a transaction (without the input and output counts)
+	TxOverheadSize = 8
+
+	// compressedPubKeySize is the size of a compressed public key
+	compressedPubKeySize = 33
 )
 
 const (
@@ -33,29 +123,3 @@ const (
 	// MinerMatterpool is the name of the known miner for "Matterpool"
 	MinerMatterpool = "Matterpool"
 )
-
-// KnownMiners is a pre-filled list of known miners
-// Any pre-filled tokens are for free use only
-// update your custom token with client.MinerUpdateToken("name", "token")
-const KnownMiners = `
-[
-  {
-   "name": "Taal",
-   "miner_id": "03e92d3e5c3f7bd945dfbf48e7a99393b1bfb3f11f380ae30d286e7ff2aec5a270",
-   "token": "",
-   "url": "merchantapi.taal.com"
-  },
-  {
-   "name": "Mempool",
-   "miner_id": "03e92d3e5c3f7bd945dfbf48e7a99393b1bfb3f11f380ae30d286e7ff2aec5a270",
-   "token": "561b756d12572020ea9a104c3441b71790acbbce95a6ddbf7e0630971af9424b",
-   "url": "www.ddpurse.com/openapi"
-  },
-  {
-   "name": "Matterpool",
-   "miner_id": "0211ccfc29e3058b770f3cf3eb34b0b2fd2293057a994d4d275121be4151cdf087",
-   "token": "",
-   "url": "merchantapi.matterpool.io"
-  }
-]
-`
@@ -0,0 +1,118 @@
+package minercraft
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxPendingConfirmations is the max number of accepted transactions waiting for a confirmation
+// (new submissions are not tracked until pending transactions are confirmed)
+const maxPendingConfirmations = 10000
+
+// ConfirmationStats are the times from submission to the first confirmation of the transactions of a miner
+// (see: ConfirmationMetrics)
+type ConfirmationStats struct {
+	Average   time.Duration `json:"average"`
+	Confirmed int64         `json:"confirmed"` // Number of transactions seen mined
+	Last      time.Duration `json:"last"`
+	Max       time.Duration `json:"max"`
+	Min       time.Duration `json:"min"`
+	Miner     string        `json:"miner"`
+	Pending   int64         `json:"pending"` // Number of accepted transactions not yet seen mined
+	total     time.Duration
+}
+
+// confirmationTracker is the submission time of each accepted transaction (by miner and txid) and the stats of each miner
+type confirmationTracker struct {
+	sync.Mutex
+	pending map[string]time.Time
+	stats   map[string]*ConfirmationStats
+}
+
+// newConfirmationTracker will return a new tracker without transactions
+func newConfirmationTracker() *confirmationTracker {
+	return &confirmationTracker{
+		pending: make(map[string]time.Time),
+		stats:   make(map[string]*ConfirmationStats),
+	}
+}
+
+// ConfirmationMetrics will return the time-to-confirmation stats of each miner (sorted by miner name)
+//
+// A transaction is tracked from its acceptance (SubmitTransaction or SubmitTransactionStatus) until the first
+// QueryTransaction that shows it mined, so the accuracy depends on how often the transaction is queried
+func (c *Client) ConfirmationMetrics() []*ConfirmationStats {
+	c.confirmations.Lock()
+	defer c.confirmations.Unlock()
+	metrics := make([]*ConfirmationStats, 0, len(c.confirmations.stats))
+	for _, stats := range c.confirmations.stats {
+		copied := *stats
+		metrics = append(metrics, &copied)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		return metrics[i].Miner < metrics[j].Miner
+	})
+	return metrics
+}
+
+// submitted will start tracking the accepted transaction of the miner
+func (t *confirmationTracker) submitted(miner *Miner, txID string, at time.Time) {
+	if miner == nil || len(txID) == 0 {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	key := confirmationKey(miner, txID)
+	if _, ok := t.pending[key]; ok || len(t.pending) >= maxPendingConfirmations {
+		return
+	}
+	t.pending[key] = at
+	t.minerStats(miner).Pending++
+}
+
+// confirmed will record the time-to-confirmation of the transaction (if it was tracked)
+func (t *confirmationTracker) confirmed(miner *Miner, txID string, at time.Time) {
+	if miner == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	key := confirmationKey(miner, txID)
+	submittedAt, ok := t.pending[key]
+	if !ok {
+		return
+	}
+	delete(t.pending, key)
+
+	// Update the stats of the miner
+	elapsed := at.Sub(submittedAt)
+	stats := t.minerStats(miner)
+	stats.Pending--
+	stats.Confirmed++
+	stats.Last = elapsed
+	stats.total += elapsed
+	stats.Average = stats.total / time.Duration(stats.Confirmed)
+	if elapsed > stats.Max {
+		stats.Max = elapsed
+	}
+	if stats.Min == 0 || elapsed < stats.Min {
+		stats.Min = elapsed
+	}
+}
+
+// minerStats will return the stats of the miner (created if not found, the lock must be held)
+func (t *confirmationTracker) minerStats(miner *Miner) *ConfirmationStats {
+	stats, ok := t.stats[strings.ToLower(miner.Name)]
+	if !ok {
+		stats = &ConfirmationStats{Miner: miner.Name}
+		t.stats[strings.ToLower(miner.Name)] = stats
+	}
+	return stats
+}
+
+// confirmationKey will return the key of the transaction of the miner
+func confirmationKey(miner *Miner, txID string) string {
+	return strings.ToLower(miner.Name) + ":" + strings.ToLower(txID)
+}
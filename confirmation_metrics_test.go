@@ -0,0 +1,66 @@
+package minercraft
+
+import (
+	"testing"
+	"time"
+)
+
+// TestClient_ConfirmationMetrics tests the method ConfirmationMetrics()
+func TestClient_ConfirmationMetrics(t *testing.T) {
+	t.Parallel()
+
+	t.Run("time to confirmation", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		taal := client.MinerByName(MinerTaal)
+		submittedAt := time.Now().Add(-time.Hour)
+		client.confirmations.submitted(taal, "tx1", submittedAt)
+		client.confirmations.submitted(taal, "tx2", submittedAt)
+		client.confirmations.submitted(taal, "tx3", submittedAt)
+		client.confirmations.confirmed(taal, "tx1", submittedAt.Add(10*time.Minute))
+		client.confirmations.confirmed(taal, "tx2", submittedAt.Add(30*time.Minute))
+		client.confirmations.confirmed(taal, "unknown", submittedAt.Add(time.Minute))
+
+		metrics := client.ConfirmationMetrics()
+		if len(metrics) != 1 {
+			t.Fatalf("expected 1 miner, got: %d", len(metrics))
+		}
+		stats := metrics[0]
+		if stats.Miner != MinerTaal || stats.Confirmed != 2 || stats.Pending != 1 {
+			t.Fatalf("expected 2 confirmed and 1 pending, got: %+v", stats)
+		} else if stats.Min != 10*time.Minute || stats.Max != 30*time.Minute ||
+			stats.Average != 20*time.Minute || stats.Last != 30*time.Minute {
+			t.Fatalf("unexpected durations: %+v", stats)
+		}
+
+		// Confirmations are only recorded once
+		client.confirmations.confirmed(taal, "tx1", submittedAt.Add(time.Hour))
+		if client.ConfirmationMetrics()[0].Confirmed != 2 {
+			t.Fatalf("expected the confirmation to be recorded once")
+		}
+	})
+
+	t.Run("accepted submission", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		miner := client.MinerByName(MinerMatterpool)
+		if _, err := client.SubmitTransaction(miner, &Transaction{RawTx: testRawTx}); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		metrics := client.ConfirmationMetrics()
+		if len(metrics) != 1 || metrics[0].Pending != 1 {
+			t.Fatalf("expected the submission to be pending, got: %v", metrics)
+		}
+	})
+
+	t.Run("mined query", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		miner := client.MinerByName(MinerMatterpool)
+		client.confirmations.submitted(miner, testTx, time.Now().Add(-time.Minute))
+		if _, err := client.QueryTransaction(miner, testTx); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		metrics := client.ConfirmationMetrics()
+		if len(metrics) != 1 || metrics[0].Confirmed != 1 || metrics[0].Last < time.Minute {
+			t.Fatalf("expected the transaction to be confirmed, got: %v", metrics)
+		}
+	})
+}
@@ -0,0 +1,121 @@
+package minercraft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/libsv/libsv/transaction"
+)
+
+// Default consolidation policy of a node (see: DefaultConsolidationPolicy)
+const (
+	DefaultMaxConsolidationInputScriptSize = 150
+	DefaultMinConfConsolidationInput       = 6
+	DefaultMinConsolidationFactor          = 20
+)
+
+// ConsolidationPolicy is the policy of a miner for accepting consolidation transactions (many inputs into
+// few outputs) without a fee, so wallets can sweep dust without overpaying
+//
+// Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi#get-policy-quote
+type ConsolidationPolicy struct {
+	AcceptNonStdConsolidationInput  bool   `json:"acceptnonstdconsolidationinput,omitempty"`  // Accept inputs spending non-standard scripts
+	MaxConsolidationInputScriptSize uint64 `json:"maxconsolidationinputscriptsize,omitempty"` // Max unlocking script size of each input
+	MinConfConsolidationInput       uint64 `json:"minconfconsolidationinput,omitempty"`       // Min confirmations of each input
+	MinConsolidationFactor          uint64 `json:"minconsolidationfactor,omitempty"`          // Min ratio of inputs to outputs (0 = disabled)
+}
+
+// DefaultConsolidationPolicy will return the default consolidation policy of a node
+func DefaultConsolidationPolicy() *ConsolidationPolicy {
+	return &ConsolidationPolicy{
+		MaxConsolidationInputScriptSize: DefaultMaxConsolidationInputScriptSize,
+		MinConfConsolidationInput:       DefaultMinConfConsolidationInput,
+		MinConsolidationFactor:          DefaultMinConsolidationFactor,
+	}
+}
+
+// ConsolidationPolicy will return the consolidation policy of the quote (nil if the quote has no policies)
+//
+// Policy fields missing from the received policies use the default policy of a node (see: DefaultConsolidationPolicy)
+func (f *FeePayload) ConsolidationPolicy() (*ConsolidationPolicy, error) {
+	if f.Policies == nil {
+		return nil, nil
+	} else if len(f.Policies.raw) == 0 {
+		policy := f.Policies.ConsolidationPolicy
+		return &policy, nil
+	}
+	policy := DefaultConsolidationPolicy()
+	if err := json.Unmarshal(f.Policies.raw, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// IsFreeConsolidation will return true if the transaction is a consolidation transaction that the policy
+// accepts without a fee (see: CheckConsolidation)
+func IsFreeConsolidation(tx *transaction.Transaction, policy *ConsolidationPolicy) bool {
+	return CheckConsolidation(tx, policy) == nil
+}
+
+// CheckConsolidation will return the reason the transaction is not a free consolidation transaction (nil if it is)
+//
+// The inputs must have their previous locking scripts (IE: added using tx.From()). The confirmations of the
+// inputs can't be checked from the transaction, make sure each input has at least MinConfConsolidationInput
+func CheckConsolidation(tx *transaction.Transaction, policy *ConsolidationPolicy) error {
+
+	// Make sure we have a tx and an enabled policy
+	if tx == nil {
+		return errors.New("tx was nil")
+	} else if policy == nil || policy.MinConsolidationFactor == 0 {
+		return errors.New("consolidation is disabled by the policy")
+	} else if len(tx.Inputs) == 0 || len(tx.Outputs) == 0 {
+		return errors.New("tx has no inputs or outputs")
+	}
+
+	// Enough inputs for each output?
+	if uint64(len(tx.Inputs)) < policy.MinConsolidationFactor*uint64(len(tx.Outputs)) {
+		return fmt.Errorf("%d inputs is less than %d times the %d outputs",
+			len(tx.Inputs), policy.MinConsolidationFactor, len(tx.Outputs))
+	}
+
+	// Check each input (the unlocking script size and the script it spends)
+	var inputScriptBytes uint64
+	for index, in := range tx.Inputs {
+		if in.PreviousTxScript == nil {
+			return fmt.Errorf("input %d is missing the previous locking script", index)
+		}
+		inputScriptBytes += uint64(len(*in.PreviousTxScript))
+		if in.UnlockingScript != nil && uint64(len(*in.UnlockingScript)) > policy.MaxConsolidationInputScriptSize {
+			return fmt.Errorf("input %d unlocking script is larger than %d bytes", index, policy.MaxConsolidationInputScriptSize)
+		} else if !policy.AcceptNonStdConsolidationInput && !isStandardConsolidationScript(*in.PreviousTxScript) {
+			return fmt.Errorf("input %d spends a non-standard script", index)
+		}
+	}
+
+	// The outputs must be smaller (by the factor) than the scripts being spent
+	var outputScriptBytes uint64
+	for _, out := range tx.Outputs {
+		if out.LockingScript != nil {
+			outputScriptBytes += uint64(len(*out.LockingScript))
+		}
+	}
+	if outputScriptBytes*policy.MinConsolidationFactor > inputScriptBytes {
+		return fmt.Errorf("output scripts (%d bytes) are not %d times smaller than the input scripts (%d bytes)",
+			outputScriptBytes, policy.MinConsolidationFactor, inputScriptBytes)
+	}
+	return nil
+}
+
+// isStandardConsolidationScript will return true if the locking script is P2PKH or P2PK
+func isStandardConsolidationScript(script []byte) bool {
+	return isP2PKHScript(script) ||
+		(len(script) == 35 && script[0] == 33 && script[34] == opCheckSig) ||
+		(len(script) == 67 && script[0] == 65 && script[66] == opCheckSig)
+}
+
+// isP2PKHScript will return true if the locking script is P2PKH (OP_DUP OP_HASH160 <20 bytes> OP_EQUALVERIFY OP_CHECKSIG)
+func isP2PKHScript(script []byte) bool {
+	return len(script) == P2PKHLockingScriptSize && script[0] == opDup && script[1] == opHash160 &&
+		script[2] == 20 && script[23] == opEqualVerify && script[24] == opCheckSig
+}
@@ -0,0 +1,108 @@
+package minercraft
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/libsv/libsv/script"
+	"github.com/libsv/libsv/transaction"
+	"github.com/libsv/libsv/transaction/output"
+)
+
+// testP2PKHScriptHex is the P2PKH locking script of the test inputs
+const testP2PKHScriptHex = "76a914eb0bd5edba389198e73f8efabddfc61666969ff788ac"
+
+// testConsolidationTx will return a signed (size) tx spending the inputs into one P2PKH output
+func testConsolidationTx(t *testing.T, inputs int, lockingScript string, unlockingSize int) *transaction.Transaction {
+	tx := transaction.New()
+	for index := 0; index < inputs; index++ {
+		if err := tx.From(testTx, uint32(index), lockingScript, 1000); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		tx.Inputs[index].UnlockingScript = script.NewFromBytes(bytes.Repeat([]byte{0x01}, unlockingSize))
+	}
+	out, err := output.NewP2PkhFromPubKeyHash("eb0bd5edba389198e73f8efabddfc61666969ff7", 500)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	tx.AddOutput(out)
+	return tx
+}
+
+// TestIsFreeConsolidation tests the methods IsFreeConsolidation() and CheckConsolidation()
+func TestIsFreeConsolidation(t *testing.T) {
+	t.Parallel()
+
+	policy := DefaultConsolidationPolicy()
+	nonStandard := "51" // OP_TRUE
+
+	var tests = []struct {
+		name     string
+		tx       *transaction.Transaction
+		policy   *ConsolidationPolicy
+		expected bool
+	}{
+		{"consolidation", testConsolidationTx(t, 20, testP2PKHScriptHex, P2PKHUnlockingScriptSize), policy, true},
+		{"too few inputs", testConsolidationTx(t, 19, testP2PKHScriptHex, P2PKHUnlockingScriptSize), policy, false},
+		{"large unlocking script", testConsolidationTx(t, 20, testP2PKHScriptHex, 151), policy, false},
+		{"non-standard input", testConsolidationTx(t, 20, nonStandard, 1), policy, false},
+		{"non-standard input (accepted)", testConsolidationTx(t, 30, nonStandard, 1), &ConsolidationPolicy{
+			AcceptNonStdConsolidationInput: true, MaxConsolidationInputScriptSize: 150, MinConsolidationFactor: 1,
+		}, true},
+		{"disabled", testConsolidationTx(t, 20, testP2PKHScriptHex, P2PKHUnlockingScriptSize), &ConsolidationPolicy{}, false},
+		{"no policy", testConsolidationTx(t, 20, testP2PKHScriptHex, P2PKHUnlockingScriptSize), nil, false},
+		{"no tx", nil, policy, false},
+	}
+	for _, test := range tests {
+		if output := IsFreeConsolidation(test.tx, test.policy); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%v] expected, received: [%v] (%v)",
+				t.Name(), test.name, test.expected, output, CheckConsolidation(test.tx, test.policy))
+		}
+	}
+
+	t.Run("missing previous scripts", func(t *testing.T) {
+		tx, err := transaction.NewFromString(testConsolidationTx(t, 20, testP2PKHScriptHex, P2PKHUnlockingScriptSize).ToString())
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if IsFreeConsolidation(tx, policy) {
+			t.Fatalf("expected the previous scripts to be required")
+		}
+	})
+}
+
+// TestFeePayload_ConsolidationPolicy tests the method ConsolidationPolicy()
+func TestFeePayload_ConsolidationPolicy(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no policies", func(t *testing.T) {
+		if policy, err := (&FeePayload{}).ConsolidationPolicy(); err != nil || policy != nil {
+			t.Fatalf("expected no policy, got: %v %v", policy, err)
+		}
+	})
+
+	t.Run("partial policies", func(t *testing.T) {
+		quote := new(FeePayload)
+		if err := json.Unmarshal([]byte(`{"policies":{"minconsolidationfactor":10,"acceptnonstdconsolidationinput":true,"maxtxsizepolicy":99999}}`), quote); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		policy, err := quote.ConsolidationPolicy()
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if policy.MinConsolidationFactor != 10 || !policy.AcceptNonStdConsolidationInput {
+			t.Fatalf("expected the policy fields, got: %+v", policy)
+		} else if policy.MaxConsolidationInputScriptSize != DefaultMaxConsolidationInputScriptSize ||
+			policy.MinConfConsolidationInput != DefaultMinConfConsolidationInput {
+			t.Fatalf("expected the default for missing fields, got: %+v", policy)
+		}
+	})
+
+	t.Run("policies set manually", func(t *testing.T) {
+		quote := &FeePayload{Policies: &Policies{ConsolidationPolicy: ConsolidationPolicy{MinConsolidationFactor: 5}}}
+		if policy, err := quote.ConsolidationPolicy(); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if policy.MinConsolidationFactor != 5 || policy.MaxConsolidationInputScriptSize != 0 {
+			t.Fatalf("expected the policy as set, got: %+v", policy)
+		}
+	})
+}
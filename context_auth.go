@@ -0,0 +1,69 @@
+package minercraft
+
+import "context"
+
+// tokenContextKey is the context key of the request-scoped credentials (see: ContextWithToken)
+type tokenContextKey struct{}
+
+// TokenProvider provides the token of a request to a miner (IE: the credentials of a proxied user)
+type TokenProvider interface {
+	Token(ctx context.Context, miner *Miner) (string, error)
+}
+
+// TokenProviderFunc is a function that can be used as a TokenProvider
+type TokenProviderFunc func(ctx context.Context, miner *Miner) (string, error)
+
+// Token will return the token using the function
+func (f TokenProviderFunc) Token(ctx context.Context, miner *Miner) (string, error) {
+	return f(ctx, miner)
+}
+
+// ContextWithToken will return a context with the token of the requests to miners
+// (overrides the token of the tenant and the miner)
+func ContextWithToken(ctx context.Context, token string) context.Context {
+	return ContextWithTokenProvider(ctx, TokenProviderFunc(func(context.Context, *Miner) (string, error) {
+		return token, nil
+	}))
+}
+
+// ContextWithTokenProvider will return a context with the provider of the tokens of the requests to miners
+// (overrides the token of the tenant and the miner, an empty token falls back to them)
+//
+// The last token or provider added to the context is used
+func ContextWithTokenProvider(ctx context.Context, provider TokenProvider) context.Context {
+	return context.WithValue(ctx, tokenContextKey{}, provider)
+}
+
+// contextToken will return the token of the context for the miner (empty if not set)
+func contextToken(ctx context.Context, miner *Miner) (string, error) {
+	if provider, _ := ctx.Value(tokenContextKey{}).(TokenProvider); provider != nil {
+		return provider.Token(ctx, miner)
+	}
+	return "", nil
+}
+
+// hasContextToken will return true if the context has a token or a token provider
+func hasContextToken(ctx context.Context) bool {
+	return ctx.Value(tokenContextKey{}) != nil
+}
+
+// requestToken will return the token of a request to the miner: the token of the context,
+// the token of the tenant (see: SetTenant) or the token of the request
+func (c *Client) requestToken(ctx context.Context, miner *Miner, token string) (string, error) {
+	if scoped, err := contextToken(ctx, miner); err != nil || len(scoped) > 0 {
+		return scoped, err
+	} else if settings := c.tenantMiner(ctx, miner); settings != nil && len(settings.Token) > 0 {
+		return settings.Token, nil
+	}
+	return token, nil
+}
+
+// scopedToken will return true if the request to the miner does not use the token of the miner
+// (a token of the context or the tenant, the responses are not shared)
+func (c *Client) scopedToken(ctx context.Context, miner *Miner) bool {
+	if hasContextToken(ctx) {
+		return true
+	}
+	settings := c.tenantMiner(ctx, miner)
+	return settings != nil && len(settings.Token) > 0
+}
@@ -0,0 +1,59 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+// TestClient_ContextToken tests the methods ContextWithToken() and ContextWithTokenProvider()
+func TestClient_ContextToken(t *testing.T) {
+	t.Parallel()
+
+	var token string
+	client := newTestClient(RequestHandlerFunc(func(req *http.Request) (*http.Response, error) {
+		token = req.Header.Get("token")
+		return (&mockHTTPValidQuery{}).Do(req)
+	}))
+	miner := client.MinerByName(MinerMatterpool)
+	miner.Token = "miner-token"
+	_ = client.SetTenant("tenant", map[string]*TenantMiner{MinerMatterpool: {Token: "tenant-token"}})
+
+	provider := TokenProviderFunc(func(_ context.Context, miner *Miner) (string, error) {
+		if miner.Name != MinerMatterpool {
+			return "", nil
+		}
+		return "provided-token", nil
+	})
+	tenantCtx := ContextWithTenant(context.Background(), "tenant")
+
+	var tests = []struct {
+		ctx      context.Context
+		expected string
+	}{
+		{context.Background(), "miner-token"},
+		{tenantCtx, "tenant-token"},
+		{ContextWithToken(tenantCtx, "user-token"), "user-token"},
+		{ContextWithTokenProvider(context.Background(), provider), "provided-token"},
+		{ContextWithTokenProvider(ContextWithToken(context.Background(), "user-token"), provider), "provided-token"},
+		{ContextWithToken(ContextWithTokenProvider(context.Background(), provider), "user-token"), "user-token"},
+	}
+	for _, test := range tests {
+		if result := queryTransaction(test.ctx, client, miner, testTx); result.Response.Error != nil {
+			t.Fatalf("error occurred: %s", result.Response.Error.Error())
+		} else if token != test.expected {
+			t.Errorf("%s Failed: [%s] expected, received: [%s]", t.Name(), test.expected, token)
+		}
+	}
+
+	// The provider failed
+	failing := ContextWithTokenProvider(context.Background(), TokenProviderFunc(
+		func(context.Context, *Miner) (string, error) {
+			return "", errors.New("vault is sealed")
+		},
+	))
+	if result := queryTransaction(failing, client, miner, testTx); result.Response.Error == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
@@ -0,0 +1,116 @@
+package minercraft
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"github.com/bitcoinschema/go-bitcoin"
+	"github.com/libsv/libsv/transaction"
+)
+
+// DataTxFee is the fee breakdown of a data-carrier transaction (see: CalculateDataTxFee)
+type DataTxFee struct {
+	DataFee     uint64  `json:"data_fee"`     // Fee of the pushdata bytes of the data outputs ("FeeTypeData" rate)
+	StandardFee uint64  `json:"standard_fee"` // Fee of all other bytes ("FeeTypeStandard" rate)
+	Total       uint64  `json:"total"`
+	TxSize      *TxSize `json:"tx_size"`
+}
+
+// DataTxSize will return the size breakdown of a data-carrier transaction where only the pushdata bytes
+// of the data outputs (OP_RETURN or OP_FALSE OP_RETURN) are data bytes
+//
+// The value, script length, opcodes and push prefixes of the data outputs are standard bytes
+// (unlike TxSizeFromTx, which counts the entire data output as data bytes)
+func DataTxSize(tx *transaction.Transaction) (*TxSize, error) {
+
+	// Make sure we have a tx
+	if tx == nil {
+		return nil, errors.New("tx was nil")
+	}
+
+	// Loop all data outputs and accumulate the pushdata bytes
+	var dataBytes uint64
+	for _, out := range tx.GetOutputs() {
+		if out.LockingScript != nil && isDataScript(*out.LockingScript) {
+			dataBytes += pushDataBytes(*out.LockingScript)
+		}
+	}
+
+	return &TxSize{DataBytes: dataBytes, StandardBytes: uint64(len(tx.ToBytes())) - dataBytes}, nil
+}
+
+// CalculateDataTxFee will return the fee breakdown of a data-carrier transaction (see: DataTxSize)
+// Category: "FeeCategoryMining" or "FeeCategoryRelay"
+//
+// # Each fee type is calculated separately (rounded down), a transaction without data bytes has no data fee
+//
+// Spec: https://github.com/bitcoin-sv-specs/brfc-misc/tree/master/feespec#deterministic-transaction-fee-calculation-dtfc
+func (f *FeePayload) CalculateDataTxFee(feeCategory string, tx *transaction.Transaction) (*DataTxFee, error) {
+
+	// Get the size breakdown
+	size, err := DataTxSize(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Calculate each portion
+	fee := &DataTxFee{TxSize: size}
+	if size.StandardBytes > 0 {
+		if fee.StandardFee, err = f.CalculateTxFee(feeCategory, &TxSize{StandardBytes: size.StandardBytes}); err != nil {
+			return nil, err
+		}
+	}
+	if size.DataBytes > 0 {
+		if fee.DataFee, err = f.CalculateTxFee(feeCategory, &TxSize{DataBytes: size.DataBytes}); err != nil {
+			return nil, err
+		}
+	}
+	fee.Total = fee.StandardFee + fee.DataFee
+	return fee, nil
+}
+
+// CalculateDataTxFeeFromHex will return the fee breakdown of a data-carrier raw transaction hex
+// (see: CalculateDataTxFee)
+func (f *FeePayload) CalculateDataTxFeeFromHex(feeCategory, rawTx string) (*DataTxFee, error) {
+	tx, err := bitcoin.TxFromHex(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	return f.CalculateDataTxFee(feeCategory, tx)
+}
+
+// pushDataBytes will return the number of bytes pushed by the script (without the opcodes and push prefixes)
+//
+// A truncated push only counts the bytes that are present
+func pushDataBytes(script []byte) (dataBytes uint64) {
+	for index := 0; index < len(script); {
+		opcode := script[index]
+		index++
+
+		// Get the length of the push
+		var length int
+		switch {
+		case opcode >= 0x01 && opcode <= 0x4b:
+			length = int(opcode)
+		case opcode == 0x4c && index+1 <= len(script): // OP_PUSHDATA1
+			length = int(script[index])
+			index++
+		case opcode == 0x4d && index+2 <= len(script): // OP_PUSHDATA2
+			length = int(binary.LittleEndian.Uint16(script[index:]))
+			index += 2
+		case opcode == 0x4e && index+4 <= len(script): // OP_PUSHDATA4
+			length = int(binary.LittleEndian.Uint32(script[index:]))
+			index += 4
+		default:
+			continue
+		}
+
+		// Count the pushed bytes (that are present)
+		if length < 0 || length > len(script)-index {
+			length = len(script) - index
+		}
+		dataBytes += uint64(length)
+		index += length
+	}
+	return
+}
@@ -0,0 +1,96 @@
+package minercraft
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// TestPushDataBytes tests the method pushDataBytes()
+func TestPushDataBytes(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		script   []byte
+		expected uint64
+	}{
+		{[]byte{opFalse, opReturn}, 0},
+		{append([]byte{opFalse, opReturn, 0x05}, []byte("hello")...), 5},
+		{append([]byte{opFalse, opReturn, 0x4c, 0x64}, bytes.Repeat([]byte{0x01}, 100)...), 100},
+		{append([]byte{opFalse, opReturn, 0x4d, 0x2c, 0x01}, bytes.Repeat([]byte{0x01}, 300)...), 300},
+		{append([]byte{opReturn, 0x4e, 0x03, 0x00, 0x00, 0x00}, []byte("abc")...), 3},
+		{append([]byte{opFalse, opReturn, 0x05}, []byte("hi")...), 2}, // Truncated
+		{[]byte{opFalse, opReturn, 0x4d, 0x01}, 0},                    // Truncated prefix
+	}
+	for _, test := range tests {
+		if output := pushDataBytes(test.script); output != test.expected {
+			t.Errorf("%s Failed: [%x] inputted and [%d] expected, received: [%d]", t.Name(), test.script, test.expected, output)
+		}
+	}
+}
+
+// TestFeePayload_CalculateDataTxFee tests the method CalculateDataTxFee()
+func TestFeePayload_CalculateDataTxFee(t *testing.T) {
+	t.Parallel()
+
+	// Create a client (standard: 475/150 data: 500/250)
+	client := newTestClient(&mockHTTPBetterRate{})
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	t.Run("data transaction", func(t *testing.T) {
+		tx := testDataTx(t, bytes.Repeat([]byte{0x01}, 1000))
+		fee, err := response.Quote.CalculateDataTxFee(FeeCategoryMining, tx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if fee.TxSize.DataBytes != 1000 || fee.TxSize.TotalBytes() != uint64(len(tx.ToBytes())) {
+			t.Fatalf("expected 1000 data bytes, got: %+v", fee.TxSize)
+		}
+		standardFee, _ := response.Quote.CalculateTxFee(FeeCategoryMining, &TxSize{StandardBytes: fee.TxSize.StandardBytes})
+		dataFee, _ := response.Quote.CalculateTxFee(FeeCategoryMining, &TxSize{DataBytes: fee.TxSize.DataBytes})
+		if fee.StandardFee != standardFee || fee.DataFee != dataFee || fee.Total != standardFee+dataFee {
+			t.Fatalf("unexpected fee breakdown: %+v", fee)
+		}
+	})
+
+	t.Run("from hex", func(t *testing.T) {
+		tx := testDataTx(t, []byte("hello"))
+		if fee, err := response.Quote.CalculateDataTxFeeFromHex(FeeCategoryMining, tx.ToString()); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if fee.TxSize.DataBytes != 5 {
+			t.Fatalf("expected 5 data bytes, got: %d", fee.TxSize.DataBytes)
+		} else if _, err = response.Quote.CalculateDataTxFeeFromHex(FeeCategoryMining, "invalid"); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		if _, err = response.Quote.CalculateDataTxFee(FeeCategoryMining, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = response.Quote.CalculateDataTxFee("invalid", testDataTx(t, []byte("hello"))); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleFeePayload_CalculateDataTxFeeFromHex example using CalculateDataTxFeeFromHex()
+func ExampleFeePayload_CalculateDataTxFeeFromHex() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPBetterRate{})
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	// A transaction without data outputs only has a standard fee
+	fee, err := response.Quote.CalculateDataTxFeeFromHex(FeeCategoryMining, testRawTx)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("standard: %d data: %d total: %d", fee.StandardFee, fee.DataFee, fee.Total)
+	// Output:standard: 53 data: 0 total: 53
+}
@@ -1,19 +1,47 @@
 package minercraft
 
 import (
-	"crypto/sha256"
+	"bytes"
 	"encoding/json"
 	"strings"
-
-	"github.com/bitcoinschema/go-bitcoin"
+	"time"
+	"unicode/utf8"
 )
 
 // Miner is a configuration per miner, including connection url, auth token, etc
 type Miner struct {
-	MinerID string `json:"miner_id,omitempty"`
-	Name    string `json:"name,omitempty"`
-	Token   string `json:"token,omitempty"`
-	URL     string `json:"url"`
+	AllowedIPs     []string      `json:"allowed_ips,omitempty"`     // IPs or CIDRs the transport may connect to (refuses other addresses, IE: DNS hijacks)
+	Compression    string        `json:"compression,omitempty"`     // Request body compression supported by the miner (IE: CompressionGzip)
+	ConnectAddress string        `json:"connect_address,omitempty"` // Host[:port] to connect to instead of the url host (the Host and SNI are kept)
+	DailyBudget    int           `json:"daily_budget,omitempty"`    // Max requests per day (UTC), refused or queued beyond it (see: ClientOptions.QueueOverBudget)
+	HourlyBudget   int           `json:"hourly_budget,omitempty"`   // Max requests per hour, refused or queued beyond it (IE: a paid API plan)
+	LongPoll       bool          `json:"long_poll,omitempty"`       // Supports long-polling tx queries (see: ClientOptions.QueryWait)
+	MaxTxSize      int64         `json:"max_tx_size,omitempty"`     // Overrides the client's MaxTxSize (IE: from the miner's policy)
+	MinerID        string        `json:"miner_id,omitempty"`
+	Name           string        `json:"name,omitempty"`
+	Network        string        `json:"network,omitempty"`    // Defaults to mainnet if not set
+	RateLimit      time.Duration `json:"rate_limit,omitempty"` // Minimum time between quote requests (on-demand and prefetched)
+	Scheme         string        `json:"scheme,omitempty"`     // Defaults to https if not set
+	StatusURL      string        `json:"status_url,omitempty"` // Server-sent events stream of tx statuses (IE: an ARC gateway, {txid} is replaced)
+	Timeout        time.Duration `json:"timeout,omitempty"`    // Overrides the operation timeouts (IE: slow or distant miners)
+	Token          string        `json:"token,omitempty"`
+	URL            string        `json:"url"`
+}
+
+// GetNetwork will return the network of the miner (defaults to mainnet)
+func (m *Miner) GetNetwork() string {
+	if len(m.Network) == 0 {
+		return NetworkMainnet
+	}
+	return m.Network
+}
+
+// GetScheme will return the url scheme of the miner (defaults to https)
+func (m *Miner) GetScheme() string {
+	if len(m.Scheme) == 0 {
+		return SchemeHTTPS
+	}
+	return strings.ToLower(m.Scheme)
 }
 
 // JSONEnvelope is a standard response from the Merchant API requests
@@ -31,46 +59,198 @@ type Miner struct {
 //
 // Specs: https://github.com/bitcoin-sv-specs/brfc-misc/tree/master/jsonenvelope
 type JSONEnvelope struct {
-	Miner     *Miner `json:"miner"`     // Custom field for our internal Miner configuration
-	Validated bool   `json:"validated"` // Custom field if the signature has been validated
-	Payload   string `json:"payload"`
-	Signature string `json:"signature"`
-	PublicKey string `json:"publicKey"`
-	Encoding  string `json:"encoding"`
-	MimeType  string `json:"mimetype"`
+	Miner           *Miner             `json:"miner"`                // Custom field for our internal Miner configuration
+	MinerIDMismatch bool               `json:"miner_id_mismatch"`    // Custom field if the publicKey does not match the minerId of the payload
+	Revoked         bool               `json:"revoked"`              // Custom field if the publicKey was revoked (see: RevokeMinerIDs)
+	Trusted         bool               `json:"trusted"`              // Custom field if the signing key is the pinned minerId of the miner (or rotated from it)
+	Validated       bool               `json:"validated"`            // Custom field if the signature has been validated
+	Violations      []*SchemaViolation `json:"violations,omitempty"` // Custom field for the schema violations of the payload (see: ClientOptions.ValidatePayloads)
+	Payload         string             `json:"payload"`
+	Signature       string             `json:"signature"`
+	PublicKey       string             `json:"publicKey"`
+	Encoding        string             `json:"encoding"`
+	MimeType        string             `json:"mimetype"`
+	payload         []byte             // Unescaped payload bytes (released after decoding)
+	strict          bool               // Decode the payload in strict mode (see: ParseModeStrict)
+	validate        bool               // Validate the decoded payload (see: ValidatePayload)
 }
 
 // process will take the raw payload and process into a struct
-// while also validating the signature vs payload
-func (p *JSONEnvelope) process(miner *Miner, bodyContents []byte) error {
+// while also validating the signature vs payload (using the default verifier if the client is nil)
+func (p *JSONEnvelope) process(client *Client, miner *Miner, bodyContents []byte) error {
 
 	// Set the miner on the response
 	p.Miner = miner
 
-	// Unmarshal the response
-	var err error
-	if err = json.Unmarshal(bodyContents, &p); err != nil {
+	// Check the structure before decoding (untrusted input)
+	err := checkEnvelope("", bodyContents, client.maxEnvelopeSize())
+	if err != nil {
 		return err
 	}
 
-	// Do we have a payload?
-	if len(p.Payload) > 0 {
-
-		// Remove all escaped slashes from payload envelope
-		// Also needed for signature validation since it was signed before escaping
-		p.Payload = strings.Replace(p.Payload, "\\", "", -1)
+	// Unmarshal the response (the payload is unescaped while decoding)
+	var body envelopeBody
+	p.validate = client != nil && client.Options != nil && client.Options.ValidatePayloads
+	if p.strict = client.strictParsing(); p.strict {
+		if err = decodeStrict("", bodyContents, &body, envelopeFields); err != nil {
+			return err
+		}
+	} else if err = json.Unmarshal(bodyContents, &body); err != nil {
+		return envelopeDecodeError("", err)
+	}
+	if err = checkEnvelopeFields(&body); err != nil {
+		return err
+	} else if len(body.Payload) > 0 {
+		if err = checkEnvelope("payload", body.Payload, 0); err != nil {
+			return err
+		}
 	}
+	p.Encoding = body.Encoding
+	p.MimeType = body.MimeType
+	p.Payload = string(body.Payload)
+	p.PublicKey = body.PublicKey
+	p.Signature = body.Signature
+	p.payload = body.Payload
 
 	// Verify using DER format
-	p.Validated, err = validateSignature(p.Signature, p.PublicKey, p.Payload)
+	if p.Validated, err = validateSignature(client.signatureVerifier(), p.Signature, p.PublicKey, p.payload); err != nil {
+		return err
+	}
+
+	// Check if the key was revoked
+	client.checkRevoked(p)
+
+	// Check the key against the pinned minerId of the miner
+	p.Trusted = p.Validated && client.trustedMinerID(miner, p.PublicKey)
+
+	// Track the signing key of the miner
+	if p.Validated && client != nil && miner != nil {
+		return client.checkMinerKey(miner, p.PublicKey, p.Trusted)
+	}
+	return nil
+}
+
+// revalidate will validate the signature of the (processed) payload again (IE: a restored response)
+//
+// The payload bytes are kept for decoding the payload (see: decodePayload)
+func (p *JSONEnvelope) revalidate(client *Client) (err error) {
+	p.payload = []byte(p.Payload)
+	if p.Validated, err = validateSignature(client.signatureVerifier(), p.Signature, p.PublicKey, p.payload); err != nil {
+		return
+	}
+	client.checkRevoked(p)
+	p.Trusted = p.Validated && client.trustedMinerID(p.Miner, p.PublicKey)
+	return
+}
+
+// decodePayload will decode the (processed) payload into v and release the payload bytes
+//
+// The payload bytes are shared by the signature validation and decoding (avoids copying large payloads)
+func (p *JSONEnvelope) decodePayload(v interface{}) error {
+	if len(p.payload) == 0 {
+		return nil
+	}
+	versioned, err := decodeVersionedPayload(p.payload, v, p.strict)
+	if !versioned {
+		err = decodePayloadData(p.payload, v, p.strict, requiredPayloadFields(v))
+	}
+	p.payload = nil
+	if err == nil && p.validate {
+		p.Violations = ValidatePayload(v)
+	}
 	return err
 }
 
-// validateSignature will check the data against the pubkey + signature
-func validateSignature(signature, pubKey, data string) (bool, error) {
-	// Only if we have a signature and pubkey
-	if len(signature) > 0 && len(pubKey) > 0 {
-		return bitcoin.VerifyMessageDER(sha256.Sum256([]byte(data)), pubKey, signature)
+// checkPayloadMinerID will flag the response if the publicKey does not match the minerId of the (decoded) payload
+//
+// A mismatch indicates a proxy re-signing responses or a protocol violation (unsigned responses are not flagged)
+func (p *JSONEnvelope) checkPayloadMinerID(minerID string) {
+	p.MinerIDMismatch = len(p.PublicKey) > 0 && len(minerID) > 0 && !strings.EqualFold(p.PublicKey, minerID)
+}
+
+// envelopeBody is the envelope as sent by the miner
+type envelopeBody struct {
+	Encoding  string          `json:"encoding"`
+	MimeType  string          `json:"mimetype"`
+	Payload   envelopePayload `json:"payload"`
+	PublicKey string          `json:"publicKey"`
+	Signature string          `json:"signature"`
+}
+
+// envelopePayload is the payload of the envelope, decoded and unescaped in a single copy
+//
+// Also needed for signature validation since it was signed before escaping
+type envelopePayload []byte
+
+// UnmarshalJSON will decode the payload string without any backslashes
+//
+// Payloads that only escape quotes, slashes and backslashes (most) are decoded in one pass, any other
+// escape sequence is decoded as a JSON string first (see: unescapePayload)
+func (e *envelopePayload) UnmarshalJSON(data []byte) error {
+	if payload, ok := unquotePayload(data); ok {
+		*e = payload
+		return nil
+	}
+	var payload *string
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	} else if payload != nil {
+		*e = unescapePayload(*payload)
+	}
+	return nil
+}
+
+// unquotePayload will return the JSON string without the quotes and backslashes (in a single copy)
+//
+// Returns false if the string has other escape sequences, control characters or invalid UTF-8 (or is not a string)
+func unquotePayload(data []byte) ([]byte, bool) {
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return nil, false
+	}
+	data = data[1 : len(data)-1]
+	payload := make([]byte, 0, len(data))
+	for len(data) > 0 {
+		index := bytes.IndexByte(data, '\\')
+		if index < 0 {
+			payload = append(payload, data...)
+			break
+		} else if index == len(data)-1 {
+			return nil, false
+		}
+		payload = append(payload, data[:index]...)
+		switch data[index+1] {
+		case '"', '/':
+			payload = append(payload, data[index+1])
+		case '\\': // An escaped backslash is removed (see: unescapePayload)
+		default:
+			return nil, false
+		}
+		data = data[index+2:]
+	}
+	for _, b := range payload {
+		if b < 0x20 {
+			return nil, false
+		}
+	}
+	if !utf8.Valid(payload) {
+		return nil, false
+	}
+	return payload, true
+}
+
+// unescapePayload will return the payload without any backslashes (in a single copy)
+//
+// Payloads without backslashes are copied in one move, otherwise the segments between backslashes are copied
+func unescapePayload(payload string) []byte {
+	index := strings.IndexByte(payload, '\\')
+	if index < 0 {
+		return []byte(payload)
+	}
+	data := make([]byte, 0, len(payload)-1)
+	for index >= 0 {
+		data = append(data, payload[:index]...)
+		payload = payload[index+1:]
+		index = strings.IndexByte(payload, '\\')
 	}
-	return false, nil
+	return append(data, payload...)
 }
@@ -0,0 +1,174 @@
+package minercraft
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// testEnvelope is a signed fee quote envelope (signature is valid)
+const testEnvelope = `{"payload": "{\"apiVersion\":\"` + testAPIVersion + `\",\"timestamp\":\"2020-10-09T21:26:17.410Z\",\"expiryTime\":\"2020-10-09T21:36:17.410Z\",\"minerId\":\"03e92d3e5c3f7bd945dfbf48e7a99393b1bfb3f11f380ae30d286e7ff2aec5a270\",\"currentHighestBlockHash\":\"0000000000000000035c5f8c0294802a01e500fa7b95337963bb3640da3bd565\",\"currentHighestBlockHeight\":656169,\"minerReputation\":null,\"fees\":[{\"id\":1,\"feeType\":\"standard\",\"miningFee\":{\"satoshis\":500,\"bytes\":1000},\"relayFee\":{\"satoshis\":250,\"bytes\":1000}},{\"id\":2,\"feeType\":\"data\",\"miningFee\":{\"satoshis\":500,\"bytes\":1000},\"relayFee\":{\"satoshis\":250,\"bytes\":1000}}]}",
+	"signature": "3045022100eed49f6bf75d8f975f581271e3df658fbe8ec67e6301ea8fc25a72d18c92e30e022056af253f0d24db6a8fde4e2c1ee95e7a5ecf2c7cdc93246f8328c9e0ca582fc4",
+	"publicKey": "03e92d3e5c3f7bd945dfbf48e7a99393b1bfb3f11f380ae30d286e7ff2aec5a270","encoding": "` + testEncoding + `","mimetype": "` + testMimeType + `"}`
+
+// TestJSONEnvelope_Process tests the method process()
+func TestJSONEnvelope_Process(t *testing.T) {
+	t.Parallel()
+
+	var response FeeQuoteResponse
+	if err := response.process(nil, &Miner{Name: MinerTaal}, []byte(testEnvelope)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if !response.Validated {
+		t.Fatalf("expected the signature to be validated")
+	} else if strings.Contains(response.Payload, `\`) {
+		t.Fatalf("expected the payload to be unescaped")
+	}
+
+	// Decode the payload (and release the bytes)
+	if err := response.decodePayload(&response.Quote); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if response.Quote == nil || len(response.Quote.Fees) != 2 {
+		t.Fatalf("expected the quote to be decoded, got: %v", response.Quote)
+	} else if response.payload != nil {
+		t.Fatalf("expected the payload bytes to be released")
+	}
+}
+
+// TestUnescapePayload tests the method unescapePayload()
+func TestUnescapePayload(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected string
+	}{
+		{"", ""},
+		{`{"a":"b"}`, `{"a":"b"}`},
+		{`{\"a\":\"b\"}`, `{"a":"b"}`},
+		{`\\\\`, ``},
+	}
+
+	for _, test := range tests {
+		if output := string(unescapePayload(test.input)); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%s] expected, but got: %s", t.Name(), test.input, test.expected, output)
+		}
+	}
+}
+
+// TestJSONEnvelope_CheckPayloadMinerID tests the method checkPayloadMinerID()
+func TestJSONEnvelope_CheckPayloadMinerID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("matching minerId", func(t *testing.T) {
+		result := &internalResult{Miner: &Miner{Name: MinerTaal}, Response: &RequestResponse{BodyContents: []byte(testEnvelope)}}
+		response, err := result.parseQuote()
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.MinerIDMismatch {
+			t.Fatalf("expected the minerId to match the publicKey")
+		}
+	})
+
+	t.Run("re-signed by a different key", func(t *testing.T) {
+		body := testSignedEnvelope(t, testMinerIDKeyOther, `{"apiVersion":"1.2.3","minerId":"`+testPubKey(t, testMinerIDKeyOld)+`","fees":[]}`)
+		result := &internalResult{Miner: &Miner{Name: MinerTaal}, Response: &RequestResponse{BodyContents: body}}
+		response, err := result.parseQuote()
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !response.Validated || !response.MinerIDMismatch {
+			t.Fatalf("expected a valid signature with a minerId mismatch, got: validated %t mismatch %t", response.Validated, response.MinerIDMismatch)
+		}
+
+		// The flag survives a binary round trip
+		data, _ := response.MarshalBinary()
+		var restored FeeQuoteResponse
+		if err = restored.UnmarshalBinary(data); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !restored.MinerIDMismatch {
+			t.Fatalf("expected the restored response to be flagged")
+		}
+	})
+
+	t.Run("unsigned response", func(t *testing.T) {
+		var envelope JSONEnvelope
+		envelope.checkPayloadMinerID(testPubKey(t, testMinerIDKeyOld))
+		if envelope.MinerIDMismatch {
+			t.Fatalf("expected an unsigned response to not be flagged")
+		}
+	})
+}
+
+// TestEnvelopePayload_UnmarshalJSON tests the method UnmarshalJSON() (the fast path matches decoding + unescaping)
+func TestEnvelopePayload_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		fastPath bool
+	}{
+		{`""`, true},
+		{`"{}"`, true},
+		{`"{\"a\":\"b\"}"`, true},
+		{`"{\\\"a\\\":\\\"b\\\"}"`, true},
+		{`"a\/b"`, true},
+		{`"héllo"`, true},
+		{`"line\nbreak"`, false},
+		{`"\u00e9\\u00e9"`, false},
+		{`"tab\t"`, false},
+		{`null`, false},
+	}
+
+	for _, test := range tests {
+		var decoded *string
+		if err := json.Unmarshal([]byte(test.input), &decoded); err != nil {
+			t.Fatalf("%s Failed: [%s] inputted and error not expected: %s", t.Name(), test.input, err.Error())
+		}
+		var expected string
+		if decoded != nil {
+			expected = string(unescapePayload(*decoded))
+		}
+
+		var payload envelopePayload
+		if err := json.Unmarshal([]byte(test.input), &payload); err != nil {
+			t.Fatalf("%s Failed: [%s] inputted and error not expected: %s", t.Name(), test.input, err.Error())
+		} else if string(payload) != expected {
+			t.Fatalf("%s Failed: [%s] inputted and [%s] expected, but got: %s", t.Name(), test.input, expected, string(payload))
+		} else if _, ok := unquotePayload([]byte(test.input)); ok != test.fastPath {
+			t.Fatalf("%s Failed: [%s] inputted and fast path [%t] expected", t.Name(), test.input, test.fastPath)
+		}
+	}
+
+	var payload envelopePayload
+	if err := json.Unmarshal([]byte(`123`), &payload); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// BenchmarkJSONEnvelope_Process benchmarks the method process() with a large payload
+func BenchmarkJSONEnvelope_Process(b *testing.B) {
+	body := []byte(`{"payload": "{\"data\":\"` + strings.Repeat("ab", 1024*1024) + `\"}"}`)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var response JSONEnvelope
+		_ = response.process(nil, &Miner{}, body)
+	}
+}
+
+// BenchmarkInternalResult_ParseQuote benchmarks the method parseQuote() (unsigned, to exclude the signature validation)
+func BenchmarkInternalResult_ParseQuote(b *testing.B) {
+	body := []byte(strings.Replace(testEnvelope, `"signature": "`, `"signature": "", "ignored": "`, 1))
+	result := &internalResult{Miner: &Miner{Name: MinerTaal}, Response: &RequestResponse{BodyContents: body}}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = result.parseQuote()
+	}
+}
+
+// BenchmarkUnescapePayload benchmarks the method unescapePayload() with a (double) escaped payload
+func BenchmarkUnescapePayload(b *testing.B) {
+	payload := strings.Repeat(`{\"apiVersion\":\"1.2.3\"}`, 1024)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = unescapePayload(payload)
+	}
+}
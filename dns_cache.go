@@ -0,0 +1,97 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// dialFunc is the signature of a net dialer (see: net.Dialer.DialContext)
+type dialFunc func(ctx context.Context, network, address string) (net.Conn, error)
+
+// lookupFunc is the signature of a host lookup (see: net.Resolver.LookupHost)
+type lookupFunc func(ctx context.Context, host string) ([]string, error)
+
+// dnsCache is a caching resolver for miner hostnames (see: ClientOptions.DNSCacheTTL)
+//
+// Lookups are cached for the TTL (overriding the TTL of the records), and an expired entry is
+// still used if a new lookup fails (IE: a brief DNS outage)
+type dnsCache struct {
+	sync.Mutex
+	entries map[string]*dnsEntry
+	clock   Clock
+	lookup  lookupFunc
+	ttl     time.Duration
+}
+
+// dnsEntry is the cached addresses of a host
+type dnsEntry struct {
+	addresses []string
+	expiresAt time.Time
+}
+
+// newDNSCache will return a new empty dns cache
+func newDNSCache(ttl time.Duration, lookup lookupFunc, clock Clock) *dnsCache {
+	return &dnsCache{clock: clock, entries: make(map[string]*dnsEntry), lookup: lookup, ttl: ttl}
+}
+
+// resolve will return the addresses of the host (cached, or a new lookup if expired)
+func (d *dnsCache) resolve(ctx context.Context, host string) ([]string, error) {
+	key := strings.ToLower(host)
+
+	// Use the cached entry (if not expired)
+	d.Lock()
+	entry, ok := d.entries[key]
+	d.Unlock()
+	if ok && d.clock.Now().Before(entry.expiresAt) {
+		return entry.addresses, nil
+	}
+
+	// Lookup the host (use the expired entry if the lookup fails)
+	addresses, err := d.lookup(ctx, host)
+	if err != nil || len(addresses) == 0 {
+		if ok {
+			return entry.addresses, nil
+		} else if err == nil {
+			err = errors.New("no addresses found for host: " + host)
+		}
+		return nil, err
+	}
+
+	d.Lock()
+	d.entries[key] = &dnsEntry{addresses: addresses, expiresAt: d.clock.Now().Add(d.ttl)}
+	d.Unlock()
+	return addresses, nil
+}
+
+// dialContext will return a dialer that resolves the host using the cache (trying each address in order)
+func (d *dnsCache) dialContext(dial dialFunc) dialFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+
+		// Dial IP addresses (and invalid addresses) directly
+		host, port, err := net.SplitHostPort(address)
+		if err != nil || net.ParseIP(host) != nil {
+			return dial(ctx, network, address)
+		}
+
+		// Resolve the host
+		var addresses []string
+		if addresses, err = d.resolve(ctx, host); err != nil {
+			return nil, err
+		}
+
+		// Dial each address until one connects
+		var conn net.Conn
+		for _, ip := range addresses {
+			if conn, err = dial(ctx, network, net.JoinHostPort(ip, port)); err == nil {
+				return conn, nil
+			} else if ctx.Err() != nil {
+				break
+			}
+		}
+		return nil, err
+	}
+}
@@ -0,0 +1,127 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockLookup is a host lookup that counts the lookups (and fails if set)
+type mockLookup struct {
+	sync.Mutex
+	addresses []string
+	err       error
+	lookups   int
+}
+
+// lookupHost is the mock lookup
+func (m *mockLookup) lookupHost(_ context.Context, _ string) ([]string, error) {
+	m.Lock()
+	defer m.Unlock()
+	m.lookups++
+	return m.addresses, m.err
+}
+
+// TestDNSCache_Resolve tests the method resolve()
+func TestDNSCache_Resolve(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cached until expired", func(t *testing.T) {
+		lookup := &mockLookup{addresses: []string{"10.0.0.1"}}
+		cache := newDNSCache(50*time.Millisecond, lookup.lookupHost, RealClock())
+
+		for i := 0; i < 3; i++ {
+			if addresses, err := cache.resolve(context.Background(), "merchantapi.taal.com"); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			} else if len(addresses) != 1 || addresses[0] != "10.0.0.1" {
+				t.Fatalf("unexpected addresses: %v", addresses)
+			}
+		}
+		if lookup.lookups != 1 {
+			t.Fatalf("expected 1 lookup, got: %d", lookup.lookups)
+		}
+
+		time.Sleep(60 * time.Millisecond)
+		_, _ = cache.resolve(context.Background(), "MerchantAPI.taal.com")
+		if lookup.lookups != 2 {
+			t.Fatalf("expected 2 lookups, got: %d", lookup.lookups)
+		}
+	})
+
+	t.Run("expired entry is used during an outage", func(t *testing.T) {
+		lookup := &mockLookup{addresses: []string{"10.0.0.1"}}
+		cache := newDNSCache(time.Nanosecond, lookup.lookupHost, RealClock())
+		_, _ = cache.resolve(context.Background(), "merchantapi.taal.com")
+
+		lookup.err = errors.New("dns outage")
+		if addresses, err := cache.resolve(context.Background(), "merchantapi.taal.com"); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if len(addresses) != 1 {
+			t.Fatalf("expected the expired addresses, got: %v", addresses)
+		}
+	})
+
+	t.Run("failed lookups", func(t *testing.T) {
+		cache := newDNSCache(time.Minute, (&mockLookup{err: errors.New("dns outage")}).lookupHost, RealClock())
+		if _, err := cache.resolve(context.Background(), "merchantapi.taal.com"); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+		cache = newDNSCache(time.Minute, (&mockLookup{}).lookupHost, RealClock())
+		if _, err := cache.resolve(context.Background(), "merchantapi.taal.com"); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestDNSCache_DialContext tests the method dialContext()
+func TestDNSCache_DialContext(t *testing.T) {
+	t.Parallel()
+
+	var dialed []string
+	dial := func(_ context.Context, _, address string) (net.Conn, error) {
+		dialed = append(dialed, address)
+		if address == "10.0.0.2:443" {
+			client, _ := net.Pipe()
+			return client, nil
+		}
+		return nil, errors.New("connection refused")
+	}
+
+	cache := newDNSCache(time.Minute, (&mockLookup{addresses: []string{"10.0.0.1", "10.0.0.2"}}).lookupHost, RealClock())
+	conn, err := cache.dialContext(dial)(context.Background(), "tcp", "merchantapi.taal.com:443")
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	_ = conn.Close()
+	if len(dialed) != 2 || dialed[0] != "10.0.0.1:443" {
+		t.Fatalf("expected each address to be dialed in order, got: %v", dialed)
+	}
+
+	// IP addresses are dialed directly
+	dialed = nil
+	_, _ = cache.dialContext(dial)(context.Background(), "tcp", "10.0.0.3:443")
+	if len(dialed) != 1 || dialed[0] != "10.0.0.3:443" {
+		t.Fatalf("expected the ip to be dialed directly, got: %v", dialed)
+	}
+
+	// Nothing connects
+	cache = newDNSCache(time.Minute, (&mockLookup{addresses: []string{"10.0.0.1"}}).lookupHost, RealClock())
+	if _, err = cache.dialContext(dial)(context.Background(), "tcp", "merchantapi.taal.com:443"); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// TestNewTransport_DNSCache tests the method NewTransport() with the dns cache
+func TestNewTransport_DNSCache(t *testing.T) {
+	t.Parallel()
+	skipDialer(t)
+
+	options := DefaultClientOptions()
+	options.DNSCacheTTL = time.Minute
+	if transport := NewTransport(options); transport.DialContext == nil {
+		t.Fatalf("expected a dialer")
+	}
+}
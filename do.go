@@ -0,0 +1,85 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// Do will fire a Merchant API request to any endpoint of the miner (IE: miner specific extensions)
+//
+// The path is appended to the miner url (IE: "/mapi/policyQuote"), the body (if not nil) is sent as JSON (POST or PUT)
+// and the miner's token is used for auth. The response is processed as a JSONEnvelope (signature
+// validated, see: JSONEnvelope.Validated) and the payload is decoded into out (if not nil).
+//
+// The request uses the RequestTimeout (or the miner's timeout), a tighter deadline on the context is kept
+func (c *Client) Do(ctx context.Context, miner *Miner, method, path string, body, out interface{}) (*JSONEnvelope, error) {
+
+	// Make sure we have a valid miner and path
+	if miner == nil {
+		return nil, errors.New("miner was nil")
+	} else if len(strings.Trim(path, "/")) == 0 {
+		return nil, errors.New("missing path")
+	} else if err := c.checkNetwork(miner); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	result := doRequest(ctx, c, miner, method, path, body)
+	if result.Response.Error != nil {
+		return nil, result.Response.Error
+	}
+
+	// Process the envelope (validates the signature)
+	envelope := new(JSONEnvelope)
+	if err := envelope.process(c, miner, result.Response.BodyContents); err != nil {
+		return nil, err
+	}
+
+	// Decode the payload
+	if out != nil {
+		if err := envelope.decodePayload(out); err != nil {
+			return nil, err
+		}
+	}
+	envelope.payload = nil
+
+	return envelope, nil
+}
+
+// doRequest will fire the HTTP request to the miner endpoint
+func doRequest(ctx context.Context, client *Client, miner *Miner, method, path string, body interface{}) (result *internalResult) {
+	result = &internalResult{Miner: miner, client: client}
+	if len(method) == 0 {
+		method = http.MethodGet
+	}
+
+	// Build the endpoint url
+	endpoint, err := buildURL(miner, path)
+	if err != nil {
+		result.Response = &RequestResponse{Error: err, Method: method}
+		return
+	}
+
+	// Encode the body
+	var data []byte
+	if body != nil {
+		if data, err = json.Marshal(body); err != nil {
+			result.Response = &RequestResponse{Error: err, Method: method, URL: endpoint}
+			return
+		}
+	}
+
+	result.Response = httpRequest(ctx, client, &httpPayload{
+		Miner:     miner,
+		Method:    method,
+		Operation: OperationCustom,
+		URL:       endpoint,
+		Token:     miner.Token,
+		Timeout:   client.minerTimeout(miner, 0),
+		Data:      data,
+	})
+	return
+}
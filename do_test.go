@@ -0,0 +1,149 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+// mockHTTPCustomEndpoint for mocking requests to a custom endpoint (records the last request)
+type mockHTTPCustomEndpoint struct {
+	body   string
+	method string
+	mu     sync.Mutex
+	token  string
+	url    string
+}
+
+// Do is a mock http request
+func (m *mockHTTPCustomEndpoint) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+	resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(`{"status":400}`)))
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Record the request
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.method = req.Method
+	m.token = req.Header.Get("token")
+	m.url = req.URL.String()
+	m.body = ""
+	if req.Body != nil {
+		data, _ := ioutil.ReadAll(req.Body)
+		m.body = string(data)
+	}
+
+	// Valid response (a signed envelope)
+	if req.URL.Path == "/mapi/custom/ext" {
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(testEnvelope)))
+	}
+
+	return resp, nil
+}
+
+// TestClient_Do tests the method Do()
+func TestClient_Do(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid request", func(t *testing.T) {
+		mock := &mockHTTPCustomEndpoint{}
+		client := newTestClient(mock)
+		miner := client.MinerByName(MinerTaal)
+		miner.Token = testMinerToken
+
+		var payload FeePayload
+		envelope, err := client.Do(context.Background(), miner, http.MethodPost, "/mapi/custom/ext", map[string]string{"key": "value"}, &payload)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !envelope.Validated || envelope.Miner != miner {
+			t.Fatalf("expected a validated envelope from [%s], got: %v", miner.Name, envelope)
+		} else if len(payload.Fees) != 2 || payload.APIVersion != testAPIVersion {
+			t.Fatalf("expected the payload to be decoded, got: %v", payload)
+		}
+
+		// The request used the miner url, token and body
+		mock.mu.Lock()
+		defer mock.mu.Unlock()
+		if mock.method != http.MethodPost || mock.url != "https://merchantapi.taal.com/mapi/custom/ext" {
+			t.Fatalf("unexpected request: %s %s", mock.method, mock.url)
+		} else if mock.token != testMinerToken {
+			t.Fatalf("expected token [%s] but got: %s", testMinerToken, mock.token)
+		} else if mock.body != `{"key":"value"}` {
+			t.Fatalf("unexpected body: %s", mock.body)
+		}
+	})
+
+	t.Run("default method and no output", func(t *testing.T) {
+		mock := &mockHTTPCustomEndpoint{}
+		client := newTestClient(mock)
+		envelope, err := client.Do(context.Background(), client.MinerByName(MinerTaal), "", "mapi/custom/ext/", nil, nil)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !envelope.Validated || len(envelope.Payload) == 0 {
+			t.Fatalf("expected a validated envelope, got: %v", envelope)
+		}
+
+		mock.mu.Lock()
+		defer mock.mu.Unlock()
+		if mock.method != http.MethodGet || len(mock.body) > 0 {
+			t.Fatalf("unexpected request: %s [%s]", mock.method, mock.body)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		client := newTestClient(&mockHTTPCustomEndpoint{})
+		if _, err := client.Do(context.Background(), nil, http.MethodGet, "/mapi/custom/ext", nil, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.Do(context.Background(), client.MinerByName(MinerTaal), http.MethodGet, "/", nil, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.Do(context.Background(), client.MinerByName(MinerTaal), http.MethodGet, "/mapi/unknown", nil, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.Do(context.Background(), client.MinerByName(MinerTaal), http.MethodPost, "/mapi/custom/ext", make(chan int), nil); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		client := newTestClient(&mockHTTPCustomEndpoint{})
+		var out []string
+		if _, err := client.Do(context.Background(), client.MinerByName(MinerTaal), http.MethodGet, "/mapi/custom/ext", nil, &out); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleClient_Do example using Do()
+func ExampleClient_Do() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPCustomEndpoint{})
+
+	// Request a miner specific endpoint
+	var payload FeePayload
+	envelope, err := client.Do(context.Background(), client.MinerByName(MinerTaal), http.MethodGet, "/mapi/custom/ext", nil, &payload)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("validated: %v api version: %s", envelope.Validated, payload.APIVersion)
+	// Output:validated: true api version: 0.1.0
+}
+
+// BenchmarkClient_Do benchmarks the method Do()
+func BenchmarkClient_Do(b *testing.B) {
+	client := newTestClient(&mockHTTPCustomEndpoint{})
+	miner := client.MinerByName(MinerTaal)
+	for i := 0; i < b.N; i++ {
+		var payload FeePayload
+		_, _ = client.Do(context.Background(), miner, http.MethodGet, "/mapi/custom/ext", nil, &payload)
+	}
+}
@@ -0,0 +1,115 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Endpoint is a custom (miner specific) mAPI endpoint (IE: a miner's proprietary "/mapi/txstatus/ext")
+type Endpoint struct {
+	Method     string             `json:"method"` // HTTP method (defaults to GET)
+	Name       string             `json:"name"`   // Unique name of the endpoint (case-insensitive)
+	NewPayload func() interface{} `json:"-"`      // Returns a new payload value (pointer) to decode into (optional)
+	Path       string             `json:"path"`   // Path template, params use braces (IE: "/mapi/txstatus/ext/{txid}")
+}
+
+// EndpointResponse is the response from a custom endpoint
+type EndpointResponse struct {
+	JSONEnvelope
+	Endpoint *Endpoint   `json:"endpoint"` // The custom endpoint that was requested
+	Result   interface{} `json:"result"`   // Custom field for the unmarshalled payload (from Endpoint.NewPayload)
+}
+
+// endpointParam matches the params in an endpoint path template (IE: "{txid}")
+var endpointParam = regexp.MustCompile(`{([^{}/]+)}`)
+
+// endpointRegistry is the list of registered custom endpoints
+type endpointRegistry struct {
+	sync.RWMutex
+	endpoints map[string]*Endpoint
+}
+
+// newEndpointRegistry will return a new empty endpoint registry
+func newEndpointRegistry() *endpointRegistry {
+	return &endpointRegistry{endpoints: make(map[string]*Endpoint)}
+}
+
+// RegisterEndpoint will register a custom endpoint that can be requested using RequestEndpoint()
+//
+// Custom endpoints use the same auth, timeouts and envelope signature validation as the mAPI requests
+func (c *Client) RegisterEndpoint(endpoint Endpoint) error {
+
+	// Make sure we have a name and path
+	if len(endpoint.Name) == 0 {
+		return errors.New("missing endpoint name")
+	} else if len(strings.Trim(endpoint.Path, "/")) == 0 {
+		return errors.New("missing endpoint path")
+	}
+
+	// Check if an endpoint with that name already exists
+	c.endpoints.Lock()
+	defer c.endpoints.Unlock()
+	name := strings.ToLower(endpoint.Name)
+	if _, ok := c.endpoints.endpoints[name]; ok {
+		return fmt.Errorf("endpoint %s already exists", endpoint.Name)
+	}
+	c.endpoints.endpoints[name] = &endpoint
+	return nil
+}
+
+// Endpoint will return a registered custom endpoint given a name (or nil if not found)
+func (c *Client) Endpoint(name string) *Endpoint {
+	c.endpoints.RLock()
+	defer c.endpoints.RUnlock()
+	return c.endpoints.endpoints[strings.ToLower(name)]
+}
+
+// RequestEndpoint will fire a request to a registered custom endpoint of the miner
+//
+// The params are escaped and replaced in the path template (all params are required) and the body
+// (if not nil) is sent as JSON (POST or PUT). The payload is decoded into a new Endpoint.NewPayload value.
+func (c *Client) RequestEndpoint(ctx context.Context, miner *Miner, name string,
+	params map[string]string, body interface{}) (*EndpointResponse, error) {
+
+	// Find the endpoint
+	endpoint := c.Endpoint(name)
+	if endpoint == nil {
+		return nil, fmt.Errorf("endpoint %s was not found", name)
+	}
+
+	// Build the path from the template
+	path, err := endpoint.buildPath(params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fire the request and decode the payload
+	response := &EndpointResponse{Endpoint: endpoint}
+	if endpoint.NewPayload != nil {
+		response.Result = endpoint.NewPayload()
+	}
+	var envelope *JSONEnvelope
+	if envelope, err = c.Do(ctx, miner, endpoint.Method, path, body, response.Result); err != nil {
+		return nil, err
+	}
+	response.JSONEnvelope = *envelope
+
+	return response, nil
+}
+
+// buildPath will replace the params in the path template (all params are required)
+func (e *Endpoint) buildPath(params map[string]string) (path string, err error) {
+	path = endpointParam.ReplaceAllStringFunc(e.Path, func(match string) string {
+		value, ok := params[match[1:len(match)-1]]
+		if !ok && err == nil {
+			err = fmt.Errorf("missing endpoint param: %s", match[1:len(match)-1])
+		}
+		return url.PathEscape(value)
+	})
+	return
+}
@@ -0,0 +1,156 @@
+package minercraft
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestClient_RegisterEndpoint tests the method RegisterEndpoint()
+func TestClient_RegisterEndpoint(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPCustomEndpoint{})
+
+	// Create the list of tests
+	var tests = []struct {
+		input         Endpoint
+		expectedError bool
+	}{
+		{Endpoint{Name: "txStatus", Path: "/mapi/txstatus/ext/{txid}"}, false},
+		{Endpoint{Name: "TXSTATUS", Path: "/mapi/other"}, true},
+		{Endpoint{Name: "", Path: "/mapi/other"}, true},
+		{Endpoint{Name: "other", Path: "/"}, true},
+		{Endpoint{Name: "other", Path: "/mapi/other", Method: http.MethodPost}, false},
+	}
+
+	// Run tests
+	for _, test := range tests {
+		if err := client.RegisterEndpoint(test.input); err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%v] inputted and error not expected but got: %s", t.Name(), test.input, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%v] inputted and error was expected", t.Name(), test.input)
+		}
+	}
+
+	// Find the endpoints
+	if endpoint := client.Endpoint("TxStatus"); endpoint == nil || endpoint.Path != "/mapi/txstatus/ext/{txid}" {
+		t.Fatalf("expected endpoint [txStatus] but got: %v", endpoint)
+	} else if endpoint = client.Endpoint("unknown"); endpoint != nil {
+		t.Fatalf("expected no endpoint but got: %v", endpoint)
+	}
+}
+
+// TestEndpoint_buildPath tests the method buildPath()
+func TestEndpoint_buildPath(t *testing.T) {
+	t.Parallel()
+
+	// Create the list of tests
+	var tests = []struct {
+		inputPath     string
+		inputParams   map[string]string
+		expectedPath  string
+		expectedError bool
+	}{
+		{"/mapi/tx/{txid}", map[string]string{"txid": testTx}, "/mapi/tx/" + testTx, false},
+		{"/mapi/{a}/{b}", map[string]string{"a": "one", "b": "two/three"}, "/mapi/one/two%2Fthree", false},
+		{"/mapi/status", nil, "/mapi/status", false},
+		{"/mapi/tx/{txid}", nil, "", true},
+		{"/mapi/{a}/{b}", map[string]string{"a": "one"}, "", true},
+	}
+
+	// Run tests
+	for _, test := range tests {
+		endpoint := &Endpoint{Path: test.inputPath}
+		if path, err := endpoint.buildPath(test.inputParams); err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] [%v] inputted and error not expected but got: %s", t.Name(), test.inputPath, test.inputParams, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] [%v] inputted and error was expected", t.Name(), test.inputPath, test.inputParams)
+		} else if err == nil && path != test.expectedPath {
+			t.Errorf("%s Failed: [%s] [%v] inputted and [%s] expected but got: %s", t.Name(), test.inputPath, test.inputParams, test.expectedPath, path)
+		}
+	}
+}
+
+// TestClient_RequestEndpoint tests the method RequestEndpoint()
+func TestClient_RequestEndpoint(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCustomEndpoint{}
+	client := newTestClient(mock)
+	if err := client.RegisterEndpoint(Endpoint{
+		Method:     http.MethodPost,
+		Name:       "custom",
+		NewPayload: func() interface{} { return &FeePayload{} },
+		Path:       "/mapi/custom/{name}",
+	}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	t.Run("valid request", func(t *testing.T) {
+		response, err := client.RequestEndpoint(context.Background(), client.MinerByName(MinerTaal), "custom", map[string]string{"name": "ext"}, nil)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !response.Validated || response.Endpoint.Name != "custom" {
+			t.Fatalf("expected a validated response from [custom], got: %v", response)
+		}
+		payload, ok := response.Result.(*FeePayload)
+		if !ok || len(payload.Fees) != 2 {
+			t.Fatalf("expected the payload to be decoded, got: %v", response.Result)
+		}
+		mock.mu.Lock()
+		defer mock.mu.Unlock()
+		if mock.method != http.MethodPost {
+			t.Fatalf("expected method [%s] but got: %s", http.MethodPost, mock.method)
+		}
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		if _, err := client.RequestEndpoint(context.Background(), client.MinerByName(MinerTaal), "unknown", nil, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.RequestEndpoint(context.Background(), client.MinerByName(MinerTaal), "custom", nil, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.RequestEndpoint(context.Background(), client.MinerByName(MinerTaal), "custom", map[string]string{"name": "other"}, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.RequestEndpoint(context.Background(), nil, "custom", map[string]string{"name": "ext"}, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleClient_RequestEndpoint example using RequestEndpoint()
+func ExampleClient_RequestEndpoint() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPCustomEndpoint{})
+
+	// Register a miner specific endpoint
+	if err := client.RegisterEndpoint(Endpoint{
+		Name:       "custom",
+		NewPayload: func() interface{} { return &FeePayload{} },
+		Path:       "/mapi/custom/{name}",
+	}); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	// Request the endpoint
+	response, err := client.RequestEndpoint(context.Background(), client.MinerByName(MinerTaal), "custom", map[string]string{"name": "ext"}, nil)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("validated: %v api version: %s", response.Validated, response.Result.(*FeePayload).APIVersion)
+	// Output:validated: true api version: 0.1.0
+}
+
+// BenchmarkClient_RequestEndpoint benchmarks the method RequestEndpoint()
+func BenchmarkClient_RequestEndpoint(b *testing.B) {
+	client := newTestClient(&mockHTTPCustomEndpoint{})
+	_ = client.RegisterEndpoint(Endpoint{Name: "custom", Path: "/mapi/custom/{name}"})
+	miner := client.MinerByName(MinerTaal)
+	params := map[string]string{"name": "ext"}
+	for i := 0; i < b.N; i++ {
+		_, _ = client.RequestEndpoint(context.Background(), miner, "custom", params, nil)
+	}
+}
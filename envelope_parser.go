@@ -0,0 +1,142 @@
+package minercraft
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// DefaultMaxEnvelopeSize is the default max size of a response envelope (see: ClientOptions.MaxEnvelopeSize)
+const DefaultMaxEnvelopeSize = 16 << 20
+
+const (
+
+	// maxEnvelopeDepth is the max nesting of objects and arrays in an envelope or its payload
+	// (mAPI payloads are nested a few levels, deeper documents are junk)
+	maxEnvelopeDepth = 32
+
+	// maxEnvelopeKeySize is the max size of the signature and publicKey fields (hex DER signature and public key)
+	maxEnvelopeKeySize = 256
+)
+
+// Envelope errors (see: EnvelopeError)
+var (
+	ErrEnvelopeEncoding      = errors.New("unsupported envelope encoding")
+	ErrEnvelopeFieldTooLarge = errors.New("envelope field is too large")
+	ErrEnvelopeMalformed     = errors.New("envelope is malformed")
+	ErrEnvelopeTooDeep       = errors.New("envelope is nested too deep")
+	ErrEnvelopeTooLarge      = errors.New("envelope is too large")
+	ErrEnvelopeTruncated     = errors.New("envelope is truncated")
+)
+
+// EnvelopeError is returned when a response envelope (or its payload) is malformed
+type EnvelopeError struct {
+	Field  string // The field of the envelope (IE: payload, empty for the envelope)
+	Offset int64  // Offset of the failure in the envelope or field (if known)
+	Reason error  // One of the envelope errors (IE: ErrEnvelopeTruncated)
+}
+
+// Error will return the error message
+func (e *EnvelopeError) Error() string {
+	message := e.Reason.Error()
+	if len(e.Field) > 0 {
+		message += ": field " + e.Field
+	}
+	if e.Offset > 0 {
+		message += " at offset " + strconv.FormatInt(e.Offset, 10)
+	}
+	return message
+}
+
+// Unwrap will return the reason (for use with errors.Is())
+func (e *EnvelopeError) Unwrap() error {
+	return e.Reason
+}
+
+// ParseEnvelope will parse (and validate the signature of) a response envelope, malformed input returns an
+// EnvelopeError (bounded by DefaultMaxEnvelopeSize, safe for untrusted input and fuzzing)
+func ParseEnvelope(data []byte) (*JSONEnvelope, error) {
+	envelope := new(JSONEnvelope)
+	if err := envelope.process(nil, nil, data); err != nil {
+		return nil, err
+	}
+	return envelope, nil
+}
+
+// maxEnvelopeSize will return the max size of an envelope (the default if the client is nil)
+func (c *Client) maxEnvelopeSize() int64 {
+	if c == nil || c.Options == nil {
+		return DefaultMaxEnvelopeSize
+	}
+	return c.Options.MaxEnvelopeSize
+}
+
+// checkEnvelope will check the size and structure of an envelope (or a payload) before decoding it:
+// the nesting depth is bounded and truncated documents are detected in a single pass without allocating
+func checkEnvelope(field string, data []byte, maxSize int64) error {
+	if maxSize > 0 && int64(len(data)) > maxSize {
+		return &EnvelopeError{Field: field, Offset: maxSize, Reason: ErrEnvelopeTooLarge}
+	}
+
+	var depth int
+	var inString, escaped, started bool
+	for offset, b := range data {
+		switch {
+		case inString:
+			if escaped {
+				escaped = false
+			} else if b == '\\' {
+				escaped = true
+			} else if b == '"' {
+				inString = false
+			}
+		case b == '"':
+			inString, started = true, true
+		case b == '{' || b == '[':
+			if depth++; depth > maxEnvelopeDepth {
+				return &EnvelopeError{Field: field, Offset: int64(offset), Reason: ErrEnvelopeTooDeep}
+			}
+			started = true
+		case b == '}' || b == ']':
+			if depth--; depth < 0 {
+				return &EnvelopeError{Field: field, Offset: int64(offset), Reason: ErrEnvelopeMalformed}
+			}
+		case b != ' ' && b != '\t' && b != '\r' && b != '\n':
+			started = true
+		}
+	}
+	if !started || inString || depth > 0 {
+		return &EnvelopeError{Field: field, Offset: int64(len(data)), Reason: ErrEnvelopeTruncated}
+	}
+	return nil
+}
+
+// checkEnvelopeFields will check the fields of a decoded envelope (encoding and key sizes)
+func checkEnvelopeFields(body *envelopeBody) error {
+	if len(body.Encoding) > 0 && !strings.EqualFold(body.Encoding, "UTF-8") && !strings.EqualFold(body.Encoding, "UTF8") {
+		return &EnvelopeError{Field: "encoding", Reason: ErrEnvelopeEncoding}
+	} else if len(body.MimeType) > 0 && !strings.Contains(strings.ToLower(body.MimeType), "json") {
+		return &EnvelopeError{Field: "mimetype", Reason: ErrEnvelopeEncoding}
+	} else if len(body.Signature) > maxEnvelopeKeySize {
+		return &EnvelopeError{Field: "signature", Reason: ErrEnvelopeFieldTooLarge}
+	} else if len(body.PublicKey) > maxEnvelopeKeySize {
+		return &EnvelopeError{Field: "publicKey", Reason: ErrEnvelopeFieldTooLarge}
+	}
+	return nil
+}
+
+// envelopeDecodeError will return the decoding error as an EnvelopeError
+func envelopeDecodeError(field string, err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &syntaxErr) {
+		return &EnvelopeError{Field: field, Offset: syntaxErr.Offset, Reason: ErrEnvelopeMalformed}
+	} else if errors.As(err, &typeErr) {
+		if len(typeErr.Field) > 0 {
+			field = typeErr.Field
+		}
+		return &EnvelopeError{Field: field, Offset: typeErr.Offset, Reason: ErrEnvelopeMalformed}
+	}
+	return err
+}
@@ -0,0 +1,78 @@
+package minercraft
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+// TestParseEnvelope tests the method ParseEnvelope()
+func TestParseEnvelope(t *testing.T) {
+	t.Parallel()
+
+	t.Run("malformed envelopes", func(t *testing.T) {
+		var tests = []struct {
+			input    string
+			expected error
+		}{
+			{"", ErrEnvelopeTruncated},
+			{"  ", ErrEnvelopeTruncated},
+			{testCallback[:len(testCallback)/2], ErrEnvelopeTruncated},
+			{`{"payload":"{\"txid\":\"abc`, ErrEnvelopeTruncated},
+			{strings.Repeat("[", 1000) + strings.Repeat("]", 1000), ErrEnvelopeTooDeep},
+			{`{"payload":"` + strings.Repeat(`[`, 100) + `"}`, ErrEnvelopeTooDeep},
+			{`{"payload":"{}"}}`, ErrEnvelopeMalformed},
+			{`{"payload":"{}",}`, ErrEnvelopeMalformed},
+			{`{"payload":{}}`, ErrEnvelopeMalformed},
+			{`{"payload":"{}","encoding":"base64"}`, ErrEnvelopeEncoding},
+			{`{"payload":"{}","mimetype":"text/html"}`, ErrEnvelopeEncoding},
+			{`{"payload":"{}","signature":"` + strings.Repeat("ab", 1000) + `"}`, ErrEnvelopeFieldTooLarge},
+			{`{"payload":"` + strings.Repeat(" ", DefaultMaxEnvelopeSize) + `"}`, ErrEnvelopeTooLarge},
+		}
+		for _, test := range tests {
+			var envelopeErr *EnvelopeError
+			if _, err := ParseEnvelope([]byte(test.input)); !errors.Is(err, test.expected) || !errors.As(err, &envelopeErr) {
+				input := test.input
+				if len(input) > 64 {
+					input = input[:64]
+				}
+				t.Errorf("%s Failed: [%s] inputted and [%v] expected, received: [%v]", t.Name(), input, test.expected, err)
+			}
+		}
+	})
+
+	t.Run("valid envelope", func(t *testing.T) {
+		envelope, err := ParseEnvelope([]byte(testCallback))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !strings.Contains(envelope.Payload, testSubmittedTx) {
+			t.Fatalf("expected the payload, got: %s", envelope.Payload)
+		}
+	})
+
+	t.Run("mutated envelopes", func(t *testing.T) {
+		random := rand.New(rand.NewSource(1)) //nolint:gosec // reproducible mutations
+		junk := []byte(`{}[]"\,: 0aZ` + "\x00\xff")
+		for i := 0; i < 5000; i++ {
+			data := []byte(testCallback)
+			for j := random.Intn(8); j >= 0; j-- {
+				index := random.Intn(len(data))
+				switch random.Intn(3) {
+				case 0:
+					data[index] = junk[random.Intn(len(junk))]
+				case 1:
+					data = data[:index]
+				default:
+					data = append(data[:index], append([]byte{junk[random.Intn(len(junk))]}, data[index:]...)...)
+				}
+				if len(data) == 0 {
+					break
+				}
+			}
+			if _, err := ParseEnvelope(data); err != nil && len(err.Error()) == 0 {
+				t.Fatalf("expected an error message for: %q", data)
+			}
+		}
+	})
+}
@@ -0,0 +1,152 @@
+package minercraft
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EventType is the type of miner event
+type EventType string
+
+const (
+
+	// EventCircuitOpened is published when quote requests to a miner start failing
+	// (see: ClientOptions.QuoteFailureTTL, requests are skipped until the failure expires)
+	EventCircuitOpened EventType = "circuit_opened"
+
+	// EventKeyRotated is published when a miner signs with a new key that was rotated from the previous key
+	// (see: AddMinerIDRotation)
+	EventKeyRotated EventType = "key_rotated"
+
+	// EventMinerIDChanged is a warning published when a miner signs with a new key without a valid rotation
+	// (IE: a compromised endpoint, see: ClientOptions.StrictMinerID)
+	EventMinerIDChanged EventType = "miner_id_changed"
+
+	// EventMinerUnhealthy is published when a health check marks a healthy (or unchecked) miner as unhealthy
+	EventMinerUnhealthy EventType = "miner_unhealthy"
+
+	// EventQueuedTxFlushed is published with the result of each queued submission that was flushed to a recovered miner
+	// (see: SubmitTransactionAny)
+	EventQueuedTxFlushed EventType = "queued_tx_flushed"
+
+	// EventQuoteChanged is published when a new quote of a miner has different fees than the previous quote
+	EventQuoteChanged EventType = "quote_changed"
+)
+
+// Event is a structured event about a miner (see: Subscribe)
+type Event struct {
+	Error       string            `json:"error,omitempty"` // The error (EventCircuitOpened and EventMinerUnhealthy)
+	Miner       *Miner            `json:"miner"`
+	PreviousKey string            `json:"previous_key,omitempty"` // The previous key (EventKeyRotated and EventMinerIDChanged)
+	PublicKey   string            `json:"public_key,omitempty"`   // The new key (EventKeyRotated and EventMinerIDChanged)
+	Quote       *FeeQuoteResponse `json:"quote,omitempty"`        // The new quote (EventQuoteChanged)
+	Submission  *SubmitResult     `json:"submission,omitempty"`   // The result of the submission (EventQueuedTxFlushed)
+	Time        time.Time         `json:"time"`
+	Type        EventType         `json:"type"`
+}
+
+// eventSubscriber is a subscribed channel and the event types it receives (all if empty)
+type eventSubscriber struct {
+	events chan *Event
+	types  map[EventType]bool
+}
+
+// eventBus is the list of subscribers and the last known fees of each miner (for EventQuoteChanged)
+type eventBus struct {
+	sync.RWMutex
+	fees        map[string]string
+	nextID      int
+	subscribers map[int]*eventSubscriber
+}
+
+// newEventBus will return a new event bus without subscribers
+func newEventBus() *eventBus {
+	return &eventBus{
+		fees:        make(map[string]string),
+		subscribers: make(map[int]*eventSubscriber),
+	}
+}
+
+// Subscribe will return a channel that receives the miner events of the given types (all events if no types are given)
+// and a function to unsubscribe (which closes the channel)
+//
+// Events are dropped if the channel buffer is full, publishing never blocks the client
+func (c *Client) Subscribe(bufferSize int, types ...EventType) (<-chan *Event, func()) {
+	subscriber := &eventSubscriber{
+		events: make(chan *Event, bufferSize),
+		types:  make(map[EventType]bool),
+	}
+	for _, eventType := range types {
+		subscriber.types[eventType] = true
+	}
+
+	c.events.Lock()
+	c.events.nextID++
+	id := c.events.nextID
+	c.events.subscribers[id] = subscriber
+	c.events.Unlock()
+
+	var once sync.Once
+	return subscriber.events, func() {
+		once.Do(func() {
+			c.events.Lock()
+			delete(c.events.subscribers, id)
+			c.events.Unlock()
+			close(subscriber.events)
+		})
+	}
+}
+
+// publish will send the event to all subscribers of the event type
+func (e *eventBus) publish(event *Event, now time.Time) {
+	if event.Time.IsZero() {
+		event.Time = now.UTC()
+	}
+	e.RLock()
+	defer e.RUnlock()
+	for _, subscriber := range e.subscribers {
+		if len(subscriber.types) > 0 && !subscriber.types[event.Type] {
+			continue
+		}
+		select {
+		case subscriber.events <- event:
+		default:
+		}
+	}
+}
+
+// quoteChanged will store the fees of the quote and return true if the previous quote of the miner had different fees
+//
+// The first quote seen for a miner is not a change
+func (e *eventBus) quoteChanged(quote *FeeQuoteResponse) bool {
+	if quote == nil || quote.Miner == nil || quote.Quote == nil {
+		return false
+	}
+	fees := feesFingerprint(quote.Quote.Fees)
+
+	e.Lock()
+	defer e.Unlock()
+	previous, ok := e.fees[strings.ToLower(quote.Miner.Name)]
+	e.fees[strings.ToLower(quote.Miner.Name)] = fees
+	return ok && previous != fees
+}
+
+// feesFingerprint will return a comparable representation of the fee types and rates
+func feesFingerprint(fees []*feeType) string {
+	var builder strings.Builder
+	for _, fee := range fees {
+		if fee == nil {
+			continue
+		}
+		builder.WriteString(fee.FeeType)
+		for _, amount := range []*feeAmount{fee.MiningFee, fee.RelayFee} {
+			if amount != nil {
+				builder.WriteString(":" + strconv.FormatUint(amount.Satoshis, 10) + "/" + strconv.FormatUint(amount.Bytes, 10))
+			}
+		}
+		builder.WriteString(";")
+	}
+	return builder.String()
+}
@@ -0,0 +1,120 @@
+package minercraft
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestClient_Subscribe tests the method Subscribe()
+func TestClient_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	t.Run("quote changed", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		events, unsubscribe := client.Subscribe(10, EventQuoteChanged)
+		defer unsubscribe()
+
+		miner := client.MinerByName(MinerTaal)
+		for _, httpClient := range []httpInterface{&mockHTTPValidFeeQuote{}, &mockHTTPValidFeeQuote{}, &mockHTTPBetterRate{}} {
+			client.httpClient = httpClient
+			if _, err := client.FeeQuote(miner); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+		}
+
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event (fees changed once), got: %d", len(events))
+		}
+		event := <-events
+		if event.Type != EventQuoteChanged || event.Miner != miner || event.Quote == nil {
+			t.Fatalf("unexpected event: %+v", event)
+		} else if event.Time.IsZero() {
+			t.Fatalf("expected the event time to be set")
+		}
+	})
+
+	t.Run("miner unhealthy", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBadRequest{})
+		events, unsubscribe := client.Subscribe(10)
+		defer unsubscribe()
+
+		miner := client.MinerByName(MinerTaal)
+		client.HealthCheck(context.Background(), miner)
+		client.HealthCheck(context.Background(), miner)
+
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event (still unhealthy), got: %d", len(events))
+		} else if event := <-events; event.Type != EventMinerUnhealthy || len(event.Error) == 0 {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+
+		// Recover and fail again
+		client.httpClient = &mockHTTPValidFeeQuote{}
+		client.HealthCheck(context.Background(), miner)
+		client.httpClient = &mockHTTPBadRequest{}
+		client.HealthCheck(context.Background(), miner)
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event (unhealthy again), got: %d", len(events))
+		}
+	})
+
+	t.Run("circuit opened", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBadRequest{})
+		client.Options.QuoteFailureTTL = time.Minute
+		events, unsubscribe := client.Subscribe(10, EventCircuitOpened)
+		defer unsubscribe()
+
+		miner := client.MinerByName(MinerTaal)
+		for i := 0; i < 2; i++ {
+			if _, err := client.FeeQuote(miner); err == nil {
+				t.Fatalf("error should have occurred")
+			}
+		}
+
+		if len(events) != 1 {
+			t.Fatalf("expected 1 event, got: %d", len(events))
+		} else if event := <-events; event.Type != EventCircuitOpened || event.Miner != miner {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	})
+
+	t.Run("filtered types, full buffer and unsubscribe", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBadRequest{})
+		events, unsubscribe := client.Subscribe(1, EventQuoteChanged)
+		all, unsubscribeAll := client.Subscribe(1)
+
+		for _, miner := range client.Miners {
+			client.HealthCheck(context.Background(), miner)
+		}
+		if len(events) != 0 {
+			t.Fatalf("expected 0 events (filtered), got: %d", len(events))
+		} else if len(all) != 1 {
+			t.Fatalf("expected 1 event (dropped when full), got: %d", len(all))
+		}
+
+		unsubscribe()
+		unsubscribe()
+		unsubscribeAll()
+		if _, ok := <-events; ok {
+			t.Fatalf("expected the channel to be closed")
+		}
+		client.HealthCheck(context.Background(), client.MinerByName(MinerTaal))
+	})
+}
+
+// ExampleClient_Subscribe example using Subscribe()
+func ExampleClient_Subscribe() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPBadRequest{})
+
+	// Subscribe to unhealthy miners
+	events, unsubscribe := client.Subscribe(10, EventMinerUnhealthy)
+	defer unsubscribe()
+
+	client.HealthCheck(context.Background(), client.MinerByName(MinerTaal))
+	event := <-events
+	fmt.Printf("%s: %s", event.Type, event.Miner.Name)
+	// Output:miner_unhealthy: Taal
+}
@@ -0,0 +1,22 @@
+package minercraft
+
+import (
+	"context"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// DefaultMaxConcurrency is the default max number of requests in flight when fanning out to all miners
+const DefaultMaxConcurrency = 16
+
+// fanOut will return a group for the requests to all miners and its context (cancelled when the first
+// request of the group fails or the group is done), at most ClientOptions.MaxConcurrency requests run at once
+//
+// Requests over the limit are started when a running request is done (0 = no limit)
+func (c *Client) fanOut(ctx context.Context) (*errgroup.Group, context.Context) {
+	group, ctx := errgroup.WithContext(ctx)
+	if c.Options.MaxConcurrency > 0 {
+		group.SetLimit(c.Options.MaxConcurrency)
+	}
+	return group, ctx
+}
@@ -0,0 +1,125 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockHTTPConcurrentQuote for mocking quote requests and tracking the requests in flight
+type mockHTTPConcurrentQuote struct {
+	mockHTTPCountingQuote
+	inFlight    int
+	maxInFlight int
+	mu          sync.Mutex
+}
+
+// Do is a mock http request
+func (m *mockHTTPConcurrentQuote) Do(req *http.Request) (*http.Response, error) {
+	m.mu.Lock()
+	if m.inFlight++; m.inFlight > m.maxInFlight {
+		m.maxInFlight = m.inFlight
+	}
+	m.mu.Unlock()
+
+	time.Sleep(10 * time.Millisecond)
+
+	m.mu.Lock()
+	m.inFlight--
+	m.mu.Unlock()
+	return m.mockHTTPCountingQuote.Do(req)
+}
+
+// mockHTTPSlowQuote for mocking a failing miner (taal) while the other miners wait for cancellation
+type mockHTTPSlowQuote struct{}
+
+// Do is a mock http request
+func (m *mockHTTPSlowQuote) Do(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.Host, "taal") {
+		return nil, errors.New("miner is down")
+	}
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case <-time.After(10 * time.Second):
+		return nil, errors.New("request was not cancelled")
+	}
+}
+
+// TestClient_FanOut tests the bounded concurrency of the requests to all miners (see: ClientOptions.MaxConcurrency)
+func TestClient_FanOut(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		maxConcurrency int
+		expected       int
+	}{
+		{1, 1},
+		{2, 2},
+		{0, 0}, // No limit (all miners)
+	}
+
+	for _, test := range tests {
+		mock := &mockHTTPConcurrentQuote{mockHTTPCountingQuote: mockHTTPCountingQuote{expiresIn: time.Minute}}
+		client := newTestClient(mock)
+		client.Options.MaxConcurrency = test.maxConcurrency
+		expected := test.expected
+		if expected == 0 {
+			expected = len(client.Miners)
+		}
+
+		if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if mock.count() != len(client.Miners) {
+			t.Fatalf("expected %d quote requests, got %d", len(client.Miners), mock.count())
+		} else if mock.maxInFlight > expected || (test.maxConcurrency > 0 && mock.maxInFlight != expected) {
+			t.Errorf("%s Failed: [%d] inputted and [%d] expected, received: [%d]",
+				t.Name(), test.maxConcurrency, expected, mock.maxInFlight)
+		}
+	}
+}
+
+// TestClient_FanOutReports tests the bounded concurrency of the reports of all miners
+func TestClient_FanOutReports(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name string
+		run  func(client *Client)
+	}{
+		{"CompareQuotes", func(client *Client) { client.CompareQuotes(context.Background()) }},
+		{"ComparePolicies", func(client *Client) { client.ComparePolicies(context.Background()) }},
+		{"WarmUp", func(client *Client) { _, _ = client.WarmUp(context.Background()) }},
+	}
+
+	for _, test := range tests {
+		mock := &mockHTTPConcurrentQuote{mockHTTPCountingQuote: mockHTTPCountingQuote{expiresIn: time.Minute}}
+		client := newTestClient(mock)
+		client.Options.MaxConcurrency = 1
+
+		if test.run(client); mock.maxInFlight != 1 {
+			t.Errorf("%s Failed: [%s] inputted and [%d] expected, received: [%d]", t.Name(), test.name, 1, mock.maxInFlight)
+		}
+	}
+}
+
+// TestClient_BestQuoteEarlyExit tests that the first failed miner cancels the other quote requests
+func TestClient_BestQuoteEarlyExit(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPSlowQuote{})
+	client.Options.RequestRetryCount = 0
+
+	started := time.Now()
+	if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err == nil {
+		t.Fatal("error should have occurred")
+	} else if !strings.Contains(err.Error(), "miner is down") {
+		t.Fatalf("expected the error of the failed miner, got: %s", err.Error())
+	} else if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Fatalf("expected the other requests to be cancelled, took %s", elapsed)
+	}
+}
@@ -2,7 +2,7 @@ package minercraft
 
 import (
 	"context"
-	"sync"
+	"errors"
 )
 
 // FastestQuote will check all known miners and return the fastest quote response
@@ -30,30 +30,37 @@ func (c *Client) FastestQuote() (*FeeQuoteResponse, error) {
 }
 
 // fetchFastestQuote will return a quote that is the quickest to resolve
+//
+// The other requests are cancelled as soon as the first result is received (early exit)
 func (c *Client) fetchFastestQuote() *internalResult {
 
-	// The channel for the internal results
+	// The channel for the internal results (never blocks the requests)
 	resultsChannel := make(chan *internalResult, len(c.Miners))
 
-	// Create a context (to cancel)
+	// Create a context (to cancel the other requests)
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Loop each miner (break into a Go routine for each quote request)
-	var wg sync.WaitGroup
-	for _, miner := range c.Miners {
-		wg.Add(1)
-		go func(ctx context.Context, client *Client, miner *Miner) {
-			defer wg.Done()
-			resultsChannel <- getQuote(ctx, client, miner)
-		}(ctx, c, miner)
-	}
-
-	// Waiting for all requests to finish
+	// Fire a quote request to each miner (the group is cancelled with the context)
+	group, groupCtx := c.fanOut(ctx)
 	go func() {
-		wg.Wait()
+		for _, miner := range c.Miners {
+			miner := miner
+			group.Go(func() error {
+				if groupCtx.Err() != nil { // Queued over the limit after the first result
+					return groupCtx.Err()
+				}
+				resultsChannel <- getQuote(groupCtx, c, miner)
+				return nil
+			})
+		}
+		_ = group.Wait()
 		close(resultsChannel)
 	}()
 
-	return <-resultsChannel
+	// Return the first result (or a failed result if there are no miners)
+	if result := <-resultsChannel; result != nil {
+		return result
+	}
+	return &internalResult{client: c, Response: &RequestResponse{Error: errors.New("no miners found")}}
 }
@@ -0,0 +1,129 @@
+package minercraft
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+const (
+
+	// FeeAlertAbove is the direction when a rate crosses above the threshold
+	FeeAlertAbove = "above"
+
+	// FeeAlertBelow is the direction when a rate crosses back to (or below) the threshold
+	FeeAlertBelow = "below"
+)
+
+// FeeAlert is fired when a miner's quoted standard mining rate (satoshis per 1000 bytes) crosses a threshold
+type FeeAlert struct {
+	Direction string            `json:"direction"` // FeeAlertAbove or FeeAlertBelow
+	Miner     *Miner            `json:"miner"`
+	Quote     *FeeQuoteResponse `json:"quote"`
+	Rate      uint64            `json:"rate"`
+	Threshold uint64            `json:"threshold"`
+}
+
+// FeeAlertHandler is the callback fired for a fee alert
+type FeeAlertHandler func(alert *FeeAlert)
+
+// feeAlert is a registered threshold and the last known side of the threshold (by miner)
+type feeAlert struct {
+	above     map[string]bool
+	handler   FeeAlertHandler
+	threshold uint64
+}
+
+// feeAlerts is the list of registered fee alerts
+type feeAlerts struct {
+	sync.Mutex
+	alerts map[int]*feeAlert
+	nextID int
+}
+
+// AddFeeAlert will register a handler that is fired when any miner's quoted standard mining rate
+// (satoshis per 1000 bytes) crosses the threshold, returning the id of the alert
+//
+// The handler fires when the rate goes above the threshold (including the first quote seen for a miner)
+// and again when the rate returns to or below the threshold. Handlers are called synchronously.
+func (c *Client) AddFeeAlert(threshold uint64, handler FeeAlertHandler) (int, error) {
+	if handler == nil {
+		return 0, errors.New("missing fee alert handler")
+	}
+
+	c.feeAlerts.Lock()
+	defer c.feeAlerts.Unlock()
+	c.feeAlerts.nextID++
+	c.feeAlerts.alerts[c.feeAlerts.nextID] = &feeAlert{
+		above:     make(map[string]bool),
+		handler:   handler,
+		threshold: threshold,
+	}
+	return c.feeAlerts.nextID, nil
+}
+
+// FeeAlertChannel will register a fee alert that sends to the returned channel, returning the id of the alert
+//
+// Alerts are dropped if the channel buffer is full (the channel is never closed)
+func (c *Client) FeeAlertChannel(threshold uint64, bufferSize int) (int, <-chan *FeeAlert) {
+	alertChannel := make(chan *FeeAlert, bufferSize)
+	id, _ := c.AddFeeAlert(threshold, func(alert *FeeAlert) {
+		select {
+		case alertChannel <- alert:
+		default:
+		}
+	})
+	return id, alertChannel
+}
+
+// RemoveFeeAlert will remove a registered fee alert by id
+func (c *Client) RemoveFeeAlert(id int) {
+	c.feeAlerts.Lock()
+	delete(c.feeAlerts.alerts, id)
+	c.feeAlerts.Unlock()
+}
+
+// check will fire any alerts that were crossed by the given quote
+func (f *feeAlerts) check(quote *FeeQuoteResponse) {
+	if quote == nil || quote.Quote == nil || quote.Miner == nil {
+		return
+	}
+
+	// Get the standard mining rate
+	rate, err := quote.Quote.CalculateFee(FeeCategoryMining, FeeTypeStandard, 1000)
+	if err != nil {
+		return
+	}
+
+	// Find the crossed alerts (handlers are fired outside the lock)
+	var fired []*FeeAlert
+	var handlers []FeeAlertHandler
+	minerKey := strings.ToLower(quote.Miner.Name)
+	f.Lock()
+	for _, alert := range f.alerts {
+		isAbove := rate > alert.threshold
+		wasAbove, seen := alert.above[minerKey]
+		alert.above[minerKey] = isAbove
+		if isAbove == wasAbove || (!seen && !isAbove) {
+			continue
+		}
+		direction := FeeAlertBelow
+		if isAbove {
+			direction = FeeAlertAbove
+		}
+		fired = append(fired, &FeeAlert{
+			Direction: direction,
+			Miner:     quote.Miner,
+			Quote:     quote,
+			Rate:      rate,
+			Threshold: alert.threshold,
+		})
+		handlers = append(handlers, alert.handler)
+	}
+	f.Unlock()
+
+	// Fire the handlers
+	for index, handler := range handlers {
+		handler(fired[index])
+	}
+}
@@ -0,0 +1,112 @@
+package minercraft
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestClient_AddFeeAlert tests the method AddFeeAlert()
+func TestClient_AddFeeAlert(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPDefaultClient{})
+
+	var alerts []*FeeAlert
+	id, err := client.AddFeeAlert(450, func(alert *FeeAlert) {
+		alerts = append(alerts, alert)
+	})
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if id == 0 {
+		t.Fatalf("expected a valid alert id")
+	}
+
+	// Create the list of tests (the alert state is kept between tests)
+	var tests = []struct {
+		inputMiner        string
+		inputRate         uint64
+		expectedAlerts    int
+		expectedDirection string
+	}{
+		{"miner1", 400, 0, ""},
+		{"miner1", 500, 1, FeeAlertAbove},
+		{"miner1", 550, 1, ""},
+		{"miner1", 450, 2, FeeAlertBelow},
+		{"miner2", 600, 3, FeeAlertAbove},
+		{"miner1", 400, 3, ""},
+	}
+
+	// Run tests
+	for _, test := range tests {
+		client.feeAlerts.check(testQuote(test.inputMiner, test.inputRate))
+		if len(alerts) != test.expectedAlerts {
+			t.Fatalf("%s Failed: [%s] [%d] inputted and [%d] alerts expected, got: %d", t.Name(), test.inputMiner, test.inputRate, test.expectedAlerts, len(alerts))
+		} else if len(test.expectedDirection) > 0 {
+			alert := alerts[len(alerts)-1]
+			if alert.Direction != test.expectedDirection {
+				t.Fatalf("%s Failed: [%s] [%d] inputted and [%s] expected, got: %s", t.Name(), test.inputMiner, test.inputRate, test.expectedDirection, alert.Direction)
+			} else if alert.Rate != test.inputRate || alert.Miner.Name != test.inputMiner || alert.Threshold != 450 {
+				t.Fatalf("%s Failed: [%s] [%d] inputted and got an invalid alert: %v", t.Name(), test.inputMiner, test.inputRate, alert)
+			}
+		}
+	}
+
+	// Remove the alert
+	client.RemoveFeeAlert(id)
+	client.feeAlerts.check(testQuote("miner3", 1000))
+	if len(alerts) != 3 {
+		t.Fatalf("expected no more alerts after removing, got: %d", len(alerts))
+	}
+
+	// Missing handler
+	if _, err = client.AddFeeAlert(450, nil); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// TestClient_FeeAlertChannel tests the method FeeAlertChannel()
+func TestClient_FeeAlertChannel(t *testing.T) {
+	t.Parallel()
+
+	// Standard mining rate is 500
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	_, alerts := client.FeeAlertChannel(450, 1)
+
+	if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	select {
+	case alert := <-alerts:
+		if alert.Miner.Name != MinerTaal || alert.Direction != FeeAlertAbove {
+			t.Fatalf("expected an alert for %s, got: %v", MinerTaal, alert)
+		}
+	default:
+		t.Fatalf("expected an alert on the channel")
+	}
+}
+
+// ExampleClient_AddFeeAlert example using AddFeeAlert()
+func ExampleClient_AddFeeAlert() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+
+	// Alert when the standard mining rate goes above 450 sat/kb
+	_, _ = client.AddFeeAlert(450, func(alert *FeeAlert) {
+		fmt.Printf("%s rate went %s %d: %d", alert.Miner.Name, alert.Direction, alert.Threshold, alert.Rate)
+	})
+
+	// Get a quote
+	_, _ = client.FeeQuote(client.MinerByName(MinerTaal))
+	// Output:Taal rate went above 450: 500
+}
+
+// BenchmarkFeeAlerts_Check benchmarks the method check()
+func BenchmarkFeeAlerts_Check(b *testing.B) {
+	client := newTestClient(&mockHTTPDefaultClient{})
+	_, _ = client.AddFeeAlert(450, func(alert *FeeAlert) {})
+	quote := testQuote("miner1", 500)
+	for i := 0; i < b.N; i++ {
+		client.feeAlerts.check(quote)
+	}
+}
@@ -0,0 +1,81 @@
+package minercraft
+
+import (
+	"errors"
+)
+
+// FeeEstimate is the estimated fee for a planned transaction (see: EstimateFee)
+type FeeEstimate struct {
+	ChangeFee uint64  `json:"change_fee"` // Part of the fee that pays for the change output
+	Fee       uint64  `json:"fee"`        // Total fee (including the change output)
+	MinChange uint64  `json:"min_change"` // Smallest change worth adding (otherwise leave the change to the miner)
+	TxSize    *TxSize `json:"tx_size"`    // Estimated size (including the change output)
+}
+
+// EstimateFee will estimate the fee for a planned transaction using the given output scripts,
+// the number of (P2PKH) inputs and a P2PKH change output
+// Category: "FeeCategoryMining" or "FeeCategoryRelay"
+//
+// Data outputs (OP_RETURN or OP_FALSE OP_RETURN) use the "FeeTypeData" rate.
+// If the change (inputs - outputs - Fee) is less than MinChange, drop the change output
+// and the fee is Fee - ChangeFee
+func (f *FeePayload) EstimateFee(feeCategory string, outputScripts [][]byte, inputCount int) (*FeeEstimate, error) {
+
+	// Make sure we have inputs
+	if inputCount <= 0 {
+		return nil, errors.New("input count must be greater than zero")
+	}
+
+	// Size of the tx without the change output
+	size := estimateTxSize(outputScripts, inputCount, false)
+	fee, err := f.CalculateTxFee(feeCategory, size)
+	if err != nil {
+		return nil, err
+	}
+
+	// Size of the tx with the change output
+	estimate := &FeeEstimate{TxSize: estimateTxSize(outputScripts, inputCount, true)}
+	if estimate.Fee, err = f.CalculateTxFee(feeCategory, estimate.TxSize); err != nil {
+		return nil, err
+	}
+	estimate.ChangeFee = estimate.Fee - fee
+
+	// Change is only worth adding if it's more than it costs (and isn't dust)
+	estimate.MinChange = DustLimit
+	if estimate.ChangeFee > estimate.MinChange {
+		estimate.MinChange = estimate.ChangeFee
+	}
+
+	return estimate, nil
+}
+
+// estimateTxSize will return the estimated size breakdown for the planned transaction
+func estimateTxSize(outputScripts [][]byte, inputCount int, withChange bool) *TxSize {
+
+	// Count the outputs
+	outputCount := uint64(len(outputScripts))
+	if withChange {
+		outputCount++
+	}
+
+	// Version, input count, inputs, output count and lock time
+	size := &TxSize{
+		StandardBytes: TxOverheadSize + VarIntSize(uint64(inputCount)) + uint64(inputCount)*P2PKHInputSize +
+			VarIntSize(outputCount),
+	}
+	if withChange {
+		size.StandardBytes += P2PKHOutputSize
+	}
+
+	// Value, script length and script of each output
+	for _, script := range outputScripts {
+		outputSize := OutputSize(len(script))
+		if isDataScript(script) {
+			size.DataBytes += outputSize
+		} else {
+			size.StandardBytes += outputSize
+		}
+	}
+
+	return size
+}
@@ -0,0 +1,97 @@
+package minercraft
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+// testP2PKHScript is a P2PKH locking script (25 bytes)
+var testP2PKHScript = append(append([]byte{0x76, 0xa9, 0x14}, bytes.Repeat([]byte{0x01}, 20)...), 0x88, 0xac)
+
+// testDataScript is an OP_FALSE OP_RETURN data script (12 bytes)
+var testDataScript = append([]byte{opFalse, opReturn}, bytes.Repeat([]byte{0x02}, 10)...)
+
+// TestFeePayload_EstimateFee tests the method EstimateFee()
+func TestFeePayload_EstimateFee(t *testing.T) {
+	t.Parallel()
+
+	// Create a client
+	client := newTestClient(&mockHTTPBetterRate{})
+
+	// Create a req (standard: 475/150 data: 500/250)
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Create the list of tests
+	var tests = []struct {
+		inputScripts      [][]byte
+		inputCount        int
+		expectedSize      TxSize
+		expectedFee       uint64
+		expectedChangeFee uint64
+	}{
+		{[][]byte{testP2PKHScript}, 1, TxSize{StandardBytes: 226}, 107, 16},
+		{[][]byte{testP2PKHScript, testDataScript}, 1, TxSize{StandardBytes: 226, DataBytes: 21}, 117, 16},
+		{[][]byte{testP2PKHScript}, 2, TxSize{StandardBytes: 374}, 177, 16},
+		{nil, 1, TxSize{StandardBytes: 192}, 91, 16},
+	}
+
+	// Run tests
+	for _, test := range tests {
+		if estimate, err := response.Quote.EstimateFee(FeeCategoryMining, test.inputScripts, test.inputCount); err != nil {
+			t.Errorf("%s Failed: [%d] [%d] inputted and error not expected but got: %s", t.Name(), len(test.inputScripts), test.inputCount, err.Error())
+		} else if *estimate.TxSize != test.expectedSize {
+			t.Errorf("%s Failed: [%d] [%d] inputted and [%v] expected but got: %v", t.Name(), len(test.inputScripts), test.inputCount, test.expectedSize, *estimate.TxSize)
+		} else if estimate.Fee != test.expectedFee || estimate.ChangeFee != test.expectedChangeFee {
+			t.Errorf("%s Failed: [%d] [%d] inputted and [%d/%d] expected but got: %d/%d", t.Name(), len(test.inputScripts), test.inputCount, test.expectedFee, test.expectedChangeFee, estimate.Fee, estimate.ChangeFee)
+		} else if estimate.MinChange != DustLimit {
+			t.Errorf("%s Failed: [%d] [%d] inputted and [%d] expected but got: %d", t.Name(), len(test.inputScripts), test.inputCount, DustLimit, estimate.MinChange)
+		}
+	}
+
+	t.Run("invalid input count", func(t *testing.T) {
+		if _, err = response.Quote.EstimateFee(FeeCategoryMining, [][]byte{testP2PKHScript}, 0); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("invalid category", func(t *testing.T) {
+		if _, err = response.Quote.EstimateFee("invalid", [][]byte{testP2PKHScript}, 1); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleFeePayload_EstimateFee example using EstimateFee()
+func ExampleFeePayload_EstimateFee() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPBetterRate{})
+
+	// Get a fee quote from a miner
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	// Estimate the fee for paying one P2PKH output using one input
+	var estimate *FeeEstimate
+	if estimate, err = response.Quote.EstimateFee(FeeCategoryMining, [][]byte{testP2PKHScript}, 1); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("fee: %d min change: %d", estimate.Fee, estimate.MinChange)
+	// Output:fee: 107 min change: 546
+}
+
+// BenchmarkFeePayload_EstimateFee benchmarks the method EstimateFee()
+func BenchmarkFeePayload_EstimateFee(b *testing.B) {
+	client := newTestClient(&mockHTTPBetterRate{})
+	response, _ := client.FeeQuote(client.MinerByName(MinerTaal))
+	for i := 0; i < b.N; i++ {
+		_, _ = response.Quote.EstimateFee(FeeCategoryMining, [][]byte{testP2PKHScript, testDataScript}, 2)
+	}
+}
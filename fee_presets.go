@@ -0,0 +1,112 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Names of the default fee presets (see: DefaultFeePresets)
+const (
+	PresetData1KB      = "data_1kb"
+	PresetData80Bytes  = "data_80_bytes"
+	PresetMultisig2Of3 = "multisig_2_of_3"
+	PresetP2PKH1In1Out = "p2pkh_1_in_1_out"
+	PresetP2PKH1In2Out = "p2pkh_1_in_2_out"
+	PresetP2PKH2In2Out = "p2pkh_2_in_2_out"
+)
+
+// FeePreset is the fee of a common transaction template (see: PresetFees)
+type FeePreset struct {
+	Fee    uint64  `json:"fee"`
+	Name   string  `json:"name"`
+	TxSize *TxSize `json:"tx_size"`
+}
+
+// P2PKHTemplate will return the estimated size of a transaction spending P2PKH inputs to P2PKH outputs
+func P2PKHTemplate(inputs, outputs int) (*TxSize, error) {
+	if inputs <= 0 || outputs <= 0 {
+		return nil, errors.New("input and output count must be greater than zero")
+	}
+	return &TxSize{StandardBytes: EstimateP2PKHTxSize(inputs, outputs)}, nil
+}
+
+// MultisigTemplate will return the estimated size of a transaction funding a bare required-of-total multisig
+// output (compressed keys) from a P2PKH input, with a P2PKH change output
+func MultisigTemplate(required, total int) (*TxSize, error) {
+	if required <= 0 || total > 16 || required > total {
+		return nil, fmt.Errorf("invalid multisig: %d of %d", required, total)
+	}
+
+	// OP_m <pubkey>... OP_n OP_CHECKMULTISIG
+	script := make([]byte, 3+total*(1+compressedPubKeySize))
+	script[0] = opSmallInt(required)
+	return estimateTxSize([][]byte{script}, 1, true), nil
+}
+
+// DataTemplate will return the estimated size of a transaction with an OP_FALSE OP_RETURN output carrying
+// the data (a single push) funded by a P2PKH input, with a P2PKH change output
+func DataTemplate(dataBytes int) (*TxSize, error) {
+	if dataBytes <= 0 {
+		return nil, errors.New("data bytes must be greater than zero")
+	}
+	script := make([]byte, 2+pushDataPrefixSize(dataBytes)+dataBytes)
+	script[0], script[1] = opFalse, opReturn
+	return estimateTxSize([][]byte{script}, 1, true), nil
+}
+
+// DefaultFeePresets will return the sizes of common transaction templates by preset name
+func DefaultFeePresets() map[string]*TxSize {
+	presets := make(map[string]*TxSize)
+	presets[PresetP2PKH1In1Out], _ = P2PKHTemplate(1, 1)
+	presets[PresetP2PKH1In2Out], _ = P2PKHTemplate(1, 2)
+	presets[PresetP2PKH2In2Out], _ = P2PKHTemplate(2, 2)
+	presets[PresetMultisig2Of3], _ = MultisigTemplate(2, 3)
+	presets[PresetData80Bytes], _ = DataTemplate(80)
+	presets[PresetData1KB], _ = DataTemplate(1024)
+	return presets
+}
+
+// PresetFees will return the fee of each preset using the rates of the quote (sorted by name)
+// so customers can be quoted without building the transaction (uses DefaultFeePresets if nil)
+// Category: "FeeCategoryMining" or "FeeCategoryRelay"
+func (f *FeePayload) PresetFees(feeCategory string, presets map[string]*TxSize) ([]*FeePreset, error) {
+	if presets == nil {
+		presets = DefaultFeePresets()
+	}
+
+	fees := make([]*FeePreset, 0, len(presets))
+	for name, size := range presets {
+		if size == nil {
+			return nil, errors.New("missing size for preset: " + name)
+		}
+		fee, err := f.CalculateTxFee(feeCategory, size)
+		if err != nil {
+			return nil, err
+		}
+		fees = append(fees, &FeePreset{Fee: fee, Name: name, TxSize: size})
+	}
+	sort.Slice(fees, func(i, j int) bool {
+		return fees[i].Name < fees[j].Name
+	})
+	return fees, nil
+}
+
+// pushDataPrefixSize will return the size of the push opcode (and length) for pushing the data
+func pushDataPrefixSize(dataBytes int) int {
+	switch {
+	case dataBytes < 0x4c:
+		return 1
+	case dataBytes <= 0xff:
+		return 2
+	case dataBytes <= 0xffff:
+		return 3
+	default:
+		return 5
+	}
+}
+
+// opSmallInt will return the opcode that pushes the number (OP_1 to OP_16)
+func opSmallInt(n int) byte {
+	return byte(0x50 + n)
+}
@@ -0,0 +1,90 @@
+package minercraft
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestFeePresetTemplates tests the methods P2PKHTemplate(), MultisigTemplate() and DataTemplate()
+func TestFeePresetTemplates(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		size     func() (*TxSize, error)
+		expected TxSize
+	}{
+		{"p2pkh 1 in 2 out", func() (*TxSize, error) { return P2PKHTemplate(1, 2) }, TxSize{StandardBytes: 226}},
+		{"p2pkh 2 in 2 out", func() (*TxSize, error) { return P2PKHTemplate(2, 2) }, TxSize{StandardBytes: 374}},
+		{"multisig 2 of 3", func() (*TxSize, error) { return MultisigTemplate(2, 3) }, TxSize{StandardBytes: 306}},
+		{"data 10 bytes", func() (*TxSize, error) { return DataTemplate(10) }, TxSize{StandardBytes: 192, DataBytes: 22}},
+		{"data 80 bytes", func() (*TxSize, error) { return DataTemplate(80) }, TxSize{StandardBytes: 192, DataBytes: 93}},
+		{"data 1kb", func() (*TxSize, error) { return DataTemplate(1024) }, TxSize{StandardBytes: 192, DataBytes: 1040}},
+	}
+	for _, test := range tests {
+		if size, err := test.size(); err != nil {
+			t.Errorf("%s Failed: [%s] inputted and error not expected but got: %s", t.Name(), test.name, err.Error())
+		} else if *size != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%v] expected but got: %v", t.Name(), test.name, test.expected, *size)
+		}
+	}
+
+	t.Run("invalid templates", func(t *testing.T) {
+		if _, err := P2PKHTemplate(0, 1); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = MultisigTemplate(3, 2); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = MultisigTemplate(1, 17); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = DataTemplate(0); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestFeePayload_PresetFees tests the method PresetFees()
+func TestFeePayload_PresetFees(t *testing.T) {
+	t.Parallel()
+
+	// Create a client (standard: 475/150 data: 500/250)
+	client := newTestClient(&mockHTTPBetterRate{})
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	var presets []*FeePreset
+	if presets, err = response.Quote.PresetFees(FeeCategoryMining, nil); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(presets) != len(DefaultFeePresets()) {
+		t.Fatalf("expected %d presets, got: %d", len(DefaultFeePresets()), len(presets))
+	}
+	for _, preset := range presets {
+		if expected, _ := response.Quote.CalculateTxFee(FeeCategoryMining, preset.TxSize); preset.Fee != expected {
+			t.Errorf("%s Failed: [%s] inputted and [%d] expected but got: %d", t.Name(), preset.Name, expected, preset.Fee)
+		}
+	}
+
+	t.Run("missing size", func(t *testing.T) {
+		if _, err = response.Quote.PresetFees(FeeCategoryMining, map[string]*TxSize{"custom": nil}); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleFeePayload_PresetFees example using PresetFees()
+func ExampleFeePayload_PresetFees() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPBetterRate{})
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	// Quote a simple payment
+	size, _ := P2PKHTemplate(1, 2)
+	presets, _ := response.Quote.PresetFees(FeeCategoryMining, map[string]*TxSize{PresetP2PKH1In2Out: size})
+	fmt.Printf("%s: %d sats", presets[0].Name, presets[0].Fee)
+	// Output:p2pkh_1_in_2_out: 107 sats
+}
@@ -2,11 +2,11 @@ package minercraft
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 )
 
 const (
@@ -36,6 +36,10 @@ Example feeQuote response from Merchant API:
 }
 */
 
+// ErrMinerRecentlyFailed is returned when a quote request is skipped because the miner recently failed
+// (see: ClientOptions.QuoteFailureTTL)
+var ErrMinerRecentlyFailed = errors.New("miner recently failed")
+
 // FeeQuoteResponse is the raw response from the Merchant API request
 //
 // Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi/tree/v1.2-beta#get-fee-quote
@@ -92,6 +96,7 @@ type FeePayload struct {
 	CurrentHighestBlockHeight uint64      `json:"currentHighestBlockHeight"`
 	MinerReputation           interface{} `json:"minerReputation"` // Not sure what this value is
 	Fees                      []*feeType  `json:"fees"`
+	Policies                  *Policies   `json:"policies,omitempty"` // Policies of the miner (policy quotes)
 }
 
 // CalculateFee will return the fee for the given txBytes
@@ -110,33 +115,124 @@ func (f *FeePayload) CalculateFee(feeCategory, feeType string, txBytes uint64) (
 		return 0, fmt.Errorf("feeCategory %s is not recognized", feeCategory)
 	}
 
+	// Get the rate for the feeType (data or standard)
+	amount, err := f.getFeeAmount(feeCategory, feeType)
+	if err != nil {
+		return 1, err
+	}
+
+	// Multiply & Divide
+	calcFee := (amount.Satoshis * txBytes) / amount.Bytes
+
+	// Check for zero
+	if calcFee != 0 {
+		return calcFee, nil
+	}
+
+	// If txBytes is zero this error will occur
+	return 1, fmt.Errorf("warning: fee calculation was 0")
+}
+
+// ExpiresAt will return the parsed expiration time of the quote
+func (f *FeePayload) ExpiresAt() (time.Time, error) {
+	return time.Parse(time.RFC3339, f.ExpirationTime)
+}
+
+// IsExpired will return true if the quote has expired (or the expiration time is invalid)
+func (f *FeePayload) IsExpired() bool {
+	return f.IsExpiredAt(time.Now())
+}
+
+// IsExpiredAt will return true if the quote has expired at the given time (or the expiration time is invalid)
+func (f *FeePayload) IsExpiredAt(now time.Time) bool {
+	expiresAt, err := f.ExpiresAt()
+	return err != nil || !now.Before(expiresAt)
+}
+
+// CalculateTxFee will return the fee for the given transaction size breakdown
+// Standard bytes use the "FeeTypeStandard" rate and data bytes use the "FeeTypeData" rate
+// Category: "FeeCategoryMining" or "FeeCategoryRelay"
+//
+// If fee is 0, returns 1 & error
+//
+// Spec: https://github.com/bitcoin-sv-specs/brfc-misc/tree/master/feespec#deterministic-transaction-fee-calculation-dtfc
+func (f *FeePayload) CalculateTxFee(feeCategory string, txSize *TxSize) (uint64, error) {
+
+	// Make sure we have a tx size
+	if txSize == nil {
+		return 0, errors.New("tx size was nil")
+	}
+
+	// Calculate the standard portion
+	var totalFee uint64
+	if txSize.StandardBytes > 0 {
+		rate, err := f.getFeeAmount(feeCategory, FeeTypeStandard)
+		if err != nil {
+			return 0, err
+		}
+		totalFee += (rate.Satoshis * txSize.StandardBytes) / rate.Bytes
+	}
+
+	// Calculate the data portion
+	if txSize.DataBytes > 0 {
+		rate, err := f.getFeeAmount(feeCategory, FeeTypeData)
+		if err != nil {
+			return 0, err
+		}
+		totalFee += (rate.Satoshis * txSize.DataBytes) / rate.Bytes
+	}
+
+	// Check for zero
+	if totalFee == 0 {
+		return 1, fmt.Errorf("warning: fee calculation was 0")
+	}
+
+	return totalFee, nil
+}
+
+// DataFee will return the mining fee for the given data bytes (IE: OP_RETURN payloads) using the "FeeTypeData" rate
+//
+// If fee is 0, returns 1 & error
+func (f *FeePayload) DataFee(dataBytes uint64) (uint64, error) {
+	return f.CalculateTxFee(FeeCategoryMining, &TxSize{DataBytes: dataBytes})
+}
+
+// DataRelayFee will return the relay fee for the given data bytes (IE: OP_RETURN payloads) using the "FeeTypeData" rate
+//
+// If fee is 0, returns 1 & error
+func (f *FeePayload) DataRelayFee(dataBytes uint64) (uint64, error) {
+	return f.CalculateTxFee(FeeCategoryRelay, &TxSize{DataBytes: dataBytes})
+}
+
+// getFeeAmount will return the rate for the given category and type (both are case-insensitive)
+func (f *FeePayload) getFeeAmount(feeCategory, feeType string) (*feeAmount, error) {
+
+	// Valid feeCategory?
+	if !strings.EqualFold(feeCategory, FeeCategoryMining) && !strings.EqualFold(feeCategory, FeeCategoryRelay) {
+		return nil, fmt.Errorf("feeCategory %s is not recognized", feeCategory)
+	}
+
 	// Loop all fee types looking for feeType (data or standard)
 	for _, fee := range f.Fees {
-
-		// Detect the type (data or standard)
-		if fee.FeeType != feeType {
+		if !strings.EqualFold(fee.FeeType, feeType) {
 			continue
 		}
 
-		// Multiply & Divide
-		var calcFee uint64
+		// Get the amount for the category
+		amount := fee.RelayFee
 		if strings.EqualFold(feeCategory, FeeCategoryMining) {
-			calcFee = (fee.MiningFee.Satoshis * txBytes) / fee.MiningFee.Bytes
-		} else {
-			calcFee = (fee.RelayFee.Satoshis * txBytes) / fee.RelayFee.Bytes
+			amount = fee.MiningFee
 		}
 
-		// Check for zero
-		if calcFee != 0 {
-			return calcFee, nil
+		// Avoid dividing by zero
+		if amount == nil || amount.Bytes == 0 {
+			return nil, fmt.Errorf("feeType %s has an invalid %s rate", feeType, feeCategory)
 		}
-
-		// If txBytes is zero this error will occur
-		return 1, fmt.Errorf("warning: fee calculation was 0")
+		return amount, nil
 	}
 
 	// No fee type found in the slice of fees
-	return 1, fmt.Errorf("feeType %s is not found in fees", feeType)
+	return nil, fmt.Errorf("feeType %s is not found in fees", feeType)
 }
 
 /*
@@ -179,6 +275,8 @@ func (c *Client) FeeQuote(miner *Miner) (*FeeQuoteResponse, error) {
 	// Make sure we have a valid miner
 	if miner == nil {
 		return nil, errors.New("miner was nil")
+	} else if err := c.checkNetwork(miner); err != nil {
+		return nil, err
 	}
 
 	// Make the HTTP request
@@ -204,32 +302,125 @@ func (c *Client) FeeQuote(miner *Miner) (*FeeQuoteResponse, error) {
 
 // internalResult is a shim for storing miner & http response data
 type internalResult struct {
+	client   *Client // Client that made the request (validates the response)
 	Response *RequestResponse
 	Miner    *Miner
+	quote    *FeeQuoteResponse // Already parsed quote (from the quote cache)
 }
 
 // parseQuote will convert the HTTP response into a struct and also unmarshal the payload JSON data
 func (i *internalResult) parseQuote() (response FeeQuoteResponse, err error) {
 
+	// Already parsed (return a copy, so the caller can't change the cached quote)
+	if i.quote != nil {
+		return *copyQuote(i.quote), nil
+	}
+
 	// Process the initial response payload
-	if err = response.process(i.Miner, i.Response.BodyContents); err != nil {
+	if err = response.process(i.client, i.Miner, i.Response.BodyContents); err != nil {
 		return
 	}
 
 	// If we have a valid payload
-	if len(response.Payload) > 0 {
-		err = json.Unmarshal([]byte(response.Payload), &response.Quote)
+	if err = response.decodePayload(&response.Quote); err == nil && response.Quote != nil {
+		response.checkPayloadMinerID(response.Quote.MinerID)
 	}
 	return
 }
 
 // getQuote will fire the HTTP request to retrieve the fee quote
-func getQuote(ctx context.Context, client *Client, miner *Miner) (result *internalResult) {
-	result = &internalResult{Miner: miner}
+//
+// If quote caching is enabled, an unexpired cached quote (local or from the store) is used instead.
+// If failure caching is enabled (see: ClientOptions.QuoteFailureTTL), a recently failed miner is not re-contacted
+func getQuote(ctx context.Context, client *Client, miner *Miner) *internalResult {
+
+	// Use the cached quote if found (locally or in the shared store, not for a scoped token)
+	if client.Options.QuoteCacheEnabled && !client.scopedToken(ctx, miner) {
+		if cached := client.quoteCache.get(miner, client.now()); cached != nil {
+			return &internalResult{Miner: miner, Response: cached.response, quote: cached.quote, client: client}
+		} else if client.store != nil {
+			if stored := client.storedQuote(ctx, miner); stored != nil {
+				return stored
+			}
+		}
+	}
+
+	// Use the cached failure if found
+	if client.Options.QuoteFailureTTL > 0 {
+		if err := client.quoteCache.getFailure(miner, client.now()); err != nil {
+			return &internalResult{Miner: miner, client: client, Response: &RequestResponse{
+				Error:  fmt.Errorf("%w: %s", ErrMinerRecentlyFailed, err.Error()),
+				Method: http.MethodGet,
+			}}
+		}
+	}
+
+	return fetchQuote(ctx, client, miner)
+}
+
+// fetchQuote will fire the HTTP request to retrieve a new fee quote (ignoring the quote cache)
+func fetchQuote(ctx context.Context, client *Client, miner *Miner) (result *internalResult) {
+	result = &internalResult{Miner: miner, client: client}
+
+	// Never request quotes more often than the miner's rate limit (or the rate limit of the tenant)
+	if err := client.rateLimits.wait(ctx, client.rateLimitedMiner(ctx, miner), client.clock()); err != nil {
+		result.Response = &RequestResponse{Error: err, Method: http.MethodGet}
+		return
+	}
+
+	// Build the endpoint url
+	endpoint, err := buildURL(miner, routeFeeQuote)
+	if err != nil {
+		result.Response = &RequestResponse{Error: err, Method: http.MethodGet}
+		return
+	}
+
 	result.Response = httpRequest(ctx, client, &httpPayload{
-		Method: http.MethodGet,
-		URL:    defaultProtocol + miner.URL + routeFeeQuote,
-		Token:  miner.Token,
+		Miner:     miner,
+		Method:    http.MethodGet,
+		Operation: OperationFeeQuote,
+		URL:       endpoint,
+		Token:     miner.Token,
+		Timeout:   client.minerTimeout(miner, client.Options.QuoteTimeout),
 	})
+
+	// Parse the new quote (if the parsing fails, the error is returned when the caller parses the result)
+	err = result.Response.Error
+	if err == nil {
+		var quote FeeQuoteResponse
+		if quote, err = result.parseQuote(); err == nil {
+			result.quote = &quote
+			client.quoteReceived(&quote, result.Response, !client.scopedToken(ctx, miner))
+		}
+	}
+
+	// Remember the failure (unless the caller gave up or used a scoped token) or the recovery of the miner
+	if client.Options.QuoteFailureTTL > 0 && ctx.Err() == nil && !client.scopedToken(ctx, miner) &&
+		client.quoteCache.setFailure(miner, err, client.Options.QuoteFailureTTL, client.now()) {
+		client.events.publish(&Event{Error: err.Error(), Miner: miner, Type: EventCircuitOpened}, client.now())
+	}
 	return
 }
+
+// quoteReceived is fired for every new (not cached) quote that was successfully parsed
+func (c *Client) quoteReceived(quote *FeeQuoteResponse, response *RequestResponse, cache bool) {
+
+	// Cache the quote (if enabled, not the quotes of a scoped token)
+	if cache && c.Options.QuoteCacheEnabled {
+		c.quoteCache.set(quote.Miner, quote, response, c.now())
+		if c.store != nil {
+			c.storeQuote(context.Background(), quote, response)
+		}
+	}
+
+	// Check the fee alerts
+	c.feeAlerts.check(quote)
+
+	// Publish the event if the fees of the miner changed
+	if c.events.quoteChanged(quote) {
+		c.events.publish(&Event{Miner: quote.Miner, Quote: quote, Type: EventQuoteChanged}, c.now())
+	}
+
+	// Fire the hooks
+	c.hooks.fireQuoteReceived(quote)
+}
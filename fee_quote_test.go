@@ -518,6 +518,14 @@ func TestFeePayload_CalculateFee(t *testing.T) {
 	} else if fee != 250 {
 		t.Fatalf("fee was: %d but expected: %d", fee, 250)
 	}
+
+	// Fee types are case-insensitive (same as CalculateTxFee)
+	fee, err = response.Quote.CalculateFee(FeeCategoryMining, "Standard", 1000)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if txFee, _ := response.Quote.CalculateTxFee(FeeCategoryMining, &TxSize{StandardBytes: 1000}); fee != txFee {
+		t.Fatalf("fee was: %d but expected: %d", fee, txFee)
+	}
 }
 
 // ExampleFeePayload_CalculateFee example using CalculateFee()
@@ -603,3 +611,136 @@ func TestFeePayload_CalculateFeeMissingFeeType(t *testing.T) {
 		t.Fatalf("fee was: %d but expected: %d", fee, 1)
 	}
 }
+
+// TestFeePayload_CalculateTxFee tests the method CalculateTxFee()
+func TestFeePayload_CalculateTxFee(t *testing.T) {
+	t.Parallel()
+
+	// Create a client
+	client := newTestClient(&mockHTTPBetterRate{})
+
+	// Create a req (standard: 475/150 data: 500/250)
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Create the list of tests
+	var tests = []struct {
+		inputCategory string
+		inputSize     *TxSize
+		expectedFee   uint64
+		expectedError bool
+	}{
+		{FeeCategoryMining, &TxSize{StandardBytes: 1000, DataBytes: 1000}, 975, false},
+		{FeeCategoryMining, &TxSize{StandardBytes: 1000}, 475, false},
+		{FeeCategoryMining, &TxSize{DataBytes: 1000}, 500, false},
+		{FeeCategoryRelay, &TxSize{StandardBytes: 1000, DataBytes: 1000}, 400, false},
+		{FeeCategoryMining, &TxSize{}, 1, true},
+		{FeeCategoryMining, nil, 0, true},
+		{"invalid", &TxSize{StandardBytes: 1000}, 0, true},
+	}
+
+	// Run tests
+	for _, test := range tests {
+		if fee, err := response.Quote.CalculateTxFee(test.inputCategory, test.inputSize); err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] [%v] inputted and error not expected but got: %s", t.Name(), test.inputCategory, test.inputSize, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] [%v] inputted and error was expected", t.Name(), test.inputCategory, test.inputSize)
+		} else if fee != test.expectedFee {
+			t.Errorf("%s Failed: [%s] [%v] inputted and [%d] expected but got: %d", t.Name(), test.inputCategory, test.inputSize, test.expectedFee, fee)
+		}
+	}
+}
+
+// TestFeePayload_CalculateTxFeeMissingFeeType tests the method CalculateTxFee()
+func TestFeePayload_CalculateTxFeeMissingFeeType(t *testing.T) {
+	t.Parallel()
+
+	// Create a client
+	client := newTestClient(&mockHTTPMissingFeeType{})
+
+	// Create a req (only has the data fee type)
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	if _, err = response.Quote.CalculateTxFee(FeeCategoryMining, &TxSize{StandardBytes: 1000}); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// TestFeePayload_DataFee tests the methods DataFee() and DataRelayFee()
+func TestFeePayload_DataFee(t *testing.T) {
+	t.Parallel()
+
+	// Create a client
+	client := newTestClient(&mockHTTPBetterRate{})
+
+	// Create a req (data: 500/250)
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Create the list of tests
+	var tests = []struct {
+		inputBytes     uint64
+		expectedMining uint64
+		expectedRelay  uint64
+		expectedError  bool
+	}{
+		{1000, 500, 250, false},
+		{100000, 50000, 25000, false},
+		{1, 1, 1, true},
+		{0, 1, 1, true},
+	}
+
+	// Run tests
+	for _, test := range tests {
+		if fee, err := response.Quote.DataFee(test.inputBytes); (err != nil) != test.expectedError {
+			t.Errorf("%s Failed: [%d] inputted and error expected: %v but got: %v", t.Name(), test.inputBytes, test.expectedError, err)
+		} else if fee != test.expectedMining {
+			t.Errorf("%s Failed: [%d] inputted and [%d] expected but got: %d", t.Name(), test.inputBytes, test.expectedMining, fee)
+		}
+		if fee, err := response.Quote.DataRelayFee(test.inputBytes); (err != nil) != test.expectedError {
+			t.Errorf("%s Failed: [%d] inputted and error expected: %v but got: %v", t.Name(), test.inputBytes, test.expectedError, err)
+		} else if fee != test.expectedRelay {
+			t.Errorf("%s Failed: [%d] inputted and [%d] expected but got: %d", t.Name(), test.inputBytes, test.expectedRelay, fee)
+		}
+	}
+
+	t.Run("missing data fee type", func(t *testing.T) {
+		client = newTestClient(&mockHTTPMissingFeeType{})
+		response, err = client.FeeQuote(client.MinerByName(MinerTaal))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		if _, err = response.Quote.DataFee(1000); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	})
+}
+
+// ExampleFeePayload_DataFee example using DataFee()
+func ExampleFeePayload_DataFee() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPBetterRate{})
+
+	// Get a fee quote from a miner
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	// Price a 1kb OP_RETURN payload
+	var fee uint64
+	if fee, err = response.Quote.DataFee(1000); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("data fee: %d", fee)
+	// Output:data fee: 500
+}
@@ -0,0 +1,150 @@
+package minercraft
+
+import (
+	"errors"
+	"sort"
+)
+
+const (
+
+	// DefaultFeeReportBuckets is the default number of histogram buckets in a fee report
+	DefaultFeeReportBuckets = 5
+
+	// DefaultFeeOutlierThreshold is the default deviation from the median rate (50%) before a rate is flagged as an outlier
+	DefaultFeeOutlierThreshold = 0.5
+)
+
+// FeeReport is the distribution of quoted rates (satoshis per 1000 bytes) across miners
+type FeeReport struct {
+	Buckets     []*FeeBucket `json:"buckets"`
+	FeeCategory string       `json:"fee_category"`
+	FeeType     string       `json:"fee_type"`
+	MaxRate     uint64       `json:"max_rate"`
+	MeanRate    float64      `json:"mean_rate"`
+	MedianRate  float64      `json:"median_rate"`
+	MinRate     uint64       `json:"min_rate"`
+	Outliers    []*MinerRate `json:"outliers"`
+	Rates       []*MinerRate `json:"rates"` // Sorted by rate (cheapest first)
+}
+
+// MinerRate is the rate quoted by a single miner
+type MinerRate struct {
+	Deviation float64 `json:"deviation"` // Relative deviation from the median rate (0.5 = 50% higher)
+	Miner     *Miner  `json:"miner"`
+	Outlier   bool    `json:"outlier"`
+	Rate      uint64  `json:"rate"`
+}
+
+// FeeBucket is a single histogram bucket of rates (inclusive range)
+type FeeBucket struct {
+	Count   int      `json:"count"`
+	MaxRate uint64   `json:"max_rate"`
+	Miners  []string `json:"miners"`
+	MinRate uint64   `json:"min_rate"`
+}
+
+// FeeSpread will check all known miners and return a report of the distribution of the quoted rates
+// using the default number of buckets and outlier threshold
+//
+// Miners that fail to return a valid quote are not included in the report
+func (c *Client) FeeSpread(feeCategory, feeType string) (*FeeReport, error) {
+
+	// Make sure we have miners
+	if len(c.Miners) == 0 {
+		return nil, errors.New("no miners found")
+	}
+
+	// Get all quotes
+	quotes, err := c.CheapestMiners(len(c.Miners), feeCategory, feeType)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the report
+	return NewFeeReport(quotes, feeCategory, feeType, DefaultFeeReportBuckets, DefaultFeeOutlierThreshold)
+}
+
+// NewFeeReport will create a report of the distribution of rates for the given quotes
+//
+// Rates that deviate from the median by more than the outlierThreshold (0.5 = 50%) are flagged as outliers
+func NewFeeReport(quotes []*FeeQuoteResponse, feeCategory, feeType string,
+	buckets int, outlierThreshold float64) (*FeeReport, error) {
+
+	// Make sure we have valid parameters
+	if len(quotes) == 0 {
+		return nil, errors.New("missing quotes")
+	} else if buckets <= 0 {
+		return nil, errors.New("number of buckets must be greater than zero")
+	}
+
+	// Calculate all the rates
+	report := &FeeReport{FeeCategory: feeCategory, FeeType: feeType}
+	var total uint64
+	for _, quote := range quotes {
+		if quote == nil || quote.Quote == nil {
+			return nil, errors.New("quote is missing a payload")
+		}
+		rate, err := quote.Quote.CalculateFee(feeCategory, feeType, 1000)
+		if err != nil {
+			return nil, err
+		}
+		total += rate
+		report.Rates = append(report.Rates, &MinerRate{Miner: quote.Miner, Rate: rate})
+	}
+
+	// Sort by rate
+	sort.SliceStable(report.Rates, func(i, j int) bool {
+		return report.Rates[i].Rate < report.Rates[j].Rate
+	})
+
+	// Set the stats
+	count := len(report.Rates)
+	report.MinRate = report.Rates[0].Rate
+	report.MaxRate = report.Rates[count-1].Rate
+	report.MeanRate = float64(total) / float64(count)
+	if count%2 == 0 {
+		report.MedianRate = float64(report.Rates[count/2-1].Rate+report.Rates[count/2].Rate) / 2
+	} else {
+		report.MedianRate = float64(report.Rates[count/2].Rate)
+	}
+
+	// Flag the outliers
+	for _, rate := range report.Rates {
+		if report.MedianRate > 0 {
+			rate.Deviation = (float64(rate.Rate) - report.MedianRate) / report.MedianRate
+		}
+		if rate.Deviation > outlierThreshold || rate.Deviation < -outlierThreshold {
+			rate.Outlier = true
+			report.Outliers = append(report.Outliers, rate)
+		}
+	}
+
+	// Create the buckets (equal width)
+	spread := report.MaxRate - report.MinRate + 1
+	if uint64(buckets) > spread {
+		buckets = int(spread)
+	}
+	width := spread / uint64(buckets)
+	for i := 0; i < buckets; i++ {
+		bucket := &FeeBucket{MinRate: report.MinRate + uint64(i)*width}
+		bucket.MaxRate = bucket.MinRate + width - 1
+		if i == buckets-1 {
+			bucket.MaxRate = report.MaxRate
+		}
+		report.Buckets = append(report.Buckets, bucket)
+	}
+
+	// Fill the buckets
+	for _, rate := range report.Rates {
+		index := int((rate.Rate - report.MinRate) / width)
+		if index >= buckets {
+			index = buckets - 1
+		}
+		report.Buckets[index].Count++
+		if rate.Miner != nil {
+			report.Buckets[index].Miners = append(report.Buckets[index].Miners, rate.Miner.Name)
+		}
+	}
+
+	return report, nil
+}
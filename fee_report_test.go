@@ -0,0 +1,155 @@
+package minercraft
+
+import (
+	"fmt"
+	"testing"
+)
+
+// testQuote will return a quote for the miner using the same mining rate for both fee types
+func testQuote(minerName string, rate uint64) *FeeQuoteResponse {
+	amount := &feeAmount{Bytes: 1000, Satoshis: rate}
+	return &FeeQuoteResponse{
+		JSONEnvelope: JSONEnvelope{Miner: &Miner{Name: minerName}},
+		Quote: &FeePayload{Fees: []*feeType{
+			{FeeType: FeeTypeStandard, MiningFee: amount, RelayFee: amount},
+			{FeeType: FeeTypeData, MiningFee: amount, RelayFee: amount},
+		}},
+	}
+}
+
+// TestNewFeeReport tests the method NewFeeReport()
+func TestNewFeeReport(t *testing.T) {
+	t.Parallel()
+
+	quotes := []*FeeQuoteResponse{
+		testQuote("miner1", 500),
+		testQuote("miner2", 1200),
+		testQuote("miner3", 400),
+		testQuote("miner4", 430),
+		testQuote("miner5", 420),
+	}
+
+	report, err := NewFeeReport(quotes, FeeCategoryMining, FeeTypeStandard, 5, DefaultFeeOutlierThreshold)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Check the stats
+	if report.MinRate != 400 {
+		t.Fatalf("expected min rate %d, got %d", 400, report.MinRate)
+	} else if report.MaxRate != 1200 {
+		t.Fatalf("expected max rate %d, got %d", 1200, report.MaxRate)
+	} else if report.MedianRate != 430 {
+		t.Fatalf("expected median rate %d, got %f", 430, report.MedianRate)
+	} else if report.MeanRate != 590 {
+		t.Fatalf("expected mean rate %d, got %f", 590, report.MeanRate)
+	} else if report.Rates[0].Miner.Name != "miner3" {
+		t.Fatalf("expected the cheapest rate first, got %s", report.Rates[0].Miner.Name)
+	}
+
+	// Check the outliers
+	if len(report.Outliers) != 1 || report.Outliers[0].Miner.Name != "miner2" {
+		t.Fatalf("expected miner2 to be the only outlier, got %d outliers", len(report.Outliers))
+	}
+
+	// Check the buckets
+	if len(report.Buckets) != 5 {
+		t.Fatalf("expected %d buckets, got %d", 5, len(report.Buckets))
+	} else if report.Buckets[0].Count != 4 {
+		t.Fatalf("expected %d rates in the first bucket, got %d", 4, report.Buckets[0].Count)
+	} else if report.Buckets[4].Count != 1 || report.Buckets[4].Miners[0] != "miner2" {
+		t.Fatalf("expected miner2 in the last bucket, got %v", report.Buckets[4].Miners)
+	} else if report.Buckets[4].MaxRate != 1200 {
+		t.Fatalf("expected last bucket to end at %d, got %d", 1200, report.Buckets[4].MaxRate)
+	}
+}
+
+// TestNewFeeReport_SameRates tests the method NewFeeReport()
+func TestNewFeeReport_SameRates(t *testing.T) {
+	t.Parallel()
+
+	quotes := []*FeeQuoteResponse{testQuote("miner1", 500), testQuote("miner2", 500)}
+
+	report, err := NewFeeReport(quotes, FeeCategoryMining, FeeTypeStandard, 5, DefaultFeeOutlierThreshold)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(report.Buckets) != 1 || report.Buckets[0].Count != 2 {
+		t.Fatalf("expected a single bucket with both rates")
+	} else if len(report.Outliers) != 0 {
+		t.Fatalf("expected no outliers")
+	}
+}
+
+// TestNewFeeReport_Errors tests the method NewFeeReport()
+func TestNewFeeReport_Errors(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewFeeReport(nil, FeeCategoryMining, FeeTypeStandard, 5, 0.5); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+
+	quotes := []*FeeQuoteResponse{testQuote("miner1", 500)}
+	if _, err := NewFeeReport(quotes, FeeCategoryMining, FeeTypeStandard, 0, 0.5); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+
+	if _, err := NewFeeReport(quotes, FeeCategoryMining, "invalid", 5, 0.5); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+
+	if _, err := NewFeeReport([]*FeeQuoteResponse{{}}, FeeCategoryMining, FeeTypeStandard, 5, 0.5); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// TestClient_FeeSpread tests the method FeeSpread()
+func TestClient_FeeSpread(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidBestQuote{})
+
+	report, err := client.FeeSpread(FeeCategoryMining, FeeTypeData)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(report.Rates) != 3 {
+		t.Fatalf("expected %d rates, got %d", 3, len(report.Rates))
+	} else if report.MinRate != 420 || report.MaxRate != 500 {
+		t.Fatalf("expected rates between %d and %d, got %d and %d", 420, 500, report.MinRate, report.MaxRate)
+	}
+
+	// HTTP error
+	client = newTestClient(&mockHTTPError{})
+	if _, err = client.FeeSpread(FeeCategoryMining, FeeTypeData); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+
+	// No miners
+	client = newTestClient(&mockHTTPValidBestQuote{})
+	client.Miners = nil
+	if _, err = client.FeeSpread(FeeCategoryMining, FeeTypeData); err == nil || err.Error() != "no miners found" {
+		t.Fatalf("expected [no miners found] but got: %v", err)
+	}
+}
+
+// ExampleClient_FeeSpread example using FeeSpread()
+func ExampleClient_FeeSpread() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidBestQuote{})
+
+	report, err := client.FeeSpread(FeeCategoryMining, FeeTypeData)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("rates from %d to %d with %d outliers", report.MinRate, report.MaxRate, len(report.Outliers))
+	// Output:rates from 420 to 500 with 0 outliers
+}
+
+// BenchmarkNewFeeReport benchmarks the method NewFeeReport()
+func BenchmarkNewFeeReport(b *testing.B) {
+	quotes := []*FeeQuoteResponse{testQuote("miner1", 500), testQuote("miner2", 1200), testQuote("miner3", 400)}
+	for i := 0; i < b.N; i++ {
+		_, _ = NewFeeReport(quotes, FeeCategoryMining, FeeTypeStandard, 5, 0.5)
+	}
+}
@@ -0,0 +1,29 @@
+package minercraft
+
+import (
+	"github.com/libsv/libsv/transaction"
+)
+
+// IsFeeSufficient will return true if the paid fee covers the mining fee of the quote for the transaction size,
+// or false and the shortfall (satoshis missing) so third-party transactions can be verified before relaying them
+func (f *FeePayload) IsFeeSufficient(txSize *TxSize, paidFee uint64) (bool, uint64, error) {
+	requiredFee, err := f.CalculateTxFee(FeeCategoryMining, txSize)
+	if err != nil {
+		return false, 0, err
+	} else if paidFee >= requiredFee {
+		return true, 0, nil
+	}
+	return false, requiredFee - paidFee, nil
+}
+
+// IsTxFeeSufficient will return true if the paid fee covers the mining fee of the quote for the transaction,
+// or false and the shortfall (see: IsFeeSufficient)
+//
+// The paid fee is the input satoshis minus the output satoshis (the raw tx does not contain the input satoshis)
+func (f *FeePayload) IsTxFeeSufficient(tx *transaction.Transaction, paidFee uint64) (bool, uint64, error) {
+	txSize, err := TxSizeFromTx(tx)
+	if err != nil {
+		return false, 0, err
+	}
+	return f.IsFeeSufficient(txSize, paidFee)
+}
@@ -0,0 +1,69 @@
+package minercraft
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestFeePayload_IsFeeSufficient tests the method IsFeeSufficient()
+func TestFeePayload_IsFeeSufficient(t *testing.T) {
+	t.Parallel()
+
+	quote := testQuote(MinerTaal, 500).Quote
+
+	var tests = []struct {
+		txSize            *TxSize
+		paidFee           uint64
+		expected          bool
+		expectedShortfall uint64
+		expectedError     bool
+	}{
+		{&TxSize{StandardBytes: 1000}, 500, true, 0, false},
+		{&TxSize{StandardBytes: 1000}, 600, true, 0, false},
+		{&TxSize{StandardBytes: 1000}, 450, false, 50, false},
+		{&TxSize{StandardBytes: 500, DataBytes: 500}, 0, false, 500, false},
+		{&TxSize{}, 100, false, 0, true},
+		{nil, 100, false, 0, true},
+	}
+	for _, test := range tests {
+		if output, shortfall, err := quote.IsFeeSufficient(test.txSize, test.paidFee); output != test.expected ||
+			shortfall != test.expectedShortfall || (err != nil) != test.expectedError {
+			t.Errorf("%s Failed: [%+v, %d] inputted and [%v %d %v] expected, received: [%v %d %v]",
+				t.Name(), test.txSize, test.paidFee, test.expected, test.expectedShortfall, test.expectedError, output, shortfall, err)
+		}
+	}
+}
+
+// TestFeePayload_IsTxFeeSufficient tests the method IsTxFeeSufficient()
+func TestFeePayload_IsTxFeeSufficient(t *testing.T) {
+	t.Parallel()
+
+	quote := testQuote(MinerTaal, 500).Quote
+
+	t.Run("insufficient fee", func(t *testing.T) {
+		tx := testDataTx(t, []byte("hello"))
+		sufficient, shortfall, err := quote.IsTxFeeSufficient(tx, 10)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		txSize, _ := TxSizeFromTx(tx)
+		expected, _ := quote.CalculateTxFee(FeeCategoryMining, txSize)
+		if sufficient || shortfall != expected-10 {
+			t.Fatalf("expected a shortfall of %d, got: %v %d", expected-10, sufficient, shortfall)
+		}
+	})
+
+	t.Run("nil tx", func(t *testing.T) {
+		if _, _, err := quote.IsTxFeeSufficient(nil, 10); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleFeePayload_IsFeeSufficient example using IsFeeSufficient()
+func ExampleFeePayload_IsFeeSufficient() {
+	quote := testQuote(MinerTaal, 500).Quote
+	sufficient, shortfall, _ := quote.IsFeeSufficient(&TxSize{StandardBytes: 226}, 100)
+	fmt.Printf("sufficient: %v shortfall: %d", sufficient, shortfall)
+	// Output:sufficient: false shortfall: 13
+}
@@ -0,0 +1,149 @@
+package minercraft
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FileStore is a Store backed by local files (one file per key), for single binary deployments
+// without external infrastructure (IE: persisting quotes and submission receipts across restarts)
+//
+// Expired keys are removed when read, or by using Prune()
+type FileStore struct {
+	clock Clock
+	dir   string
+	mu    sync.RWMutex
+}
+
+// NewFileStore will return a new file store using the directory (created if it does not exist)
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+// SetClock will set the clock used for the expiration of the keys (defaults to the real time)
+func (f *FileStore) SetClock(clock Clock) {
+	f.mu.Lock()
+	f.clock = clock
+	f.mu.Unlock()
+}
+
+// now will return the current time of the clock (the real time if not set)
+func (f *FileStore) now() time.Time {
+	f.mu.RLock()
+	clock := f.clock
+	f.mu.RUnlock()
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
+
+// Delete will remove the key
+func (f *FileStore) Delete(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := os.Remove(f.path(key)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Get will return the value of the key (or nil if not found or expired)
+func (f *FileStore) Get(_ context.Context, key string) ([]byte, error) {
+	f.mu.RLock()
+	data, err := ioutil.ReadFile(f.path(key))
+	f.mu.RUnlock()
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// Check the expiration
+	value, expired := decodeFileValue(data, f.now())
+	if expired {
+		return nil, f.Delete(context.Background(), key)
+	}
+	return value, nil
+}
+
+// Set will store the value of the key, it expires after the ttl (zero means it does not expire)
+//
+// The file is written atomically (a temp file is renamed), so readers never see a partial value
+func (f *FileStore) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+
+	// The value is the expiration time (unix nanoseconds, zero means it does not expire) followed by the data
+	data := make([]byte, 8, 8+len(value))
+	if ttl > 0 {
+		binary.BigEndian.PutUint64(data, uint64(f.now().Add(ttl).UnixNano()))
+	}
+	data = append(data, value...)
+
+	// Write a temp file and replace the current file
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	file, err := ioutil.TempFile(f.dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(data); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return err
+	} else if err = file.Close(); err != nil {
+		_ = os.Remove(file.Name())
+		return err
+	}
+	return os.Rename(file.Name(), f.path(key))
+}
+
+// Prune will remove all expired keys
+func (f *FileStore) Prune() error {
+	now := f.now()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	files, err := ioutil.ReadDir(f.dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || file.Name()[0] == '.' {
+			continue
+		}
+		path := filepath.Join(f.dir, file.Name())
+		if data, err := ioutil.ReadFile(path); err == nil {
+			if _, expired := decodeFileValue(data, now); expired {
+				_ = os.Remove(path)
+			}
+		}
+	}
+	return nil
+}
+
+// path will return the file path of the key (the key is hashed, so any key is a valid file name)
+func (f *FileStore) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(f.dir, hex.EncodeToString(hash[:]))
+}
+
+// decodeFileValue will return the value and true if the value has expired at now (invalid values are expired)
+func decodeFileValue(data []byte, now time.Time) ([]byte, bool) {
+	if len(data) < 8 {
+		return nil, true
+	}
+	expiresAt := binary.BigEndian.Uint64(data[:8])
+	if expiresAt > 0 && !now.Before(time.Unix(0, int64(expiresAt))) {
+		return nil, true
+	}
+	return data[8:], false
+}
@@ -0,0 +1,154 @@
+package minercraft
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testSubmittedTx is the tx id of the mocked submission (see: mockHTTPValidSubmission)
+const testSubmittedTx = "6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0"
+
+// TestFileStore tests the FileStore
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("get, set and delete", func(t *testing.T) {
+		store, err := NewFileStore(filepath.Join(t.TempDir(), "store"))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		if err = store.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if value, err := store.Get(ctx, "key"); err != nil || string(value) != "value" {
+			t.Fatalf("expected [value] but got: %s %v", value, err)
+		} else if err = store.Set(ctx, "key", []byte("updated"), 0); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if value, err = store.Get(ctx, "key"); err != nil || string(value) != "updated" {
+			t.Fatalf("expected [updated] but got: %s %v", value, err)
+		} else if err = store.Delete(ctx, "key"); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if value, err = store.Get(ctx, "key"); err != nil || value != nil {
+			t.Fatalf("expected no value but got: %s %v", value, err)
+		} else if err = store.Delete(ctx, "key"); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	})
+
+	t.Run("persisted", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir)
+		_ = store.Set(ctx, "key", []byte("value"), 0)
+
+		reopened, _ := NewFileStore(dir)
+		if value, err := reopened.Get(ctx, "key"); err != nil || string(value) != "value" {
+			t.Fatalf("expected [value] but got: %s %v", value, err)
+		}
+	})
+
+	t.Run("expiration and prune", func(t *testing.T) {
+		dir := t.TempDir()
+		store, _ := NewFileStore(dir)
+		_ = store.Set(ctx, "key", []byte("value"), 10*time.Millisecond)
+		_ = store.Set(ctx, "other", []byte("value"), 10*time.Millisecond)
+		_ = store.Set(ctx, "forever", []byte("value"), 0)
+		time.Sleep(20 * time.Millisecond)
+		if value, _ := store.Get(ctx, "key"); value != nil {
+			t.Fatalf("expected no value but got: %s", value)
+		} else if err := store.Prune(); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+			t.Fatalf("expected %d file, got %d", 1, len(files))
+		} else if value, _ := store.Get(ctx, "forever"); string(value) != "value" {
+			t.Fatalf("expected [value] but got: %s", value)
+		}
+	})
+
+	t.Run("invalid directory", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "file")
+		_ = ioutil.WriteFile(file, nil, 0o600)
+		if _, err := NewFileStore(file); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+		store := &FileStore{dir: filepath.Join(file, "missing")}
+		if err := store.Set(ctx, "key", nil, 0); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if err = store.Prune(); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = store.Get(ctx, "key"); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestClient_SubmissionReceipt tests the method SubmissionReceipt()
+func TestClient_SubmissionReceipt(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewFileStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Submit a transaction
+	client := newTestClient(&mockHTTPValidSubmission{})
+	miner := client.MinerByName(MinerTaal)
+
+	// No store is set
+	if receipt, err := client.SubmissionReceipt(context.Background(), miner, testSubmittedTx); err != nil || receipt != nil {
+		t.Fatalf("expected no receipt but got: %v %v", receipt, err)
+	}
+
+	client.SetStore(store)
+	if _, err = client.SubmitTransaction(miner, &Transaction{RawTx: testRawTx}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// A restarted client finds the receipt
+	restarted := newTestClient(&mockHTTPValidSubmission{})
+	restarted.SetStore(store)
+	if receipt, err := restarted.SubmissionReceipt(context.Background(), restarted.MinerByName(MinerTaal), testSubmittedTx); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if receipt == nil || receipt.Results == nil || receipt.Results.TxID != testSubmittedTx {
+		t.Fatalf("expected the receipt for [%s] but got: %v", testSubmittedTx, receipt)
+	}
+
+	// Not found
+	if receipt, err := restarted.SubmissionReceipt(context.Background(), restarted.MinerByName(MinerMempool), testSubmittedTx); err != nil || receipt != nil {
+		t.Fatalf("expected no receipt but got: %v %v", receipt, err)
+	} else if _, err = restarted.SubmissionReceipt(context.Background(), nil, testSubmittedTx); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// ExampleNewFileStore example using NewFileStore()
+func ExampleNewFileStore() {
+	dir, _ := ioutil.TempDir("", "minercraft")
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	// Persist quotes and receipts in a local directory
+	store, err := NewFileStore(dir)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	client := newTestClient(&mockHTTPValidSubmission{})
+	client.SetStore(store)
+	if _, err = client.SubmitTransaction(client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx}); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	receipt, _ := client.SubmissionReceipt(context.Background(), client.MinerByName(MinerTaal), testSubmittedTx)
+	fmt.Printf("receipt: %s", receipt.Results.ReturnResult)
+	// Output:receipt: success
+}
@@ -0,0 +1,106 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/libsv/libsv/script"
+	"github.com/libsv/libsv/transaction"
+	"github.com/libsv/libsv/transaction/input"
+	"github.com/libsv/libsv/transaction/output"
+)
+
+// UTXO is an unspent output that funds a transaction (see: Funder)
+type UTXO struct {
+	LockingScript string `json:"locking_script"` // Hex of the locking script (IE: P2PKH)
+	Satoshis      uint64 `json:"satoshis"`
+	TxID          string `json:"txid"`
+	Vout          uint32 `json:"vout"`
+}
+
+// FundingRequest is what a Funder needs to select the UTXOs for a transaction
+type FundingRequest struct {
+	Amount uint64                   `json:"amount"` // Satoshis of the outputs of the transaction
+	Miner  *Miner                   `json:"miner"`  // The miner that will receive the broadcast
+	Quote  *FeePayload              `json:"quote"`  // The current quote of the miner (see: FeePayload.EstimateFee)
+	Tx     *transaction.Transaction `json:"-"`      // The transaction to fund (outputs only)
+}
+
+// Funder is the capability of funding transactions (coin selection) that is aware of the miner that will
+// receive the broadcast and its exact rates, implemented by wallets (see: FundTransaction)
+type Funder interface {
+	// ChangeScript will return the hex of the locking script for the change of the transaction
+	ChangeScript(ctx context.Context, request *FundingRequest) (string, error)
+
+	// SelectUTXOs will return the UTXOs that fund the amount and the fee of the quote (including the change output)
+	SelectUTXOs(ctx context.Context, request *FundingRequest) ([]*UTXO, error)
+}
+
+// FundTransaction will fund the transaction for the miner: the funder selects the UTXOs using the current
+// quote of the miner, the UTXOs are added as inputs and a change output is added (see: FeePayload.AdjustChange)
+//
+// Returns the fee of the funded transaction, the inputs still need to be signed. If the selected UTXOs
+// don't cover the fee (or the change would be dust), no inputs or outputs are added
+func (c *Client) FundTransaction(ctx context.Context, funder Funder, miner *Miner,
+	tx *transaction.Transaction) (uint64, error) {
+
+	// Make sure we have a funder, miner and tx
+	if funder == nil {
+		return 0, errors.New("funder was nil")
+	} else if miner == nil {
+		return 0, errors.New("miner was nil")
+	} else if tx == nil || len(tx.Outputs) == 0 {
+		return 0, errors.New("tx has no outputs")
+	} else if err := c.checkNetwork(miner); err != nil {
+		return 0, err
+	}
+
+	// Get the current quote of the miner
+	result := getQuote(ctx, c, miner)
+	if result.Response.Error != nil {
+		return 0, result.Response.Error
+	}
+	quote, err := result.parseQuote()
+	if err != nil {
+		return 0, err
+	} else if quote.Quote == nil || len(quote.Quote.Fees) == 0 {
+		return 0, errors.New("failed getting quotes from: " + miner.Name)
+	}
+
+	// Select the UTXOs and the change script
+	request := &FundingRequest{Amount: tx.GetTotalOutputSatoshis(), Miner: miner, Quote: quote.Quote, Tx: tx}
+	utxos, err := funder.SelectUTXOs(ctx, request)
+	if err != nil {
+		return 0, err
+	} else if len(utxos) == 0 {
+		return 0, errors.New("funder did not select any utxos")
+	}
+	var changeScript string
+	if changeScript, err = funder.ChangeScript(ctx, request); err != nil {
+		return 0, err
+	}
+
+	// Fund a copy (the tx is only changed if the funding succeeds)
+	funded := *tx
+	funded.Inputs = append([]*input.Input(nil), tx.Inputs...)
+	funded.Outputs = append([]*output.Output(nil), tx.Outputs...)
+	for _, utxo := range utxos {
+		if err = funded.From(utxo.TxID, utxo.Vout, utxo.LockingScript, utxo.Satoshis); err != nil {
+			return 0, fmt.Errorf("invalid utxo %s:%d: %w", utxo.TxID, utxo.Vout, err)
+		}
+	}
+	var change *script.Script
+	if change, err = script.NewFromHexString(changeScript); err != nil {
+		return 0, fmt.Errorf("invalid change script: %w", err)
+	}
+	funded.AddOutput(&output.Output{LockingScript: change})
+
+	// Set the change for the exact fee of the quote
+	var fee uint64
+	if fee, err = quote.Quote.AdjustChange(&funded, len(funded.Outputs)-1); err != nil {
+		return 0, err
+	}
+	*tx = funded
+	return fee, nil
+}
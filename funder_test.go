@@ -0,0 +1,133 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/libsv/libsv/transaction"
+)
+
+// stubFunder is a stub Funder (IE: a wallet) selecting UTXOs until the amount and estimated fee are covered
+type stubFunder struct {
+	all   bool   // Select all UTXOs (without estimating the fee)
+	miner string // The miner of the last request
+	utxos []*UTXO
+}
+
+// ChangeScript will return a P2PKH change script
+func (s *stubFunder) ChangeScript(_ context.Context, _ *FundingRequest) (string, error) {
+	return testP2PKHScriptHex, nil
+}
+
+// SelectUTXOs will select UTXOs until the amount and the estimated fee (with change) are covered
+func (s *stubFunder) SelectUTXOs(_ context.Context, request *FundingRequest) ([]*UTXO, error) {
+	s.miner = request.Miner.Name
+	if s.all {
+		return s.utxos, nil
+	}
+	var selected []*UTXO
+	var total uint64
+	for _, utxo := range s.utxos {
+		selected = append(selected, utxo)
+		total += utxo.Satoshis
+		estimate, err := request.Quote.EstimateFee(FeeCategoryMining, nil, len(selected))
+		if err != nil {
+			return nil, err
+		} else if total >= request.Amount+estimate.Fee+estimate.MinChange {
+			return selected, nil
+		}
+	}
+	return nil, errors.New("insufficient funds")
+}
+
+// testUnfundedTx will return a tx with a single P2PKH output
+func testUnfundedTx(t *testing.T, satoshis uint64) *transaction.Transaction {
+	tx := transaction.New()
+	if err := tx.PayTo("1NTDHDz8hdmqyCxHATqgwT4u1SpERr7KXP", satoshis); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return tx
+}
+
+// TestClient_FundTransaction tests the method FundTransaction()
+func TestClient_FundTransaction(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	miner := client.MinerByName(MinerTaal)
+	utxos := []*UTXO{
+		{LockingScript: testP2PKHScriptHex, Satoshis: 1000, TxID: testTx, Vout: 0},
+		{LockingScript: testP2PKHScriptHex, Satoshis: 5000, TxID: testTx, Vout: 1},
+	}
+
+	t.Run("funded", func(t *testing.T) {
+		funder := &stubFunder{utxos: utxos}
+		tx := testUnfundedTx(t, 2000)
+		fee, err := client.FundTransaction(ctx, funder, miner, tx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if funder.miner != MinerTaal {
+			t.Fatalf("expected the funder to know the miner, got: %s", funder.miner)
+		} else if len(tx.Inputs) != 2 || len(tx.Outputs) != 2 {
+			t.Fatalf("expected 2 inputs and a change output, got: %d %d", len(tx.Inputs), len(tx.Outputs))
+		} else if change := tx.Outputs[1].Satoshis; change != 6000-2000-fee {
+			t.Fatalf("expected change of %d, got: %d", 6000-2000-fee, change)
+		}
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		tx := testUnfundedTx(t, 10000)
+		if _, err := client.FundTransaction(ctx, &stubFunder{utxos: utxos}, miner, tx); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if len(tx.Inputs) != 0 || len(tx.Outputs) != 1 {
+			t.Fatalf("expected the tx to be unchanged")
+		}
+	})
+
+	t.Run("change below dust", func(t *testing.T) {
+		tx := testUnfundedTx(t, 4400)
+		_, err := client.FundTransaction(ctx, &stubFunder{all: true, utxos: utxos[1:]}, miner, tx)
+		if !errors.Is(err, ErrChangeBelowDust) {
+			t.Fatalf("expected an error, got: %v", err)
+		} else if len(tx.Inputs) != 0 || len(tx.Outputs) != 1 {
+			t.Fatalf("expected the tx to be unchanged")
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		if _, err := client.FundTransaction(ctx, nil, miner, testUnfundedTx(t, 1000)); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.FundTransaction(ctx, &stubFunder{}, nil, testUnfundedTx(t, 1000)); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.FundTransaction(ctx, &stubFunder{}, miner, transaction.New()); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("quote failed", func(t *testing.T) {
+		if _, err := newTestClient(&mockHTTPBadRequest{}).FundTransaction(ctx, &stubFunder{utxos: utxos}, miner,
+			testUnfundedTx(t, 1000)); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleClient_FundTransaction example using FundTransaction()
+func ExampleClient_FundTransaction() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+
+	// Fund a tx (paying 2000 satoshis) using the rates of the miner that will receive the broadcast
+	tx := testUnfundedTx(&testing.T{}, 2000)
+	funder := &stubFunder{utxos: []*UTXO{{LockingScript: testP2PKHScriptHex, Satoshis: 5000, TxID: testTx}}}
+	fee, err := client.FundTransaction(context.Background(), funder, client.MinerByName(MinerTaal), tx)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("fee: %d change: %d", fee, tx.Outputs[1].Satoshis)
+	// Output:fee: 113 change: 2887
+}
@@ -1,13 +1,27 @@
 module github.com/tonicpow/go-minercraft
 
-go 1.15
+go 1.18
 
 require (
 	github.com/bitcoinschema/go-bitcoin v0.2.13
 	github.com/gojektech/heimdall/v6 v6.1.0
+	github.com/libsv/libsv v0.0.11
+	golang.org/x/crypto v0.0.0-20201016220609-9e8e0b390897
+	golang.org/x/sync v0.1.0
+)
+
+require (
+	github.com/bitcoinsv/bsvd v0.0.0-20190609155523-4c29707f7173 // indirect
+	github.com/bitcoinsv/bsvlog v0.0.0-20181216181007-cb81b076bf2e // indirect
+	github.com/bitcoinsv/bsvutil v0.0.0-20181216182056-1d77cf353ea9 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/gojektech/valkyrie v0.0.0-20190210220504-8f62c1e7ba45 // indirect
+	github.com/itchyny/base58-go v0.1.0 // indirect
+	github.com/piotrnar/gocoin v0.0.0-20201027184336-0c389d7eb2c0 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.3.0 // indirect
 	github.com/stretchr/testify v1.6.1 // indirect
+	golang.org/x/sys v0.0.0-20190412213103-97732733099d // indirect
 	gopkg.in/yaml.v3 v3.0.0-20200615113413-eeeca48fe776 // indirect
 )
@@ -0,0 +1,95 @@
+package minercraft
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MinerHealth is the result of the last health check of a miner
+type MinerHealth struct {
+	CheckedAt time.Time     `json:"checked_at"`
+	Error     string        `json:"error,omitempty"`
+	Healthy   bool          `json:"healthy"`
+	Latency   time.Duration `json:"latency"`
+	Miner     *Miner        `json:"miner"`
+	Validated bool          `json:"validated"` // If the signature of the quote was validated
+}
+
+// healthRegistry is the last known health of each miner
+type healthRegistry struct {
+	sync.RWMutex
+	miners map[string]*MinerHealth
+}
+
+// newHealthRegistry will return a new empty health registry
+func newHealthRegistry() *healthRegistry {
+	return &healthRegistry{miners: make(map[string]*MinerHealth)}
+}
+
+// get will return the last known health of the miner (or nil if never checked)
+func (h *healthRegistry) get(name string) *MinerHealth {
+	h.RLock()
+	defer h.RUnlock()
+	return h.miners[strings.ToLower(name)]
+}
+
+// set will store the health of the miner, returning the previous health (or nil if never checked)
+func (h *healthRegistry) set(health *MinerHealth) (previous *MinerHealth) {
+	h.Lock()
+	previous = h.miners[strings.ToLower(health.Miner.Name)]
+	h.miners[strings.ToLower(health.Miner.Name)] = health
+	h.Unlock()
+	return
+}
+
+// HealthCheck will check the health of a miner by requesting a new fee quote
+//
+// Note: this does not validate the token, miners can serve fee quotes without one (an invalid
+// token is only detected if the miner rejects the request)
+//
+// The result is stored and available using MinerHealth(). Failures are reported in the result
+// (Healthy=false and the Error), a nil miner is always unhealthy (and not stored)
+func (c *Client) HealthCheck(ctx context.Context, miner *Miner) *MinerHealth {
+
+	// Make sure we have a valid miner
+	started := time.Now() // The latency is measured in real time
+	health := &MinerHealth{Miner: miner, CheckedAt: c.now()}
+	if miner == nil {
+		health.Error = "miner was nil"
+		return health
+	}
+
+	// Request a new quote
+	result := fetchQuote(ctx, c, miner)
+	health.Latency = time.Since(started)
+
+	// Check the response
+	if result.Response.Error != nil {
+		health.Error = result.Response.Error.Error()
+	} else if quote, err := result.parseQuote(); err != nil {
+		health.Error = err.Error()
+	} else if quote.Quote == nil || len(quote.Quote.Fees) == 0 {
+		health.Error = "failed getting quotes from: " + miner.Name
+	} else {
+		health.Healthy = true
+		health.Validated = quote.Validated
+	}
+
+	// Store the result (and publish the event if the miner became unhealthy)
+	if previous := c.health.set(health); !health.Healthy && (previous == nil || previous.Healthy) {
+		c.events.publish(&Event{Error: health.Error, Miner: miner, Type: EventMinerUnhealthy}, c.now())
+	}
+
+	// Flush the offline queue to the healthy miner (in the background, see: SubmitTransactionAny)
+	if health.Healthy && c.QueuedSubmissions() > 0 {
+		go c.FlushQueue(context.Background(), miner)
+	}
+	return health
+}
+
+// MinerHealth will return the result of the last health check for the miner (or nil if never checked)
+func (c *Client) MinerHealth(name string) *MinerHealth {
+	return c.health.get(name)
+}
@@ -0,0 +1,52 @@
+package minercraft
+
+import (
+	"context"
+	"testing"
+)
+
+// TestClient_HealthCheck tests the method HealthCheck()
+func TestClient_HealthCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("healthy miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		health := client.HealthCheck(context.Background(), client.MinerByName(MinerTaal))
+		if !health.Healthy {
+			t.Fatalf("expected miner to be healthy, got error: %s", health.Error)
+		} else if !health.Validated {
+			t.Fatalf("expected the signature to be validated")
+		}
+
+		// Stored health
+		if stored := client.MinerHealth(MinerTaal); stored != health {
+			t.Fatalf("expected the health check to be stored")
+		}
+	})
+
+	t.Run("unhealthy miners", func(t *testing.T) {
+		for _, mock := range []httpInterface{&mockHTTPError{}, &mockHTTPInvalidJSON{}, &mockHTTPMissingFees{}} {
+			client := newTestClient(mock)
+			health := client.HealthCheck(context.Background(), client.MinerByName(MinerTaal))
+			if health.Healthy {
+				t.Fatalf("expected miner to be unhealthy using %T", mock)
+			} else if len(health.Error) == 0 {
+				t.Fatalf("expected an error using %T", mock)
+			}
+		}
+	})
+
+	t.Run("nil miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		if health := client.HealthCheck(context.Background(), nil); health.Healthy || len(health.Error) == 0 {
+			t.Fatalf("expected a nil miner to be unhealthy")
+		}
+	})
+
+	t.Run("never checked", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		if health := client.MinerHealth(MinerTaal); health != nil {
+			t.Fatalf("expected no health, got: %v", health)
+		}
+	})
+}
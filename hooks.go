@@ -0,0 +1,159 @@
+package minercraft
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// QuoteReceivedHook is fired for every new fee quote that was parsed (cached quotes are not fired again)
+type QuoteReceivedHook func(quote *FeeQuoteResponse)
+
+// SubmitResultHook is fired for the outcome of every transaction submission that was sent to a miner
+type SubmitResultHook func(result *SubmitResult)
+
+// CallbackHook is fired for every callback from a miner that was parsed (see: ParseCallback)
+type CallbackHook func(callback *CallbackResponse)
+
+// UsageHook is fired after every request to a miner (see: OnUsage)
+type UsageHook func(usage *UsageRecord)
+
+// UsageOutcome is the outcome of a request to a miner (see: UsageRecord)
+type UsageOutcome string
+
+// Outcomes of the requests to miners
+const (
+	UsageFailed      UsageOutcome = "failed"      // The miner responded with an error status code (or an unreadable body)
+	UsageRefused     UsageOutcome = "refused"     // Not sent, the request budget of the miner was exceeded
+	UsageSuccess     UsageOutcome = "success"     // The miner responded
+	UsageUnreachable UsageOutcome = "unreachable" // The miner did not respond
+)
+
+// UsageRecord is the usage of a request to a miner for accounting and billing (IE: per tenant submission)
+type UsageRecord struct {
+	BytesReceived int64        `json:"bytes_received"` // Size of the response body
+	BytesSent     int64        `json:"bytes_sent"`     // Size of the request body
+	Miner         *Miner       `json:"miner"`
+	Operation     Operation    `json:"operation"`
+	Outcome       UsageOutcome `json:"outcome"`
+	StatusCode    int          `json:"status_code"`
+	Tenant        string       `json:"tenant"` // Tenant of the client (see: WithTenant)
+}
+
+// SubmitResult is the outcome of a transaction submission
+type SubmitResult struct {
+	Error    error                      `json:"error"`    // If the submission failed (request or response error)
+	Miner    *Miner                     `json:"miner"`    // The miner the transaction was submitted to
+	Response *SubmitTransactionResponse `json:"response"` // The response (if no error)
+	Tx       *Transaction               `json:"tx"`       // The submitted transaction (nil or without the RawTx if streamed)
+}
+
+// hooks is the registry of event hooks
+//
+// The hooks are called without holding the lock (a hook can register another hook)
+type hooks struct {
+	sync.RWMutex
+	callback      []CallbackHook
+	quoteReceived []QuoteReceivedHook
+	submitResult  []SubmitResultHook
+	usage         []UsageHook
+}
+
+// OnQuoteReceived will register a hook that is fired for every new fee quote (IE: for auditing)
+//
+// Hooks are called synchronously, in the order they were registered
+func (c *Client) OnQuoteReceived(hook QuoteReceivedHook) {
+	c.hooks.Lock()
+	c.hooks.quoteReceived = append(c.hooks.quoteReceived, hook)
+	c.hooks.Unlock()
+}
+
+// OnSubmitResult will register a hook that is fired for the outcome of every transaction submission
+//
+// Hooks are called synchronously, in the order they were registered
+func (c *Client) OnSubmitResult(hook SubmitResultHook) {
+	c.hooks.Lock()
+	c.hooks.submitResult = append(c.hooks.submitResult, hook)
+	c.hooks.Unlock()
+}
+
+// OnCallback will register a hook that is fired for every parsed callback (see: ParseCallback)
+//
+// Hooks are called synchronously, in the order they were registered
+func (c *Client) OnCallback(hook CallbackHook) {
+	c.hooks.Lock()
+	c.hooks.callback = append(c.hooks.callback, hook)
+	c.hooks.Unlock()
+}
+
+// OnUsage will register a hook that is fired after every request to a miner with the tenant, miner, operation,
+// bytes and outcome (IE: billing customers per submission or per status query)
+//
+// Hooks are called synchronously, in the order they were registered (cached quotes are not requests)
+func (c *Client) OnUsage(hook UsageHook) {
+	c.hooks.Lock()
+	c.hooks.usage = append(c.hooks.usage, hook)
+	c.hooks.Unlock()
+}
+
+// fireQuoteReceived will fire the quote received hooks
+func (h *hooks) fireQuoteReceived(quote *FeeQuoteResponse) {
+	h.RLock()
+	hooks := append([]QuoteReceivedHook(nil), h.quoteReceived...)
+	h.RUnlock()
+	for _, hook := range hooks {
+		hook(quote)
+	}
+}
+
+// fireSubmitResult will fire the submit result hooks
+func (h *hooks) fireSubmitResult(result *SubmitResult) {
+	h.RLock()
+	hooks := append([]SubmitResultHook(nil), h.submitResult...)
+	h.RUnlock()
+	for _, hook := range hooks {
+		hook(result)
+	}
+}
+
+// fireCallback will fire the callback hooks
+func (h *hooks) fireCallback(callback *CallbackResponse) {
+	h.RLock()
+	hooks := append([]CallbackHook(nil), h.callback...)
+	h.RUnlock()
+	for _, hook := range hooks {
+		hook(callback)
+	}
+}
+
+// fireUsage will fire the usage hooks for the request and its response
+func (h *hooks) fireUsage(tenant string, payload *httpPayload, response *RequestResponse) {
+	h.RLock()
+	hooks := append([]UsageHook(nil), h.usage...)
+	h.RUnlock()
+	if len(hooks) == 0 || payload.Miner == nil {
+		return
+	}
+
+	usage := &UsageRecord{
+		BytesReceived: int64(len(response.BodyContents)),
+		BytesSent:     int64(len(payload.Data)) + payload.ContentLength,
+		Miner:         payload.Miner,
+		Operation:     payload.Operation,
+		Outcome:       UsageSuccess,
+		StatusCode:    response.StatusCode,
+		Tenant:        tenant,
+	}
+	switch {
+	case response.StatusCode == http.StatusOK && response.Error == nil:
+	case response.StatusCode > 0:
+		usage.Outcome = UsageFailed
+	case errors.Is(response.Error, ErrBudgetExceeded):
+		usage.Outcome = UsageRefused
+	default:
+		usage.Outcome = UsageUnreachable
+	}
+	for _, hook := range hooks {
+		hook(usage)
+	}
+}
@@ -0,0 +1,278 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testCallbackMinerID is the minerId (public key of testMinerIDKeyOld) signing testCallback
+const testCallbackMinerID = "031b8c93100d35bd448f4646cc4678f278351b439b52b303ea31ec9edb5475e73f"
+
+// testCallback is a merkle proof callback signed by testCallbackMinerID (see: addTestCallbackMiner)
+const testCallback = `{"payload": "{\"apiVersion\":\"` + testAPIVersion + `\",\"timestamp\":\"2020-11-03T13:24:31.233647Z\",\"blockHash\":\"34bbc00697512058cb040e1c7bbba5d03a2e94270093eb28114747a6f7507d48\",\"blockHeight\":5,\"callbackTxId\":\"` + testSubmittedTx + `\",\"callbackReason\":\"merkleProof\",\"callbackPayload\":\"{}\",\"minerId\":\"` + testCallbackMinerID + `\"}",
+	"signature": "304402205c859d536a0f4fb247a0ba0a563319119853796f8bfd4a9f8332ccc87f11e527022009b5e496cca5958bcea6d414747b80ab9f5673994af9ae6058b738d5ab662d62","publicKey": "` + testCallbackMinerID + `","encoding": "` + testEncoding + `","mimetype": "` + testMimeType + `"}`
+
+// testUnsignedCallback is an unsigned merkle proof callback
+const testUnsignedCallback = `{"payload": "{\"apiVersion\":\"` + testAPIVersion + `\",\"timestamp\":\"2020-11-03T13:24:31.233647Z\",\"blockHash\":\"34bbc00697512058cb040e1c7bbba5d03a2e94270093eb28114747a6f7507d48\",\"blockHeight\":5,\"callbackTxId\":\"` + testSubmittedTx + `\",\"callbackReason\":\"merkleProof\",\"callbackPayload\":\"{}\",\"minerId\":\"` + testCallbackMinerID + `\"}",
+	"signature": null,"publicKey": null,"encoding": "` + testEncoding + `","mimetype": "` + testMimeType + `"}`
+
+// testCallbackMinerName is the name of the miner signing testCallback
+const testCallbackMinerName = "Callback"
+
+// addTestCallbackMiner will add the miner signing testCallback (so the callback is trusted)
+func addTestCallbackMiner(client *Client) *Client {
+	_ = client.AddMiner(Miner{MinerID: testCallbackMinerID, Name: testCallbackMinerName, URL: "https://callback.example.com"})
+	return client
+}
+
+// TestClient_OnQuoteReceived tests the method OnQuoteReceived()
+func TestClient_OnQuoteReceived(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fired once per new quote", func(t *testing.T) {
+		client := newTestCachingClient(&mockHTTPCountingQuote{expiresIn: time.Minute})
+
+		var received []*FeeQuoteResponse
+		client.OnQuoteReceived(func(quote *FeeQuoteResponse) {
+			received = append(received, quote)
+		})
+
+		miner := client.MinerByName(MinerTaal)
+		for i := 0; i < 2; i++ {
+			if _, err := client.FeeQuote(miner); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+		}
+
+		if len(received) != 1 {
+			t.Fatalf("expected 1 hook call (cached quote), got: %d", len(received))
+		} else if received[0].Miner != miner {
+			t.Fatalf("expected miner %s, got: %v", miner.Name, received[0].Miner)
+		}
+	})
+
+	t.Run("hook registering a hook", func(t *testing.T) {
+		client := newTestClient(&mockHTTPCountingQuote{expiresIn: time.Minute})
+
+		var calls int
+		client.OnQuoteReceived(func(quote *FeeQuoteResponse) {
+			client.OnQuoteReceived(func(quote *FeeQuoteResponse) {
+				calls++
+			})
+		})
+
+		miner := client.MinerByName(MinerTaal)
+		for i := 0; i < 2; i++ {
+			if _, err := client.FeeQuote(miner); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+		}
+
+		// The hook registered by the first quote is fired for the second quote
+		if calls != 1 {
+			t.Fatalf("expected 1 hook call, got: %d", calls)
+		}
+	})
+
+	t.Run("not fired on error", func(t *testing.T) {
+		client := newTestClient(&mockHTTPInvalidSignature{})
+
+		var calls int
+		client.OnQuoteReceived(func(quote *FeeQuoteResponse) {
+			calls++
+		})
+
+		if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if calls != 0 {
+			t.Fatalf("expected 0 hook calls, got: %d", calls)
+		}
+	})
+}
+
+// TestClient_OnSubmitResult tests the method OnSubmitResult()
+func TestClient_OnSubmitResult(t *testing.T) {
+	t.Parallel()
+
+	t.Run("successful submission", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+
+		var results []*SubmitResult
+		client.OnSubmitResult(func(result *SubmitResult) {
+			results = append(results, result)
+		})
+
+		tx := &Transaction{RawTx: testRawTx}
+		response, err := client.SubmitTransaction(client.MinerByName(MinerMatterpool), tx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 hook call, got: %d", len(results))
+		} else if results[0].Error != nil {
+			t.Fatalf("expected no error, got: %s", results[0].Error.Error())
+		} else if results[0].Response != response || results[0].Tx != tx {
+			t.Fatalf("expected the response and tx of the submission")
+		} else if results[0].Miner.Name != MinerMatterpool {
+			t.Fatalf("expected miner %s, got: %s", MinerMatterpool, results[0].Miner.Name)
+		}
+	})
+
+	t.Run("failed submission", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBadSubmission{})
+
+		var results []*SubmitResult
+		client.OnSubmitResult(func(result *SubmitResult) {
+			results = append(results, result)
+		})
+
+		if _, err := client.SubmitTransaction(client.MinerByName(MinerMatterpool), &Transaction{RawTx: testRawTx}); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+
+		if len(results) != 1 {
+			t.Fatalf("expected 1 hook call, got: %d", len(results))
+		} else if results[0].Error == nil || results[0].Response != nil {
+			t.Fatalf("expected an error and no response")
+		}
+	})
+
+	t.Run("not fired if rejected locally", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+
+		var calls int
+		client.OnSubmitResult(func(result *SubmitResult) {
+			calls++
+		})
+
+		if _, err := client.SubmitTransaction(client.MinerByName(MinerMatterpool), &Transaction{RawTx: "invalid"}); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if calls != 0 {
+			t.Fatalf("expected 0 hook calls, got: %d", calls)
+		}
+	})
+}
+
+// TestClient_ParseCallback tests the method ParseCallback()
+func TestClient_ParseCallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid callback", func(t *testing.T) {
+		client := addTestCallbackMiner(newTestClient(&mockHTTPValidSubmission{}))
+
+		var callbacks []*CallbackResponse
+		client.OnCallback(func(callback *CallbackResponse) {
+			callbacks = append(callbacks, callback)
+		})
+
+		response, err := client.ParseCallback([]byte(testCallback))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Callback.CallbackTxID != testSubmittedTx {
+			t.Fatalf("expected txid %s, got: %s", testSubmittedTx, response.Callback.CallbackTxID)
+		} else if response.Callback.CallbackReason != CallbackReasonMerkleProof {
+			t.Fatalf("expected reason %s, got: %s", CallbackReasonMerkleProof, response.Callback.CallbackReason)
+		} else if response.Callback.BlockHeight != 5 {
+			t.Fatalf("expected block height 5, got: %d", response.Callback.BlockHeight)
+		} else if response.Miner == nil || response.Miner.Name != testCallbackMinerName || !response.Trusted {
+			t.Fatalf("expected trusted miner %s, got: %v", testCallbackMinerName, response.Miner)
+		}
+
+		if len(callbacks) != 1 || callbacks[0] != response {
+			t.Fatalf("expected 1 hook call with the response, got: %d", len(callbacks))
+		}
+	})
+
+	t.Run("invalid callbacks", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+
+		var calls int
+		client.OnCallback(func(callback *CallbackResponse) {
+			calls++
+		})
+
+		for _, body := range []string{"", "{}", `{"payload":"{}"}`, `{"payload":"not-json"}`} {
+			if _, err := client.ParseCallback([]byte(body)); err == nil {
+				t.Fatalf("%s Failed: [%s] inputted and error was expected", t.Name(), body)
+			}
+		}
+		if calls != 0 {
+			t.Fatalf("expected 0 hook calls, got: %d", calls)
+		}
+	})
+
+	t.Run("untrusted callbacks", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+
+		var calls int
+		client.OnCallback(func(callback *CallbackResponse) {
+			calls++
+		})
+
+		// Signed by an unknown key
+		if _, err := client.ParseCallback([]byte(testCallback)); !errors.Is(err, ErrUntrustedCallback) {
+			t.Fatalf("expected ErrUntrustedCallback for an unknown key, got: %v", err)
+		}
+
+		// Unsigned, or signed by another key than the minerId of the payload
+		addTestCallbackMiner(client)
+		payload := `{"callbackTxId":"` + testSubmittedTx + `","minerId":"` + testCallbackMinerID + `"}`
+		for _, body := range [][]byte{[]byte(testUnsignedCallback), testSignedEnvelope(t, testMinerIDKeyOther, payload)} {
+			if _, err := client.ParseCallback(body); !errors.Is(err, ErrUntrustedCallback) {
+				t.Fatalf("%s Failed: [%s] inputted and ErrUntrustedCallback was expected, got: %v", t.Name(), body, err)
+			}
+		}
+		if calls != 0 {
+			t.Fatalf("expected 0 hook calls, got: %d", calls)
+		}
+	})
+}
+
+// ExampleClient_OnSubmitResult example using OnSubmitResult()
+func ExampleClient_OnSubmitResult() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidSubmission{})
+
+	// Audit every submission
+	client.OnSubmitResult(func(result *SubmitResult) {
+		if result.Error == nil {
+			fmt.Printf("submitted: %s to: %s", result.Response.Results.TxID, result.Miner.Name)
+		}
+	})
+
+	_, _ = client.SubmitTransaction(client.MinerByName(MinerMatterpool), &Transaction{RawTx: testRawTx})
+	// Output:submitted: 6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0 to: Matterpool
+}
+
+// TestClient_OnUsage tests the method OnUsage()
+func TestClient_OnUsage(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidSubmission{})
+	var records []*UsageRecord
+	client.OnUsage(func(usage *UsageRecord) {
+		records = append(records, usage)
+	})
+
+	// Submitted by a tenant
+	miner := client.MinerByName(MinerMatterpool)
+	miner.HourlyBudget = 1
+	if _, err := client.With(WithTenant("tenant")).SubmitTransaction(miner, &Transaction{RawTx: testRawTx}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(records) != 1 {
+		t.Fatalf("expected 1 hook call, got: %d", len(records))
+	} else if usage := records[0]; usage.Tenant != "tenant" || usage.Miner != miner || usage.Operation != OperationSubmitTx ||
+		usage.Outcome != UsageSuccess || usage.BytesSent == 0 || usage.BytesReceived == 0 {
+		t.Fatalf("expected the usage of the submission, got: %+v", usage)
+	}
+
+	// Refused by the request budget
+	if _, err := client.SubmitTransaction(miner, &Transaction{RawTx: testRawTx}); err == nil {
+		t.Fatalf("error should have occurred")
+	} else if len(records) != 2 || records[1].Outcome != UsageRefused || len(records[1].Tenant) > 0 {
+		t.Fatalf("expected a refused request without a tenant, got: %+v", records[len(records)-1])
+	}
+}
@@ -0,0 +1,40 @@
+package minercraft
+
+import "io"
+
+// FeeQuoter is the capability of requesting fee quotes from the miners
+type FeeQuoter interface {
+	BestQuote(feeCategory, feeType string) (*FeeQuoteResponse, error)
+	BestQuoteForTx(feeCategory string, txSize *TxSize) (*FeeQuoteResponse, uint64, error)
+	CheapestMiners(n int, feeCategory, feeType string) ([]*FeeQuoteResponse, error)
+	FastestQuote() (*FeeQuoteResponse, error)
+	FeeQuote(miner *Miner) (*FeeQuoteResponse, error)
+}
+
+// Submitter is the capability of submitting transactions to a miner
+type Submitter interface {
+	SubmitTransaction(miner *Miner, tx *Transaction) (*SubmitTransactionResponse, error)
+	SubmitTransactionReader(miner *Miner, rawTx io.Reader, size int64, tx *Transaction) (*SubmitTransactionResponse, error)
+}
+
+// Querier is the capability of querying the status of a transaction from a miner
+type Querier interface {
+	QueryTransaction(miner *Miner, txID string) (*QueryTransactionResponse, error)
+}
+
+// PolicyReader is the capability of reading the policy of a miner (local or from a policy quote)
+type PolicyReader interface {
+	MaxTxSize(miner *Miner) int64
+	PolicyQuote(miner *Miner) (*PolicyQuoteResponse, error)
+}
+
+// ClientInterface is the combined capabilities of the client (see: FeeQuoter, Submitter, Querier and PolicyReader)
+type ClientInterface interface {
+	FeeQuoter
+	PolicyReader
+	Querier
+	Submitter
+}
+
+// Make sure the client implements all the capabilities
+var _ ClientInterface = (*Client)(nil)
@@ -0,0 +1,48 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// stubQuerier is a stub Querier (IE: a mock in a downstream package)
+type stubQuerier struct{}
+
+// QueryTransaction is a stub query (always returns a "success" result)
+func (s *stubQuerier) QueryTransaction(miner *Miner, txID string) (*QueryTransactionResponse, error) {
+	if miner == nil {
+		return nil, errors.New("miner was nil")
+	}
+	return &QueryTransactionResponse{Query: &QueryPayload{ReturnResult: "success", TxID: txID}}, nil
+}
+
+// txStatus is an example of a downstream function that depends on the Querier capability only
+func txStatus(querier Querier, miner *Miner, txID string) (string, error) {
+	response, err := querier.QueryTransaction(miner, txID)
+	if err != nil {
+		return "", err
+	}
+	return response.Query.ReturnResult, nil
+}
+
+// TestQuerier tests using the client or a stub as a Querier
+func TestQuerier(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidQuery{})
+	for _, querier := range []Querier{client, &stubQuerier{}} {
+		if status, err := txStatus(querier, client.MinerByName(MinerTaal), testTx); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if status != "success" {
+			t.Fatalf("expected status [success] but got: %s", status)
+		}
+	}
+}
+
+// ExampleQuerier example using the Querier interface
+func ExampleQuerier() {
+	status, _ := txStatus(&stubQuerier{}, &Miner{Name: MinerTaal}, testTx)
+	fmt.Printf("status: %s", status)
+	// Output:status: success
+}
@@ -0,0 +1,245 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrStoreRequired is returned when a feature that persists data is used without a store (see: SetStore)
+var ErrStoreRequired = errors.New("store is required")
+
+// journalIndexKey is the store key of the list of pending journal entries
+const journalIndexKey = "journal:pending"
+
+// journalSentTTL is how long a sent journal entry is kept in the store (for auditing)
+const journalSentTTL = 24 * time.Hour
+
+// JournalEntry is a journaled submission (see: SubmitTransactionJournaled)
+type JournalEntry struct {
+	Attempts  int          `json:"attempts"`
+	CreatedAt time.Time    `json:"created_at"`
+	LastError string       `json:"last_error,omitempty"` // The error of the last attempt (or the rejection of the miner)
+	Miner     string       `json:"miner"`
+	Sent      bool         `json:"sent"` // Set when the miner accepts the transaction (or already has it)
+	SentAt    time.Time    `json:"sent_at,omitempty"`
+	Tx        *Transaction `json:"tx"`
+	TxID      string       `json:"txid"`
+}
+
+// journal guards the index of pending entries (read, modify and write)
+//
+// The index is a single key of the store, guarded in this process only: processes sharing the store
+// would overwrite each other's index (see: SubmitTransactionJournaled)
+type journal struct {
+	sync.Mutex
+}
+
+// SubmitTransactionJournaled will write the submission to the store (see: SetStore) before submitting it,
+// the entry is only marked sent when the miner accepts the transaction
+//
+// Submissions that were not accepted (a crash, a network outage or a rejection) stay pending and are
+// retried using ResumePending(), or removed using DiscardPending()
+//
+// Note: the journal is single-process, the index of pending entries is rewritten on every change (Store has no
+// way to list keys), so each process needs its own store (IE: a NewRedisStore prefix per instance)
+func (c *Client) SubmitTransactionJournaled(ctx context.Context, miner *Miner,
+	tx *Transaction) (*SubmitTransactionResponse, error) {
+
+	// Make sure we have a valid miner, transaction and store
+	if err := c.checkSubmission(ctx, miner, tx); err != nil {
+		return nil, err
+	} else if c.store == nil {
+		return nil, ErrStoreRequired
+	}
+
+	// Write the entry before submitting
+	txID, err := rawTxID(tx.RawTx)
+	if err != nil {
+		return nil, err
+	}
+	entry := &JournalEntry{CreatedAt: c.now().UTC(), Miner: miner.Name, Tx: tx, TxID: txID}
+	if err = c.addJournalEntry(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	return c.sendJournalEntry(ctx, miner, entry)
+}
+
+// ResumePending will retry all pending journaled submissions (IE: after a crash or network outage)
+//
+// A result is returned for each pending entry, entries of unknown miners are skipped (with an error)
+func (c *Client) ResumePending(ctx context.Context) ([]*SubmitResult, error) {
+	entries, err := c.PendingSubmissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*SubmitResult, 0, len(entries))
+	for _, entry := range entries {
+		result := &SubmitResult{Tx: entry.Tx}
+		if result.Miner = c.MinerByName(entry.Miner); result.Miner == nil {
+			result.Error = errors.New("miner not found: " + entry.Miner)
+		} else {
+			result.Response, result.Error = c.sendJournalEntry(ctx, result.Miner, entry)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// PendingSubmissions will return the journaled submissions that were not accepted yet (oldest first)
+func (c *Client) PendingSubmissions(ctx context.Context) ([]*JournalEntry, error) {
+	if c.store == nil {
+		return nil, ErrStoreRequired
+	}
+
+	keys, err := c.journalIndex(ctx)
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*JournalEntry, 0, len(keys))
+	for _, key := range keys {
+		var data []byte
+		if data, err = c.store.Get(ctx, key); err != nil {
+			return nil, err
+		}
+		entry := new(JournalEntry)
+		if len(data) > 0 && json.Unmarshal(data, entry) == nil && !entry.Sent {
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.Before(entries[j].CreatedAt)
+	})
+	return entries, nil
+}
+
+// DiscardPending will remove a pending journaled submission (IE: a transaction the miner will never accept)
+func (c *Client) DiscardPending(ctx context.Context, minerName, txID string) error {
+	if c.store == nil {
+		return ErrStoreRequired
+	}
+	key := journalEntryKey(minerName, txID)
+	if err := c.removeJournalIndex(ctx, key); err != nil {
+		return err
+	}
+	return c.store.Delete(ctx, key)
+}
+
+// sendJournalEntry will submit the journaled transaction and update the entry with the result
+func (c *Client) sendJournalEntry(ctx context.Context, miner *Miner, entry *JournalEntry) (*SubmitTransactionResponse, error) {
+
+	// Submit the transaction
+	response, err := c.submissionResult(submitTransaction(ctx, c, miner, entry.Tx), entry.Tx)
+	entry.Attempts++
+	switch outcome := ClassifySubmission(response, err); {
+	case err != nil:
+		entry.LastError = err.Error()
+	case outcome != SubmitAccepted && outcome != SubmitAlreadyKnown:
+		entry.LastError = response.Results.ResultDescription
+	default: // Accepted (or the miner already has the transaction)
+		entry.LastError = ""
+		entry.Sent = true
+		entry.SentAt = c.now().UTC()
+	}
+
+	// Update the entry (sent entries are removed from the index)
+	data, _ := json.Marshal(entry) // Ignoring error - the entry was already marshalled when it was added
+	key := journalEntryKey(entry.Miner, entry.TxID)
+	if !entry.Sent {
+		return response, firstError(err, c.store.Set(ctx, key, data, 0))
+	} else if storeErr := c.store.Set(ctx, key, data, journalSentTTL); storeErr != nil {
+		return response, storeErr
+	}
+	return response, c.removeJournalIndex(ctx, key)
+}
+
+// addJournalEntry will write the entry and add it to the index of pending entries
+func (c *Client) addJournalEntry(ctx context.Context, entry *JournalEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	key := journalEntryKey(entry.Miner, entry.TxID)
+	if err = c.store.Set(ctx, key, data, 0); err != nil {
+		return err
+	}
+
+	c.journal.Lock()
+	defer c.journal.Unlock()
+	keys, err := c.journalIndex(ctx)
+	if err != nil || containsFold(keys, key) {
+		return err
+	}
+	return c.setJournalIndex(ctx, append(keys, key))
+}
+
+// removeJournalIndex will remove the entry from the index of pending entries
+func (c *Client) removeJournalIndex(ctx context.Context, key string) error {
+	c.journal.Lock()
+	defer c.journal.Unlock()
+	keys, err := c.journalIndex(ctx)
+	if err != nil {
+		return err
+	}
+	remaining := make([]string, 0, len(keys))
+	for _, existing := range keys {
+		if existing != key {
+			remaining = append(remaining, existing)
+		}
+	}
+	return c.setJournalIndex(ctx, remaining)
+}
+
+// journalIndex will return the keys of the pending entries
+func (c *Client) journalIndex(ctx context.Context) (keys []string, err error) {
+	var data []byte
+	if data, err = c.store.Get(ctx, journalIndexKey); err != nil || len(data) == 0 {
+		return
+	}
+	err = json.Unmarshal(data, &keys)
+	return
+}
+
+// setJournalIndex will write the keys of the pending entries
+func (c *Client) setJournalIndex(ctx context.Context, keys []string) error {
+	data, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+	return c.store.Set(ctx, journalIndexKey, data, 0)
+}
+
+// journalEntryKey will return the store key of the journal entry for the miner and tx
+func journalEntryKey(minerName, txID string) string {
+	return "journal:" + strings.ToLower(minerName) + ":" + strings.ToLower(txID)
+}
+
+// rawTxID will return the transaction id of the raw tx hex
+func rawTxID(rawTx string) (string, error) {
+	txBytes, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return "", err
+	}
+	tx, err := decodeTx(txBytes)
+	if err != nil {
+		return "", err
+	}
+	return tx.GetTxID(), nil
+}
+
+// firstError will return the first error that is not nil
+func firstError(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// TestClient_SubmitTransactionJournaled tests the methods SubmitTransactionJournaled() and ResumePending()
+func TestClient_SubmitTransactionJournaled(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newJournaledClient := func(t *testing.T, httpClient httpInterface) *Client {
+		store, err := NewFileStore(t.TempDir())
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		client := newTestClient(httpClient)
+		client.SetStore(store)
+		return client
+	}
+
+	t.Run("no store", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		if _, err := client.SubmitTransactionJournaled(ctx, client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx}); !errors.Is(err, ErrStoreRequired) {
+			t.Fatalf("expected error: %v got: %v", ErrStoreRequired, err)
+		} else if _, err = client.ResumePending(ctx); !errors.Is(err, ErrStoreRequired) {
+			t.Fatalf("expected error: %v got: %v", ErrStoreRequired, err)
+		}
+	})
+
+	t.Run("accepted", func(t *testing.T) {
+		client := newJournaledClient(t, &mockHTTPValidSubmission{})
+		if _, err := client.SubmitTransactionJournaled(ctx, client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx}); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		if pending, err := client.PendingSubmissions(ctx); err != nil || len(pending) != 0 {
+			t.Fatalf("expected no pending submissions, got: %v %v", pending, err)
+		}
+	})
+
+	t.Run("resume after an outage", func(t *testing.T) {
+		client := newJournaledClient(t, &mockHTTPBadRequest{})
+		if _, err := client.SubmitTransactionJournaled(ctx, client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx}); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+		pending, err := client.PendingSubmissions(ctx)
+		if err != nil || len(pending) != 1 {
+			t.Fatalf("expected 1 pending submission, got: %v %v", pending, err)
+		} else if pending[0].Attempts != 1 || len(pending[0].LastError) == 0 || pending[0].Miner != MinerTaal {
+			t.Fatalf("unexpected pending submission: %+v", pending[0])
+		}
+
+		// The miner is reachable again
+		client.httpClient = &mockHTTPValidSubmission{}
+		var results []*SubmitResult
+		if results, err = client.ResumePending(ctx); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if len(results) != 1 || results[0].Error != nil || results[0].Response == nil {
+			t.Fatalf("expected the submission to be resumed, got: %v", results)
+		}
+		if pending, err = client.PendingSubmissions(ctx); err != nil || len(pending) != 0 {
+			t.Fatalf("expected no pending submissions, got: %v %v", pending, err)
+		}
+	})
+
+	t.Run("rejected and discarded", func(t *testing.T) {
+		client := newJournaledClient(t, &mockHTTPRejectedSubmission{})
+		if _, err := client.SubmitTransactionJournaled(ctx, client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx}); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		pending, err := client.PendingSubmissions(ctx)
+		if err != nil || len(pending) != 1 {
+			t.Fatalf("expected 1 pending submission, got: %v %v", pending, err)
+		}
+		if err = client.DiscardPending(ctx, pending[0].Miner, pending[0].TxID); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if pending, err = client.PendingSubmissions(ctx); err != nil || len(pending) != 0 {
+			t.Fatalf("expected no pending submissions, got: %v %v", pending, err)
+		}
+	})
+
+	t.Run("unknown miner", func(t *testing.T) {
+		client := newJournaledClient(t, &mockHTTPBadRequest{})
+		_, _ = client.SubmitTransactionJournaled(ctx, client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx})
+		client.Miners = MinerSlice{client.MinerByName(MinerMempool)}
+		results, err := client.ResumePending(ctx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if len(results) != 1 || results[0].Error == nil {
+			t.Fatalf("expected an error for the unknown miner, got: %v", results)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package minercraft
+
+import "container/list"
+
+// lruCache is a size bounded cache that evicts the least recently used entry (not safe for concurrent use)
+type lruCache struct {
+	entries    *list.List
+	items      map[string]*list.Element
+	maxEntries int // Zero means there is no limit
+}
+
+// lruEntry is an entry in the lruCache
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+// newLRUCache will return a new empty cache with the max entries (zero means there is no limit)
+func newLRUCache(maxEntries int) *lruCache {
+	return &lruCache{
+		entries:    list.New(),
+		items:      make(map[string]*list.Element),
+		maxEntries: maxEntries,
+	}
+}
+
+// get will return the value for the key (and mark it as recently used)
+func (l *lruCache) get(key string) (interface{}, bool) {
+	if element, ok := l.items[key]; ok {
+		l.entries.MoveToFront(element)
+		return element.Value.(*lruEntry).value, true
+	}
+	return nil, false
+}
+
+// set will store the value for the key, evicting the least recently used entries if the cache is full
+func (l *lruCache) set(key string, value interface{}) {
+	if element, ok := l.items[key]; ok {
+		l.entries.MoveToFront(element)
+		element.Value.(*lruEntry).value = value
+		return
+	}
+	l.items[key] = l.entries.PushFront(&lruEntry{key: key, value: value})
+	l.resize(l.maxEntries)
+}
+
+// delete will remove the key (if found)
+func (l *lruCache) delete(key string) {
+	if element, ok := l.items[key]; ok {
+		l.entries.Remove(element)
+		delete(l.items, key)
+	}
+}
+
+// len will return the number of entries
+func (l *lruCache) len() int {
+	return l.entries.Len()
+}
+
+// resize will set the max entries and evict the least recently used entries over the limit
+func (l *lruCache) resize(maxEntries int) {
+	l.maxEntries = maxEntries
+	for l.maxEntries > 0 && l.entries.Len() > l.maxEntries {
+		l.delete(l.entries.Back().Value.(*lruEntry).key)
+	}
+}
@@ -0,0 +1,69 @@
+package minercraft
+
+import (
+	"strconv"
+	"testing"
+)
+
+// TestLRUCache tests the lruCache
+func TestLRUCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("evicts the least recently used", func(t *testing.T) {
+		cache := newLRUCache(2)
+		cache.set("a", 1)
+		cache.set("b", 2)
+		if value, ok := cache.get("a"); !ok || value.(int) != 1 {
+			t.Fatalf("expected [1] but got: %v", value)
+		}
+		cache.set("c", 3)
+		if _, ok := cache.get("b"); ok {
+			t.Fatalf("expected [b] to be evicted")
+		} else if _, ok = cache.get("a"); !ok {
+			t.Fatalf("expected [a] to be found")
+		} else if cache.len() != 2 {
+			t.Fatalf("expected %d entries, got %d", 2, cache.len())
+		}
+	})
+
+	t.Run("update and delete", func(t *testing.T) {
+		cache := newLRUCache(2)
+		cache.set("a", 1)
+		cache.set("a", 2)
+		if value, _ := cache.get("a"); value.(int) != 2 || cache.len() != 1 {
+			t.Fatalf("expected [2] and %d entry, got: %v and %d", 1, value, cache.len())
+		}
+		cache.delete("a")
+		cache.delete("unknown")
+		if _, ok := cache.get("a"); ok || cache.len() != 0 {
+			t.Fatalf("expected [a] to be deleted")
+		}
+	})
+
+	t.Run("resize and no limit", func(t *testing.T) {
+		cache := newLRUCache(0)
+		for i := 0; i < 100; i++ {
+			cache.set(strconv.Itoa(i), i)
+		}
+		if cache.len() != 100 {
+			t.Fatalf("expected %d entries, got %d", 100, cache.len())
+		}
+		cache.resize(10)
+		if _, ok := cache.get("89"); ok || cache.len() != 10 {
+			t.Fatalf("expected %d entries, got %d", 10, cache.len())
+		} else if _, ok = cache.get("90"); !ok {
+			t.Fatalf("expected [90] to be found")
+		}
+	})
+}
+
+// BenchmarkLRUCache benchmarks the lruCache
+func BenchmarkLRUCache(b *testing.B) {
+	cache := newLRUCache(1000)
+	for i := 0; i < b.N; i++ {
+		key := strconv.Itoa(i % 2000)
+		if _, ok := cache.get(key); !ok {
+			cache.set(key, i)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package minercraft
+
+import "net/http"
+
+// RequestHandler is used to fire a HTTP request (IE: the http client or the next middleware in the chain)
+type RequestHandler interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// RequestHandlerFunc is a function that can be used as a RequestHandler
+type RequestHandlerFunc func(req *http.Request) (*http.Response, error)
+
+// Do will fire the request using the function
+func (f RequestHandlerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the next RequestHandler (IE: caching, logging or metrics)
+type Middleware func(next RequestHandler) RequestHandler
+
+// Use will add the middleware to the chain of all requests to the miners
+//
+// Middleware is applied in order (the first middleware is the outermost, later calls to Use() wrap the
+// existing chain) and wraps the http client (including any retries), so a middleware that returns
+// early (IE: a cached response) skips the request
+func (c *Client) Use(middleware ...Middleware) {
+	for i := len(middleware) - 1; i >= 0; i-- {
+		c.httpClient = middleware[i](c.httpClient)
+		c.streamClient = middleware[i](c.streamClient)
+	}
+}
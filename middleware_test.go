@@ -0,0 +1,32 @@
+package minercraft
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestClient_Use tests the method Use()
+func TestClient_Use(t *testing.T) {
+	t.Parallel()
+
+	// recordingMiddleware will record the order of the middleware
+	var calls []string
+	recordingMiddleware := func(name string) Middleware {
+		return func(next RequestHandler) RequestHandler {
+			return RequestHandlerFunc(func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name)
+				return next.Do(req)
+			})
+		}
+	}
+
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	client.Use(recordingMiddleware("first"), recordingMiddleware("second"))
+	client.Use(recordingMiddleware("outer"))
+
+	if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(calls) != 3 || calls[0] != "outer" || calls[1] != "first" || calls[2] != "second" {
+		t.Fatalf("expected middleware order [outer first second] but got: %v", calls)
+	}
+}
@@ -0,0 +1,144 @@
+package minercraft
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+
+	// NetworkMainnet is the network name for mainnet miners (default if not set)
+	NetworkMainnet = "mainnet"
+
+	// NetworkTestnet is the network name for testnet miners
+	NetworkTestnet = "testnet"
+
+	// NetworkSTN is the network name for scaling test network miners
+	NetworkSTN = "stn"
+)
+
+// MinerSlice is a list of miners with helper methods for filtering and sorting
+type MinerSlice []*Miner
+
+// Filter will return a new slice of all miners that match the given function
+func (m MinerSlice) Filter(fn func(miner *Miner) bool) MinerSlice {
+	filtered := make(MinerSlice, 0, len(m))
+	for _, miner := range m {
+		if fn(miner) {
+			filtered = append(filtered, miner)
+		}
+	}
+	return filtered
+}
+
+// WithToken will return all miners that have an auth token set
+func (m MinerSlice) WithToken() MinerSlice {
+	return m.Filter(func(miner *Miner) bool {
+		return len(miner.Token) > 0
+	})
+}
+
+// WithoutToken will return all miners that do not have an auth token set
+func (m MinerSlice) WithoutToken() MinerSlice {
+	return m.Filter(func(miner *Miner) bool {
+		return len(miner.Token) == 0
+	})
+}
+
+// ByNetwork will return all miners on the given network (miners without a network are mainnet)
+func (m MinerSlice) ByNetwork(network string) MinerSlice {
+	return m.Filter(func(miner *Miner) bool {
+		return strings.EqualFold(miner.GetNetwork(), network)
+	})
+}
+
+// ByScheme will return all miners using the given url scheme (miners without a scheme use https)
+func (m MinerSlice) ByScheme(scheme string) MinerSlice {
+	return m.Filter(func(miner *Miner) bool {
+		return strings.EqualFold(miner.GetScheme(), scheme)
+	})
+}
+
+// Names will return the names of all miners
+func (m MinerSlice) Names() []string {
+	names := make([]string, 0, len(m))
+	for _, miner := range m {
+		names = append(names, miner.Name)
+	}
+	return names
+}
+
+// Map will apply the function to a copy of each miner and return the modified miners
+//
+// The original miners are not modified
+func (m MinerSlice) Map(fn func(miner *Miner)) MinerSlice {
+	mapped := make(MinerSlice, 0, len(m))
+	for _, miner := range m {
+		minerCopy := *miner
+		fn(&minerCopy)
+		mapped = append(mapped, &minerCopy)
+	}
+	return mapped
+}
+
+// SortBy will return a new slice sorted by the given less function (stable sort)
+func (m MinerSlice) SortBy(less func(a, b *Miner) bool) MinerSlice {
+	sorted := make(MinerSlice, len(m))
+	copy(sorted, m)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// SortByLatency will return a new slice sorted by the given latencies (fastest first)
+//
+// Latencies are keyed by miner name, miners without a latency are sorted last
+func (m MinerSlice) SortByLatency(latencies map[string]time.Duration) MinerSlice {
+	return m.SortBy(func(a, b *Miner) bool {
+		latencyA, okA := latencies[a.Name]
+		latencyB, okB := latencies[b.Name]
+		if okA && okB {
+			return latencyA < latencyB
+		}
+		return okA
+	})
+}
+
+// SortByFee will return a new slice sorted by the rate in the given quotes (cheapest first)
+//
+// Rates are compared using a 1000 byte tx, miners without a (valid) quote are sorted last
+func (m MinerSlice) SortByFee(quotes []*FeeQuoteResponse, feeCategory, feeType string) MinerSlice {
+
+	// Calculate the rate for each miner
+	rates := make(map[string]uint64, len(quotes))
+	for _, quote := range quotes {
+		if quote == nil || quote.Miner == nil || quote.Quote == nil {
+			continue
+		}
+		if rate, err := quote.Quote.CalculateFee(feeCategory, feeType, 1000); err == nil {
+			rates[quote.Miner.Name] = rate
+		}
+	}
+
+	return m.SortBy(func(a, b *Miner) bool {
+		rateA, okA := rates[a.Name]
+		rateB, okB := rates[b.Name]
+		if okA && okB {
+			return rateA < rateB
+		}
+		return okA
+	})
+}
+
+// PermittingTx will return all miners whose policies accept a transaction of the given size (see: Policies.CheckTx),
+// avoiding guaranteed rejections (IE: a large data transaction and the datacarriersize of a miner)
+//
+// Policies are keyed by miner name (see: PolicyComparison.PoliciesByMiner), miners without policies are kept
+func (m MinerSlice) PermittingTx(policies map[string]*Policies, txSize *TxSize) MinerSlice {
+	return m.Filter(func(miner *Miner) bool {
+		policy, ok := policies[miner.Name]
+		return !ok || policy == nil || policy.PermitsTx(txSize)
+	})
+}
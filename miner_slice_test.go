@@ -0,0 +1,189 @@
+package minercraft
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// testMinerSlice returns a list of miners for testing
+func testMinerSlice() MinerSlice {
+	return MinerSlice{
+		{Name: MinerTaal, URL: "merchantapi.taal.com"},
+		{Name: MinerMempool, Token: testMinerToken, URL: "www.ddpurse.com/openapi"},
+		{Name: testMinerName, Network: NetworkTestnet, URL: "testminer.com"},
+	}
+}
+
+// TestMinerSlice_Filter tests the method Filter()
+func TestMinerSlice_Filter(t *testing.T) {
+	t.Parallel()
+
+	miners := testMinerSlice()
+
+	filtered := miners.Filter(func(miner *Miner) bool {
+		return miner.Name == MinerTaal
+	})
+	if len(filtered) != 1 || filtered[0].Name != MinerTaal {
+		t.Fatalf("expected only %s, got: %v", MinerTaal, filtered.Names())
+	}
+
+	// Original slice is untouched
+	if len(miners) != 3 {
+		t.Fatalf("expected original slice to have %d miners, got %d", 3, len(miners))
+	}
+}
+
+// TestMinerSlice_WithToken tests the method WithToken() and WithoutToken()
+func TestMinerSlice_WithToken(t *testing.T) {
+	t.Parallel()
+
+	miners := testMinerSlice()
+
+	if withToken := miners.WithToken(); len(withToken) != 1 || withToken[0].Name != MinerMempool {
+		t.Fatalf("expected only %s, got: %v", MinerMempool, withToken.Names())
+	}
+
+	if withoutToken := miners.WithoutToken(); len(withoutToken) != 2 {
+		t.Fatalf("expected %d miners, got: %v", 2, withoutToken.Names())
+	}
+}
+
+// TestMinerSlice_ByNetwork tests the method ByNetwork()
+func TestMinerSlice_ByNetwork(t *testing.T) {
+	t.Parallel()
+
+	miners := testMinerSlice()
+
+	if mainnet := miners.ByNetwork(NetworkMainnet); len(mainnet) != 2 {
+		t.Fatalf("expected %d mainnet miners, got: %v", 2, mainnet.Names())
+	}
+
+	if testnet := miners.ByNetwork(NetworkTestnet); len(testnet) != 1 || testnet[0].Name != testMinerName {
+		t.Fatalf("expected only %s, got: %v", testMinerName, testnet.Names())
+	}
+
+	if stn := miners.ByNetwork(NetworkSTN); len(stn) != 0 {
+		t.Fatalf("expected no stn miners, got: %v", stn.Names())
+	}
+}
+
+// ExampleMinerSlice_ByNetwork example using ByNetwork()
+func ExampleMinerSlice_ByNetwork() {
+	client, err := NewClient(nil, nil)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("found %d mainnet miners", len(client.Miners.ByNetwork(NetworkMainnet)))
+	// Output:found 3 mainnet miners
+}
+
+// TestMinerSlice_ByScheme tests the method ByScheme()
+func TestMinerSlice_ByScheme(t *testing.T) {
+	t.Parallel()
+
+	miners := append(testMinerSlice(), &Miner{Name: "Local", Scheme: SchemeHTTP, URL: "localhost:9004"})
+
+	if secure := miners.ByScheme(SchemeHTTPS); len(secure) != len(miners)-1 {
+		t.Fatalf("expected %d https miners, got: %v", len(miners)-1, secure.Names())
+	}
+
+	if insecure := miners.ByScheme("HTTP"); len(insecure) != 1 || insecure[0].Name != "Local" {
+		t.Fatalf("expected only %s, got: %v", "Local", insecure.Names())
+	}
+}
+
+// TestMinerSlice_Map tests the method Map()
+func TestMinerSlice_Map(t *testing.T) {
+	t.Parallel()
+
+	miners := testMinerSlice()
+
+	mapped := miners.Map(func(miner *Miner) {
+		miner.Token = "9999"
+	})
+	if len(mapped.WithToken()) != 3 {
+		t.Fatalf("expected all miners to have a token, got: %v", mapped.WithToken().Names())
+	}
+
+	// Original miners are untouched
+	if len(miners.WithToken()) != 1 {
+		t.Fatalf("expected original miners to be untouched")
+	}
+}
+
+// TestMinerSlice_SortByLatency tests the method SortByLatency()
+func TestMinerSlice_SortByLatency(t *testing.T) {
+	t.Parallel()
+
+	sorted := testMinerSlice().SortByLatency(map[string]time.Duration{
+		MinerMempool:  30 * time.Millisecond,
+		testMinerName: 10 * time.Millisecond,
+	})
+
+	expected := []string{testMinerName, MinerMempool, MinerTaal}
+	for index, name := range sorted.Names() {
+		if name != expected[index] {
+			t.Fatalf("expected order %v, got: %v", expected, sorted.Names())
+		}
+	}
+}
+
+// TestMinerSlice_SortByFee tests the method SortByFee()
+func TestMinerSlice_SortByFee(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidBestQuote{})
+
+	// Get all the quotes
+	var quotes []*FeeQuoteResponse
+	for _, miner := range client.Miners {
+		quote, err := client.FeeQuote(miner)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		quotes = append(quotes, quote)
+	}
+
+	// Only include two quotes (one miner is missing a quote)
+	sorted := client.Miners.SortByFee(quotes[1:], FeeCategoryMining, FeeTypeData)
+
+	expected := []string{MinerMempool, MinerMatterpool, MinerTaal}
+	for index, name := range sorted.Names() {
+		if name != expected[index] {
+			t.Fatalf("expected order %v, got: %v", expected, sorted.Names())
+		}
+	}
+}
+
+// BenchmarkMinerSlice_Filter benchmarks the method Filter()
+func BenchmarkMinerSlice_Filter(b *testing.B) {
+	miners := testMinerSlice()
+	for i := 0; i < b.N; i++ {
+		_ = miners.WithToken()
+	}
+}
+
+// TestMinerSlice_PermittingTx tests the method PermittingTx()
+func TestMinerSlice_PermittingTx(t *testing.T) {
+	t.Parallel()
+
+	miners := testMinerSlice()
+	policies := map[string]*Policies{
+		MinerTaal:    {DataCarrierSize: 100000, MaxTxSizePolicy: 1000000},
+		MinerMempool: {DataCarrierSize: 1000, MaxTxSizePolicy: 1000000},
+	}
+
+	// A 90KB data tx (the test miner has no policies and is kept)
+	permitted := miners.PermittingTx(policies, &TxSize{DataBytes: 90000, StandardBytes: 200})
+	if len(permitted) != 2 || permitted[0].Name != MinerTaal || permitted[1].Name != testMinerName {
+		t.Fatalf("expected %s and %s, got: %v", MinerTaal, testMinerName, permitted.Names())
+	}
+
+	// A small data tx
+	if permitted = miners.PermittingTx(policies, &TxSize{DataBytes: 80, StandardBytes: 200}); len(permitted) != 3 {
+		t.Fatalf("expected all miners, got: %v", permitted.Names())
+	}
+}
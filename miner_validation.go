@@ -0,0 +1,153 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Miner validation errors (use errors.Is() to check the reason of a MinerValidationError)
+var (
+	ErrDuplicateMinerID       = errors.New("miner id already exists")
+	ErrDuplicateMinerName     = errors.New("miner name already exists")
+	ErrDuplicateMinerURL      = errors.New("miner url already exists")
+	ErrInvalidAllowedIP       = errors.New("invalid miner allowed ip")
+	ErrInvalidConnectAddress  = errors.New("invalid miner connect address")
+	ErrInvalidMinerURL        = errors.New("invalid miner url")
+	ErrInvalidStatusURL       = errors.New("invalid miner status url")
+	ErrMissingMinerName       = errors.New("missing miner name")
+	ErrMissingMinerURL        = errors.New("missing miner url")
+	ErrNilMiner               = errors.New("miner was nil")
+	ErrUnsupportedCompression = errors.New("unsupported miner compression")
+	ErrUnsupportedScheme      = errors.New("unsupported miner url scheme")
+)
+
+// MinerValidationError is returned when a miner configuration is invalid
+type MinerValidationError struct {
+	Miner  string // Name of the miner (if known)
+	Reason error  // One of the miner validation errors (IE: ErrInvalidMinerURL)
+	Detail string // Additional detail about the failure (IE: the offending value)
+}
+
+// Error will return the error message
+func (e *MinerValidationError) Error() string {
+	message := e.Reason.Error()
+	if len(e.Detail) > 0 {
+		message += ": " + e.Detail
+	}
+	if len(e.Miner) > 0 {
+		message = "miner " + e.Miner + ": " + message
+	}
+	return message
+}
+
+// Unwrap will return the reason (for use with errors.Is())
+func (e *MinerValidationError) Unwrap() error {
+	return e.Reason
+}
+
+// ValidateMiner will validate the miner's name and url (syntax and scheme)
+func ValidateMiner(miner *Miner) error {
+	if miner == nil {
+		return &MinerValidationError{Reason: ErrNilMiner}
+	} else if len(strings.TrimSpace(miner.Name)) == 0 {
+		return &MinerValidationError{Reason: ErrMissingMinerName}
+	} else if len(strings.TrimSpace(miner.URL)) == 0 {
+		return &MinerValidationError{Miner: miner.Name, Reason: ErrMissingMinerURL}
+	} else if len(miner.Compression) > 0 && !strings.EqualFold(miner.Compression, CompressionGzip) {
+		return &MinerValidationError{Miner: miner.Name, Reason: ErrUnsupportedCompression, Detail: miner.Compression}
+	} else if len(miner.ConnectAddress) > 0 && !validConnectAddress(miner.ConnectAddress) {
+		return &MinerValidationError{Miner: miner.Name, Reason: ErrInvalidConnectAddress, Detail: miner.ConnectAddress}
+	} else if len(miner.StatusURL) > 0 && !validStatusURL(miner.StatusURL) {
+		return &MinerValidationError{Miner: miner.Name, Reason: ErrInvalidStatusURL, Detail: miner.StatusURL}
+	}
+	for _, allowed := range miner.AllowedIPs {
+		if _, err := parseAllowedIP(allowed); err != nil {
+			return &MinerValidationError{Miner: miner.Name, Reason: ErrInvalidAllowedIP, Detail: allowed}
+		}
+	}
+	_, err := normalizeMinerURL(miner)
+	return err
+}
+
+// parseMinerURL will validate and parse the miner url (using the miner's scheme, or https if not set)
+func parseMinerURL(miner *Miner) (*url.URL, error) {
+
+	// Add the scheme if missing (urls are stored without a scheme)
+	rawURL := strings.TrimSpace(miner.URL)
+	if !strings.Contains(rawURL, "://") {
+		rawURL = miner.GetScheme() + "://" + rawURL
+	}
+
+	// Parse the url
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, &MinerValidationError{Miner: miner.Name, Reason: ErrInvalidMinerURL, Detail: miner.URL}
+	}
+
+	// The miner's scheme overrides any scheme in the url
+	if len(miner.Scheme) > 0 {
+		u.Scheme = strings.ToLower(miner.Scheme)
+	}
+
+	// Check the scheme and host
+	if u.Scheme != SchemeHTTPS && u.Scheme != SchemeHTTP {
+		return nil, &MinerValidationError{Miner: miner.Name, Reason: ErrUnsupportedScheme, Detail: u.Scheme}
+	} else if len(u.Hostname()) == 0 || strings.ContainsAny(u.Host, " \t") ||
+		len(u.RawQuery) > 0 || len(u.Fragment) > 0 || len(u.User.String()) > 0 {
+		return nil, &MinerValidationError{Miner: miner.Name, Reason: ErrInvalidMinerURL, Detail: miner.URL}
+	}
+
+	return u, nil
+}
+
+// normalizeMinerURL will validate the miner url and return it without the scheme or trailing slash
+func normalizeMinerURL(miner *Miner) (string, error) {
+	u, err := parseMinerURL(miner)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(u.Host+u.EscapedPath(), "/"), nil
+}
+
+// validateMinerConfig will validate all the configured miners (syntax and duplicate names/urls)
+//
+// Unlike AddMiner(), duplicate miner ids are allowed: a registry can list several endpoints for the
+// same miner (IE: the embedded registry has Taal and Mempool sharing a miner id), while AddMiner()
+// rejects them to avoid adding the same miner twice at runtime
+func validateMinerConfig(miners []*Miner) error {
+	names := make(map[string]bool, len(miners))
+	urls := make(map[string]bool, len(miners))
+	for _, miner := range miners {
+		if err := ValidateMiner(miner); err != nil {
+			return err
+		}
+		normalizedURL, _ := normalizeMinerURL(miner)
+		name := strings.ToLower(miner.Name)
+		normalizedURL = strings.ToLower(normalizedURL)
+		if names[name] {
+			return &MinerValidationError{Miner: miner.Name, Reason: ErrDuplicateMinerName}
+		} else if urls[normalizedURL] {
+			return &MinerValidationError{Miner: miner.Name, Reason: ErrDuplicateMinerURL, Detail: miner.URL}
+		}
+		names[name] = true
+		urls[normalizedURL] = true
+	}
+	return nil
+}
+
+// minerByURL will return a miner given a (normalized) url
+func (c *Client) minerByURL(normalizedURL string) *Miner {
+	for index, miner := range c.Miners {
+		if existing, err := normalizeMinerURL(miner); err == nil && strings.EqualFold(existing, normalizedURL) {
+			return c.Miners[index]
+		}
+	}
+	return nil
+}
+
+// newDuplicateError will return a validation error for a duplicate value
+func newDuplicateError(miner *Miner, reason error, value string) error {
+	return &MinerValidationError{Miner: miner.Name, Reason: reason, Detail: fmt.Sprintf("%q", value)}
+}
@@ -0,0 +1,172 @@
+package minercrafttest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/bitcoinschema/go-bitcoin"
+	"github.com/tonicpow/go-minercraft"
+)
+
+// FixtureTime is the timestamp of the fixtures that have no timestamp (fixtures are reproducible)
+var FixtureTime = time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// FeeQuote is the payload of a fee quote fixture (see: Fixtures.FeeQuote)
+type FeeQuote struct {
+	APIVersion  string    // Defaults to the api version of the mock miner
+	BlockHash   string    // Current highest block hash
+	BlockHeight uint64    // Current highest block height
+	ExpiryTime  time.Time // Defaults to 10 minutes after the timestamp (IE: set in the past for an expired quote)
+	Fees        []*Fee    // Defaults to DefaultFees
+	MinerID     string    // Defaults to the public key of the fixtures
+	Timestamp   time.Time // Defaults to FixtureTime
+}
+
+// Fixtures generates signed envelopes (fee quotes, query results, submit results and callbacks) with a key,
+// the envelopes of the same payloads and key are identical (deterministic signatures, RFC 6979)
+type Fixtures struct {
+	PrivateKey string // Signing key (hex)
+	PublicKey  string // Public key of the signing key (the minerId)
+}
+
+// NewFixtures will return the fixtures of the private key (hex), a new key is generated if not set
+func NewFixtures(privateKey string) (*Fixtures, error) {
+	var err error
+	if len(privateKey) == 0 {
+		if privateKey, err = bitcoin.CreatePrivateKeyString(); err != nil {
+			return nil, err
+		}
+	}
+	f := &Fixtures{PrivateKey: privateKey}
+	if f.PublicKey, err = bitcoin.PubKeyFromPrivateKeyString(privateKey); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Sign will return the DER signature (hex) of the payload
+func (f *Fixtures) Sign(payload []byte) (string, error) {
+	privateKey, err := bitcoin.PrivateKeyFromString(f.PrivateKey)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(payload)
+	signature, err := privateKey.Sign(hash[:])
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(signature.Serialize()), nil
+}
+
+// Envelope will return the signed mAPI envelope of the payload (marshalled as JSON)
+func (f *Fixtures) Envelope(payload interface{}) ([]byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	var signature string
+	if signature, err = f.Sign(data); err != nil {
+		return nil, err
+	}
+	return json.Marshal(map[string]string{
+		"encoding":  "UTF-8",
+		"mimetype":  "application/json",
+		"payload":   string(data),
+		"publicKey": f.PublicKey,
+		"signature": signature,
+	})
+}
+
+// FeeQuote will return the signed envelope of a fee quote
+func (f *Fixtures) FeeQuote(quote *FeeQuote) ([]byte, error) {
+	q := *quote
+	if len(q.APIVersion) == 0 {
+		q.APIVersion = mockAPIVersion
+	}
+	if len(q.Fees) == 0 {
+		q.Fees = DefaultFees
+	}
+	if len(q.MinerID) == 0 {
+		q.MinerID = f.PublicKey
+	}
+	if q.Timestamp.IsZero() {
+		q.Timestamp = FixtureTime
+	}
+	if q.ExpiryTime.IsZero() {
+		q.ExpiryTime = q.Timestamp.Add(10 * time.Minute)
+	}
+
+	// The fees in the mAPI format
+	type feeAmount struct {
+		Bytes    uint64 `json:"bytes"`
+		Satoshis uint64 `json:"satoshis"`
+	}
+	type fee struct {
+		FeeType   string     `json:"feeType"`
+		MiningFee *feeAmount `json:"miningFee"`
+		RelayFee  *feeAmount `json:"relayFee"`
+	}
+	fees := make([]*fee, 0, len(q.Fees))
+	for _, quoted := range q.Fees {
+		fees = append(fees, &fee{
+			FeeType:   quoted.FeeType,
+			MiningFee: &feeAmount{Bytes: 1000, Satoshis: quoted.MiningFee},
+			RelayFee:  &feeAmount{Bytes: 1000, Satoshis: quoted.RelayFee},
+		})
+	}
+	return f.Envelope(&struct {
+		APIVersion                string      `json:"apiVersion"`
+		Timestamp                 string      `json:"timestamp"`
+		ExpiryTime                string      `json:"expiryTime"`
+		MinerID                   string      `json:"minerId"`
+		CurrentHighestBlockHash   string      `json:"currentHighestBlockHash"`
+		CurrentHighestBlockHeight uint64      `json:"currentHighestBlockHeight"`
+		MinerReputation           interface{} `json:"minerReputation"`
+		Fees                      []*fee      `json:"fees"`
+	}{
+		APIVersion:                q.APIVersion,
+		Timestamp:                 q.Timestamp.UTC().Format(time.RFC3339Nano),
+		ExpiryTime:                q.ExpiryTime.UTC().Format(time.RFC3339Nano),
+		MinerID:                   q.MinerID,
+		CurrentHighestBlockHash:   q.BlockHash,
+		CurrentHighestBlockHeight: q.BlockHeight,
+		Fees:                      fees,
+	})
+}
+
+// QueryResult will return the signed envelope of a query result
+func (f *Fixtures) QueryResult(result *minercraft.QueryPayload) ([]byte, error) {
+	r := *result
+	r.APIVersion, r.MinerID, r.Timestamp = f.defaults(r.APIVersion, r.MinerID, r.Timestamp)
+	return f.Envelope(&r)
+}
+
+// SubmitResult will return the signed envelope of a submit result
+func (f *Fixtures) SubmitResult(result *minercraft.SubmissionPayload) ([]byte, error) {
+	r := *result
+	r.APIVersion, r.MinerID, r.Timestamp = f.defaults(r.APIVersion, r.MinerID, r.Timestamp)
+	return f.Envelope(&r)
+}
+
+// Callback will return the signed envelope of a callback (IE: a merkle proof)
+func (f *Fixtures) Callback(callback *minercraft.CallbackPayload) ([]byte, error) {
+	c := *callback
+	c.APIVersion, c.MinerID, c.Timestamp = f.defaults(c.APIVersion, c.MinerID, c.Timestamp)
+	return f.Envelope(&c)
+}
+
+// defaults will return the api version, miner id and timestamp of a payload (the defaults if not set)
+func (f *Fixtures) defaults(apiVersion, minerID, timestamp string) (string, string, string) {
+	if len(apiVersion) == 0 {
+		apiVersion = mockAPIVersion
+	}
+	if len(minerID) == 0 {
+		minerID = f.PublicKey
+	}
+	if len(timestamp) == 0 {
+		timestamp = FixtureTime.Format(time.RFC3339Nano)
+	}
+	return apiVersion, minerID, timestamp
+}
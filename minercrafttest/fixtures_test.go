@@ -0,0 +1,93 @@
+package minercrafttest
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/tonicpow/go-minercraft"
+)
+
+// testPrivateKey is the signing key of the fixtures
+const testPrivateKey = "e83385af76b2b1997326b567461fb73dd9c27eab9e1e86d26779f4650c5f2b75"
+
+// TestFixtures_SubmitResult tests the method SubmitResult()
+func TestFixtures_SubmitResult(t *testing.T) {
+	t.Parallel()
+
+	result := &minercraft.SubmissionPayload{ReturnResult: minercraft.ReturnResultSuccess, TxID: "abc"}
+	var envelopes [][]byte
+	for i := 0; i < 2; i++ {
+		fixtures, err := NewFixtures(testPrivateKey)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		var envelope []byte
+		if envelope, err = fixtures.SubmitResult(result); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		envelopes = append(envelopes, envelope)
+	}
+	if !bytes.Equal(envelopes[0], envelopes[1]) {
+		t.Fatalf("expected identical envelopes, got:\n%s\n%s", envelopes[0], envelopes[1])
+	} else if len(result.MinerID) > 0 {
+		t.Fatalf("expected the payload to be unchanged")
+	}
+}
+
+// TestFixtures_FeeQuote tests the method FeeQuote()
+func TestFixtures_FeeQuote(t *testing.T) {
+	t.Parallel()
+
+	fixtures, _ := NewFixtures(testPrivateKey)
+	expired, err := fixtures.FeeQuote(&FeeQuote{ExpiryTime: time.Now().Add(-time.Minute)})
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(expired)
+	}))
+	defer server.Close()
+
+	client, _ := minercraft.NewClient(nil, nil)
+	u, _ := url.Parse(server.URL)
+	var quote *minercraft.FeeQuoteResponse
+	if quote, err = client.FeeQuote(&minercraft.Miner{
+		MinerID: fixtures.PublicKey, Name: "Fixture", Scheme: minercraft.SchemeHTTP, URL: u.Host,
+	}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if !quote.Validated || !quote.Quote.IsExpired() {
+		t.Fatalf("expected a signed expired quote, got: %v %s", quote.Validated, quote.Quote.ExpirationTime)
+	}
+}
+
+// TestFixtures_Callback tests the method Callback()
+func TestFixtures_Callback(t *testing.T) {
+	t.Parallel()
+
+	fixtures, _ := NewFixtures(testPrivateKey)
+	body, err := fixtures.Callback(&minercraft.CallbackPayload{
+		BlockHeight:     5,
+		CallbackPayload: "{}",
+		CallbackReason:  minercraft.CallbackReasonMerkleProof,
+		CallbackTxID:    "6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0",
+	})
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Callbacks are only trusted from a known miner
+	client, _ := minercraft.NewClient(nil, nil)
+	if err = client.AddMiner(minercraft.Miner{MinerID: fixtures.PublicKey, Name: "Fixtures", URL: "https://fixtures.example.com"}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var callback *minercraft.CallbackResponse
+	if callback, err = client.ParseCallback(body); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if !callback.Validated || callback.Callback.MinerID != fixtures.PublicKey {
+		t.Fatalf("expected a signed callback of %s", fixtures.PublicKey)
+	}
+}
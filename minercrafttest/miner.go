@@ -0,0 +1,229 @@
+// Package minercrafttest provides a mock miner and signed fixtures for tests of minercraft consumers
+//
+// The mock miner is a local mAPI server that signs its responses with an ephemeral key (like a real miner),
+// so the signature validation of the client is exercised end-to-end. The fixtures are signed envelopes
+// generated from payloads with a supplied key (IE: golden files of edge cases like expired quotes)
+package minercrafttest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/libsv/libsv/transaction"
+	"github.com/tonicpow/go-minercraft"
+)
+
+// Fee is a fee of the mock miner (satoshis per 1000 bytes)
+type Fee struct {
+	FeeType   string `json:"feeType"` // IE: minercraft.FeeTypeStandard
+	MiningFee uint64 `json:"miningFee"`
+	RelayFee  uint64 `json:"relayFee"`
+}
+
+// TxStatus is the status of a transaction returned by the mock miner (see: Miner.SetStatus)
+type TxStatus struct {
+	BlockHash         string `json:"blockHash"`
+	BlockHeight       int64  `json:"blockHeight"`
+	Confirmations     int64  `json:"confirmations"`
+	ResultDescription string `json:"resultDescription"`
+	ReturnResult      string `json:"returnResult"` // IE: minercraft.ReturnResultSuccess
+}
+
+// MinerOptions are the options of the mock miner
+type MinerOptions struct {
+	BlockHeight       int64         // Current highest block height (defaults to DefaultBlockHeight)
+	Fees              []*Fee        // Quoted fees (defaults to DefaultFees)
+	PrivateKey        string        // Signing key (hex, an ephemeral key is generated if not set)
+	QuoteExpiry       time.Duration // Expiry of the quotes (defaults to 10 minutes, negative for expired quotes)
+	RejectSubmissions string        // Rejects all submissions with the description (if set)
+}
+
+// Defaults of the mock miner
+const (
+	DefaultBlockHeight = 700000
+	mockAPIVersion     = "1.4.0"
+	mockBlockHash      = "0000000000000000045b4a4d6d7e3b0b2b6a0a36d0e3a36f6f1bc1c07ea8ed41"
+)
+
+// DefaultFees are the fees of the mock miner (if not set)
+var DefaultFees = []*Fee{
+	{FeeType: minercraft.FeeTypeStandard, MiningFee: 500, RelayFee: 250},
+	{FeeType: minercraft.FeeTypeData, MiningFee: 500, RelayFee: 250},
+}
+
+// Miner is a mock miner (see: NewMiner), close it after the test
+type Miner struct {
+	*Fixtures                  // Signs the responses (the public key is the minerId)
+	Server    *httptest.Server // The mAPI server
+
+	mu        sync.Mutex
+	options   MinerOptions
+	statuses  map[string]*TxStatus
+	submitted []string
+}
+
+// NewMiner will start a new mock miner (with an ephemeral signing key if the key is not set)
+func NewMiner(options *MinerOptions) (*Miner, error) {
+	m := &Miner{statuses: make(map[string]*TxStatus)}
+	if options != nil {
+		m.options = *options
+	}
+	var err error
+	if m.Fixtures, err = NewFixtures(m.options.PrivateKey); err != nil {
+		return nil, err
+	}
+	if m.options.BlockHeight == 0 {
+		m.options.BlockHeight = DefaultBlockHeight
+	}
+	if len(m.options.Fees) == 0 {
+		m.options.Fees = DefaultFees
+	}
+	if m.options.QuoteExpiry == 0 {
+		m.options.QuoteExpiry = 10 * time.Minute
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/mapi/feeQuote", m.feeQuote)
+	mux.HandleFunc("/mapi/tx", m.submitTransaction)
+	mux.HandleFunc("/mapi/tx/", m.queryTransaction)
+	m.Server = httptest.NewServer(mux)
+	return m, nil
+}
+
+// Close will stop the mock miner
+func (m *Miner) Close() {
+	m.Server.Close()
+}
+
+// Miner will return the mock miner as a minercraft miner (the minerId is pinned to the signing key)
+func (m *Miner) Miner(name string) *minercraft.Miner {
+	u, _ := url.Parse(m.Server.URL)
+	return &minercraft.Miner{
+		MinerID: m.PublicKey,
+		Name:    name,
+		Scheme:  minercraft.SchemeHTTP,
+		URL:     u.Host,
+	}
+}
+
+// SetFees will change the quoted fees
+func (m *Miner) SetFees(fees ...*Fee) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.options.Fees = fees
+}
+
+// SetStatus will set the status of a transaction (IE: mined)
+func (m *Miner) SetStatus(txID string, status *TxStatus) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.statuses[txID] = status
+}
+
+// Submitted will return the txids of the accepted submissions
+func (m *Miner) Submitted() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]string(nil), m.submitted...)
+}
+
+// feeQuote will respond with the quoted fees
+func (m *Miner) feeQuote(w http.ResponseWriter, _ *http.Request) {
+	m.mu.Lock()
+	now := time.Now().UTC()
+	quote := &FeeQuote{
+		BlockHash:   mockBlockHash,
+		BlockHeight: uint64(m.options.BlockHeight),
+		ExpiryTime:  now.Add(m.options.QuoteExpiry),
+		Fees:        m.options.Fees,
+		Timestamp:   now,
+	}
+	m.mu.Unlock()
+	m.respond(w)(m.FeeQuote(quote))
+}
+
+// submitTransaction will accept (or reject) a submission
+func (m *Miner) submitTransaction(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	var tx minercraft.Transaction
+	if err = json.Unmarshal(body, &tx); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	// Accept the transaction (the status is mempool until set)
+	result := &minercraft.SubmissionPayload{
+		CurrentHighestBlockHash:   mockBlockHash,
+		CurrentHighestBlockHeight: m.options.BlockHeight,
+		Timestamp:                 m.timestamp(),
+	}
+	parsed, err := transaction.NewFromString(tx.RawTx)
+	m.mu.Lock()
+	switch {
+	case err != nil:
+		result.ReturnResult, result.ResultDescription = "failure", "invalid transaction"
+	case len(m.options.RejectSubmissions) > 0:
+		result.ReturnResult, result.ResultDescription = "failure", m.options.RejectSubmissions
+	default:
+		result.ReturnResult, result.TxID = minercraft.ReturnResultSuccess, parsed.GetTxID()
+		if _, ok := m.statuses[result.TxID]; !ok {
+			m.statuses[result.TxID] = &TxStatus{ReturnResult: minercraft.ReturnResultSuccess}
+		}
+		m.submitted = append(m.submitted, result.TxID)
+	}
+	m.mu.Unlock()
+	m.respond(w)(m.SubmitResult(result))
+}
+
+// queryTransaction will respond with the status of a transaction
+func (m *Miner) queryTransaction(w http.ResponseWriter, req *http.Request) {
+	txID := strings.TrimPrefix(req.URL.Path, "/mapi/tx/")
+
+	m.mu.Lock()
+	status, ok := m.statuses[txID]
+	if !ok {
+		status = &TxStatus{ResultDescription: "No such mempool or blockchain transaction", ReturnResult: "failure"}
+	}
+	result := &minercraft.QueryPayload{
+		BlockHash:         status.BlockHash,
+		BlockHeight:       status.BlockHeight,
+		Confirmations:     status.Confirmations,
+		ResultDescription: status.ResultDescription,
+		ReturnResult:      status.ReturnResult,
+		Timestamp:         m.timestamp(),
+		TxID:              txID,
+	}
+	m.mu.Unlock()
+	m.respond(w)(m.QueryResult(result))
+}
+
+// timestamp will return the timestamp of a response
+func (m *Miner) timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339Nano)
+}
+
+// respond will return a writer of a signed envelope (see: Fixtures)
+func (m *Miner) respond(w http.ResponseWriter) func(envelope []byte, err error) {
+	return func(envelope []byte, err error) {
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(envelope)
+	}
+}
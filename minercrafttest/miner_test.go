@@ -0,0 +1,81 @@
+package minercrafttest
+
+import (
+	"testing"
+
+	"github.com/tonicpow/go-minercraft"
+)
+
+// testRawTx is a valid raw transaction
+const testRawTx = "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff1c03d7c6082f7376706f6f6c2e636f6d2f3edff034600055b8467f0040ffffffff01247e814a000000001976a914492558fb8ca71a3591316d095afc0f20ef7d42f788ac00000000"
+
+// newTestClient will return a client with the mock miner
+func newTestClient(t *testing.T, options *MinerOptions) (*minercraft.Client, *Miner) {
+	mock, err := NewMiner(options)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	t.Cleanup(mock.Close)
+
+	var client *minercraft.Client
+	if client, err = minercraft.NewClient(nil, nil); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	client.Miners = minercraft.MinerSlice{mock.Miner("Mock")}
+	return client, mock
+}
+
+// TestNewMiner tests the method NewMiner()
+func TestNewMiner(t *testing.T) {
+	t.Parallel()
+
+	t.Run("signed quotes", func(t *testing.T) {
+		client, mock := newTestClient(t, &MinerOptions{Fees: []*Fee{{FeeType: minercraft.FeeTypeStandard, MiningFee: 50}}})
+		quote, err := client.FeeQuote(client.Miners[0])
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !quote.Validated || !quote.Trusted || quote.PublicKey != mock.PublicKey {
+			t.Fatalf("expected a trusted signature, got: %v %v", quote.Validated, quote.Trusted)
+		}
+
+		var fee uint64
+		if fee, err = quote.Quote.CalculateFee(minercraft.FeeCategoryMining, minercraft.FeeTypeStandard, 1000); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if fee != 50 {
+			t.Fatalf("expected a fee of 50, got: %d", fee)
+		}
+	})
+
+	t.Run("submitted and mined", func(t *testing.T) {
+		client, mock := newTestClient(t, nil)
+		submission, err := client.SubmitTransaction(client.Miners[0], &minercraft.Transaction{RawTx: testRawTx})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !submission.Validated || submission.Results.ReturnResult != minercraft.ReturnResultSuccess {
+			t.Fatalf("expected a signed success, got: %+v", submission.Results)
+		} else if submitted := mock.Submitted(); len(submitted) != 1 || submitted[0] != submission.Results.TxID {
+			t.Fatalf("expected the submission, got: %v", submitted)
+		}
+
+		txID := submission.Results.TxID
+		mock.SetStatus(txID, &TxStatus{
+			BlockHash: mockBlockHash, BlockHeight: 700001, Confirmations: 1, ReturnResult: minercraft.ReturnResultSuccess,
+		})
+		var query *minercraft.QueryTransactionResponse
+		if query, err = client.QueryTransaction(client.Miners[0], txID); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !query.Validated || query.Query.BlockHeight != 700001 {
+			t.Fatalf("expected a signed mined status, got: %+v", query.Query)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		client, mock := newTestClient(t, &MinerOptions{RejectSubmissions: "Not enough fees"})
+		submission, err := client.SubmitTransaction(client.Miners[0], &minercraft.Transaction{RawTx: testRawTx})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if submission.Results.ReturnResult == minercraft.ReturnResultSuccess || len(mock.Submitted()) != 0 {
+			t.Fatalf("expected a rejection, got: %+v", submission.Results)
+		}
+	})
+}
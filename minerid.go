@@ -0,0 +1,255 @@
+package minercraft
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// ErrInvalidMinerIDRotation is returned when a minerId rotation is not signed by the previous key
+var ErrInvalidMinerIDRotation = errors.New("invalid minerId rotation")
+
+// ErrUnexpectedMinerID is returned when a miner signs with a new key without a valid rotation (see: ClientOptions.StrictMinerID)
+var ErrUnexpectedMinerID = errors.New("unexpected minerId change")
+
+// maxMinerIDRotations is the max length of a rotation chain that is followed (guards against cycles)
+const maxMinerIDRotations = 100
+
+// MinerIDRotation is the rotation of a miner's key from the previous minerId to the new minerId
+// (from the minerId document in the coinbase of the miner's blocks)
+//
+// Specs: https://github.com/bitcoin-sv-specs/brfc-minerid
+type MinerIDRotation struct {
+	MinerID        string `json:"minerId"`        // New minerId (public key)
+	PrevMinerID    string `json:"prevMinerId"`    // Previous minerId (public key)
+	PrevMinerIDSig string `json:"prevMinerIdSig"` // Signature (DER) of the previous key on: prevMinerId + minerId + vctxid
+	VcTxID         string `json:"vctxid"`         // Validity check transaction id
+}
+
+// Verify will check that the rotation is signed by the previous minerId (using the default verifier if nil)
+func (r *MinerIDRotation) Verify(verifier SignatureVerifier) error {
+
+	// Make sure we have a rotation
+	if len(r.MinerID) == 0 || len(r.PrevMinerID) == 0 || len(r.PrevMinerIDSig) == 0 {
+		return fmt.Errorf("%w: missing minerId, prevMinerId or prevMinerIdSig", ErrInvalidMinerIDRotation)
+	}
+
+	// The signed message is the concatenation of the (hex decoded) keys and the vctxid
+	message, err := hex.DecodeString(r.PrevMinerID + r.MinerID + r.VcTxID)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMinerIDRotation, err.Error())
+	}
+
+	// Verify the signature of the previous key
+	if verifier == nil {
+		verifier = defaultSignatureVerifier
+	}
+	var valid bool
+	if valid, err = verifier.VerifySignature(sha256.Sum256(message), r.PrevMinerID, r.PrevMinerIDSig); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidMinerIDRotation, err.Error())
+	} else if !valid {
+		return fmt.Errorf("%w: signature does not match prevMinerId", ErrInvalidMinerIDRotation)
+	}
+	return nil
+}
+
+// minerIDRegistry is the verified minerId rotations (by previous minerId) and the last seen key (by miner)
+type minerIDRegistry struct {
+	sync.RWMutex
+	lastSeen    map[string]string
+	revoked     map[string]bool
+	revokedHook RevokedKeyHook
+	rotations   map[string]*MinerIDRotation
+}
+
+// newMinerIDRegistry will return a new registry without rotations
+func newMinerIDRegistry() *minerIDRegistry {
+	return &minerIDRegistry{
+		lastSeen:  make(map[string]string),
+		revoked:   make(map[string]bool),
+		rotations: make(map[string]*MinerIDRotation),
+	}
+}
+
+// AddMinerIDRotation will verify and add a minerId key rotation
+//
+// Responses signed with a key that is rotated (directly or through a chain of rotations) from the pinned
+// minerId of the miner (see: Miner.MinerID) are trusted (see: JSONEnvelope.Trusted)
+func (c *Client) AddMinerIDRotation(rotation *MinerIDRotation) error {
+	if rotation == nil {
+		return fmt.Errorf("%w: rotation was nil", ErrInvalidMinerIDRotation)
+	} else if err := rotation.Verify(c.signatureVerifier()); err != nil {
+		return err
+	}
+
+	c.minerIDs.Lock()
+	defer c.minerIDs.Unlock()
+	key := strings.ToLower(rotation.PrevMinerID)
+	if existing, ok := c.minerIDs.rotations[key]; ok && !strings.EqualFold(existing.MinerID, rotation.MinerID) {
+		return fmt.Errorf("%w: prevMinerId was already rotated to: %s", ErrInvalidMinerIDRotation, existing.MinerID)
+	}
+	c.minerIDs.rotations[key] = rotation
+	return nil
+}
+
+// MinerIDChain will return the pinned minerId of the miner followed by the keys it was rotated to (in order)
+func (c *Client) MinerIDChain(miner *Miner) []string {
+	if miner == nil || len(miner.MinerID) == 0 {
+		return nil
+	}
+	return c.minerIDChain(miner.MinerID)
+}
+
+// minerIDChain will return the key followed by the keys it was rotated to (in order)
+func (c *Client) minerIDChain(minerID string) (chain []string) {
+	c.minerIDs.RLock()
+	defer c.minerIDs.RUnlock()
+	chain = append(chain, minerID)
+	for len(chain) <= maxMinerIDRotations {
+		rotation, ok := c.minerIDs.rotations[strings.ToLower(chain[len(chain)-1])]
+		if !ok || containsFold(chain, rotation.MinerID) {
+			break
+		}
+		chain = append(chain, rotation.MinerID)
+	}
+	return
+}
+
+// trustedMinerID will return true if the key is the pinned minerId of the miner (or rotated from it)
+//
+// Miners without a pinned minerId trust any key, a nil client only trusts the pinned minerId
+func (c *Client) trustedMinerID(miner *Miner, publicKey string) bool {
+	if miner == nil || len(miner.MinerID) == 0 {
+		return true
+	} else if strings.EqualFold(miner.MinerID, publicKey) {
+		return true
+	} else if c == nil {
+		return false
+	}
+	return containsFold(c.MinerIDChain(miner), publicKey)
+}
+
+// containsFold will return true if the list contains the value (case-insensitive)
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// LastSeenMinerID will return the last key that signed a response of the miner (or empty if none)
+func (c *Client) LastSeenMinerID(miner *Miner) string {
+	if miner == nil {
+		return ""
+	}
+	c.minerIDs.RLock()
+	defer c.minerIDs.RUnlock()
+	return c.minerIDs.lastSeen[strings.ToLower(miner.Name)]
+}
+
+// checkMinerKey will track the key that signed a response of the miner and publish an event if the key changed
+//
+// The first key is compared to the pinned minerId (if set). A change to a trusted key (rotated from the pinned
+// minerId, or from the previous key if not pinned) publishes EventKeyRotated, any other change publishes
+// EventMinerIDChanged and fails in strict mode (the key is not kept)
+func (c *Client) checkMinerKey(miner *Miner, publicKey string, trusted bool) error {
+
+	// Get the previous key (or the pinned minerId)
+	previous := c.LastSeenMinerID(miner)
+	if len(previous) == 0 {
+		previous = miner.MinerID
+	}
+	if len(previous) == 0 || strings.EqualFold(previous, publicKey) {
+		c.setLastSeenMinerID(miner, publicKey)
+		return nil
+	}
+
+	// The key changed, was it rotated?
+	rotated := trusted && (len(miner.MinerID) > 0 || containsFold(c.minerIDChain(previous), publicKey))
+	event := &Event{Miner: miner, PreviousKey: previous, PublicKey: publicKey, Type: EventKeyRotated}
+	if !rotated {
+		event.Type = EventMinerIDChanged
+	}
+	c.events.publish(event, c.now())
+
+	// Fail in strict mode (keep the previous key)
+	if !rotated && c.Options.StrictMinerID {
+		return fmt.Errorf("%w: %s signed with: %s (expected: %s)", ErrUnexpectedMinerID, miner.Name, publicKey, previous)
+	}
+	c.setLastSeenMinerID(miner, publicKey)
+	return nil
+}
+
+// setLastSeenMinerID will store the last key that signed a response of the miner
+func (c *Client) setLastSeenMinerID(miner *Miner, publicKey string) {
+	c.minerIDs.Lock()
+	c.minerIDs.lastSeen[strings.ToLower(miner.Name)] = publicKey
+	c.minerIDs.Unlock()
+}
+
+// RevokedKeyHook decides if a response signed by a revoked key is still valid (return true to trust it)
+type RevokedKeyHook func(envelope *JSONEnvelope) bool
+
+// MinerIDRevocationList is a list of revoked minerIds (IE: compromised keys from minerId revocation documents)
+type MinerIDRevocationList struct {
+	Revoked []string `json:"revoked"`
+}
+
+// RevokeMinerIDs will add the minerIds to the revoked keys
+//
+// Responses signed by a revoked key are flagged (see: JSONEnvelope.Revoked) and invalid (Validated is false),
+// unless the revoked key hook decides to trust them (see: OnRevokedKey)
+func (c *Client) RevokeMinerIDs(minerIDs ...string) {
+	c.minerIDs.Lock()
+	for _, minerID := range minerIDs {
+		if len(minerID) > 0 {
+			c.minerIDs.revoked[strings.ToLower(minerID)] = true
+		}
+	}
+	c.minerIDs.Unlock()
+}
+
+// LoadRevocationList will add the minerIds of the revocation list (JSON, see: MinerIDRevocationList) to the revoked keys
+func (c *Client) LoadRevocationList(reader io.Reader) error {
+	var list MinerIDRevocationList
+	if err := json.NewDecoder(reader).Decode(&list); err != nil {
+		return err
+	}
+	c.RevokeMinerIDs(list.Revoked...)
+	return nil
+}
+
+// IsRevokedMinerID will return true if the minerId was revoked
+func (c *Client) IsRevokedMinerID(minerID string) bool {
+	c.minerIDs.RLock()
+	defer c.minerIDs.RUnlock()
+	return c.minerIDs.revoked[strings.ToLower(minerID)]
+}
+
+// OnRevokedKey will set the hook that decides if a response signed by a revoked key is still valid
+// (nil restores the default: responses signed by a revoked key are invalid)
+func (c *Client) OnRevokedKey(hook RevokedKeyHook) {
+	c.minerIDs.Lock()
+	c.minerIDs.revokedHook = hook
+	c.minerIDs.Unlock()
+}
+
+// checkRevoked will flag the (validated) envelope if the key was revoked and invalidate it (unless the hook trusts it)
+func (c *Client) checkRevoked(envelope *JSONEnvelope) {
+	if c == nil || !envelope.Validated || !c.IsRevokedMinerID(envelope.PublicKey) {
+		return
+	}
+	envelope.Revoked = true
+
+	c.minerIDs.RLock()
+	hook := c.minerIDs.revokedHook
+	c.minerIDs.RUnlock()
+	envelope.Validated = hook != nil && hook(envelope)
+}
@@ -0,0 +1,331 @@
+package minercraft
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/bitcoinschema/go-bitcoin"
+)
+
+const (
+	testMinerIDKeyOld   = "54035dd4c7dda99ac473905a3d82f7864322b49bab1ff441cc457183b9bd8abd"
+	testMinerIDKeyNew   = "7a4ab0ad3d6ea9b2d2c5d6b7c8d9e0f1a2b3c4d5e6f708192a3b4c5d6e7f8091"
+	testMinerIDKeyOther = "1f2e3d4c5b6a79880716253443526170f1e2d3c4b5a69788796a5b4c3d2e1f0a"
+	testVcTxID          = "6839008199026098cc78bf5f34c9a6bdf7a8009c9f019f8399c7ca1945b4a4ff"
+)
+
+// testPubKey will return the public key (hex) of the private key (hex)
+func testPubKey(t *testing.T, privateKey string) string {
+	pubKey, err := bitcoin.PubKeyFromPrivateKeyString(privateKey)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return pubKey
+}
+
+// testSign will return the DER signature (hex) of the sha256 of the data
+func testSign(t *testing.T, privateKey string, data []byte) string {
+	key, err := bitcoin.PrivateKeyFromString(privateKey)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	hash := sha256.Sum256(data)
+	signature, err := key.Sign(hash[:])
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	return hex.EncodeToString(signature.Serialize())
+}
+
+// testRotation will return a rotation from the previous key to the new key (signed by the signing key)
+func testRotation(t *testing.T, prevKey, newKey, signingKey string) *MinerIDRotation {
+	rotation := &MinerIDRotation{
+		MinerID:     testPubKey(t, newKey),
+		PrevMinerID: testPubKey(t, prevKey),
+		VcTxID:      testVcTxID,
+	}
+	message, _ := hex.DecodeString(rotation.PrevMinerID + rotation.MinerID + rotation.VcTxID)
+	rotation.PrevMinerIDSig = testSign(t, signingKey, message)
+	return rotation
+}
+
+// testSignedEnvelope will return an envelope with the payload signed by the private key
+func testSignedEnvelope(t *testing.T, privateKey, payload string) []byte {
+	return []byte(`{"payload":` + strconv.Quote(payload) + `,"signature":"` + testSign(t, privateKey, []byte(payload)) +
+		`","publicKey":"` + testPubKey(t, privateKey) + `","encoding":"` + testEncoding + `","mimetype":"` + testMimeType + `"}`)
+}
+
+// TestMinerIDRotation_Verify tests the method Verify()
+func TestMinerIDRotation_Verify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid rotation", func(t *testing.T) {
+		if err := testRotation(t, testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyOld).Verify(nil); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	})
+
+	t.Run("invalid rotations", func(t *testing.T) {
+		wrongSigner := testRotation(t, testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyNew)
+		badHex := testRotation(t, testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyOld)
+		badHex.VcTxID = "not-hex"
+
+		for _, rotation := range []*MinerIDRotation{{}, wrongSigner, badHex} {
+			if err := rotation.Verify(nil); !errors.Is(err, ErrInvalidMinerIDRotation) {
+				t.Fatalf("%s Failed: [%v] inputted and ErrInvalidMinerIDRotation was expected, got: %v", t.Name(), rotation, err)
+			}
+		}
+	})
+}
+
+// TestClient_AddMinerIDRotation tests the method AddMinerIDRotation()
+func TestClient_AddMinerIDRotation(t *testing.T) {
+	t.Parallel()
+
+	t.Run("response signed with a rotated key", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		miner := &Miner{MinerID: testPubKey(t, testMinerIDKeyOld), Name: "Rotating", URL: "rotating.example.com"}
+		body := testSignedEnvelope(t, testMinerIDKeyNew, `{"apiVersion":"1.2.3"}`)
+
+		var response JSONEnvelope
+		if err := response.process(client, miner, body); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !response.Validated || response.Trusted {
+			t.Fatalf("expected a valid signature of an untrusted key, got: validated %t trusted %t", response.Validated, response.Trusted)
+		}
+
+		if err := client.AddMinerIDRotation(testRotation(t, testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyOld)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		if err := response.process(client, miner, body); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !response.Validated || !response.Trusted {
+			t.Fatalf("expected a trusted key, got: validated %t trusted %t", response.Validated, response.Trusted)
+		}
+	})
+
+	t.Run("chain of rotations", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		miner := &Miner{MinerID: testPubKey(t, testMinerIDKeyOld), Name: "Rotating", URL: "rotating.example.com"}
+
+		for _, rotation := range []*MinerIDRotation{
+			testRotation(t, testMinerIDKeyNew, testMinerIDKeyOther, testMinerIDKeyNew),
+			testRotation(t, testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyOld),
+			testRotation(t, testMinerIDKeyOther, testMinerIDKeyOld, testMinerIDKeyOther), // Cycle back to the pinned key
+		} {
+			if err := client.AddMinerIDRotation(rotation); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+		}
+
+		chain := client.MinerIDChain(miner)
+		if len(chain) != 3 || chain[2] != testPubKey(t, testMinerIDKeyOther) {
+			t.Fatalf("expected a chain of 3 keys, got: %v", chain)
+		} else if !client.trustedMinerID(miner, testPubKey(t, testMinerIDKeyOther)) {
+			t.Fatalf("expected the last key of the chain to be trusted")
+		}
+	})
+
+	t.Run("invalid or conflicting rotations", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		if err := client.AddMinerIDRotation(nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if err = client.AddMinerIDRotation(testRotation(t, testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyNew)); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if err = client.AddMinerIDRotation(testRotation(t, testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyOld)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if err = client.AddMinerIDRotation(testRotation(t, testMinerIDKeyOld, testMinerIDKeyOther, testMinerIDKeyOld)); err == nil {
+			t.Fatalf("error should have occurred (forked rotation)")
+		}
+	})
+
+	t.Run("pinned and unpinned miners", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !response.Trusted {
+			t.Fatalf("expected the pinned key to be trusted")
+		} else if client.trustedMinerID(&Miner{}, "any") != true {
+			t.Fatalf("expected a miner without a minerId to trust any key")
+		}
+	})
+}
+
+// TestClient_LastSeenMinerID tests tracking the signing key of the miners (and the change events)
+func TestClient_LastSeenMinerID(t *testing.T) {
+	t.Parallel()
+
+	payload := `{"apiVersion":"1.2.3"}`
+	newMiner := func() *Miner {
+		return &Miner{MinerID: testPubKey(t, testMinerIDKeyOld), Name: "Rotating", URL: "rotating.example.com"}
+	}
+
+	t.Run("rotated key", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		events, unsubscribe := client.Subscribe(10, EventKeyRotated, EventMinerIDChanged)
+		defer unsubscribe()
+		if err := client.AddMinerIDRotation(testRotation(t, testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyOld)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		miner := newMiner()
+		for _, key := range []string{testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyNew} {
+			var response JSONEnvelope
+			if err := response.process(client, miner, testSignedEnvelope(t, key, payload)); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+		}
+
+		if client.LastSeenMinerID(miner) != testPubKey(t, testMinerIDKeyNew) {
+			t.Fatalf("expected the new key to be the last seen key, got: %s", client.LastSeenMinerID(miner))
+		} else if len(events) != 1 {
+			t.Fatalf("expected 1 event, got: %d", len(events))
+		} else if event := <-events; event.Type != EventKeyRotated || event.PreviousKey != miner.MinerID {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	})
+
+	t.Run("unexpected key", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		events, unsubscribe := client.Subscribe(10, EventKeyRotated, EventMinerIDChanged)
+		defer unsubscribe()
+
+		miner := newMiner()
+		var response JSONEnvelope
+		if err := response.process(client, miner, testSignedEnvelope(t, testMinerIDKeyOther, payload)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if len(events) != 1 {
+			t.Fatalf("expected 1 event, got: %d", len(events))
+		} else if event := <-events; event.Type != EventMinerIDChanged || event.PublicKey != testPubKey(t, testMinerIDKeyOther) {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	})
+
+	t.Run("strict mode", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		client.Options.StrictMinerID = true
+
+		miner := newMiner()
+		for i := 0; i < 2; i++ {
+			var response JSONEnvelope
+			if err := response.process(client, miner, testSignedEnvelope(t, testMinerIDKeyOther, payload)); !errors.Is(err, ErrUnexpectedMinerID) {
+				t.Fatalf("expected ErrUnexpectedMinerID, got: %v", err)
+			}
+		}
+		if len(client.LastSeenMinerID(miner)) != 0 {
+			t.Fatalf("expected the unexpected key to not be kept")
+		}
+	})
+
+	t.Run("unpinned miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		client.Options.StrictMinerID = true
+
+		miner := newMiner()
+		miner.MinerID = ""
+		var response JSONEnvelope
+		if err := response.process(client, miner, testSignedEnvelope(t, testMinerIDKeyOld, payload)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if err = response.process(client, miner, testSignedEnvelope(t, testMinerIDKeyNew, payload)); !errors.Is(err, ErrUnexpectedMinerID) {
+			t.Fatalf("expected ErrUnexpectedMinerID, got: %v", err)
+		} else if err = client.AddMinerIDRotation(testRotation(t, testMinerIDKeyOld, testMinerIDKeyNew, testMinerIDKeyOld)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if err = response.process(client, miner, testSignedEnvelope(t, testMinerIDKeyNew, payload)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	})
+}
+
+// TestClient_RevokeMinerIDs tests the method RevokeMinerIDs()
+func TestClient_RevokeMinerIDs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("revoked key", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		miner := client.MinerByName(MinerTaal)
+		client.RevokeMinerIDs("", strings.ToUpper(miner.MinerID))
+
+		response, err := client.FeeQuote(miner)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !response.Revoked || response.Validated || response.Trusted {
+			t.Fatalf("expected a revoked and invalid response, got: revoked %t validated %t trusted %t",
+				response.Revoked, response.Validated, response.Trusted)
+		}
+	})
+
+	t.Run("hook trusts the revoked key", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		miner := client.MinerByName(MinerTaal)
+		client.RevokeMinerIDs(miner.MinerID)
+
+		var hookCalls int
+		client.OnRevokedKey(func(envelope *JSONEnvelope) bool {
+			hookCalls++
+			return envelope.Miner.Name == MinerTaal
+		})
+
+		response, err := client.FeeQuote(miner)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !response.Revoked || !response.Validated || hookCalls != 1 {
+			t.Fatalf("expected a revoked but valid response, got: revoked %t validated %t", response.Revoked, response.Validated)
+		}
+
+		client.OnRevokedKey(nil)
+		if response, err = client.FeeQuote(miner); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Validated {
+			t.Fatalf("expected an invalid response")
+		}
+	})
+
+	t.Run("not revoked", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		client.RevokeMinerIDs(testPubKey(t, testMinerIDKeyOld))
+
+		response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Revoked || !response.Validated {
+			t.Fatalf("expected a valid response")
+		}
+	})
+}
+
+// TestClient_LoadRevocationList tests the method LoadRevocationList()
+func TestClient_LoadRevocationList(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	if err := client.LoadRevocationList(strings.NewReader(`{"revoked":["` + testPubKey(t, testMinerIDKeyOld) + `"]}`)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if !client.IsRevokedMinerID(testPubKey(t, testMinerIDKeyOld)) {
+		t.Fatalf("expected the key to be revoked")
+	} else if client.IsRevokedMinerID(testPubKey(t, testMinerIDKeyNew)) {
+		t.Fatalf("expected the key to not be revoked")
+	} else if err = client.LoadRevocationList(strings.NewReader("not-json")); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// ExampleClient_AddMinerIDRotation example using AddMinerIDRotation()
+func ExampleClient_AddMinerIDRotation() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+
+	// Rotations are verified before they are added
+	err := client.AddMinerIDRotation(&MinerIDRotation{
+		MinerID:        "03e92d3e5c3f7bd945dfbf48e7a99393b1bfb3f11f380ae30d286e7ff2aec5a270",
+		PrevMinerID:    "0211ccfc29e3058b770f3cf3eb34b0b2fd2293057a994d4d275121be4151cdf087",
+		PrevMinerIDSig: "3044022000000000",
+	})
+	fmt.Printf("valid: %t", err == nil)
+	// Output:valid: false
+}
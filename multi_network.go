@@ -0,0 +1,87 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrNetworkMismatch is returned when a miner is not on the network of the client (see: WithNetwork)
+var ErrNetworkMismatch = errors.New("miner is not on the network of the client")
+
+// WithNetwork will only keep the miners on the network and restrict the clone to the network
+//
+// Requests to (and adding) miners of any other network fail with ErrNetworkMismatch,
+// so a testnet tx is never submitted or queried using a mainnet miner by mistake
+func WithNetwork(network string) CloneOption {
+	return func(c *Client) {
+		c.network = strings.ToLower(network)
+		c.Miners = c.Miners.ByNetwork(network)
+	}
+}
+
+// Network will return the network of the client (or empty if the client is not restricted to a network)
+func (c *Client) Network() string {
+	return c.network
+}
+
+// checkNetwork will return an error if the client is restricted to a network and the miner is on another network
+func (c *Client) checkNetwork(miner *Miner) error {
+	if len(c.network) > 0 && !strings.EqualFold(miner.GetNetwork(), c.network) {
+		return fmt.Errorf("%w: %s is on %s (client: %s)", ErrNetworkMismatch, miner.Name, miner.GetNetwork(), c.network)
+	}
+	return nil
+}
+
+// MultiNetworkClient is a set of clients (one per network) sharing the http clients (connection pools)
+type MultiNetworkClient struct {
+	clients map[string]*Client
+}
+
+// NewMultiNetworkClient creates a new client for each network (IE: NetworkMainnet and NetworkTestnet)
+//
+// Each client only uses (and accepts) the miners on its network (see: WithNetwork).
+// The clients share the http clients, caches and health of a single client (see: NewClient)
+func NewMultiNetworkClient(clientOptions *ClientOptions, customHTTPClient *http.Client,
+	networks ...string) (*MultiNetworkClient, error) {
+
+	// Make sure we have networks
+	if len(networks) == 0 {
+		return nil, errors.New("missing networks")
+	}
+
+	// Create the shared client
+	client, err := NewClient(clientOptions, customHTTPClient)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a client for each network
+	multi := &MultiNetworkClient{clients: make(map[string]*Client, len(networks))}
+	for _, network := range networks {
+		if len(network) == 0 {
+			return nil, errors.New("missing network")
+		} else if _, ok := multi.clients[strings.ToLower(network)]; ok {
+			return nil, fmt.Errorf("duplicate network: %s", network)
+		}
+		multi.clients[strings.ToLower(network)] = client.With(WithNetwork(network))
+	}
+	return multi, nil
+}
+
+// Client will return the client for the network (or nil if not found)
+func (m *MultiNetworkClient) Client(network string) *Client {
+	return m.clients[strings.ToLower(network)]
+}
+
+// Networks will return the networks of the clients
+func (m *MultiNetworkClient) Networks() []string {
+	networks := make([]string, 0, len(m.clients))
+	for network := range m.clients {
+		networks = append(networks, network)
+	}
+	sort.Strings(networks)
+	return networks
+}
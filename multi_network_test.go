@@ -0,0 +1,98 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestNewMultiNetworkClient tests the method NewMultiNetworkClient()
+func TestNewMultiNetworkClient(t *testing.T) {
+	t.Parallel()
+
+	multi, err := NewMultiNetworkClient(nil, nil, NetworkMainnet, NetworkTestnet)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if networks := multi.Networks(); len(networks) != 2 || networks[0] != NetworkMainnet || networks[1] != NetworkTestnet {
+		t.Fatalf("expected networks [%s %s] but got: %v", NetworkMainnet, NetworkTestnet, networks)
+	}
+
+	mainnet, testnet := multi.Client(NetworkMainnet), multi.Client("TESTNET")
+	if mainnet == nil || testnet == nil {
+		t.Fatalf("expected a client for each network")
+	} else if mainnet.Network() != NetworkMainnet || testnet.Network() != NetworkTestnet {
+		t.Fatalf("expected the networks [%s %s] but got: [%s %s]", NetworkMainnet, NetworkTestnet, mainnet.Network(), testnet.Network())
+	} else if len(mainnet.Miners) == 0 || len(testnet.Miners) != 0 {
+		t.Fatalf("expected only mainnet miners but got: %d %d", len(mainnet.Miners), len(testnet.Miners))
+	} else if mainnet.quoteCache != testnet.quoteCache || mainnet.httpClient != testnet.httpClient {
+		t.Fatalf("expected the clients to share the http client and caches")
+	} else if multi.Client(NetworkSTN) != nil {
+		t.Fatalf("expected no client for [%s]", NetworkSTN)
+	}
+
+	t.Run("invalid networks", func(t *testing.T) {
+		if _, err = NewMultiNetworkClient(nil, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = NewMultiNetworkClient(nil, nil, NetworkMainnet, ""); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = NewMultiNetworkClient(nil, nil, NetworkMainnet, "Mainnet"); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestClient_WithNetwork tests the network isolation of WithNetwork()
+func TestClient_WithNetwork(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidQuery{})
+	mainnet, testnet := client.With(WithNetwork(NetworkMainnet)), client.With(WithNetwork(NetworkTestnet))
+
+	// Only miners on the network can be added
+	if err := testnet.AddMiner(Miner{Name: testMinerName, Network: NetworkTestnet, URL: testMinerURL}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if err = testnet.AddMiner(Miner{Name: "Mainnet", URL: "mainnet.com"}); !errors.Is(err, ErrNetworkMismatch) {
+		t.Fatalf("expected [%v] but got: %v", ErrNetworkMismatch, err)
+	}
+
+	// Requests to miners of another network fail (before any request is made)
+	testnetMiner, mainnetMiner := testnet.MinerByName(testMinerName), mainnet.MinerByName(MinerTaal)
+	if _, err := mainnet.QueryTransaction(testnetMiner, testTx); !errors.Is(err, ErrNetworkMismatch) {
+		t.Fatalf("expected [%v] but got: %v", ErrNetworkMismatch, err)
+	} else if _, err = testnet.QueryTransaction(mainnetMiner, testTx); !errors.Is(err, ErrNetworkMismatch) {
+		t.Fatalf("expected [%v] but got: %v", ErrNetworkMismatch, err)
+	} else if _, err = testnet.FeeQuote(mainnetMiner); !errors.Is(err, ErrNetworkMismatch) {
+		t.Fatalf("expected [%v] but got: %v", ErrNetworkMismatch, err)
+	} else if _, err = testnet.SubmitTransaction(mainnetMiner, &Transaction{RawTx: testRawTx}); !errors.Is(err, ErrNetworkMismatch) {
+		t.Fatalf("expected [%v] but got: %v", ErrNetworkMismatch, err)
+	} else if _, err = testnet.SubmitTransactionReader(mainnetMiner, strings.NewReader("00"), 1, nil); !errors.Is(err, ErrNetworkMismatch) {
+		t.Fatalf("expected [%v] but got: %v", ErrNetworkMismatch, err)
+	} else if _, err = testnet.Do(context.Background(), mainnetMiner, http.MethodGet, "/mapi/custom/ext", nil, nil); !errors.Is(err, ErrNetworkMismatch) {
+		t.Fatalf("expected [%v] but got: %v", ErrNetworkMismatch, err)
+	}
+
+	// Requests on the same network are allowed (the unrestricted client allows all networks)
+	if _, err := mainnet.QueryTransaction(mainnetMiner, testTx); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if _, err = client.QueryTransaction(mainnetMiner, testTx); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+}
+
+// ExampleNewMultiNetworkClient example using NewMultiNetworkClient()
+func ExampleNewMultiNetworkClient() {
+	multi, err := NewMultiNetworkClient(nil, nil, NetworkMainnet, NetworkTestnet)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	// A mainnet miner can't be used on the testnet client
+	testnet := multi.Client(NetworkTestnet)
+	_, err = testnet.QueryTransaction(multi.Client(NetworkMainnet).MinerByName(MinerTaal), testTx)
+	fmt.Printf("error: %s", err.Error())
+	// Output:error: miner is not on the network of the client: Taal is on mainnet (client: testnet)
+}
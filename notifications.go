@@ -0,0 +1,249 @@
+package minercraft
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxNotifiedTxs is the number of transactions the notifications remember (for ordering and deduplication)
+const maxNotifiedTxs = 10000
+
+// NotificationType is the type of transaction notification
+type NotificationType string
+
+const (
+
+	// NotificationDoubleSpend is sent when a transaction was double spent (Payload has the details)
+	NotificationDoubleSpend NotificationType = "double_spend"
+
+	// NotificationDoubleSpendAttempt is sent when a miner saw an attempt to double spend the inputs of a transaction
+	NotificationDoubleSpendAttempt NotificationType = "double_spend_attempt"
+
+	// NotificationMempool is sent when a miner has the transaction but it is not yet in a block
+	NotificationMempool NotificationType = "mempool"
+
+	// NotificationMerkleProof is sent with the merkle proof of a mined transaction (Payload has the proof)
+	NotificationMerkleProof NotificationType = "merkle_proof"
+
+	// NotificationMined is sent when the transaction is in a block (again for a different block, IE: a reorg)
+	NotificationMined NotificationType = "mined"
+
+	// NotificationRejected is sent when a miner rejected the transaction
+	NotificationRejected NotificationType = "rejected"
+)
+
+// Sources (transports) of the notifications
+const (
+	NotificationSourceARCCallback  = "arc_callback"  // See: ParseARCCallback
+	NotificationSourceMAPICallback = "mapi_callback" // See: ParseCallback
+	NotificationSourcePoll         = TxStatusSourcePoll
+	NotificationSourcePush         = TxStatusSourcePush
+	NotificationSourceSPVChannel   = "spv_channel"
+)
+
+// Notification is a typed event about a transaction, from any transport (see: SubscribeNotifications)
+type Notification struct {
+	BlockHash   string           `json:"block_hash,omitempty"`
+	BlockHeight int64            `json:"block_height,omitempty"`
+	Miner       *Miner           `json:"miner,omitempty"`   // The miner that sent the notification (if known)
+	Payload     string           `json:"payload,omitempty"` // The merkle proof, double spend details or reject reason
+	Sequence    uint64           `json:"sequence"`          // Position in the stream of the transaction (starts at 1)
+	Source      string           `json:"source"`            // The transport (IE: NotificationSourcePoll)
+	Time        time.Time        `json:"time"`
+	TxID        string           `json:"txid"`
+	Type        NotificationType `json:"type"`
+}
+
+// notificationSubscriber is a subscribed channel and the transaction it receives (all if empty)
+type notificationSubscriber struct {
+	notifications chan *Notification
+	txID          string
+}
+
+// notifiedTx is the state of the stream of a transaction
+type notifiedTx struct {
+	mined    bool            // Set once a mined notification was sent (mempool notifications are stale)
+	sequence uint64          // Sequence of the last notification
+	sent     map[string]bool // Keys of the sent notifications (see: notificationKey)
+}
+
+// notificationHub is the list of subscribers and the recently notified transactions
+type notificationHub struct {
+	sync.Mutex
+	nextID      int
+	subscribers map[int]*notificationSubscriber
+	txs         *lruCache
+}
+
+// newNotificationHub will return a new notification hub without subscribers
+func newNotificationHub() *notificationHub {
+	return &notificationHub{
+		subscribers: make(map[int]*notificationSubscriber),
+		txs:         newLRUCache(maxNotifiedTxs),
+	}
+}
+
+// SubscribeNotifications will return a channel that receives the notifications of the transaction (all
+// transactions if the txid is empty) and a function to unsubscribe (which closes the channel)
+//
+// Notifications from all transports (polling, status streams, mAPI and ARC callbacks and SPV channels) are merged
+// into one stream: each notification is sent once (IE: the same block from a poll and a callback) in the order it
+// was received, and a mempool notification received after the transaction was mined is dropped
+func (c *Client) SubscribeNotifications(txID string, bufferSize int) (<-chan *Notification, func()) {
+	subscriber := &notificationSubscriber{
+		notifications: make(chan *Notification, bufferSize),
+		txID:          strings.ToLower(txID),
+	}
+
+	c.notifications.Lock()
+	c.notifications.nextID++
+	id := c.notifications.nextID
+	c.notifications.subscribers[id] = subscriber
+	c.notifications.Unlock()
+
+	var once sync.Once
+	return subscriber.notifications, func() {
+		once.Do(func() {
+			c.notifications.Lock()
+			delete(c.notifications.subscribers, id)
+			c.notifications.Unlock()
+			close(subscriber.notifications)
+		})
+	}
+}
+
+// Notify will send the notification to the subscribers (IE: from a custom transport, see: SubscribeNotifications)
+//
+// Returns false if a subscriber's buffer was full, the notification is not marked as sent so it can be
+// delivered again (duplicates are possible for the subscribers that received it)
+func (c *Client) Notify(notification *Notification) bool {
+	if notification == nil || !IsValidTxID(notification.TxID) {
+		return true
+	}
+	return c.notifications.publish(notification, c.now())
+}
+
+// WatchTx will send the status of the transaction from the miner to the notifications (see: SubscribeTxStatus)
+// until the status is final or the context is done
+func (c *Client) WatchTx(ctx context.Context, miner *Miner, txID string) error {
+	events, err := c.SubscribeTxStatus(ctx, miner, txID)
+	if err != nil {
+		return err
+	}
+	go func() {
+		for event := range events {
+			if notification := statusNotification(event); notification != nil {
+				c.notifications.publish(notification, c.now())
+			}
+		}
+	}()
+	return nil
+}
+
+// publish will send the notification to the subscribers of the transaction, returning false if a subscriber's
+// buffer was full (sending never blocks the client)
+func (n *notificationHub) publish(notification *Notification, now time.Time) bool {
+	n.Lock()
+	defer n.Unlock()
+
+	// Find the stream of the transaction
+	notification.TxID = strings.ToLower(notification.TxID)
+	tx, ok := n.txs.get(notification.TxID)
+	if !ok {
+		tx = &notifiedTx{sent: make(map[string]bool)}
+		n.txs.set(notification.TxID, tx)
+	}
+	stream := tx.(*notifiedTx)
+
+	// Drop duplicates and stale notifications
+	key := notificationKey(notification)
+	if stream.sent[key] || (notification.Type == NotificationMempool && stream.mined) {
+		return true
+	}
+
+	// Send to the subscribers (in order of the sequence)
+	notification.Sequence = stream.sequence + 1
+	if notification.Time.IsZero() {
+		notification.Time = now.UTC()
+	}
+	delivered := true
+	for _, subscriber := range n.subscribers {
+		if len(subscriber.txID) > 0 && subscriber.txID != notification.TxID {
+			continue
+		}
+		select {
+		case subscriber.notifications <- notification:
+		default:
+			delivered = false
+		}
+	}
+	if delivered {
+		stream.sequence = notification.Sequence
+		stream.sent[key] = true
+		stream.mined = stream.mined || notification.Type == NotificationMined
+	}
+	return delivered
+}
+
+// notificationKey will return the key used to deduplicate the notification
+//
+// Mined notifications and merkle proofs are unique per block, double spends per payload
+func notificationKey(notification *Notification) string {
+	switch notification.Type {
+	case NotificationMined, NotificationMerkleProof:
+		if len(notification.BlockHash) > 0 {
+			return string(notification.Type) + ":" + strings.ToLower(notification.BlockHash)
+		}
+		return string(notification.Type) + ":" + strconv.FormatInt(notification.BlockHeight, 10)
+	case NotificationDoubleSpend, NotificationDoubleSpendAttempt:
+		return string(notification.Type) + ":" + notification.Payload
+	}
+	return string(notification.Type)
+}
+
+// statusNotification will return the notification of a status event (nil for a failed poll or an unknown status)
+func statusNotification(event *TxStatusEvent) *Notification {
+	notification := &Notification{
+		BlockHash: event.BlockHash, BlockHeight: event.BlockHeight, Miner: event.Miner,
+		Source: event.Source, Time: event.Time, TxID: event.TxID,
+	}
+	switch {
+	case len(event.Error) > 0:
+		return nil
+	case event.Status == TxStatusMempool:
+		notification.Type = NotificationMempool
+	case event.Status == TxStatusMined:
+		notification.Type = NotificationMined
+	case event.Status == TxStatusRejected:
+		notification.Type = NotificationRejected
+	default:
+		return nil
+	}
+	return notification
+}
+
+// callbackNotifications will return the notifications of a mAPI callback received from the source
+// (a merkle proof is also a mined notification)
+func callbackNotifications(callback *CallbackResponse, source string) []*Notification {
+	notification := &Notification{
+		BlockHash: callback.Callback.BlockHash, BlockHeight: int64(callback.Callback.BlockHeight), Miner: callback.Miner,
+		Payload: callback.Callback.CallbackPayload, Source: source, TxID: callback.Callback.CallbackTxID,
+	}
+	switch callback.Callback.CallbackReason {
+	case CallbackReasonMerkleProof:
+		mined := *notification
+		mined.Payload, mined.Type = "", NotificationMined
+		notification.Type = NotificationMerkleProof
+		return []*Notification{&mined, notification}
+	case CallbackReasonDoubleSpend:
+		notification.Type = NotificationDoubleSpend
+	case CallbackReasonDoubleSpendAttempt:
+		notification.Type = NotificationDoubleSpendAttempt
+	default:
+		return nil
+	}
+	return []*Notification{notification}
+}
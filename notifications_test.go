@@ -0,0 +1,165 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+const testARCMinedCallback = `{"timestamp":"2023-03-28T09:42:03.134Z","txid":"` + testTx + `","txStatus":"MINED",
+"blockHash":"0000000000000000050a09fe90b0e8542bba9e712edb8cc9349e61888fe45ac5","blockHeight":612530,"merklePath":"fe8a6a0c00"}`
+
+// receiveNotifications will return the notifications received within the timeout
+func receiveNotifications(notifications <-chan *Notification, count int) []*Notification {
+	received := make([]*Notification, 0, count)
+	for len(received) < count {
+		select {
+		case notification := <-notifications:
+			received = append(received, notification)
+		case <-time.After(5 * time.Second):
+			return received
+		}
+	}
+	return received
+}
+
+// TestClient_SubscribeNotifications tests the method SubscribeNotifications()
+func TestClient_SubscribeNotifications(t *testing.T) {
+	t.Parallel()
+
+	t.Run("merged, ordered and deduplicated", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		notifications, unsubscribe := client.SubscribeNotifications(testTx, 10)
+		defer unsubscribe()
+
+		if _, err := client.ParseARCCallback(nil, []byte(`{"txid":"`+testTx+`","txStatus":"SEEN_ON_NETWORK"}`)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if _, err = client.ParseARCCallback(client.MinerByName(MinerTaal), []byte(testARCMinedCallback)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		// Same block from a poll, a stale mempool status and another tx
+		client.Notify(&Notification{
+			BlockHash: "0000000000000000050A09FE90B0E8542BBA9E712EDB8CC9349E61888FE45AC5", Source: NotificationSourcePoll,
+			TxID: testTx, Type: NotificationMined,
+		})
+		client.Notify(&Notification{Source: NotificationSourcePoll, TxID: testTx, Type: NotificationMempool})
+		client.Notify(&Notification{Source: NotificationSourcePoll, TxID: testSubmittedTx, Type: NotificationMined})
+
+		// A reorg (mined in another block)
+		client.Notify(&Notification{BlockHash: "1111", Source: NotificationSourcePoll, TxID: testTx, Type: NotificationMined})
+
+		received := receiveNotifications(notifications, 4)
+		expected := []NotificationType{
+			NotificationMempool, NotificationMined, NotificationMerkleProof, NotificationMined,
+		}
+		if len(received) != len(expected) {
+			t.Fatalf("expected %d notifications, got: %d", len(expected), len(received))
+		}
+		for index, notification := range received {
+			if notification.Type != expected[index] || notification.Sequence != uint64(index+1) {
+				t.Fatalf("expected %s at %d, got: %s at %d", expected[index], index+1, notification.Type, notification.Sequence)
+			}
+		}
+		if received[2].Payload != "fe8a6a0c00" || received[2].Miner == nil || received[2].Source != NotificationSourceARCCallback {
+			t.Fatalf("expected the merkle path from the ARC callback of %s", MinerTaal)
+		} else if received[3].BlockHash != "1111" {
+			t.Fatalf("expected the reorg block, got: %s", received[3].BlockHash)
+		}
+		select {
+		case notification := <-notifications:
+			t.Fatalf("unexpected notification: %s", notification.Type)
+		default:
+		}
+	})
+
+	t.Run("mAPI callbacks", func(t *testing.T) {
+		client := addTestCallbackMiner(newTestClient(&mockHTTPValidQuery{}))
+		notifications, unsubscribe := client.SubscribeNotifications("", 10)
+		defer unsubscribe()
+
+		if _, err := client.ParseCallback([]byte(testCallback)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		received := receiveNotifications(notifications, 2)
+		if len(received) != 2 || received[0].Type != NotificationMined || received[1].Type != NotificationMerkleProof {
+			t.Fatalf("expected a mined notification and a merkle proof, got: %d notifications", len(received))
+		} else if received[1].TxID != testSubmittedTx || received[1].Payload != "{}" ||
+			received[1].Source != NotificationSourceMAPICallback || received[1].BlockHeight != 5 {
+			t.Fatalf("expected the merkle proof of the callback, got: %+v", received[1])
+		}
+	})
+
+	t.Run("watched transactions", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		notifications, unsubscribe := client.SubscribeNotifications(testTx, 10)
+		defer unsubscribe()
+
+		if err := client.WatchTx(context.Background(), client.MinerByName(MinerTaal), testTx); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		received := receiveNotifications(notifications, 1)
+		if len(received) != 1 || received[0].Type != NotificationMined || received[0].Source != NotificationSourcePoll {
+			t.Fatalf("expected a polled mined notification, got: %d notifications", len(received))
+		} else if err := client.WatchTx(context.Background(), nil, testTx); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("full buffers are delivered again", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		_, unsubscribeFull := client.SubscribeNotifications(testTx, 0)
+
+		if client.Notify(&Notification{TxID: testTx, Type: NotificationMempool}) {
+			t.Fatalf("expected the notification to not be delivered")
+		}
+		unsubscribeFull()
+		unsubscribeFull()
+
+		notifications, unsubscribe := client.SubscribeNotifications(testTx, 1)
+		defer unsubscribe()
+		if !client.Notify(&Notification{TxID: testTx, Type: NotificationMempool}) {
+			t.Fatalf("expected the notification to be delivered")
+		} else if notification := <-notifications; notification.Sequence != 1 || notification.Time.IsZero() {
+			t.Fatalf("expected the first notification, got: %d", notification.Sequence)
+		} else if !client.Notify(nil) || !client.Notify(&Notification{TxID: "invalid"}) {
+			t.Fatalf("expected invalid notifications to be ignored")
+		}
+	})
+}
+
+// TestClient_ParseARCCallback tests the method ParseARCCallback()
+func TestClient_ParseARCCallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("double spend attempt", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		notifications, unsubscribe := client.SubscribeNotifications(testTx, 10)
+		defer unsubscribe()
+
+		callback, err := client.ParseARCCallback(nil, []byte(`{"txid":"`+testTx+`","txStatus":"DOUBLE_SPEND_ATTEMPTED",
+"competingTxs":["`+testSubmittedTx+`"]}`))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if len(callback.CompetingTxs) != 1 {
+			t.Fatalf("expected 1 competing tx, got: %d", len(callback.CompetingTxs))
+		}
+		received := receiveNotifications(notifications, 1)
+		if len(received) != 1 || received[0].Type != NotificationDoubleSpendAttempt ||
+			received[0].Payload != `{"competingTxs":["`+testSubmittedTx+`"]}` {
+			t.Fatalf("expected a double spend attempt with the competing txs")
+		}
+	})
+
+	t.Run("invalid callbacks", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		if _, err := client.ParseARCCallback(nil, []byte(`{"txid":`)); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.ParseARCCallback(nil, []byte(`{"txid":"invalid","txStatus":"MINED"}`)); !errors.Is(err, ErrInvalidTxID) {
+			t.Fatalf("expected ErrInvalidTxID, got: %v", err)
+		} else if _, err = client.ParseARCCallback(nil, []byte(`{"txid":"`+testTx+`"}`)); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
@@ -0,0 +1,142 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrSubmissionQueued is returned when all miners are unreachable and the submission was queued
+// (see: ClientOptions.OfflineQueueSize)
+var ErrSubmissionQueued = errors.New("all miners are unreachable, submission was queued")
+
+// ErrOfflineQueueFull is returned when all miners are unreachable and the offline queue is full
+var ErrOfflineQueueFull = errors.New("all miners are unreachable and the offline queue is full")
+
+// offlineQueue is the submissions waiting for a miner to recover (oldest first)
+type offlineQueue struct {
+	sync.Mutex
+	flushing bool
+	txs      []*Transaction
+}
+
+// SubmitTransactionAny will submit the transaction to the first miner that responds
+// (miners that failed their last health check are tried last)
+//
+// If all miners are unreachable and the offline queue is enabled (see: ClientOptions.OfflineQueueSize), the
+// submission is queued and ErrSubmissionQueued is returned. Queued submissions are flushed to the first miner
+// that passes a health check (see: HealthCheck), an EventQueuedTxFlushed is published for each result
+func (c *Client) SubmitTransactionAny(ctx context.Context, tx *Transaction) (*SubmitTransactionResponse, error) {
+
+	// Make sure we have a transaction
+	if tx == nil {
+		return nil, errors.New("transaction was nil")
+	}
+
+	// Try each miner until one responds (miners over their request budget are skipped)
+	var failures []string
+	var unreachableMiners int
+	for _, miner := range c.minersByHealth() {
+		if err := c.checkSubmission(ctx, miner, tx); err != nil {
+			return nil, err
+		}
+		result := submitTransaction(ctx, c, miner, tx)
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		} else if unreachable(result) {
+			failures = append(failures, miner.Name+": "+result.Response.Error.Error())
+			unreachableMiners++
+			continue
+		} else if errors.Is(result.Response.Error, ErrBudgetExceeded) {
+			failures = append(failures, miner.Name+": "+result.Response.Error.Error())
+			continue
+		}
+		return c.submissionResult(result, tx)
+	}
+
+	// All miners are unreachable, queue the submission (if enabled)
+	if c.Options.OfflineQueueSize <= 0 || unreachableMiners == 0 || unreachableMiners < len(failures) {
+		return nil, fmt.Errorf("failed submitting to all miners: %s", strings.Join(failures, ", "))
+	}
+	c.offline.Lock()
+	defer c.offline.Unlock()
+	if len(c.offline.txs) >= c.Options.OfflineQueueSize {
+		return nil, ErrOfflineQueueFull
+	}
+	c.offline.txs = append(c.offline.txs, tx)
+	return nil, ErrSubmissionQueued
+}
+
+// QueuedSubmissions will return the number of submissions waiting for a miner to recover
+func (c *Client) QueuedSubmissions() int {
+	c.offline.Lock()
+	defer c.offline.Unlock()
+	return len(c.offline.txs)
+}
+
+// FlushQueue will submit the queued submissions to the miner, publishing an EventQueuedTxFlushed for each result
+//
+// Submissions that fail to reach the miner stay queued (rejections are not retried)
+func (c *Client) FlushQueue(ctx context.Context, miner *Miner) {
+
+	// Take the queued submissions (only one flush at a time)
+	c.offline.Lock()
+	if c.offline.flushing || len(c.offline.txs) == 0 {
+		c.offline.Unlock()
+		return
+	}
+	c.offline.flushing = true
+	txs := c.offline.txs
+	c.offline.txs = nil
+	c.offline.Unlock()
+
+	// Submit each transaction (keeping the ones that did not reach the miner, or were not sent)
+	var remaining []*Transaction
+	for index, tx := range txs {
+		result := submitTransaction(ctx, c, miner, tx)
+		if ctx.Err() != nil {
+			remaining = append(remaining, txs[index:]...)
+			break
+		} else if unreachable(result) || errors.Is(result.Response.Error, ErrBudgetExceeded) {
+			remaining = append(remaining, tx)
+			continue
+		}
+		response, err := c.submissionResult(result, tx)
+		c.events.publish(&Event{
+			Miner:      miner,
+			Submission: &SubmitResult{Error: err, Miner: miner, Response: response, Tx: tx},
+			Type:       EventQueuedTxFlushed,
+		}, c.now())
+	}
+
+	// Requeue the remaining submissions (before any queued during the flush)
+	c.offline.Lock()
+	c.offline.txs = append(remaining, c.offline.txs...)
+	c.offline.flushing = false
+	c.offline.Unlock()
+}
+
+// unreachable will return true if the submission did not reach the miner (IE: a network failure)
+//
+// Refusals before sending (IE: ErrBudgetExceeded) and terminal errors are not network failures (see: ClassifySubmission)
+func unreachable(result *internalResult) bool {
+	err := result.Response.Error
+	return err != nil && result.Response.StatusCode == 0 && !errors.Is(err, ErrBudgetExceeded) &&
+		classifySubmissionError(err) == SubmitRetryableFailure
+}
+
+// minersByHealth will return the miners, the ones that failed their last health check last
+func (c *Client) minersByHealth() MinerSlice {
+	healthy := make(MinerSlice, 0, len(c.Miners))
+	var unhealthy MinerSlice
+	for _, miner := range c.Miners {
+		if health := c.health.get(miner.Name); health != nil && !health.Healthy {
+			unhealthy = append(unhealthy, miner)
+		} else {
+			healthy = append(healthy, miner)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
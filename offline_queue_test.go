@@ -0,0 +1,131 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockHTTPUnreachable for mocking requests to unreachable miners
+type mockHTTPUnreachable struct{}
+
+// Do is a mock http request
+func (m *mockHTTPUnreachable) Do(_ *http.Request) (*http.Response, error) {
+	return nil, errors.New("dial tcp: connection refused")
+}
+
+// mockHTTPRecovered for mocking requests to recovered miners (quotes and submissions)
+type mockHTTPRecovered struct{}
+
+// Do is a mock http request
+func (m *mockHTTPRecovered) Do(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.String(), routeFeeQuote) {
+		return (&mockHTTPValidFeeQuote{}).Do(req)
+	}
+	return (&mockHTTPValidSubmission{}).Do(req)
+}
+
+// TestClient_SubmitTransactionAny tests the method SubmitTransactionAny()
+func TestClient_SubmitTransactionAny(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("first miner that responds", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		response, err := client.SubmitTransactionAny(ctx, &Transaction{RawTx: testRawTx})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Results.TxID != testSubmittedTx {
+			t.Fatalf("expected the submission response, got: %v", response.Results)
+		}
+	})
+
+	t.Run("invalid transaction", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		if _, err := client.SubmitTransactionAny(ctx, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.SubmitTransactionAny(ctx, &Transaction{RawTx: "invalid"}); !errors.Is(err, ErrInvalidRawTx) {
+			t.Fatalf("expected error: %v got: %v", ErrInvalidRawTx, err)
+		}
+	})
+
+	t.Run("queue disabled", func(t *testing.T) {
+		client := newTestClient(&mockHTTPUnreachable{})
+		if _, err := client.SubmitTransactionAny(ctx, &Transaction{RawTx: testRawTx}); err == nil || errors.Is(err, ErrSubmissionQueued) {
+			t.Fatalf("expected the submission to fail, got: %v", err)
+		} else if client.QueuedSubmissions() != 0 {
+			t.Fatalf("expected no queued submissions")
+		}
+	})
+
+	t.Run("over budget is not queued", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		client.Options.OfflineQueueSize = 1
+		for _, miner := range client.Miners {
+			miner.HourlyBudget = 1
+			client.budgets.take(miner, time.Now())
+		}
+		if _, err := client.SubmitTransactionAny(ctx, &Transaction{RawTx: testRawTx}); err == nil ||
+			errors.Is(err, ErrSubmissionQueued) || !strings.Contains(err.Error(), ErrBudgetExceeded.Error()) {
+			t.Fatalf("expected the submission to fail over budget, got: %v", err)
+		} else if client.QueuedSubmissions() != 0 {
+			t.Fatalf("expected no queued submissions")
+		}
+	})
+
+	t.Run("cancelled context is not queued", func(t *testing.T) {
+		client := newTestClient(&mockHTTPUnreachable{})
+		client.Options.OfflineQueueSize = 1
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := client.SubmitTransactionAny(cancelled, &Transaction{RawTx: testRawTx}); !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected error: %v got: %v", context.Canceled, err)
+		} else if client.QueuedSubmissions() != 0 {
+			t.Fatalf("expected no queued submissions")
+		}
+	})
+
+	t.Run("queued and flushed after recovery", func(t *testing.T) {
+		client := newTestClient(&mockHTTPUnreachable{})
+		client.Options.OfflineQueueSize = 1
+		events, unsubscribe := client.Subscribe(1, EventQueuedTxFlushed)
+		defer unsubscribe()
+
+		if _, err := client.SubmitTransactionAny(ctx, &Transaction{RawTx: testRawTx}); !errors.Is(err, ErrSubmissionQueued) {
+			t.Fatalf("expected error: %v got: %v", ErrSubmissionQueued, err)
+		} else if _, err = client.SubmitTransactionAny(ctx, &Transaction{RawTx: testRawTx}); !errors.Is(err, ErrOfflineQueueFull) {
+			t.Fatalf("expected error: %v got: %v", ErrOfflineQueueFull, err)
+		} else if client.QueuedSubmissions() != 1 {
+			t.Fatalf("expected 1 queued submission, got: %d", client.QueuedSubmissions())
+		}
+
+		// Still unreachable (the submission stays queued)
+		client.FlushQueue(ctx, client.MinerByName(MinerTaal))
+		if client.QueuedSubmissions() != 1 {
+			t.Fatalf("expected the submission to stay queued")
+		}
+
+		// The miner recovered
+		client.httpClient = &mockHTTPRecovered{}
+		if health := client.HealthCheck(ctx, client.MinerByName(MinerTaal)); !health.Healthy {
+			t.Fatalf("expected the miner to be healthy: %s", health.Error)
+		}
+		select {
+		case event := <-events:
+			if event.Submission == nil || event.Submission.Error != nil || event.Submission.Response == nil {
+				t.Fatalf("expected the flushed submission result, got: %+v", event.Submission)
+			} else if event.Miner.Name != MinerTaal {
+				t.Fatalf("expected the submission to be flushed to: %s", MinerTaal)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("expected the queue to be flushed")
+		}
+		if client.QueuedSubmissions() != 0 {
+			t.Fatalf("expected no queued submissions")
+		}
+	})
+}
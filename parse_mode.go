@@ -0,0 +1,99 @@
+package minercraft
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ParseMode is how strictly the responses of miners are parsed (see: ClientOptions.ParseMode)
+type ParseMode string
+
+const (
+
+	// ParseModeLenient ignores unknown fields and missing fields (the default)
+	ParseModeLenient ParseMode = "lenient"
+
+	// ParseModeStrict rejects responses with unknown fields or missing required fields (BRFC specs),
+	// detects miners that deviate from the specs
+	ParseModeStrict ParseMode = "strict"
+)
+
+// Strict parse errors (see: EnvelopeError)
+var (
+	ErrEnvelopeMissingField = errors.New("envelope is missing a required field")
+	ErrEnvelopeUnknownField = errors.New("envelope has an unknown field")
+)
+
+// envelopeFields are the required fields of an envelope
+var envelopeFields = []string{"encoding", "mimetype", "payload", "publicKey", "signature"}
+
+// WithParseMode will change the parse mode of the clone (IE: strict for compliance checks)
+func WithParseMode(mode ParseMode) CloneOption {
+	return func(c *Client) {
+		c.Options.ParseMode = mode
+	}
+}
+
+// strictParsing will return true if the client parses in strict mode
+func (c *Client) strictParsing() bool {
+	return c != nil && c.Options != nil && c.Options.ParseMode == ParseModeStrict
+}
+
+// requiredPayloadFields will return the required fields of a payload (BRFC specs)
+func requiredPayloadFields(v interface{}) []string {
+	switch v.(type) {
+	case **FeePayload:
+		return []string{
+			"apiVersion", "currentHighestBlockHash", "currentHighestBlockHeight", "expiryTime", "fees",
+			"minerId", "timestamp",
+		}
+	case **QueryPayload:
+		return []string{
+			"apiVersion", "minerId", "resultDescription", "returnResult", "timestamp", "txid",
+		}
+	case **SubmissionPayload:
+		return []string{
+			"apiVersion", "currentHighestBlockHash", "currentHighestBlockHeight", "minerId", "resultDescription",
+			"returnResult", "timestamp", "txSecondMempoolExpiry", "txid",
+		}
+	case **CallbackPayload:
+		return []string{
+			"apiVersion", "blockHash", "blockHeight", "callbackPayload", "callbackReason", "callbackTxId",
+			"minerId", "timestamp",
+		}
+	}
+	return nil
+}
+
+// decodeStrict will decode the JSON into v, rejecting unknown fields and missing required fields
+func decodeStrict(field string, data []byte, v interface{}, required []string) error {
+
+	// Unknown fields
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		if name := strings.TrimPrefix(err.Error(), "json: unknown field "); name != err.Error() {
+			unknown, _ := strconv.Unquote(name)
+			return &EnvelopeError{Field: strings.TrimPrefix(field+"."+unknown, "."), Reason: ErrEnvelopeUnknownField}
+		}
+		return envelopeDecodeError(field, err)
+	}
+
+	// Required fields
+	if len(required) == 0 {
+		return nil
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return envelopeDecodeError(field, err)
+	}
+	for _, name := range required {
+		if _, ok := fields[name]; !ok {
+			return &EnvelopeError{Field: strings.TrimPrefix(field+"."+name, "."), Reason: ErrEnvelopeMissingField}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package minercraft
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestWithParseMode tests the method WithParseMode()
+func TestWithParseMode(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid responses", func(t *testing.T) {
+		client := addTestCallbackMiner(newTestClient(&mockHTTPValidSubmission{})).With(WithParseMode(ParseModeStrict))
+		if _, err := client.SubmitTransaction(client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx}); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if _, err = client.ParseCallback([]byte(testCallback)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	})
+
+	t.Run("deviating responses", func(t *testing.T) {
+		var tests = []struct {
+			client   *Client
+			expected error
+			field    string
+		}{
+			{newTestClient(&mockHTTPValidQuery{}), nil, ""},
+			{newTestClient(&mockHTTPValidQuery{}).With(WithParseMode(ParseModeStrict)), ErrEnvelopeMissingField, "payload.txid"},
+			{newTestClient(&mockHTTPValidBestQuote{}).With(WithParseMode(ParseModeStrict)), ErrEnvelopeUnknownField, "payload.id"},
+		}
+		for _, test := range tests {
+			var err error
+			if test.field == "payload.id" {
+				_, err = test.client.FeeQuote(test.client.MinerByName(MinerTaal))
+			} else {
+				_, err = test.client.QueryTransaction(test.client.MinerByName(MinerMatterpool), testTx)
+			}
+			var envelopeErr *EnvelopeError
+			if !errors.Is(err, test.expected) || (test.expected != nil && (!errors.As(err, &envelopeErr) ||
+				envelopeErr.Field != test.field)) {
+				t.Errorf("%s Failed: [%s] inputted and [%v] expected, received: [%v]", t.Name(), test.field, test.expected, err)
+			}
+		}
+	})
+
+	t.Run("unknown envelope fields", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{}).With(WithParseMode(ParseModeStrict))
+		if _, err := client.ParseCallback([]byte(`{"payload":"{}","signature":null,"publicKey":null,"extra":1}`)); !errors.Is(
+			err, ErrEnvelopeUnknownField,
+		) {
+			t.Fatalf("expected error: %v got: %v", ErrEnvelopeUnknownField, err)
+		}
+	})
+}
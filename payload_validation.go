@@ -0,0 +1,160 @@
+package minercraft
+
+import (
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// SchemaViolation is a field of a payload that does not match the BRFC specs (see: ValidatePayload)
+type SchemaViolation struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+	Value  string `json:"value,omitempty"`
+}
+
+// payloadSchema collects the violations of a payload
+type payloadSchema struct {
+	violations []*SchemaViolation
+}
+
+// WithPayloadValidation will validate the payloads of the responses of the clone (see: ClientOptions.ValidatePayloads)
+func WithPayloadValidation() CloneOption {
+	return func(c *Client) {
+		c.Options.ValidatePayloads = true
+	}
+}
+
+// ValidatePayload will validate a decoded payload against the BRFC specs (required fields, hex hashes and
+// RFC3339 timestamps) and return the violations (nil if the payload is valid or the type is unknown)
+//
+// Supported payloads: *FeePayload, *QueryPayload, *SubmissionPayload and *CallbackPayload
+func ValidatePayload(payload interface{}) []*SchemaViolation {
+	s := new(payloadSchema)
+	switch p := payload.(type) {
+	case **FeePayload:
+		return ValidatePayload(*p)
+	case **QueryPayload:
+		return ValidatePayload(*p)
+	case **SubmissionPayload:
+		return ValidatePayload(*p)
+	case **CallbackPayload:
+		return ValidatePayload(*p)
+	case *FeePayload:
+		if p == nil {
+			return nil
+		}
+		s.required("apiVersion", p.APIVersion)
+		s.timestamp("timestamp", p.Timestamp)
+		s.timestamp("expiryTime", p.ExpirationTime)
+		s.minerID(p.MinerID)
+		s.hash("currentHighestBlockHash", p.CurrentHighestBlockHash, true)
+		if p.CurrentHighestBlockHeight == 0 {
+			s.add("currentHighestBlockHeight", "required", "")
+		}
+		if len(p.Fees) == 0 {
+			s.add("fees", "required", "")
+		}
+		for _, fee := range p.Fees {
+			if fee == nil {
+				s.add("fees", "invalid fee", "null")
+				continue
+			} else if fee.FeeType != FeeTypeStandard && fee.FeeType != FeeTypeData {
+				s.add("fees.feeType", "unknown fee type", fee.FeeType)
+			}
+			if fee.MiningFee == nil || fee.MiningFee.Bytes == 0 {
+				s.add("fees.miningFee", "bytes must be greater than zero", fee.FeeType)
+			}
+			if fee.RelayFee == nil || fee.RelayFee.Bytes == 0 {
+				s.add("fees.relayFee", "bytes must be greater than zero", fee.FeeType)
+			}
+		}
+	case *QueryPayload:
+		if p == nil {
+			return nil
+		}
+		s.required("apiVersion", p.APIVersion)
+		s.timestamp("timestamp", p.Timestamp)
+		s.hash("txid", p.TxID, true)
+		s.returnResult(p.ReturnResult)
+		s.minerID(p.MinerID)
+		s.hash("blockHash", p.BlockHash, false)
+	case *SubmissionPayload:
+		if p == nil {
+			return nil
+		}
+		s.required("apiVersion", p.APIVersion)
+		s.timestamp("timestamp", p.Timestamp)
+		s.hash("txid", p.TxID, strings.EqualFold(p.ReturnResult, ReturnResultSuccess))
+		s.returnResult(p.ReturnResult)
+		s.minerID(p.MinerID)
+		s.hash("currentHighestBlockHash", p.CurrentHighestBlockHash, true)
+		if p.CurrentHighestBlockHeight <= 0 {
+			s.add("currentHighestBlockHeight", "required", "")
+		}
+	case *CallbackPayload:
+		if p == nil {
+			return nil
+		}
+		s.required("apiVersion", p.APIVersion)
+		s.timestamp("timestamp", p.Timestamp)
+		s.minerID(p.MinerID)
+		s.hash("blockHash", p.BlockHash, true)
+		s.hash("callbackTxId", p.CallbackTxID, true)
+		s.required("callbackPayload", p.CallbackPayload)
+		switch p.CallbackReason {
+		case CallbackReasonDoubleSpend, CallbackReasonDoubleSpendAttempt, CallbackReasonMerkleProof:
+		default:
+			s.add("callbackReason", "unknown callback reason", p.CallbackReason)
+		}
+	}
+	return s.violations
+}
+
+// add will add a violation
+func (s *payloadSchema) add(field, reason, value string) {
+	s.violations = append(s.violations, &SchemaViolation{Field: field, Reason: reason, Value: value})
+}
+
+// required will check that the field is set
+func (s *payloadSchema) required(field, value string) {
+	if len(value) == 0 {
+		s.add(field, "required", "")
+	}
+}
+
+// timestamp will check that the field is an RFC3339 timestamp
+func (s *payloadSchema) timestamp(field, value string) {
+	if len(value) == 0 {
+		s.add(field, "required", "")
+	} else if _, err := time.Parse(time.RFC3339, value); err != nil {
+		s.add(field, "invalid RFC3339 timestamp", value)
+	}
+}
+
+// hash will check that the field is a hex hash (32 bytes)
+func (s *payloadSchema) hash(field, value string, required bool) {
+	if len(value) == 0 {
+		if required {
+			s.add(field, "required", "")
+		}
+	} else if !IsValidTxID(value) {
+		s.add(field, "invalid hex hash", value)
+	}
+}
+
+// minerID will check that the minerId is a compressed public key (hex) if set (null if the miner has no minerId)
+func (s *payloadSchema) minerID(value string) {
+	if len(value) == 0 {
+		return
+	} else if data, err := hex.DecodeString(value); err != nil || len(data) != 33 || (data[0] != 2 && data[0] != 3) {
+		s.add("minerId", "invalid public key", value)
+	}
+}
+
+// returnResult will check that the return result is success or failure
+func (s *payloadSchema) returnResult(value string) {
+	if !strings.EqualFold(value, ReturnResultSuccess) && !strings.EqualFold(value, ReturnResultFailure) {
+		s.add("returnResult", "unknown return result", value)
+	}
+}
@@ -0,0 +1,74 @@
+package minercraft
+
+import (
+	"testing"
+)
+
+// TestValidatePayload tests the method ValidatePayload()
+func TestValidatePayload(t *testing.T) {
+	t.Parallel()
+
+	validCallback := &CallbackPayload{
+		APIVersion:      testAPIVersion,
+		BlockHash:       testTx,
+		BlockHeight:     5,
+		CallbackPayload: "{}",
+		CallbackReason:  CallbackReasonMerkleProof,
+		CallbackTxID:    testSubmittedTx,
+		Timestamp:       "2020-11-03T13:24:31.233647Z",
+	}
+	invalidCallback := *validCallback
+	invalidCallback.BlockHash, invalidCallback.CallbackReason, invalidCallback.Timestamp = "xyz", "unknown", "yesterday"
+
+	var tests = []struct {
+		payload  interface{}
+		expected []string
+	}{
+		{validCallback, nil},
+		{&invalidCallback, []string{"timestamp", "blockHash", "callbackReason"}},
+		{&QueryPayload{APIVersion: testAPIVersion, ReturnResult: "ok", Timestamp: "2020-10-10T13:07:26.014Z", TxID: testTx}, []string{"returnResult"}},
+		{&SubmissionPayload{ReturnResult: ReturnResultFailure, MinerID: "03ab"}, []string{
+			"apiVersion", "timestamp", "minerId", "currentHighestBlockHash", "currentHighestBlockHeight",
+		}},
+		{&FeePayload{}, []string{
+			"apiVersion", "timestamp", "expiryTime", "currentHighestBlockHash", "currentHighestBlockHeight", "fees",
+		}},
+		{"unknown", nil},
+	}
+	for _, test := range tests {
+		violations := ValidatePayload(test.payload)
+		fields := make([]string, 0, len(violations))
+		for _, violation := range violations {
+			fields = append(fields, violation.Field)
+		}
+		if len(fields) != len(test.expected) {
+			t.Errorf("%s Failed: [%T] inputted and [%v] expected, received: [%v]", t.Name(), test.payload, test.expected, fields)
+			continue
+		}
+		for index := range fields {
+			if fields[index] != test.expected[index] {
+				t.Errorf("%s Failed: [%T] inputted and [%v] expected, received: [%v]", t.Name(), test.payload, test.expected, fields)
+				break
+			}
+		}
+	}
+}
+
+// TestWithPayloadValidation tests the method WithPayloadValidation()
+func TestWithPayloadValidation(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidQuery{})
+	response, err := client.With(WithPayloadValidation()).QueryTransaction(client.MinerByName(MinerMatterpool), testTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(response.Violations) != 1 || response.Violations[0].Field != "txid" {
+		t.Fatalf("expected the missing txid, got: %v", response.Violations)
+	}
+
+	if response, err = client.QueryTransaction(client.MinerByName(MinerMatterpool), testTx); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if response.Violations != nil {
+		t.Fatalf("expected no validation by default")
+	}
+}
@@ -0,0 +1,214 @@
+package minercraft
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+/*
+The payloads of submissions and queries differ between mAPI versions, each version is decoded into its own
+struct and mapped into the stable payload types (SubmissionPayload and QueryPayload):
+
+  - 1.2: conflictedWith is a txid (string)
+  - 1.4: conflictedWith is a list of transactions, submissions add failureRetryable and warnings,
+    queries add the merkleProof (if requested)
+*/
+
+// ConflictedTx is a transaction that conflicts with a submission (mAPI 1.4, IE: a double spend)
+type ConflictedTx struct {
+	Hex  string `json:"hex,omitempty"`
+	Size int64  `json:"size,omitempty"`
+	TxID string `json:"txid"`
+}
+
+// submissionPayloadV12 is the payload of a submission (mAPI 1.2)
+type submissionPayloadV12 struct {
+	APIVersion                string `json:"apiVersion"`
+	ConflictedWith            string `json:"conflictedWith"`
+	CurrentHighestBlockHash   string `json:"currentHighestBlockHash"`
+	CurrentHighestBlockHeight int64  `json:"currentHighestBlockHeight"`
+	MinerID                   string `json:"minerId"`
+	ResultDescription         string `json:"resultDescription"`
+	ReturnResult              string `json:"returnResult"`
+	Timestamp                 string `json:"timestamp"`
+	TxID                      string `json:"txid"`
+	TxSecondMempoolExpiry     int64  `json:"txSecondMempoolExpiry"`
+}
+
+// submissionPayloadV14 is the payload of a submission (mAPI 1.4)
+type submissionPayloadV14 struct {
+	APIVersion                string          `json:"apiVersion"`
+	ConflictedWith            []*ConflictedTx `json:"conflictedWith"`
+	CurrentHighestBlockHash   string          `json:"currentHighestBlockHash"`
+	CurrentHighestBlockHeight int64           `json:"currentHighestBlockHeight"`
+	FailureRetryable          bool            `json:"failureRetryable"`
+	MinerID                   string          `json:"minerId"`
+	ResultDescription         string          `json:"resultDescription"`
+	ReturnResult              string          `json:"returnResult"`
+	Timestamp                 string          `json:"timestamp"`
+	TxID                      string          `json:"txid"`
+	TxSecondMempoolExpiry     int64           `json:"txSecondMempoolExpiry"`
+	Warnings                  []string        `json:"warnings"`
+}
+
+// queryPayloadV12 is the payload of a query (mAPI 1.2)
+type queryPayloadV12 struct {
+	APIVersion            string `json:"apiVersion"`
+	BlockHash             string `json:"blockHash"`
+	BlockHeight           int64  `json:"blockHeight"`
+	Confirmations         int64  `json:"confirmations"`
+	MinerID               string `json:"minerId"`
+	ResultDescription     string `json:"resultDescription"`
+	ReturnResult          string `json:"returnResult"`
+	Timestamp             string `json:"timestamp"`
+	TxID                  string `json:"txid"`
+	TxSecondMempoolExpiry int64  `json:"txSecondMempoolExpiry"`
+}
+
+// queryPayloadV14 is the payload of a query (mAPI 1.4)
+type queryPayloadV14 struct {
+	queryPayloadV12
+	MerkleProof json.RawMessage `json:"merkleProof"`
+}
+
+// submission will map the payload into the stable payload type
+func (p *submissionPayloadV12) submission() *SubmissionPayload {
+	return &SubmissionPayload{
+		APIVersion:                p.APIVersion,
+		ConflictedWith:            p.ConflictedWith,
+		CurrentHighestBlockHash:   p.CurrentHighestBlockHash,
+		CurrentHighestBlockHeight: p.CurrentHighestBlockHeight,
+		MinerID:                   p.MinerID,
+		Reason:                    ClassifyResult(p.ResultDescription),
+		ResultDescription:         p.ResultDescription,
+		ReturnResult:              p.ReturnResult,
+		Timestamp:                 p.Timestamp,
+		TxID:                      p.TxID,
+		TxSecondMempoolExpiry:     p.TxSecondMempoolExpiry,
+	}
+}
+
+// submission will map the payload into the stable payload type (conflictedWith is the list of txids)
+func (p *submissionPayloadV14) submission() *SubmissionPayload {
+	txIDs := make([]string, 0, len(p.ConflictedWith))
+	for _, conflicted := range p.ConflictedWith {
+		if conflicted != nil {
+			txIDs = append(txIDs, conflicted.TxID)
+		}
+	}
+	return &SubmissionPayload{
+		APIVersion:                p.APIVersion,
+		ConflictedTxs:             p.ConflictedWith,
+		ConflictedWith:            strings.Join(txIDs, ","),
+		CurrentHighestBlockHash:   p.CurrentHighestBlockHash,
+		CurrentHighestBlockHeight: p.CurrentHighestBlockHeight,
+		FailureRetryable:          p.FailureRetryable,
+		MinerID:                   p.MinerID,
+		Reason:                    ClassifyResult(p.ResultDescription),
+		ResultDescription:         p.ResultDescription,
+		ReturnResult:              p.ReturnResult,
+		Timestamp:                 p.Timestamp,
+		TxID:                      p.TxID,
+		TxSecondMempoolExpiry:     p.TxSecondMempoolExpiry,
+		Warnings:                  p.Warnings,
+	}
+}
+
+// query will map the payload into the stable payload type
+func (p *queryPayloadV12) query() *QueryPayload {
+	return &QueryPayload{
+		APIVersion:            p.APIVersion,
+		BlockHash:             p.BlockHash,
+		BlockHeight:           p.BlockHeight,
+		Confirmations:         p.Confirmations,
+		MinerID:               p.MinerID,
+		Reason:                ClassifyResult(p.ResultDescription),
+		ResultDescription:     p.ResultDescription,
+		ReturnResult:          p.ReturnResult,
+		Timestamp:             p.Timestamp,
+		TxID:                  p.TxID,
+		TxSecondMempoolExpiry: p.TxSecondMempoolExpiry,
+	}
+}
+
+// query will map the payload into the stable payload type
+func (p *queryPayloadV14) query() *QueryPayload {
+	query := p.queryPayloadV12.query()
+	query.MerkleProof = p.MerkleProof
+	return query
+}
+
+// decodeVersionedPayload will decode a submission or query payload using the struct of its mAPI version
+// (returns false if the payload type is not versioned)
+func decodeVersionedPayload(data []byte, v interface{}, strict bool) (bool, error) {
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		return false, nil
+	}
+	v14 := isAPIVersion14(data)
+	switch facade := v.(type) {
+	case **SubmissionPayload:
+		if v14 {
+			payload := new(submissionPayloadV14)
+			if err := decodePayloadData(data, payload, strict, requiredPayloadFields(v)); err != nil {
+				return true, err
+			}
+			*facade = payload.submission()
+		} else {
+			payload := new(submissionPayloadV12)
+			if err := decodePayloadData(data, payload, strict, requiredPayloadFields(v)); err != nil {
+				return true, err
+			}
+			*facade = payload.submission()
+		}
+		return true, nil
+	case **QueryPayload:
+		if v14 {
+			payload := new(queryPayloadV14)
+			if err := decodePayloadData(data, payload, strict, requiredPayloadFields(v)); err != nil {
+				return true, err
+			}
+			*facade = payload.query()
+		} else {
+			payload := new(queryPayloadV12)
+			if err := decodePayloadData(data, payload, strict, requiredPayloadFields(v)); err != nil {
+				return true, err
+			}
+			*facade = payload.query()
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// decodePayloadData will decode the payload into v (strict or lenient)
+func decodePayloadData(data []byte, v interface{}, strict bool, required []string) error {
+	if strict {
+		return decodeStrict("payload", data, v, required)
+	}
+	return envelopeDecodeError("payload", json.Unmarshal(data, v))
+}
+
+// isAPIVersion14 will return true if the apiVersion of the payload is 1.3 or later (the 1.4 payloads)
+func isAPIVersion14(data []byte) bool {
+	var payload struct {
+		APIVersion string `json:"apiVersion"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return false
+	}
+	parts := strings.SplitN(payload.APIVersion, ".", 3)
+	if len(parts) < 2 {
+		return false
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+	var minor int
+	if minor, err = strconv.Atoi(parts[1]); err != nil {
+		return false
+	}
+	return major > 1 || (major == 1 && minor >= 3)
+}
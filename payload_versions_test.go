@@ -0,0 +1,59 @@
+package minercraft
+
+import (
+	"testing"
+)
+
+// TestDecodeVersionedPayload tests the method decodeVersionedPayload()
+func TestDecodeVersionedPayload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("submissions", func(t *testing.T) {
+		var tests = []struct {
+			payload        string
+			conflictedWith string
+			conflicts      int
+			retryable      bool
+		}{
+			{`{"apiVersion":"1.2.0","returnResult":"failure","conflictedWith":"` + testTx + `"}`, testTx, 0, false},
+			{`{"apiVersion":"1.4.0","returnResult":"failure","failureRetryable":true,"conflictedWith":[{"txid":"` +
+				testTx + `","size":191,"hex":"0100"},{"txid":"` + testSubmittedTx + `"}]}`, testTx + "," + testSubmittedTx, 2, true},
+			{`{"apiVersion":"1.4.0","returnResult":"success","warnings":["missing inputs"]}`, "", 0, false},
+		}
+		for _, test := range tests {
+			envelope := &JSONEnvelope{payload: []byte(test.payload)}
+			var payload *SubmissionPayload
+			if err := envelope.decodePayload(&payload); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			} else if payload.ConflictedWith != test.conflictedWith || len(payload.ConflictedTxs) != test.conflicts ||
+				payload.FailureRetryable != test.retryable {
+				t.Errorf("%s Failed: [%s] inputted and [%s %d %v] expected, received: [%s %d %v]", t.Name(), test.payload,
+					test.conflictedWith, test.conflicts, test.retryable,
+					payload.ConflictedWith, len(payload.ConflictedTxs), payload.FailureRetryable)
+			}
+		}
+	})
+
+	t.Run("queries", func(t *testing.T) {
+		envelope := &JSONEnvelope{payload: []byte(`{"apiVersion":"1.4.0","txid":"` + testTx + `","merkleProof":{"index":1}}`)}
+		var payload *QueryPayload
+		if err := envelope.decodePayload(&payload); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if string(payload.MerkleProof) != `{"index":1}` || payload.TxID != testTx {
+			t.Fatalf("expected the merkle proof, got: %s", payload.MerkleProof)
+		}
+
+		// Fields of another version are unknown in strict mode
+		envelope = &JSONEnvelope{payload: []byte(`{"apiVersion":"1.2.0","merkleProof":{}}`), strict: true}
+		if err := envelope.decodePayload(&payload); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+
+		// Null payloads are not mapped
+		envelope = &JSONEnvelope{payload: []byte(`null`)}
+		payload = nil
+		if err := envelope.decodePayload(&payload); err != nil || payload != nil {
+			t.Fatalf("expected no payload, got: %v %v", payload, err)
+		}
+	})
+}
@@ -0,0 +1,193 @@
+package minercraft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrDataTooLarge is returned when the data bytes of a transaction exceed the datacarriersize policy of a miner
+var ErrDataTooLarge = errors.New("transaction data exceeds the data carrier size")
+
+/*
+Example policyQuote payload "policies" (mAPI v1.4):
+
+{
+  "skipscriptflags": ["MINIMALDATA", "DERSIG", "NULLDUMMY", "DISCOURAGE_UPGRADABLE_NOPS", "CLEANSTACK"],
+  "maxtxsizepolicy": 99999,
+  "datacarriersize": 100000,
+  "maxscriptsizepolicy": 100000,
+  "maxscriptnumlengthpolicy": 100000,
+  "maxstackmemoryusagepolicy": 10000000,
+  "limitancestorcount": 1000,
+  "limitcpfpgroupmemberscount": 10,
+  "acceptnonstdoutputs": true,
+  "datacarrier": true,
+  "maxstdtxvalidationduration": 99,
+  "maxnonstdtxvalidationduration": 100,
+  "minconsolidationfactor": 10,
+  "maxconsolidationinputscriptsize": 100,
+  "minconfconsolidationinput": 10,
+  "acceptnonstdconsolidationinput": false,
+  "dustlimitfactor": 300,
+  "dustrelayfee": 150
+}
+*/
+
+// Policies is the unmarshalled version of the policies of a miner (policy quotes)
+//
+// Keys that are not known fields are kept in Extra (and written back when marshalling)
+//
+// Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi#get-policy-quote
+type Policies struct {
+	ConsolidationPolicy
+	AcceptNonStdOutputs           bool                   `json:"acceptnonstdoutputs,omitempty"`
+	DataCarrier                   bool                   `json:"datacarrier,omitempty"`
+	DataCarrierSize               uint64                 `json:"datacarriersize,omitempty"`               // Max bytes of data outputs
+	DustLimitFactor               uint64                 `json:"dustlimitfactor,omitempty"`               // Percent of the dust relay fee (see: DustThreshold)
+	DustRelayFee                  uint64                 `json:"dustrelayfee,omitempty"`                  // Satoshis per 1000 bytes
+	Extra                         map[string]interface{} `json:"-"`                                       // Unknown policy keys
+	LimitAncestorCount            uint64                 `json:"limitancestorcount,omitempty"`            // Max unconfirmed ancestors
+	LimitCPFPGroupMembersCount    uint64                 `json:"limitcpfpgroupmemberscount,omitempty"`    // Max members of a CPFP group
+	MaxNonStdTxValidationDuration uint64                 `json:"maxnonstdtxvalidationduration,omitempty"` // Milliseconds
+	MaxScriptNumLengthPolicy      uint64                 `json:"maxscriptnumlengthpolicy,omitempty"`
+	MaxScriptSizePolicy           uint64                 `json:"maxscriptsizepolicy,omitempty"`
+	MaxStackMemoryUsagePolicy     uint64                 `json:"maxstackmemoryusagepolicy,omitempty"`
+	MaxStdTxValidationDuration    uint64                 `json:"maxstdtxvalidationduration,omitempty"` // Milliseconds
+	MaxTxSizePolicy               uint64                 `json:"maxtxsizepolicy,omitempty"`
+	SkipScriptFlags               []string               `json:"skipscriptflags,omitempty"`
+
+	raw json.RawMessage // The policies as received (see: FeePayload.ConsolidationPolicy)
+}
+
+// policiesJSON is Policies without the JSON methods (avoids recursion)
+type policiesJSON Policies
+
+// policyKeys are the JSON keys of the known policy fields
+var policyKeys = jsonKeys(reflect.TypeOf(Policies{}))
+
+// UnmarshalJSON will unmarshal the known policy fields and keep the unknown keys in Extra
+func (p *Policies) UnmarshalJSON(data []byte) error {
+
+	// Decode the known fields
+	var policies policiesJSON
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return err
+	}
+
+	// Decode the unknown keys
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	for key, value := range values {
+		if !policyKeys[key] {
+			if policies.Extra == nil {
+				policies.Extra = make(map[string]interface{})
+			}
+			policies.Extra[key] = value
+		}
+	}
+
+	policies.raw = append(json.RawMessage(nil), data...)
+	*p = Policies(policies)
+	return nil
+}
+
+// MarshalJSON will marshal the known policy fields and the keys in Extra (known fields take precedence)
+func (p Policies) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(policiesJSON(p))
+	if err != nil || len(p.Extra) == 0 {
+		return data, err
+	}
+
+	var values map[string]interface{}
+	if err = json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	for key, value := range p.Extra {
+		if !policyKeys[key] {
+			values[key] = value
+		}
+	}
+	return json.Marshal(values)
+}
+
+// CheckTx will return an error if the policies reject a transaction of the given size
+// (ErrTxTooLarge for maxtxsizepolicy and ErrDataTooLarge for datacarriersize)
+//
+// Policies that are not reported (0) are not checked
+func (p *Policies) CheckTx(txSize *TxSize) error {
+	if txSize == nil {
+		return errors.New("tx size was nil")
+	} else if !PolicyAllowsTxSize(p, txSize.TotalBytes()) {
+		return fmt.Errorf("%w: %d bytes (max %d)", ErrTxTooLarge, txSize.TotalBytes(), p.MaxTxSizePolicy)
+	} else if !PolicyAllowsDataSize(p, txSize.DataBytes) {
+		return fmt.Errorf("%w: %d bytes (max %d)", ErrDataTooLarge, txSize.DataBytes, p.DataCarrierSize)
+	}
+	return nil
+}
+
+// PermitsTx will return true if the policies accept a transaction of the given size (see: CheckTx)
+func (p *Policies) PermitsTx(txSize *TxSize) bool {
+	return p.CheckTx(txSize) == nil
+}
+
+// PolicyAllowsScriptSize will return true if the policy accepts a script of the given size (maxscriptsizepolicy)
+//
+// A nil policy or a policy that is not reported (0) allows any size
+func PolicyAllowsScriptSize(policy *Policies, scriptBytes uint64) bool {
+	return policy == nil || policy.MaxScriptSizePolicy == 0 || scriptBytes <= policy.MaxScriptSizePolicy
+}
+
+// PolicyAllowsDataSize will return true if the policy accepts data outputs of the given size (datacarriersize),
+// IE: gating the max file size for on-chain storage on the live policy of a miner
+//
+// A nil policy or a policy that is not reported (0) allows any size
+func PolicyAllowsDataSize(policy *Policies, dataBytes uint64) bool {
+	return policy == nil || policy.DataCarrierSize == 0 || dataBytes <= policy.DataCarrierSize
+}
+
+// PolicyAllowsTxSize will return true if the policy accepts a transaction of the given size (maxtxsizepolicy)
+//
+// A nil policy or a policy that is not reported (0) allows any size
+func PolicyAllowsTxSize(policy *Policies, txBytes uint64) bool {
+	return policy == nil || policy.MaxTxSizePolicy == 0 || txBytes <= policy.MaxTxSizePolicy
+}
+
+// copyPolicies will return a copy of the policies (slices and maps are copied)
+func copyPolicies(policies *Policies) *Policies {
+	if policies == nil {
+		return nil
+	}
+	policiesCopy := *policies
+	policiesCopy.SkipScriptFlags = append([]string(nil), policies.SkipScriptFlags...)
+	policiesCopy.raw = append(json.RawMessage(nil), policies.raw...)
+	if policies.Extra != nil {
+		policiesCopy.Extra = make(map[string]interface{}, len(policies.Extra))
+		for key, value := range policies.Extra {
+			policiesCopy.Extra[key] = value
+		}
+	}
+	return &policiesCopy
+}
+
+// jsonKeys will return the JSON keys of the struct fields (including embedded structs)
+func jsonKeys(structType reflect.Type) map[string]bool {
+	keys := make(map[string]bool)
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			for key := range jsonKeys(field.Type) {
+				keys[key] = true
+			}
+			continue
+		}
+		if name := strings.Split(field.Tag.Get("json"), ",")[0]; name != "" && name != "-" {
+			keys[name] = true
+		}
+	}
+	return keys
+}
@@ -0,0 +1,175 @@
+package minercraft
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// testPolicies is an example of the policies of a policy quote (with an unknown key)
+const testPolicies = `{"skipscriptflags":["MINIMALDATA","DERSIG"],"maxtxsizepolicy":99999,"datacarriersize":100000,` +
+	`"maxscriptsizepolicy":100000,"limitancestorcount":1000,"acceptnonstdoutputs":true,"datacarrier":true,` +
+	`"minconsolidationfactor":10,"maxconsolidationinputscriptsize":100,"maxtxsigopscountspolicy":300}`
+
+// TestPolicies_UnmarshalJSON tests the method UnmarshalJSON()
+func TestPolicies_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known and unknown keys", func(t *testing.T) {
+		policies := new(Policies)
+		if err := json.Unmarshal([]byte(testPolicies), policies); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		if policies.MaxTxSizePolicy != 99999 || policies.DataCarrierSize != 100000 ||
+			policies.MaxScriptSizePolicy != 100000 || policies.LimitAncestorCount != 1000 ||
+			!policies.AcceptNonStdOutputs || !policies.DataCarrier || len(policies.SkipScriptFlags) != 2 {
+			t.Fatalf("expected the known fields, got: %+v", policies)
+		} else if policies.MinConsolidationFactor != 10 || policies.MaxConsolidationInputScriptSize != 100 {
+			t.Fatalf("expected the consolidation fields, got: %+v", policies.ConsolidationPolicy)
+		} else if len(policies.Extra) != 1 || policies.Extra["maxtxsigopscountspolicy"] != float64(300) {
+			t.Fatalf("expected only the unknown key in extra, got: %v", policies.Extra)
+		}
+	})
+
+	t.Run("invalid policies", func(t *testing.T) {
+		if err := json.Unmarshal([]byte(`{"maxtxsizepolicy":"big"}`), new(Policies)); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("fee payload", func(t *testing.T) {
+		quote := new(FeePayload)
+		if err := json.Unmarshal([]byte(`{"fees":[],"policies":`+testPolicies+`}`), quote); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if quote.Policies == nil || quote.Policies.DataCarrierSize != 100000 {
+			t.Fatalf("expected the policies, got: %+v", quote.Policies)
+		}
+	})
+}
+
+// TestPolicies_MarshalJSON tests the method MarshalJSON()
+func TestPolicies_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	policies := new(Policies)
+	if err := json.Unmarshal([]byte(testPolicies), policies); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	policies.Extra["maxtxsizepolicy"] = 1 // Known fields take precedence
+
+	data, err := json.Marshal(policies)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	decoded := new(Policies)
+	if err = json.Unmarshal(data, decoded); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if decoded.MaxTxSizePolicy != 99999 || decoded.Extra["maxtxsigopscountspolicy"] != float64(300) ||
+		decoded.MinConsolidationFactor != 10 {
+		t.Fatalf("expected the policies to round trip, got: %s", data)
+	}
+}
+
+// TestPolicies_CheckTx tests the method CheckTx()
+func TestPolicies_CheckTx(t *testing.T) {
+	t.Parallel()
+
+	policies := &Policies{DataCarrierSize: 1000, MaxTxSizePolicy: 5000}
+
+	var tests = []struct {
+		txSize        *TxSize
+		expectedError error
+	}{
+		{&TxSize{StandardBytes: 200, DataBytes: 1000}, nil},
+		{&TxSize{StandardBytes: 200, DataBytes: 1001}, ErrDataTooLarge},
+		{&TxSize{StandardBytes: 4500, DataBytes: 501}, ErrTxTooLarge},
+		{&TxSize{StandardBytes: 5000}, nil},
+	}
+	for _, test := range tests {
+		if err := policies.CheckTx(test.txSize); !errors.Is(err, test.expectedError) {
+			t.Errorf("%s Failed: [%+v] inputted and [%v] expected, received: [%v]",
+				t.Name(), test.txSize, test.expectedError, err)
+		}
+	}
+
+	t.Run("unreported policies", func(t *testing.T) {
+		if !(&Policies{}).PermitsTx(&TxSize{StandardBytes: 1000000, DataBytes: 1000000}) {
+			t.Fatalf("expected policies that are not reported to be ignored")
+		}
+	})
+
+	t.Run("nil tx size", func(t *testing.T) {
+		if policies.PermitsTx(nil) {
+			t.Fatalf("expected a nil tx size to be rejected")
+		}
+	})
+}
+
+// TestCopyPolicies tests the method copyPolicies()
+func TestCopyPolicies(t *testing.T) {
+	t.Parallel()
+
+	if copyPolicies(nil) != nil {
+		t.Fatalf("expected nil")
+	}
+
+	policies := new(Policies)
+	if err := json.Unmarshal([]byte(testPolicies), policies); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	policiesCopy := copyPolicies(policies)
+	policiesCopy.SkipScriptFlags[0] = "changed"
+	policiesCopy.Extra["maxtxsigopscountspolicy"] = 1
+	if policies.SkipScriptFlags[0] != "MINIMALDATA" || policies.Extra["maxtxsigopscountspolicy"] != float64(300) {
+		t.Fatalf("expected the original policies to be unchanged")
+	}
+}
+
+// ExamplePolicies_UnmarshalJSON example using UnmarshalJSON()
+func ExamplePolicies_UnmarshalJSON() {
+	policies := new(Policies)
+	if err := json.Unmarshal([]byte(testPolicies), policies); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("datacarriersize: %d extra: %v", policies.DataCarrierSize, policies.Extra)
+	// Output:datacarriersize: 100000 extra: map[maxtxsigopscountspolicy:300]
+}
+
+// TestPolicyAllowsSize tests the methods PolicyAllowsScriptSize(), PolicyAllowsDataSize() and PolicyAllowsTxSize()
+func TestPolicyAllowsSize(t *testing.T) {
+	t.Parallel()
+
+	policies := &Policies{DataCarrierSize: 1000, MaxScriptSizePolicy: 500, MaxTxSizePolicy: 5000}
+
+	var tests = []struct {
+		name     string
+		fn       func(policy *Policies, n uint64) bool
+		policy   *Policies
+		n        uint64
+		expected bool
+	}{
+		{"script size", PolicyAllowsScriptSize, policies, 500, true},
+		{"large script size", PolicyAllowsScriptSize, policies, 501, false},
+		{"data size", PolicyAllowsDataSize, policies, 1000, true},
+		{"large data size", PolicyAllowsDataSize, policies, 1001, false},
+		{"tx size", PolicyAllowsTxSize, policies, 5000, true},
+		{"large tx size", PolicyAllowsTxSize, policies, 5001, false},
+		{"unreported policy", PolicyAllowsDataSize, &Policies{}, 1000000, true},
+		{"nil policy", PolicyAllowsScriptSize, nil, 1000000, true},
+	}
+	for _, test := range tests {
+		if output := test.fn(test.policy, test.n); output != test.expected {
+			t.Errorf("%s Failed: [%s, %d] inputted and [%v] expected, received: [%v]",
+				t.Name(), test.name, test.n, test.expected, output)
+		}
+	}
+}
+
+// ExamplePolicyAllowsDataSize example using PolicyAllowsDataSize()
+func ExamplePolicyAllowsDataSize() {
+	policies := &Policies{DataCarrierSize: 100000}
+	fmt.Printf("90KB file: %v 200KB file: %v", PolicyAllowsDataSize(policies, 90000), PolicyAllowsDataSize(policies, 200000))
+	// Output:90KB file: true 200KB file: false
+}
@@ -0,0 +1,184 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// PolicyComparison is a summary of the policies of all miners (see: ComparePolicies)
+type PolicyComparison struct {
+	Common      *Policies        `json:"common"` // The lowest common denominator (nil if no miner returned policies)
+	ComparedAt  time.Time        `json:"compared_at"`
+	Differences []string         `json:"differences"` // Policy keys that are not the same for all miners (sorted)
+	Miners      []*MinerPolicies `json:"miners"`      // Sorted by miner name
+}
+
+// MinerPolicies is the policies of a miner (or the error if the policy quote failed)
+type MinerPolicies struct {
+	Error    string    `json:"error,omitempty"`
+	Miner    string    `json:"miner"`
+	Policies *Policies `json:"policies,omitempty"`
+}
+
+// ComparePolicies will request a policy quote from all miners and return a summary of the policies,
+// so data-heavy apps know the lowest common denominator they must target
+//
+// The common policies are the strictest value of each policy across the miners (IE: the smallest datacarriersize,
+// a limit that is not reported (0) is ignored). Booleans are only set if all miners set them and the skipped
+// script flags are the flags skipped by all miners. Unknown policy keys are not included in the common policies
+func (c *Client) ComparePolicies(ctx context.Context) *PolicyComparison {
+
+	// Request all policy quotes
+	comparison := &PolicyComparison{ComparedAt: c.now().UTC(), Miners: make([]*MinerPolicies, len(c.Miners))}
+	group, ctx := c.fanOut(ctx)
+	for index, miner := range c.Miners {
+		index, miner := index, miner
+		group.Go(func() error {
+			comparison.Miners[index] = newMinerPolicies(miner, getPolicyQuote(ctx, c, miner))
+			return nil
+		})
+	}
+	_ = group.Wait()
+
+	// Sort by miner
+	sort.SliceStable(comparison.Miners, func(i, j int) bool {
+		return comparison.Miners[i].Miner < comparison.Miners[j].Miner
+	})
+
+	// Summarize the policies of the miners that returned policies
+	var policies []*Policies
+	for _, miner := range comparison.Miners {
+		if miner.Policies != nil {
+			policies = append(policies, miner.Policies)
+		}
+	}
+	comparison.Common = commonPolicies(policies)
+	comparison.Differences = policyDifferences(policies)
+	return comparison
+}
+
+// PoliciesByMiner will return the policies keyed by miner name (miners without policies are not included)
+func (p *PolicyComparison) PoliciesByMiner() map[string]*Policies {
+	policies := make(map[string]*Policies, len(p.Miners))
+	for _, miner := range p.Miners {
+		if miner.Policies != nil {
+			policies[miner.Miner] = miner.Policies
+		}
+	}
+	return policies
+}
+
+// newMinerPolicies will return the policies of the policy quote (or the error)
+func newMinerPolicies(miner *Miner, result *internalResult) *MinerPolicies {
+	policies := &MinerPolicies{Miner: miner.Name}
+	if result.Response.Error != nil {
+		policies.Error = result.Response.Error.Error()
+		return policies
+	}
+	quote, err := result.parsePolicyQuote()
+	if err != nil {
+		policies.Error = err.Error()
+	} else if quote.Quote == nil || quote.Quote.Policies == nil {
+		policies.Error = "failed getting policies from: " + miner.Name
+	} else {
+		policies.Policies = quote.Quote.Policies
+	}
+	return policies
+}
+
+// commonPolicies will return the strictest value of each policy (see: ComparePolicies)
+func commonPolicies(policies []*Policies) *Policies {
+	if len(policies) == 0 {
+		return nil
+	}
+
+	// Start with the first miner
+	common := copyPolicies(policies[0])
+	common.Extra = nil
+	common.raw = nil
+
+	// Narrow down using the other miners
+	for _, p := range policies[1:] {
+		common.AcceptNonStdConsolidationInput = common.AcceptNonStdConsolidationInput && p.AcceptNonStdConsolidationInput
+		common.AcceptNonStdOutputs = common.AcceptNonStdOutputs && p.AcceptNonStdOutputs
+		common.DataCarrier = common.DataCarrier && p.DataCarrier
+		common.DataCarrierSize = minLimit(common.DataCarrierSize, p.DataCarrierSize)
+		common.LimitAncestorCount = minLimit(common.LimitAncestorCount, p.LimitAncestorCount)
+		common.LimitCPFPGroupMembersCount = minLimit(common.LimitCPFPGroupMembersCount, p.LimitCPFPGroupMembersCount)
+		if p.DustLimitFactor > common.DustLimitFactor {
+			common.DustLimitFactor = p.DustLimitFactor
+		}
+		if p.DustRelayFee > common.DustRelayFee {
+			common.DustRelayFee = p.DustRelayFee
+		}
+		common.MaxConsolidationInputScriptSize = minLimit(common.MaxConsolidationInputScriptSize, p.MaxConsolidationInputScriptSize)
+		common.MaxNonStdTxValidationDuration = minLimit(common.MaxNonStdTxValidationDuration, p.MaxNonStdTxValidationDuration)
+		common.MaxScriptNumLengthPolicy = minLimit(common.MaxScriptNumLengthPolicy, p.MaxScriptNumLengthPolicy)
+		common.MaxScriptSizePolicy = minLimit(common.MaxScriptSizePolicy, p.MaxScriptSizePolicy)
+		common.MaxStackMemoryUsagePolicy = minLimit(common.MaxStackMemoryUsagePolicy, p.MaxStackMemoryUsagePolicy)
+		common.MaxStdTxValidationDuration = minLimit(common.MaxStdTxValidationDuration, p.MaxStdTxValidationDuration)
+		common.MaxTxSizePolicy = minLimit(common.MaxTxSizePolicy, p.MaxTxSizePolicy)
+		if p.MinConfConsolidationInput > common.MinConfConsolidationInput {
+			common.MinConfConsolidationInput = p.MinConfConsolidationInput
+		}
+		if p.MinConsolidationFactor > common.MinConsolidationFactor {
+			common.MinConsolidationFactor = p.MinConsolidationFactor
+		}
+		common.SkipScriptFlags = intersectFold(common.SkipScriptFlags, p.SkipScriptFlags)
+	}
+	return common
+}
+
+// policyDifferences will return the policy keys (including unknown keys) that are not the same for all miners
+func policyDifferences(policies []*Policies) []string {
+	values := make([]map[string]interface{}, 0, len(policies))
+	keys := make(map[string]bool)
+	for _, p := range policies {
+		data, err := json.Marshal(p)
+		if err != nil {
+			continue
+		}
+		var value map[string]interface{}
+		if err = json.Unmarshal(data, &value); err != nil {
+			continue
+		}
+		for key := range value {
+			keys[key] = true
+		}
+		values = append(values, value)
+	}
+
+	differences := make([]string, 0)
+	for key := range keys {
+		for _, value := range values[1:] {
+			if !reflect.DeepEqual(values[0][key], value[key]) {
+				differences = append(differences, key)
+				break
+			}
+		}
+	}
+	sort.Strings(differences)
+	return differences
+}
+
+// minLimit will return the smallest limit (a limit of 0 is not reported and is ignored)
+func minLimit(a, b uint64) uint64 {
+	if a == 0 || (b != 0 && b < a) {
+		return b
+	}
+	return a
+}
+
+// intersectFold will return the values of a that are also in b (case-insensitive)
+func intersectFold(a, b []string) []string {
+	var values []string
+	for _, value := range a {
+		if containsFold(b, value) {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,85 @@
+package minercraft
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestClient_ComparePolicies tests the method ComparePolicies()
+func TestClient_ComparePolicies(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid policies", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidPolicyQuote{})
+		comparison := client.ComparePolicies(context.Background())
+
+		if len(comparison.Miners) != len(client.Miners) || comparison.Miners[0].Miner != MinerMatterpool {
+			t.Fatalf("expected all miners (sorted), got: %+v", comparison.Miners)
+		}
+		common := comparison.Common
+		if common.DataCarrierSize != 1000 || common.MaxTxSizePolicy != 99999 || common.MinConsolidationFactor != 20 {
+			t.Fatalf("expected the strictest limits, got: %+v", common)
+		} else if common.AcceptNonStdOutputs || !common.DataCarrier {
+			t.Fatalf("expected the booleans of all miners, got: %+v", common)
+		} else if len(common.SkipScriptFlags) != 1 || common.SkipScriptFlags[0] != "DERSIG" || common.Extra != nil {
+			t.Fatalf("expected the common script flags only, got: %+v", common)
+		}
+		expected := "acceptnonstdoutputs,datacarriersize,dustlimitfactor,maxtxsizepolicy,minconsolidationfactor,skipscriptflags"
+		if differences := strings.Join(comparison.Differences, ","); differences != expected {
+			t.Fatalf("expected differences [%s], got: [%s]", expected, differences)
+		}
+
+		// Only the miners that permit a 90KB data tx
+		permitted := client.Miners.PermittingTx(comparison.PoliciesByMiner(), &TxSize{DataBytes: 90000, StandardBytes: 200})
+		if len(permitted) != 1 || permitted[0].Name != MinerTaal {
+			t.Fatalf("expected only %s, got: %v", MinerTaal, permitted.Names())
+		}
+	})
+
+	t.Run("failed policies", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBadRequest{})
+		comparison := client.ComparePolicies(context.Background())
+
+		if comparison.Common != nil || len(comparison.Differences) != 0 || len(comparison.PoliciesByMiner()) != 0 {
+			t.Fatalf("expected no summary, got: %+v", comparison)
+		} else if len(comparison.Miners) != len(client.Miners) || len(comparison.Miners[0].Error) == 0 {
+			t.Fatalf("expected an error for each miner, got: %+v", comparison.Miners)
+		}
+	})
+}
+
+// TestMinLimit tests the method minLimit()
+func TestMinLimit(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		a        uint64
+		b        uint64
+		expected uint64
+	}{
+		{0, 0, 0},
+		{0, 10, 10},
+		{10, 0, 10},
+		{10, 5, 5},
+		{5, 10, 5},
+	}
+	for _, test := range tests {
+		if output := minLimit(test.a, test.b); output != test.expected {
+			t.Errorf("%s Failed: [%d, %d] inputted and [%d] expected, received: [%d]",
+				t.Name(), test.a, test.b, test.expected, output)
+		}
+	}
+}
+
+// ExampleClient_ComparePolicies example using ComparePolicies()
+func ExampleClient_ComparePolicies() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidPolicyQuote{})
+
+	// Compare the policies of all miners
+	comparison := client.ComparePolicies(context.Background())
+	fmt.Printf("max data carrier size for all miners: %d", comparison.Common.DataCarrierSize)
+	// Output:max data carrier size for all miners: 1000
+}
@@ -0,0 +1,91 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// PolicyQuoteResponse is the raw response from the Merchant API request
+//
+// The payload is a fee quote with the policies of the miner (see: FeePayload.Policies)
+//
+// Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi#get-policy-quote
+type PolicyQuoteResponse struct {
+	JSONEnvelope
+	Quote *FeePayload `json:"quote"` // Custom field for unmarshalled payload data
+}
+
+// PolicyQuote will fire a Merchant API request to retrieve the fees and policies from a given miner
+//
+// This endpoint is used to get the fees and the policies (IE: max tx size and data carrier size) of a miner.
+// It returns a JSONEnvelope with a payload that contains the fees and the policies of a specific BSV miner.
+// Policy quotes are not cached (see: FeeQuote)
+//
+// Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi#get-policy-quote
+func (c *Client) PolicyQuote(miner *Miner) (*PolicyQuoteResponse, error) {
+
+	// Make sure we have a valid miner
+	if miner == nil {
+		return nil, errors.New("miner was nil")
+	} else if err := c.checkNetwork(miner); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	result := getPolicyQuote(context.Background(), c, miner)
+	if result.Response.Error != nil {
+		return nil, result.Response.Error
+	}
+
+	// Parse the response
+	response, err := result.parsePolicyQuote()
+	if err != nil {
+		return nil, err
+	}
+
+	// Valid?
+	if response.Quote == nil || response.Quote.Policies == nil {
+		return nil, errors.New("failed getting policies from: " + miner.Name)
+	}
+
+	// Return the fully parsed response
+	return &response, nil
+}
+
+// getPolicyQuote will fire the HTTP request to retrieve the policy quote
+func getPolicyQuote(ctx context.Context, client *Client, miner *Miner) (result *internalResult) {
+	result = &internalResult{Miner: miner, client: client}
+
+	// Build the endpoint url
+	endpoint, err := buildURL(miner, routePolicyQuote)
+	if err != nil {
+		result.Response = &RequestResponse{Error: err, Method: http.MethodGet}
+		return
+	}
+
+	result.Response = httpRequest(ctx, client, &httpPayload{
+		Miner:     miner,
+		Method:    http.MethodGet,
+		Operation: OperationPolicyQuote,
+		URL:       endpoint,
+		Token:     miner.Token,
+		Timeout:   client.minerTimeout(miner, client.Options.QuoteTimeout),
+	})
+	return
+}
+
+// parsePolicyQuote will convert the HTTP response into a struct and also unmarshal the payload JSON data
+func (i *internalResult) parsePolicyQuote() (response PolicyQuoteResponse, err error) {
+
+	// Process the initial response payload
+	if err = response.process(i.client, i.Miner, i.Response.BodyContents); err != nil {
+		return
+	}
+
+	// If we have a valid payload
+	if err = response.decodePayload(&response.Quote); err == nil && response.Quote != nil {
+		response.checkPayloadMinerID(response.Quote.MinerID)
+	}
+	return
+}
@@ -0,0 +1,92 @@
+package minercraft
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// mockHTTPValidPolicyQuote for mocking requests (Taal has larger data limits than the other miners)
+type mockHTTPValidPolicyQuote struct{}
+
+// Do is a mock http request
+func (m *mockHTTPValidPolicyQuote) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Valid response
+	if strings.Contains(req.URL.String(), routePolicyQuote) {
+		policies := `{"skipscriptflags":["MINIMALDATA","DERSIG"],"maxtxsizepolicy":1000000,"datacarriersize":1000,` +
+			`"acceptnonstdoutputs":true,"datacarrier":true,"minconsolidationfactor":20}`
+		if strings.Contains(req.URL.Host, "taal") {
+			policies = `{"skipscriptflags":["DERSIG"],"maxtxsizepolicy":99999,"datacarriersize":100000,` +
+				`"acceptnonstdoutputs":false,"datacarrier":true,"minconsolidationfactor":10,"dustlimitfactor":300}`
+		}
+		payload, _ := json.Marshal(`{"apiVersion":"1.4.0","timestamp":"2021-11-13T07:37:44.8783319Z",` +
+			`"expiryTime":"2021-11-13T07:47:44.8783319Z","minerId":null,"currentHighestBlockHash":` +
+			`"00000000000000000b3b1bb5a1ae3cc8eb6fe1e23c11d51d1ed3cd1fe9c33a84","currentHighestBlockHeight":714330,` +
+			`"fees":[{"feeType":"standard","miningFee":{"satoshis":500,"bytes":1000},"relayFee":{"satoshis":250,"bytes":1000}}],` +
+			`"policies":` + policies + `}`)
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(`{"payload":` + string(payload) +
+			`,"signature":null,"publicKey":null,"encoding":"` + testEncoding + `","mimetype":"` + testMimeType + `"}`)))
+	}
+
+	// Default is valid
+	return resp, nil
+}
+
+// TestClient_PolicyQuote tests the method PolicyQuote()
+func TestClient_PolicyQuote(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid policy quote", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidPolicyQuote{})
+		response, err := client.PolicyQuote(client.MinerByName(MinerTaal))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Quote.Policies.DataCarrierSize != 100000 || response.Quote.Policies.MaxTxSizePolicy != 99999 {
+			t.Fatalf("expected the policies, got: %+v", response.Quote.Policies)
+		} else if len(response.Quote.Fees) != 1 || response.Miner.Name != MinerTaal {
+			t.Fatalf("expected the fees and miner, got: %+v", response.Quote)
+		}
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		client := newTestClient(&mockHTTPError{})
+		if _, err := client.PolicyQuote(client.MinerByName(MinerTaal)); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("nil miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidPolicyQuote{})
+		if _, err := client.PolicyQuote(nil); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleClient_PolicyQuote example using PolicyQuote()
+func ExampleClient_PolicyQuote() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidPolicyQuote{})
+
+	// Get a policy quote from a miner
+	response, err := client.PolicyQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("got policies from: %s datacarriersize: %d", response.Miner.Name, response.Quote.Policies.DataCarrierSize)
+	// Output:got policies from: Taal datacarriersize: 100000
+}
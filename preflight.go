@@ -0,0 +1,118 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPreflightFailed is returned when a submission fails the pre-flight validation (see: WithPreflight)
+var ErrPreflightFailed = errors.New("transaction failed pre-flight validation")
+
+// PreflightReport is the result of validating a submission locally before it is sent to the miner
+type PreflightReport struct {
+	FeeChecked  bool     `json:"fee_checked"` // Set if the fee was checked (Transaction.InputSatoshis and a quote)
+	Miner       string   `json:"miner"`
+	PaidFee     uint64   `json:"paid_fee"`     // Input satoshis minus the output satoshis (if FeeChecked)
+	Problems    []string `json:"problems"`     // Reasons the miner will reject the transaction
+	RequiredFee uint64   `json:"required_fee"` // The mining fee using the quote of the miner (0 if no quote)
+	TxID        string   `json:"txid,omitempty"`
+	TxSize      *TxSize  `json:"tx_size,omitempty"`
+	Warnings    []string `json:"warnings"` // Checks that could not be done (IE: the quote failed)
+}
+
+// OK will return true if no problems were found
+func (r *PreflightReport) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// WithPreflight will validate every submission of the clone before it is sent (see: Preflight),
+// a submission that fails returns ErrPreflightFailed without contacting the miner
+func WithPreflight() CloneOption {
+	return func(c *Client) {
+		c.Options.Preflight = true
+	}
+}
+
+// Preflight will validate the submission locally and return a report: the raw tx decodes, the inputs and outputs
+// are present, the size is within the max tx size and the fee is sufficient for the quote of the miner
+//
+// The fee is only checked if the input satoshis are known (see: Transaction.InputSatoshis), the raw tx does not
+// contain the values of the outputs being spent. The quote is requested from the miner (or the quote cache)
+func (c *Client) Preflight(ctx context.Context, miner *Miner, tx *Transaction) *PreflightReport {
+	report := &PreflightReport{Problems: make([]string, 0), Warnings: make([]string, 0)}
+
+	// Make sure we have a valid miner and transaction
+	if miner == nil {
+		report.Problems = append(report.Problems, "miner was nil")
+		return report
+	} else if report.Miner = miner.Name; tx == nil {
+		report.Problems = append(report.Problems, "transaction was nil")
+		return report
+	}
+
+	// Decode the transaction (inputs and outputs are present)
+	if err := ValidateRawTx(tx.RawTx); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+		return report
+	}
+	txBytes, _ := hex.DecodeString(tx.RawTx)
+	decoded, _ := decodeTx(txBytes)
+	report.TxID = decoded.GetTxID()
+	report.TxSize, _ = TxSizeFromTx(decoded)
+
+	// Check the size
+	if err := c.checkTxSize(miner, int64(len(txBytes))); err != nil {
+		report.Problems = append(report.Problems, err.Error())
+	}
+
+	// Get the required fee from the quote of the miner
+	result := getQuote(ctx, c, miner)
+	if result.Response.Error != nil {
+		report.Warnings = append(report.Warnings, "fee not checked, quote failed: "+result.Response.Error.Error())
+		return report
+	}
+	quote, err := result.parseQuote()
+	if err == nil && quote.Quote == nil {
+		err = errors.New("missing quote")
+	}
+	if err != nil {
+		report.Warnings = append(report.Warnings, "fee not checked, quote failed: "+err.Error())
+		return report
+	} else if report.RequiredFee, err = quote.Quote.CalculateTxFee(FeeCategoryMining, report.TxSize); err != nil {
+		report.Warnings = append(report.Warnings, "fee not checked: "+err.Error())
+		return report
+	}
+
+	// Check the fee (if the input satoshis are known)
+	if tx.InputSatoshis == 0 {
+		report.Warnings = append(report.Warnings, "fee not checked, input satoshis are unknown")
+		return report
+	}
+	var outputSatoshis uint64
+	for _, out := range decoded.Outputs {
+		outputSatoshis += out.Satoshis
+	}
+	report.FeeChecked = true
+	if outputSatoshis > tx.InputSatoshis {
+		report.Problems = append(report.Problems, fmt.Sprintf("outputs (%d satoshis) exceed the inputs (%d satoshis)",
+			outputSatoshis, tx.InputSatoshis))
+		return report
+	}
+	report.PaidFee = tx.InputSatoshis - outputSatoshis
+	if sufficient, shortfall, _ := quote.Quote.IsFeeSufficient(report.TxSize, report.PaidFee); !sufficient {
+		report.Problems = append(report.Problems, fmt.Sprintf("fee of %d satoshis is %d satoshis less than the required %d satoshis",
+			report.PaidFee, shortfall, report.RequiredFee))
+	}
+	return report
+}
+
+// checkPreflight will return ErrPreflightFailed (with the problems) if the submission fails the pre-flight validation
+func (c *Client) checkPreflight(ctx context.Context, miner *Miner, tx *Transaction) error {
+	if report := c.Preflight(ctx, miner, tx); !report.OK() {
+		return fmt.Errorf("%w: %s", ErrPreflightFailed, strings.Join(report.Problems, ", "))
+	}
+	return nil
+}
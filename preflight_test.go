@@ -0,0 +1,110 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// testRawTxOutputSatoshis is the total satoshis of the outputs of testRawTx (113 bytes, 56 satoshis at 500 sat/KB)
+const testRawTxOutputSatoshis = 1250000420
+
+// TestClient_Preflight tests the method Preflight()
+func TestClient_Preflight(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := newTestClient(&mockHTTPRecovered{})
+	miner := client.MinerByName(MinerTaal)
+
+	var tests = []struct {
+		name          string
+		tx            *Transaction
+		expectedOK    bool
+		expectedFee   bool
+		expectedPaid  uint64
+		expectedWarns int
+	}{
+		{"unknown inputs", &Transaction{RawTx: testRawTx}, true, false, 0, 1},
+		{"sufficient fee", &Transaction{RawTx: testRawTx, InputSatoshis: testRawTxOutputSatoshis + 56}, true, true, 56, 0},
+		{"insufficient fee", &Transaction{RawTx: testRawTx, InputSatoshis: testRawTxOutputSatoshis + 55}, false, true, 55, 0},
+		{"outputs exceed inputs", &Transaction{RawTx: testRawTx, InputSatoshis: 1000}, false, true, 0, 0},
+		{"invalid raw tx", &Transaction{RawTx: "0100"}, false, false, 0, 0},
+		{"nil tx", nil, false, false, 0, 0},
+	}
+	for _, test := range tests {
+		report := client.Preflight(ctx, miner, test.tx)
+		if report.OK() != test.expectedOK || report.FeeChecked != test.expectedFee ||
+			report.PaidFee != test.expectedPaid || len(report.Warnings) != test.expectedWarns {
+			t.Errorf("%s Failed: [%s] inputted and [%v %v %d %d] expected, received: [%+v]",
+				t.Name(), test.name, test.expectedOK, test.expectedFee, test.expectedPaid, test.expectedWarns, report)
+		}
+	}
+
+	t.Run("report details", func(t *testing.T) {
+		report := client.Preflight(ctx, miner, &Transaction{RawTx: testRawTx})
+		if report.Miner != MinerTaal || report.RequiredFee != 56 || report.TxSize.TotalBytes() != 113 || len(report.TxID) != 64 {
+			t.Fatalf("unexpected report: %+v", report)
+		}
+	})
+
+	t.Run("quote failed", func(t *testing.T) {
+		report := newTestClient(&mockHTTPBadRequest{}).Preflight(ctx, miner, &Transaction{RawTx: testRawTx, InputSatoshis: 1})
+		if !report.OK() || report.FeeChecked || len(report.Warnings) != 1 {
+			t.Fatalf("expected a warning only, got: %+v", report)
+		}
+	})
+
+	t.Run("nil miner", func(t *testing.T) {
+		if report := client.Preflight(ctx, nil, &Transaction{RawTx: testRawTx}); report.OK() {
+			t.Fatalf("expected a problem")
+		}
+	})
+}
+
+// TestWithPreflight tests the method WithPreflight()
+func TestWithPreflight(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPRecovered{})
+	preflight := client.With(WithPreflight())
+	if client.Options.Preflight || !preflight.Options.Preflight {
+		t.Fatalf("expected only the clone to run the pre-flight validation")
+	}
+	miner := preflight.MinerByName(MinerTaal)
+
+	t.Run("insufficient fee", func(t *testing.T) {
+		_, err := preflight.SubmitTransaction(miner, &Transaction{RawTx: testRawTx, InputSatoshis: testRawTxOutputSatoshis + 1})
+		if !errors.Is(err, ErrPreflightFailed) {
+			t.Fatalf("expected %v, got: %v", ErrPreflightFailed, err)
+		}
+	})
+
+	t.Run("sufficient fee", func(t *testing.T) {
+		response, err := preflight.SubmitTransaction(miner, &Transaction{RawTx: testRawTx, InputSatoshis: testRawTxOutputSatoshis + 100})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Results.TxID != testSubmittedTx {
+			t.Fatalf("expected the submission response, got: %+v", response.Results)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		if _, err := client.SubmitTransaction(miner, &Transaction{RawTx: testRawTx, InputSatoshis: 1}); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	})
+}
+
+// ExampleClient_Preflight example using Preflight()
+func ExampleClient_Preflight() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPRecovered{})
+
+	// Validate the submission before sending it
+	report := client.Preflight(context.Background(), client.MinerByName(MinerTaal),
+		&Transaction{RawTx: testRawTx, InputSatoshis: testRawTxOutputSatoshis + 50})
+	fmt.Printf("ok: %v problems: %v", report.OK(), report.Problems)
+	// Output:ok: false problems: [fee of 50 satoshis is 6 satoshis less than the required 56 satoshis]
+}
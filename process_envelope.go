@@ -0,0 +1,33 @@
+package minercraft
+
+import "errors"
+
+// ProcessEnvelope will validate the signature of the envelope and decode its payload into T
+// (IE: the envelope of a custom endpoint, see: Client.Do)
+//
+// The payload is decoded with the parse mode of the envelope, a versioned mAPI payload type
+// (IE: SubmissionPayload) is mapped from the apiVersion of the payload (see: decodeVersionedPayload)
+func ProcessEnvelope[T any](envelope *JSONEnvelope) (T, error) {
+	var payload T
+	if envelope == nil {
+		return payload, errors.New("envelope was nil")
+	}
+
+	// Validate the signature of the payload
+	if err := envelope.revalidate(nil); err != nil {
+		return payload, err
+	}
+
+	// Decode the payload (values are decoded as pointers, the mAPI payload types are decoded by pointer)
+	var err error
+	switch any(&payload).(type) {
+	case **CallbackPayload, **FeePayload, **QueryPayload, **SubmissionPayload:
+		err = envelope.decodePayload(&payload)
+	default:
+		var decoded *T
+		if err = envelope.decodePayload(&decoded); decoded != nil {
+			payload = *decoded
+		}
+	}
+	return payload, err
+}
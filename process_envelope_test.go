@@ -0,0 +1,60 @@
+package minercraft
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+// TestProcessEnvelope tests the method ProcessEnvelope()
+func TestProcessEnvelope(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidSubmission{})
+	miner := client.MinerByName(MinerMatterpool)
+
+	t.Run("mAPI payload", func(t *testing.T) {
+		envelope, err := client.Do(context.Background(), miner, http.MethodPost, "/mapi/tx", &Transaction{RawTx: testRawTx}, nil)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		var payload *SubmissionPayload
+		if payload, err = ProcessEnvelope[*SubmissionPayload](envelope); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if payload == nil || payload.ReturnResult != ReturnResultSuccess || !envelope.Validated {
+			t.Fatalf("expected a validated successful submission, got: %+v", payload)
+		}
+
+		var value SubmissionPayload
+		if value, err = ProcessEnvelope[SubmissionPayload](envelope); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if value.TxID != payload.TxID {
+			t.Fatalf("expected txid: %s got: %s", payload.TxID, value.TxID)
+		}
+	})
+
+	t.Run("custom payload", func(t *testing.T) {
+		envelope, err := client.Do(context.Background(), miner, http.MethodPost, "/mapi/tx", &Transaction{RawTx: testRawTx}, nil)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		var payload map[string]interface{}
+		if payload, err = ProcessEnvelope[map[string]interface{}](envelope); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if payload["returnResult"] != ReturnResultSuccess {
+			t.Fatalf("expected a successful submission, got: %v", payload)
+		}
+	})
+
+	t.Run("invalid envelopes", func(t *testing.T) {
+		if _, err := ProcessEnvelope[*QueryPayload](nil); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+		envelope := &JSONEnvelope{Payload: "{}", PublicKey: testMinerID, Signature: "3044"}
+		if _, err := ProcessEnvelope[*QueryPayload](envelope); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
@@ -4,7 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
 /*
@@ -71,6 +75,11 @@ type QueryPayload struct {
 	MinerID               string `json:"minerId"`
 	Confirmations         int64  `json:"confirmations"`
 	TxSecondMempoolExpiry int64  `json:"txSecondMempoolExpiry"`
+
+	// mAPI 1.4 (see: payload_versions.go)
+	MerkleProof json.RawMessage `json:"merkleProof,omitempty"` // The merkle proof (if requested)
+
+	Reason ResultReason `json:"-"` // Custom field for the reason of the resultDescription (see: ClassifyResult)
 }
 
 // QueryTransaction will fire a Merchant API request to check the status of a transaction
@@ -80,16 +89,28 @@ type QueryPayload struct {
 // The purpose of the envelope is to ensure strict consistency in the message content for
 // the purpose of signing responses.
 //
+// If the miner supports long-polling (see: Miner.LongPoll) and ClientOptions.QueryWait is set, the miner holds
+// the request until the status changes or the wait is over (near real-time mining notification without a poll loop)
+//
 // Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi/tree/v1.2-beta#Query-transaction-status
 func (c *Client) QueryTransaction(miner *Miner, txID string) (*QueryTransactionResponse, error) {
+	return c.queryTransactionStatus(context.Background(), miner, txID)
+}
+
+// queryTransactionStatus will query the status of the transaction (see: QueryTransaction)
+func (c *Client) queryTransactionStatus(ctx context.Context, miner *Miner, txID string) (*QueryTransactionResponse, error) {
 
 	// Make sure we have a valid miner
 	if miner == nil {
 		return nil, errors.New("miner was nil")
+	} else if !IsValidTxID(txID) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTxID, txID)
+	} else if err := c.checkNetwork(miner); err != nil {
+		return nil, err
 	}
 
 	// Make the HTTP request
-	result := queryTransaction(context.Background(), c, miner, txID)
+	result := queryTransaction(ctx, c, miner, txID)
 	if result.Response.Error != nil {
 		return nil, result.Response.Error
 	}
@@ -105,32 +126,108 @@ func (c *Client) QueryTransaction(miner *Miner, txID string) (*QueryTransactionR
 		return nil, errors.New("failed getting query response from: " + miner.Name)
 	}
 
+	// Record the time-to-confirmation (if the transaction was submitted by this client)
+	if response.IsMined() {
+		c.confirmations.confirmed(miner, txID, c.now())
+	}
+
 	// Return the fully parsed response
 	return &response, nil
 }
 
 // queryTransaction will fire the HTTP request to retrieve the tx status
 func queryTransaction(ctx context.Context, client *Client, miner *Miner, txHash string) (result *internalResult) {
-	result = &internalResult{Miner: miner}
+	result = &internalResult{Miner: miner, client: client}
+
+	// Build the endpoint url
+	endpoint, err := buildURL(miner, routeQueryTx, txHash)
+	if err != nil {
+		result.Response = &RequestResponse{Error: err, Method: http.MethodGet}
+		return
+	}
+
+	// Long-poll (the miner holds the request until the status changes or the wait is over)
+	timeout := client.minerTimeout(miner, client.Options.QueryTimeout)
+	if wait := client.queryWait(miner); wait > 0 {
+		endpoint += "?" + queryWaitParam + "=" + strconv.FormatInt(int64(wait/time.Second), 10)
+		timeout += wait
+	}
+
 	result.Response = httpRequest(ctx, client, &httpPayload{
-		Method: http.MethodGet,
-		URL:    defaultProtocol + miner.URL + routeQueryTx + txHash,
-		Token:  miner.Token,
+		Miner:     miner,
+		Method:    http.MethodGet,
+		Operation: OperationQueryTx,
+		URL:       endpoint,
+		Token:     miner.Token,
+		Timeout:   timeout,
 	})
 	return
 }
 
+// WithQueryWait will long-poll the tx queries of the clone to miners that support it (see: ClientOptions.QueryWait)
+func WithQueryWait(wait time.Duration) CloneOption {
+	return func(c *Client) {
+		c.Options.QueryWait = wait
+	}
+}
+
+// queryWait will return the long-poll wait of a tx query to the miner (0 if disabled or not supported),
+// rounded up to whole seconds
+func (c *Client) queryWait(miner *Miner) time.Duration {
+	if !miner.LongPoll || c.Options.QueryWait <= 0 {
+		return 0
+	}
+	return (c.Options.QueryWait + time.Second - 1).Truncate(time.Second)
+}
+
 // parseQuery will convert the HTTP response into a struct and also unmarshal the payload JSON data
 func (i *internalResult) parseQuery() (response QueryTransactionResponse, err error) {
 
 	// Process the initial response payload
-	if err = response.process(i.Miner, i.Response.BodyContents); err != nil {
+	if err = response.process(i.client, i.Miner, i.Response.BodyContents); err != nil {
 		return
 	}
 
 	// If we have a valid payload
-	if len(response.Payload) > 0 {
-		err = json.Unmarshal([]byte(response.Payload), &response.Query)
+	if err = response.decodePayload(&response.Query); err == nil && response.Query != nil {
+		response.checkPayloadMinerID(response.Query.MinerID)
 	}
 	return
 }
+
+// IsMined will return true if the transaction is in a block (a successful query with a block hash or height)
+func (q *QueryTransactionResponse) IsMined() bool {
+	return q.Query != nil && strings.EqualFold(q.Query.ReturnResult, ReturnResultSuccess) &&
+		(len(q.Query.BlockHash) > 0 || q.Query.BlockHeight > 0)
+}
+
+// IsInMempool will return true if the miner has the transaction but it is not yet in a block
+//
+// Miners report this as a success without a block, or (older versions) as a failure with the description
+// "Transaction in mempool but not yet in block"
+func (q *QueryTransactionResponse) IsInMempool() bool {
+	if q.Query == nil || q.IsMined() {
+		return false
+	} else if strings.EqualFold(q.Query.ReturnResult, ReturnResultSuccess) {
+		return true
+	}
+	return strings.EqualFold(q.Query.ReturnResult, ReturnResultFailure) &&
+		ClassifyResult(q.Query.ResultDescription) == ResultReasonInMempool
+}
+
+// Confirmations will return the number of confirmations of the transaction (0 if it is not mined)
+//
+// Miners that don't report confirmations for a mined transaction count as 1 confirmation (the block itself)
+func (q *QueryTransactionResponse) Confirmations() int64 {
+	if !q.IsMined() {
+		return 0
+	} else if q.Query.Confirmations > 0 {
+		return q.Query.Confirmations
+	}
+	return 1
+}
+
+// Confirmed will return true if the transaction is mined with at least the number of confirmations
+func (q *QueryTransactionResponse) Confirmed(minConfirmations int64) bool {
+	return q.IsMined() && q.Confirmations() >= minConfirmations
+}
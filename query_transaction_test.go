@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 // mockHTTPValidQuery for mocking requests
@@ -258,3 +259,92 @@ func TestClient_QueryTransactionBadQuery(t *testing.T) {
 		t.Fatalf("expected response to be nil")
 	}
 }
+
+// TestQueryTransactionResponse_Status tests the methods IsMined(), IsInMempool(), Confirmations() and Confirmed()
+func TestQueryTransactionResponse_Status(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name          string
+		query         *QueryPayload
+		mined         bool
+		inMempool     bool
+		confirmations int64
+	}{
+		{"no payload", nil, false, false, 0},
+		{"mined", &QueryPayload{ReturnResult: ReturnResultSuccess, BlockHash: "0000000000000000050a09fe90b0e8542bba9e712edb8cc9349e61888fe45ac5", BlockHeight: 612530, Confirmations: 6}, true, false, 6},
+		{"mined without confirmations", &QueryPayload{ReturnResult: ReturnResultSuccess, BlockHeight: 612530}, true, false, 1},
+		{"in mempool (success)", &QueryPayload{ReturnResult: ReturnResultSuccess}, false, true, 0},
+		{"in mempool (failure)", &QueryPayload{ReturnResult: ReturnResultFailure, ResultDescription: "Transaction in mempool but not yet in block"}, false, true, 0},
+		{"not found", &QueryPayload{ReturnResult: ReturnResultFailure, ResultDescription: "No such mempool or blockchain transaction"}, false, false, 0},
+	}
+	for _, test := range tests {
+		response := &QueryTransactionResponse{Query: test.query}
+		if mined := response.IsMined(); mined != test.mined {
+			t.Errorf("%s Failed: [%s] inputted and [%v] mined expected, received: [%v]", t.Name(), test.name, test.mined, mined)
+		} else if inMempool := response.IsInMempool(); inMempool != test.inMempool {
+			t.Errorf("%s Failed: [%s] inputted and [%v] in mempool expected, received: [%v]", t.Name(), test.name, test.inMempool, inMempool)
+		} else if confirmations := response.Confirmations(); confirmations != test.confirmations {
+			t.Errorf("%s Failed: [%s] inputted and [%d] confirmations expected, received: [%d]", t.Name(), test.name, test.confirmations, confirmations)
+		} else if response.Confirmed(1) != test.mined || (test.mined && response.Confirmed(test.confirmations+1)) {
+			t.Errorf("%s Failed: [%s] inputted and confirmed did not match the confirmations", t.Name(), test.name)
+		}
+	}
+}
+
+// ExampleQueryTransactionResponse_Confirmations example using Confirmations()
+func ExampleQueryTransactionResponse_Confirmations() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidQuery{})
+
+	// Query the transaction
+	response, err := client.QueryTransaction(client.MinerByName(MinerMatterpool), testTx)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("mined: %v confirmations: %d", response.IsMined(), response.Confirmations())
+	// Output:mined: true confirmations: 43733
+}
+
+// mockHTTPLongPollQuery for mocking requests (records the url of the query)
+type mockHTTPLongPollQuery struct {
+	mockHTTPValidQuery
+	url string
+}
+
+// Do is a mock http request
+func (m *mockHTTPLongPollQuery) Do(req *http.Request) (*http.Response, error) {
+	m.url = req.URL.String()
+	return m.mockHTTPValidQuery.Do(req)
+}
+
+// TestClient_QueryTransactionLongPoll tests the method QueryTransaction() with a long-poll wait
+func TestClient_QueryTransactionLongPoll(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		name     string
+		longPoll bool
+		wait     time.Duration
+		expected string
+	}{
+		{"long-poll", true, 30 * time.Second, "?wait=30"},
+		{"rounded up", true, 1500 * time.Millisecond, "?wait=2"},
+		{"not supported by the miner", false, 30 * time.Second, ""},
+		{"disabled", true, 0, ""},
+	}
+	for _, test := range tests {
+		mock := &mockHTTPLongPollQuery{}
+		client := newTestClient(mock).With(WithQueryWait(test.wait))
+		miner := *client.MinerByName(MinerTaal)
+		miner.LongPoll = test.longPoll
+
+		if _, err := client.QueryTransaction(&miner, testTx); err != nil {
+			t.Fatalf("%s: error occurred: %s", test.name, err.Error())
+		} else if !strings.HasSuffix(mock.url, "/mapi/tx/"+testTx+test.expected) {
+			t.Errorf("%s Failed: [%s] inputted and [%s] expected, received: [%s]", t.Name(), test.name, test.expected, mock.url)
+		}
+	}
+}
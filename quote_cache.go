@@ -0,0 +1,193 @@
+package minercraft
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// quoteCache is an in-memory cache of fee quotes (by miner) that are reused until they expire
+type quoteCache struct {
+	sync.RWMutex
+	failures map[string]*cachedFailure
+	quotes   map[string]*cachedQuote
+}
+
+// cachedQuote is a parsed quote and the original response it came from
+type cachedQuote struct {
+	endpoint  string // Miner endpoint (scheme, url and token) the quote was requested from
+	expiresAt time.Time
+	quote     *FeeQuoteResponse
+	response  *RequestResponse
+}
+
+// cachedFailure is a failed quote request (see: ClientOptions.QuoteFailureTTL)
+type cachedFailure struct {
+	endpoint  string // Miner endpoint (scheme, url and token) the quote was requested from
+	err       error
+	expiresAt time.Time
+}
+
+// newQuoteCache will return a new empty quote cache
+func newQuoteCache() *quoteCache {
+	return &quoteCache{
+		failures: make(map[string]*cachedFailure),
+		quotes:   make(map[string]*cachedQuote),
+	}
+}
+
+// get will return an unexpired cached quote for the miner (or nil if not found)
+//
+// Quotes requested from a different endpoint (IE: the token or url of the miner changed) are not returned.
+// The cached quote is a copy, so it can be modified by the caller without changing the cache
+func (q *quoteCache) get(miner *Miner, now time.Time) *cachedQuote {
+	q.RLock()
+	defer q.RUnlock()
+	if cached, ok := q.quotes[quoteCacheKey(miner)]; ok && now.Before(cached.expiresAt) &&
+		cached.endpoint == quoteCacheEndpoint(miner) {
+		return &cachedQuote{
+			endpoint:  cached.endpoint,
+			expiresAt: cached.expiresAt,
+			quote:     copyQuote(cached.quote),
+			response:  cached.response,
+		}
+	}
+	return nil
+}
+
+// delete will remove the cached quote (and failure) for the miner (if found)
+func (q *quoteCache) delete(miner *Miner) {
+	q.Lock()
+	delete(q.failures, quoteCacheKey(miner))
+	delete(q.quotes, quoteCacheKey(miner))
+	q.Unlock()
+}
+
+// clear will remove all cached quotes (and failures)
+func (q *quoteCache) clear() {
+	q.Lock()
+	q.failures = make(map[string]*cachedFailure)
+	q.quotes = make(map[string]*cachedQuote)
+	q.Unlock()
+}
+
+// getFailure will return the error of an unexpired failed quote request for the miner (or nil if not found)
+func (q *quoteCache) getFailure(miner *Miner, now time.Time) error {
+	q.RLock()
+	defer q.RUnlock()
+	if failure, ok := q.failures[quoteCacheKey(miner)]; ok && now.Before(failure.expiresAt) &&
+		failure.endpoint == quoteCacheEndpoint(miner) {
+		return failure.err
+	}
+	return nil
+}
+
+// setFailure will store the failed quote request for the miner (or remove it if err is nil)
+//
+// Returns true if the miner was not already failing (IE: the failure "circuit" was opened)
+func (q *quoteCache) setFailure(miner *Miner, err error, ttl time.Duration, now time.Time) (opened bool) {
+	q.Lock()
+	defer q.Unlock()
+	if err == nil {
+		delete(q.failures, quoteCacheKey(miner))
+		return
+	}
+	existing, ok := q.failures[quoteCacheKey(miner)]
+	opened = !ok || !now.Before(existing.expiresAt)
+	q.failures[quoteCacheKey(miner)] = &cachedFailure{
+		endpoint:  quoteCacheEndpoint(miner),
+		err:       err,
+		expiresAt: now.Add(ttl),
+	}
+	return
+}
+
+// set will store the quote for the miner (quotes without a valid expiration time are not stored)
+func (q *quoteCache) set(miner *Miner, quote *FeeQuoteResponse, response *RequestResponse, now time.Time) {
+	if quote == nil || quote.Quote == nil {
+		return
+	}
+	expiresAt, err := quote.Quote.ExpiresAt()
+	if err != nil || !now.Before(expiresAt) {
+		return
+	}
+	q.Lock()
+	q.quotes[quoteCacheKey(miner)] = &cachedQuote{
+		endpoint:  quoteCacheEndpoint(miner),
+		expiresAt: expiresAt,
+		quote:     copyQuote(quote),
+		response:  response,
+	}
+	q.Unlock()
+}
+
+// InvalidateQuote will remove the cached quote (and failure) for the miner, forcing a new quote on the
+// next request (IE: after a known fee change of the miner)
+//
+// The quote is also removed from the store (if set)
+func (c *Client) InvalidateQuote(miner *Miner) {
+	if miner != nil {
+		c.quoteCache.delete(miner)
+		if c.store != nil {
+			_ = c.store.Delete(context.Background(), quoteStoreKey(miner))
+		}
+	}
+}
+
+// InvalidateAll will remove all cached quotes (and failures), forcing new quotes on the next requests
+//
+// The quotes of the loaded miners are also removed from the store (if set)
+func (c *Client) InvalidateAll() {
+	c.quoteCache.clear()
+	if c.store != nil {
+		for _, miner := range c.Miners {
+			_ = c.store.Delete(context.Background(), quoteStoreKey(miner))
+		}
+	}
+}
+
+// quoteCacheKey will return the cache key for the miner
+func quoteCacheKey(miner *Miner) string {
+	return strings.ToLower(miner.Name)
+}
+
+// quoteCacheEndpoint will return the endpoint of the miner that a cached quote is valid for
+func quoteCacheEndpoint(miner *Miner) string {
+	endpoint, _ := buildURL(miner, routeFeeQuote)
+	return endpoint + "|" + miner.Token
+}
+
+// copyQuote will return a deep copy of the quote (including the miner and fees)
+func copyQuote(quote *FeeQuoteResponse) *FeeQuoteResponse {
+	if quote == nil {
+		return nil
+	}
+	quoteCopy := *quote
+	if quote.Miner != nil {
+		minerCopy := *quote.Miner
+		quoteCopy.Miner = &minerCopy
+	}
+	if quote.Quote != nil {
+		payloadCopy := *quote.Quote
+		payloadCopy.Policies = copyPolicies(quote.Quote.Policies)
+		payloadCopy.Fees = make([]*feeType, 0, len(quote.Quote.Fees))
+		for _, fee := range quote.Quote.Fees {
+			if fee == nil {
+				continue
+			}
+			feeCopy := *fee
+			if fee.MiningFee != nil {
+				miningFee := *fee.MiningFee
+				feeCopy.MiningFee = &miningFee
+			}
+			if fee.RelayFee != nil {
+				relayFee := *fee.RelayFee
+				feeCopy.RelayFee = &relayFee
+			}
+			payloadCopy.Fees = append(payloadCopy.Fees, &feeCopy)
+		}
+		quoteCopy.Quote = &payloadCopy
+	}
+	return &quoteCopy
+}
@@ -0,0 +1,324 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// mockHTTPCountingQuote for mocking requests (counts the requests and returns unexpired quotes)
+type mockHTTPCountingQuote struct {
+	requests  int32
+	expiresIn time.Duration
+}
+
+// Do is a mock http request
+func (m *mockHTTPCountingQuote) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+	resp.Body = ioutil.NopCloser(bytes.NewReader(nil))
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Valid response
+	if strings.Contains(req.URL.String(), "/mapi/feeQuote") {
+		atomic.AddInt32(&m.requests, 1)
+		now := time.Now().UTC()
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(`{
+    	"payload": "{\"apiVersion\":\"` + testAPIVersion + `\",\"timestamp\":\"` + now.Format(time.RFC3339) + `\",\"expiryTime\":\"` + now.Add(m.expiresIn).Format(time.RFC3339) + `\",\"minerId\":\"03e92d3e5c3f7bd945dfbf48e7a99393b1bfb3f11f380ae30d286e7ff2aec5a270\",\"currentHighestBlockHash\":\"0000000000000000035c5f8c0294802a01e500fa7b95337963bb3640da3bd565\",\"currentHighestBlockHeight\":656169,\"minerReputation\":null,\"fees\":[{\"feeType\":\"standard\",\"miningFee\":{\"satoshis\":500,\"bytes\":1000},\"relayFee\":{\"satoshis\":250,\"bytes\":1000}},{\"feeType\":\"data\",\"miningFee\":{\"satoshis\":500,\"bytes\":1000},\"relayFee\":{\"satoshis\":250,\"bytes\":1000}}]}",
+    	"signature": null,"publicKey": null,"encoding": "` + testEncoding + `","mimetype": "` + testMimeType + `"}`)))
+	}
+
+	// Default is valid
+	return resp, nil
+}
+
+// count will return the number of quote requests made
+func (m *mockHTTPCountingQuote) count() int {
+	return int(atomic.LoadInt32(&m.requests))
+}
+
+// newTestCachingClient returns a client with quote caching enabled
+func newTestCachingClient(httpClient httpInterface) *Client {
+	options := DefaultClientOptions()
+	options.QuoteCacheEnabled = true
+	client, _ := NewClient(options, nil)
+	client.httpClient = httpClient
+	return client
+}
+
+// TestClient_BestQuoteCached tests the method BestQuote() with quote caching
+func TestClient_BestQuoteCached(t *testing.T) {
+	t.Parallel()
+
+	t.Run("unexpired quotes are reused", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: 10 * time.Minute}
+		client := newTestCachingClient(mock)
+
+		for i := 0; i < 3; i++ {
+			if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+		}
+
+		if mock.count() != len(client.Miners) {
+			t.Fatalf("expected %d quote requests, got %d", len(client.Miners), mock.count())
+		}
+	})
+
+	t.Run("only lapsed quotes are re-fetched", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: 10 * time.Minute}
+		client := newTestCachingClient(mock)
+
+		if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		// Expire one quote
+		client.quoteCache.quotes[quoteCacheKey(client.MinerByName(MinerTaal))].expiresAt = time.Now()
+
+		if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		if mock.count() != len(client.Miners)+1 {
+			t.Fatalf("expected %d quote requests, got %d", len(client.Miners)+1, mock.count())
+		}
+	})
+
+	t.Run("expired quotes are not cached", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: -1 * time.Minute}
+		client := newTestCachingClient(mock)
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+		}
+
+		if mock.count() != 2*len(client.Miners) {
+			t.Fatalf("expected %d quote requests, got %d", 2*len(client.Miners), mock.count())
+		}
+	})
+
+	t.Run("cached quotes can't be modified by the caller", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: 10 * time.Minute}
+		client := newTestCachingClient(mock)
+
+		response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		response.Quote.Fees[0].MiningFee.Satoshis = 1
+		response.Miner.Token = "changed"
+
+		if response, err = client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Quote.Fees[0].MiningFee.Satoshis == 1 || response.Miner.Token == "changed" {
+			t.Fatalf("expected the cached quote to be unchanged")
+		} else if mock.count() != 1 {
+			t.Fatalf("expected %d quote requests, got %d", 1, mock.count())
+		}
+	})
+
+	t.Run("token or url changes invalidate the quote", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: 10 * time.Minute}
+		client := newTestCachingClient(mock)
+		miner := client.MinerByName(MinerTaal)
+
+		if _, err := client.FeeQuote(miner); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		// New token
+		client.MinerUpdateToken(MinerTaal, "new-token")
+		if _, err := client.FeeQuote(miner); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if mock.count() != 2 {
+			t.Fatalf("expected %d quote requests, got %d", 2, mock.count())
+		}
+
+		// New scheme (changed directly on the miner)
+		miner.Scheme = SchemeHTTP
+		if _, err := client.FeeQuote(miner); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if mock.count() != 3 {
+			t.Fatalf("expected %d quote requests, got %d", 3, mock.count())
+		}
+
+		// Unchanged
+		if _, err := client.FeeQuote(miner); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if mock.count() != 3 {
+			t.Fatalf("expected %d quote requests, got %d", 3, mock.count())
+		}
+	})
+
+	t.Run("caching disabled", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: 10 * time.Minute}
+		client := newTestClient(mock)
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+		}
+
+		if mock.count() != 2*len(client.Miners) {
+			t.Fatalf("expected %d quote requests, got %d", 2*len(client.Miners), mock.count())
+		}
+	})
+}
+
+// TestFeePayload_IsExpired tests the method IsExpired()
+func TestFeePayload_IsExpired(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected bool
+	}{
+		{time.Now().Add(10 * time.Minute).UTC().Format(time.RFC3339), false},
+		{time.Now().Add(-10 * time.Minute).UTC().Format(time.RFC3339), true},
+		{"2020-10-09T21:36:17.410Z", true},
+		{"", true},
+		{"invalid-time", true},
+	}
+
+	for _, test := range tests {
+		payload := &FeePayload{ExpirationTime: test.input}
+		if output := payload.IsExpired(); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%v] expected, received: [%v]", t.Name(), test.input, test.expected, output)
+		}
+	}
+}
+
+// BenchmarkClient_BestQuoteCached benchmarks the method BestQuote() with quote caching
+func BenchmarkClient_BestQuoteCached(b *testing.B) {
+	client := newTestCachingClient(&mockHTTPCountingQuote{expiresIn: 10 * time.Minute})
+	for i := 0; i < b.N; i++ {
+		_, _ = client.BestQuote(FeeCategoryMining, FeeTypeData)
+	}
+}
+
+// TestClient_InvalidateQuote tests the methods InvalidateQuote() and InvalidateAll()
+func TestClient_InvalidateQuote(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCountingQuote{expiresIn: time.Minute}
+	client := newTestCachingClient(mock)
+	taal, mempool := client.MinerByName(MinerTaal), client.MinerByName(MinerMempool)
+
+	// Cache a quote for both miners
+	_, _ = client.FeeQuote(taal)
+	_, _ = client.FeeQuote(mempool)
+	if mock.count() != 2 {
+		t.Fatalf("expected %d requests, got %d", 2, mock.count())
+	}
+
+	// Only the invalidated miner is re-fetched
+	client.InvalidateQuote(taal)
+	client.InvalidateQuote(nil)
+	_, _ = client.FeeQuote(taal)
+	_, _ = client.FeeQuote(mempool)
+	if mock.count() != 3 {
+		t.Fatalf("expected %d requests, got %d", 3, mock.count())
+	}
+
+	// All miners are re-fetched
+	client.InvalidateAll()
+	_, _ = client.FeeQuote(taal)
+	_, _ = client.FeeQuote(mempool)
+	if mock.count() != 5 {
+		t.Fatalf("expected %d requests, got %d", 5, mock.count())
+	}
+}
+
+// TestClient_QuoteFailureTTL tests the failure caching of getQuote()
+func TestClient_QuoteFailureTTL(t *testing.T) {
+	t.Parallel()
+
+	// Create a client that fails until the miner recovers
+	var requests, failing int32 = 0, 1
+	healthy := &mockHTTPCountingQuote{expiresIn: time.Minute}
+	client := newTestClient(RequestHandlerFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&failing) == 1 {
+			return nil, errors.New("connection refused")
+		}
+		return healthy.Do(req)
+	}))
+	client.Options.QuoteFailureTTL = 50 * time.Millisecond
+	miner := client.MinerByName(MinerTaal)
+
+	// The failure is cached
+	if _, err := client.FeeQuote(miner); err == nil || errors.Is(err, ErrMinerRecentlyFailed) {
+		t.Fatalf("expected the request error but got: %v", err)
+	} else if _, err = client.FeeQuote(miner); !errors.Is(err, ErrMinerRecentlyFailed) {
+		t.Fatalf("expected [%v] but got: %v", ErrMinerRecentlyFailed, err)
+	} else if atomic.LoadInt32(&requests) != 1 {
+		t.Fatalf("expected %d requests, got %d", 1, atomic.LoadInt32(&requests))
+	}
+
+	// Other miners are still requested
+	if _, err := client.FeeQuote(client.MinerByName(MinerMempool)); errors.Is(err, ErrMinerRecentlyFailed) {
+		t.Fatalf("error not expected: %v", err)
+	}
+
+	// The miner is re-probed after the failure expires (and the recovery clears the failure)
+	atomic.StoreInt32(&failing, 0)
+	time.Sleep(60 * time.Millisecond)
+	if _, err := client.FeeQuote(miner); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if err = client.quoteCache.getFailure(miner, time.Now()); err != nil {
+		t.Fatalf("expected the failure to be removed but got: %v", err)
+	}
+
+	// Health checks always contact the miner
+	atomic.StoreInt32(&failing, 1)
+	_, _ = client.FeeQuote(miner)
+	atomic.StoreInt32(&failing, 0)
+	if health := client.HealthCheck(context.Background(), miner); !health.Healthy {
+		t.Fatalf("expected a healthy miner but got: %s", health.Error)
+	} else if _, err := client.FeeQuote(miner); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Invalidating removes the failure
+	atomic.StoreInt32(&failing, 1)
+	_, _ = client.FeeQuote(miner)
+	client.InvalidateQuote(miner)
+	if err := client.quoteCache.getFailure(miner, time.Now()); err != nil {
+		t.Fatalf("expected the failure to be removed but got: %v", err)
+	}
+}
+
+// TestClient_QuoteFailureTTLDisabled tests that failures are not cached by default
+func TestClient_QuoteFailureTTLDisabled(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	client := newTestClient(RequestHandlerFunc(func(req *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&requests, 1)
+		return nil, errors.New("connection refused")
+	}))
+	for i := 0; i < 3; i++ {
+		if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); errors.Is(err, ErrMinerRecentlyFailed) {
+			t.Fatalf("error not expected: %v", err)
+		}
+	}
+	if atomic.LoadInt32(&requests) != 3 {
+		t.Fatalf("expected %d requests, got %d", 3, atomic.LoadInt32(&requests))
+	}
+}
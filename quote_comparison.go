@@ -0,0 +1,148 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// quoteComparisonColumns are the columns of the comparison report (see: MarshalCSV)
+var quoteComparisonColumns = []string{"miner", "fee_type", "mining_rate", "relay_rate", "expires_at", "latency_ms", "error"}
+
+// QuoteComparison is a report of the quotes of all miners (one row per miner and fee type) for fee audits
+type QuoteComparison struct {
+	ComparedAt time.Time             `json:"compared_at"`
+	Rows       []*QuoteComparisonRow `json:"rows"` // Sorted by miner name and fee type
+}
+
+// QuoteComparisonRow is a single fee type of a miner's quote (or the error if the quote failed)
+type QuoteComparisonRow struct {
+	Error      string        `json:"error,omitempty"`
+	ExpiresAt  time.Time     `json:"expires_at"`
+	FeeType    string        `json:"fee_type"`
+	Latency    time.Duration `json:"latency"`
+	Miner      string        `json:"miner"`
+	MiningRate uint64        `json:"mining_rate"` // Satoshis per 1000 bytes
+	RelayRate  uint64        `json:"relay_rate"`  // Satoshis per 1000 bytes
+}
+
+// CompareQuotes will request a quote from all miners and return a comparison report
+//
+// Miners that fail to return a valid quote are included with the error (a single row without a fee type)
+func (c *Client) CompareQuotes(ctx context.Context) *QuoteComparison {
+
+	// Request all quotes (measuring the latency of each miner)
+	comparison := &QuoteComparison{ComparedAt: c.now().UTC()}
+	rows := make([][]*QuoteComparisonRow, len(c.Miners))
+	group, ctx := c.fanOut(ctx)
+	for index, miner := range c.Miners {
+		index, miner := index, miner
+		group.Go(func() error {
+			start := time.Now()
+			result := getQuote(ctx, c, miner)
+			rows[index] = newQuoteComparisonRows(miner, result, time.Since(start))
+			return nil
+		})
+	}
+	_ = group.Wait()
+	for _, minerRows := range rows {
+		comparison.Rows = append(comparison.Rows, minerRows...)
+	}
+
+	// Sort by miner and fee type
+	sort.SliceStable(comparison.Rows, func(i, j int) bool {
+		if comparison.Rows[i].Miner != comparison.Rows[j].Miner {
+			return comparison.Rows[i].Miner < comparison.Rows[j].Miner
+		}
+		return comparison.Rows[i].FeeType < comparison.Rows[j].FeeType
+	})
+	return comparison
+}
+
+// newQuoteComparisonRows will return the rows for each fee type of the quote (or the error)
+func newQuoteComparisonRows(miner *Miner, result *internalResult, latency time.Duration) []*QuoteComparisonRow {
+
+	// Check the response
+	failed := &QuoteComparisonRow{Latency: latency, Miner: miner.Name}
+	if result.Response.Error != nil {
+		failed.Error = result.Response.Error.Error()
+		return []*QuoteComparisonRow{failed}
+	}
+	quote, err := result.parseQuote()
+	if err != nil {
+		failed.Error = err.Error()
+		return []*QuoteComparisonRow{failed}
+	} else if quote.Quote == nil || len(quote.Quote.Fees) == 0 {
+		failed.Error = "failed getting quotes from: " + miner.Name
+		return []*QuoteComparisonRow{failed}
+	}
+
+	// Add a row for each fee type
+	expiresAt, _ := quote.Quote.ExpiresAt()
+	rows := make([]*QuoteComparisonRow, 0, len(quote.Quote.Fees))
+	for _, fee := range quote.Quote.Fees {
+		if fee == nil {
+			continue
+		}
+		row := &QuoteComparisonRow{ExpiresAt: expiresAt, FeeType: fee.FeeType, Latency: latency, Miner: miner.Name}
+		row.MiningRate, _ = quote.Quote.CalculateFee(FeeCategoryMining, fee.FeeType, 1000)
+		row.RelayRate, _ = quote.Quote.CalculateFee(FeeCategoryRelay, fee.FeeType, 1000)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// MarshalJSON will encode the report as a table: the columns and a row of values for each row
+// (the expiry is RFC3339 and the latency is in milliseconds, the same values as MarshalCSV)
+func (q *QuoteComparison) MarshalJSON() ([]byte, error) {
+	rows := make([][]string, 0, len(q.Rows))
+	for _, row := range q.Rows {
+		rows = append(rows, row.values())
+	}
+	return json.Marshal(&struct {
+		Columns    []string   `json:"columns"`
+		ComparedAt time.Time  `json:"compared_at"`
+		Rows       [][]string `json:"rows"`
+	}{
+		Columns:    quoteComparisonColumns,
+		ComparedAt: q.ComparedAt,
+		Rows:       rows,
+	})
+}
+
+// MarshalCSV will encode the report as CSV (with a header row) for spreadsheets
+func (q *QuoteComparison) MarshalCSV() ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(quoteComparisonColumns); err != nil {
+		return nil, err
+	}
+	for _, row := range q.Rows {
+		if err := writer.Write(row.values()); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// values will return the values of the row (in the order of the columns)
+func (r *QuoteComparisonRow) values() []string {
+	var expiresAt string
+	if !r.ExpiresAt.IsZero() {
+		expiresAt = r.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	return []string{
+		r.Miner,
+		r.FeeType,
+		strconv.FormatUint(r.MiningRate, 10),
+		strconv.FormatUint(r.RelayRate, 10),
+		expiresAt,
+		strconv.FormatInt(r.Latency.Milliseconds(), 10),
+		r.Error,
+	}
+}
@@ -0,0 +1,106 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestClient_CompareQuotes tests the method CompareQuotes()
+func TestClient_CompareQuotes(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid quotes", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		comparison := client.CompareQuotes(context.Background())
+
+		if len(comparison.Rows) != len(client.Miners)*2 {
+			t.Fatalf("expected %d rows, got: %d", len(client.Miners)*2, len(comparison.Rows))
+		}
+		row := comparison.Rows[0]
+		if row.Miner != MinerMatterpool || row.FeeType != FeeTypeData {
+			t.Fatalf("expected the rows to be sorted, got: %s %s", row.Miner, row.FeeType)
+		} else if row.MiningRate != 500 || row.RelayRate != 250 || row.ExpiresAt.IsZero() || len(row.Error) > 0 {
+			t.Fatalf("unexpected row: %+v", row)
+		}
+	})
+
+	t.Run("failed quotes", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBadRequest{})
+		comparison := client.CompareQuotes(context.Background())
+
+		if len(comparison.Rows) != len(client.Miners) {
+			t.Fatalf("expected %d rows, got: %d", len(client.Miners), len(comparison.Rows))
+		} else if len(comparison.Rows[0].Error) == 0 || len(comparison.Rows[0].FeeType) > 0 {
+			t.Fatalf("expected an error row, got: %+v", comparison.Rows[0])
+		}
+	})
+}
+
+// TestQuoteComparison_MarshalCSV tests the method MarshalCSV()
+func TestQuoteComparison_MarshalCSV(t *testing.T) {
+	t.Parallel()
+
+	comparison := &QuoteComparison{Rows: []*QuoteComparisonRow{
+		{ExpiresAt: time.Date(2020, 10, 9, 21, 36, 17, 0, time.UTC), FeeType: FeeTypeStandard, Latency: 150 * time.Millisecond,
+			Miner: MinerTaal, MiningRate: 500, RelayRate: 250},
+		{Error: "status code: 400, bad request", Latency: time.Second, Miner: MinerMempool},
+	}}
+
+	data, err := comparison.MarshalCSV()
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	expected := "miner,fee_type,mining_rate,relay_rate,expires_at,latency_ms,error\n" +
+		"Taal,standard,500,250,2020-10-09T21:36:17Z,150,\n" +
+		"Mempool,,0,0,,1000,\"status code: 400, bad request\"\n"
+	if string(data) != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, string(data))
+	}
+}
+
+// TestQuoteComparison_MarshalJSON tests the method MarshalJSON()
+func TestQuoteComparison_MarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	data, err := json.Marshal(client.CompareQuotes(context.Background()))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	var table struct {
+		Columns []string   `json:"columns"`
+		Rows    [][]string `json:"rows"`
+	}
+	if err = json.Unmarshal(data, &table); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(table.Columns) != 7 || len(table.Rows) != len(client.Miners)*2 {
+		t.Fatalf("unexpected table: %s", string(data))
+	} else if table.Rows[0][0] != MinerMatterpool || table.Rows[0][2] != "500" {
+		t.Fatalf("unexpected first row: %v", table.Rows[0])
+	}
+}
+
+// ExampleQuoteComparison_MarshalCSV example using MarshalCSV()
+func ExampleQuoteComparison_MarshalCSV() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+
+	// Compare all miners
+	data, _ := client.CompareQuotes(context.Background()).MarshalCSV()
+	fmt.Printf("rows: %d", len(strings.Split(strings.TrimSpace(string(data)), "\n")))
+	// Output:rows: 7
+}
+
+// BenchmarkQuoteComparison_MarshalCSV benchmarks the method MarshalCSV()
+func BenchmarkQuoteComparison_MarshalCSV(b *testing.B) {
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	comparison := client.CompareQuotes(context.Background())
+	for i := 0; i < b.N; i++ {
+		_, _ = comparison.MarshalCSV()
+	}
+}
@@ -0,0 +1,68 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrFeeNotAccepted is returned when the fee of a transaction does not satisfy the quote of any miner
+var ErrFeeNotAccepted = errors.New("fee does not satisfy the quote of any miner")
+
+// QuoteForFee will check all known miners and return the quote of a miner that accepts the (fixed) fee
+// of a built transaction for the given size breakdown, and the mining fee required by that quote
+//
+// Unlike BestQuoteForTx(), the cheapest miner is not needed: the first miner (in the order of the miners
+// of the client) whose current quote the fee satisfies is returned. Miners that fail to return a quote
+// are skipped. If no quote is satisfied, ErrFeeNotAccepted is returned (with the smallest shortfall)
+func (c *Client) QuoteForFee(txSize *TxSize, paidFee uint64) (*FeeQuoteResponse, uint64, error) {
+
+	// Make sure we have a tx size (before requesting any quotes)
+	if txSize == nil {
+		return nil, 0, errors.New("tx size was nil")
+	}
+
+	// Collect the valid quotes by miner
+	quotes := make(map[string]*FeeQuoteResponse, len(c.Miners))
+	var failures []string
+	for _, result := range c.fetchAllQuotes(context.Background()) {
+		if result.Response.Error != nil {
+			failures = append(failures, result.Miner.Name+": "+result.Response.Error.Error())
+			continue
+		}
+		quote, err := result.parseQuote()
+		if err == nil && (quote.Quote == nil || len(quote.Quote.Fees) == 0) {
+			err = errors.New("failed getting quotes")
+		}
+		if err != nil {
+			failures = append(failures, result.Miner.Name+": "+err.Error())
+			continue
+		}
+		quotes[result.Miner.Name] = &quote
+	}
+
+	// Find the first miner whose quote the fee satisfies
+	var smallestShortfall uint64
+	for _, miner := range c.Miners {
+		quote, ok := quotes[miner.Name]
+		if !ok {
+			continue
+		}
+		sufficient, shortfall, err := quote.Quote.IsFeeSufficient(txSize, paidFee)
+		if err != nil {
+			failures = append(failures, miner.Name+": "+err.Error())
+		} else if sufficient {
+			requiredFee, _ := quote.Quote.CalculateTxFee(FeeCategoryMining, txSize)
+			return quote, requiredFee, nil
+		} else if smallestShortfall == 0 || shortfall < smallestShortfall {
+			smallestShortfall = shortfall
+		}
+	}
+
+	// No miner accepts the fee
+	if smallestShortfall == 0 {
+		return nil, 0, fmt.Errorf("%w: no valid quotes: %s", ErrFeeNotAccepted, strings.Join(failures, ", "))
+	}
+	return nil, 0, fmt.Errorf("%w: %d satoshis short of the cheapest quote", ErrFeeNotAccepted, smallestShortfall)
+}
@@ -0,0 +1,71 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// TestClient_QuoteForFee tests the method QuoteForFee()
+func TestClient_QuoteForFee(t *testing.T) {
+	t.Parallel()
+
+	// Standard mining rates: Taal 475, Matterpool 405, Mempool 350 (the miners are tried in this order)
+	client := newTestClient(&mockHTTPBetterRate{}).With(WithMiners(MinerTaal, MinerMatterpool, MinerMempool))
+	txSize := &TxSize{StandardBytes: 1000}
+
+	var tests = []struct {
+		paidFee       uint64
+		expectedMiner string
+		expectedFee   uint64
+	}{
+		{500, MinerTaal, 475},
+		{475, MinerTaal, 475},
+		{474, MinerMatterpool, 405},
+		{405, MinerMatterpool, 405},
+		{404, MinerMempool, 350},
+	}
+	for _, test := range tests {
+		if quote, fee, err := client.QuoteForFee(txSize, test.paidFee); err != nil {
+			t.Errorf("%s Failed: [%d] inputted, received error: %s", t.Name(), test.paidFee, err.Error())
+		} else if quote.Miner.Name != test.expectedMiner || fee != test.expectedFee {
+			t.Errorf("%s Failed: [%d] inputted and [%s %d] expected, received: [%s %d]",
+				t.Name(), test.paidFee, test.expectedMiner, test.expectedFee, quote.Miner.Name, fee)
+		}
+	}
+
+	t.Run("fee too low", func(t *testing.T) {
+		if _, _, err := client.QuoteForFee(txSize, 300); !errors.Is(err, ErrFeeNotAccepted) {
+			t.Fatalf("expected %v, got: %v", ErrFeeNotAccepted, err)
+		} else if err.Error() != ErrFeeNotAccepted.Error()+": 50 satoshis short of the cheapest quote" {
+			t.Fatalf("expected the shortfall, got: %s", err.Error())
+		}
+	})
+
+	t.Run("no quotes", func(t *testing.T) {
+		if _, _, err := newTestClient(&mockHTTPBadRequest{}).QuoteForFee(txSize, 1000); !errors.Is(err, ErrFeeNotAccepted) {
+			t.Fatalf("expected %v, got: %v", ErrFeeNotAccepted, err)
+		}
+	})
+
+	t.Run("nil tx size", func(t *testing.T) {
+		if _, _, err := client.QuoteForFee(nil, 1000); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleClient_QuoteForFee example using QuoteForFee()
+func ExampleClient_QuoteForFee() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPBetterRate{})
+
+	// Find a miner that accepts a fee of 400 satoshis for a 1000 byte tx
+	quote, fee, err := client.QuoteForFee(&TxSize{StandardBytes: 1000}, 400)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("miner: %s required fee: %d", quote.Miner.Name, fee)
+	// Output:miner: Mempool required fee: 350
+}
@@ -0,0 +1,95 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// QuotePrefetcher refreshes the cached quote of each miner in the background
+type QuotePrefetcher struct {
+	cancel   context.CancelFunc
+	client   *Client
+	interval time.Duration
+	wg       sync.WaitGroup
+}
+
+// StartQuotePrefetcher will start refreshing the quote of each miner in the background (quote caching must be enabled)
+//
+// Requests are staggered across the interval (not all miners at once), each quote is refreshed
+// every interval or before it expires (whichever is sooner), but never more often than the miner's RateLimit.
+// The prefetcher runs until Stop() is called or the context is cancelled.
+//
+// Note: the miners are loaded when the prefetcher is started
+func (c *Client) StartQuotePrefetcher(ctx context.Context, interval time.Duration) (*QuotePrefetcher, error) {
+
+	// Make sure we have valid options
+	if !c.Options.QuoteCacheEnabled {
+		return nil, errors.New("quote caching must be enabled to prefetch quotes")
+	} else if interval <= 0 {
+		return nil, errors.New("prefetch interval must be greater than zero")
+	}
+
+	// Create the prefetcher
+	p := &QuotePrefetcher{client: c, interval: interval}
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	// Start a staggered routine for each miner
+	miners := c.Miners
+	for index, miner := range miners {
+		p.wg.Add(1)
+		go p.run(ctx, miner, time.Duration(index)*interval/time.Duration(len(miners)))
+	}
+
+	return p, nil
+}
+
+// Stop will stop the prefetcher and wait for any running requests to finish
+func (p *QuotePrefetcher) Stop() {
+	p.cancel()
+	p.wg.Wait()
+}
+
+// run will refresh the miner's quote until the context is cancelled
+func (p *QuotePrefetcher) run(ctx context.Context, miner *Miner, offset time.Duration) {
+	defer p.wg.Done()
+
+	timer := p.client.clock().NewTimer(offset)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C():
+			result := fetchQuote(ctx, p.client, miner)
+			timer.Reset(p.nextRefresh(miner, result.quote))
+		}
+	}
+}
+
+// nextRefresh will return the time until the next refresh of the miner's quote
+func (p *QuotePrefetcher) nextRefresh(miner *Miner, quote *FeeQuoteResponse) time.Duration {
+
+	// Refresh before the quote expires (if sooner than the interval)
+	next := p.interval
+	if quote != nil && quote.Quote != nil {
+		if expiresAt, err := quote.Quote.ExpiresAt(); err == nil {
+			if untilExpiry := expiresAt.Sub(p.client.now()) - p.interval/10; untilExpiry < next {
+				next = untilExpiry
+			}
+		}
+	}
+
+	// Avoid hammering a miner with quotes that expire immediately
+	if minimum := p.interval / 10; next < minimum {
+		next = minimum
+	}
+
+	// Never exceed the miner's rate limit
+	if next < miner.RateLimit {
+		next = miner.RateLimit
+	}
+	return next
+}
@@ -0,0 +1,113 @@
+package minercraft
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestClient_StartQuotePrefetcher tests the method StartQuotePrefetcher()
+func TestClient_StartQuotePrefetcher(t *testing.T) {
+	t.Parallel()
+
+	t.Run("prefetches all miners", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: 10 * time.Minute}
+		client := newTestCachingClient(mock)
+
+		prefetcher, err := client.StartQuotePrefetcher(context.Background(), 30*time.Millisecond)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		// Wait for the first round of (staggered) requests
+		deadline := time.Now().Add(2 * time.Second)
+		for !allQuotesCached(client) {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected a cached quote for all miners")
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		prefetcher.Stop()
+
+		// No new requests are needed for the best quote
+		count := mock.count()
+		if _, err = client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if mock.count() != count {
+			t.Fatalf("expected %d requests, got %d", count, mock.count())
+		}
+	})
+
+	t.Run("stops with the context", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: 10 * time.Minute}
+		client := newTestCachingClient(mock)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		prefetcher, err := client.StartQuotePrefetcher(ctx, time.Hour)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		cancel()
+		prefetcher.Stop()
+	})
+
+	t.Run("caching disabled", func(t *testing.T) {
+		client := newTestClient(&mockHTTPCountingQuote{})
+		if _, err := client.StartQuotePrefetcher(context.Background(), time.Second); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("invalid interval", func(t *testing.T) {
+		client := newTestCachingClient(&mockHTTPCountingQuote{})
+		if _, err := client.StartQuotePrefetcher(context.Background(), 0); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// allQuotesCached will return true if all miners have a cached quote
+func allQuotesCached(client *Client) bool {
+	for _, miner := range client.Miners {
+		if client.quoteCache.get(miner, time.Now()) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// TestQuotePrefetcher_NextRefresh tests the method nextRefresh()
+func TestQuotePrefetcher_NextRefresh(t *testing.T) {
+	t.Parallel()
+
+	prefetcher := &QuotePrefetcher{interval: time.Minute}
+
+	// Quote expiring after the interval
+	quote := testQuote("miner1", 500)
+	quote.Quote.ExpirationTime = time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	if next := prefetcher.nextRefresh(&Miner{}, quote); next != time.Minute {
+		t.Fatalf("expected %v, got %v", time.Minute, next)
+	}
+
+	// Quote expiring before the interval
+	quote.Quote.ExpirationTime = time.Now().Add(30 * time.Second).UTC().Format(time.RFC3339)
+	if next := prefetcher.nextRefresh(&Miner{}, quote); next >= 30*time.Second || next < 6*time.Second {
+		t.Fatalf("expected a refresh before the quote expires, got %v", next)
+	}
+
+	// Quote already expired
+	quote.Quote.ExpirationTime = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	if next := prefetcher.nextRefresh(&Miner{}, quote); next != 6*time.Second {
+		t.Fatalf("expected %v, got %v", 6*time.Second, next)
+	}
+
+	// Miner rate limit
+	if next := prefetcher.nextRefresh(&Miner{RateLimit: 5 * time.Minute}, quote); next != 5*time.Minute {
+		t.Fatalf("expected %v, got %v", 5*time.Minute, next)
+	}
+
+	// No quote (failed request)
+	if next := prefetcher.nextRefresh(&Miner{}, nil); next != time.Minute {
+		t.Fatalf("expected %v, got %v", time.Minute, next)
+	}
+}
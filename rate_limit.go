@@ -0,0 +1,54 @@
+package minercraft
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// rateLimiter tracks the next allowed request time of each miner (see: Miner.RateLimit)
+type rateLimiter struct {
+	sync.Mutex
+	next map[string]time.Time
+}
+
+// newRateLimiter will return a new empty rate limiter
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{next: make(map[string]time.Time)}
+}
+
+// reserve will reserve the next request slot for the miner and return how long to wait for it
+func (r *rateLimiter) reserve(miner *Miner, now time.Time) time.Duration {
+	if miner.RateLimit <= 0 {
+		return 0
+	}
+
+	r.Lock()
+	defer r.Unlock()
+
+	key := strings.ToLower(miner.Name)
+	slot := r.next[key]
+	if slot.Before(now) {
+		slot = now
+	}
+	r.next[key] = slot.Add(miner.RateLimit)
+	return slot.Sub(now)
+}
+
+// wait will wait for the next request slot of the miner (or until the context is cancelled)
+func (r *rateLimiter) wait(ctx context.Context, miner *Miner, clock Clock) error {
+	delay := r.reserve(miner, clock.Now())
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := clock.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C():
+		return nil
+	}
+}
@@ -0,0 +1,69 @@
+package minercraft
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// TestRateLimiter_Reserve tests the method reserve()
+func TestRateLimiter_Reserve(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter()
+
+	// No rate limit
+	if delay := limiter.reserve(&Miner{Name: "Test"}, time.Now()); delay != 0 {
+		t.Fatalf("expected no delay, got %v", delay)
+	}
+
+	// First request is immediate, the next requests are spaced out
+	miner := &Miner{Name: "Test", RateLimit: time.Minute}
+	if delay := limiter.reserve(miner, time.Now()); delay != 0 {
+		t.Fatalf("expected no delay, got %v", delay)
+	}
+	if delay := limiter.reserve(miner, time.Now()); delay <= 59*time.Second || delay > time.Minute {
+		t.Fatalf("expected a delay of about %v, got %v", time.Minute, delay)
+	}
+	if delay := limiter.reserve(miner, time.Now()); delay <= 119*time.Second || delay > 2*time.Minute {
+		t.Fatalf("expected a delay of about %v, got %v", 2*time.Minute, delay)
+	}
+}
+
+// TestRateLimiter_Wait tests the method wait()
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Parallel()
+
+	limiter := newRateLimiter()
+	miner := &Miner{Name: "Test", RateLimit: time.Hour}
+	if err := limiter.wait(context.Background(), miner, RealClock()); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Cancelled while waiting
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := limiter.wait(ctx, miner, RealClock()); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// TestClient_FeeQuoteRateLimit tests the method FeeQuote() with a miner rate limit
+func TestClient_FeeQuoteRateLimit(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	miner := client.MinerByName(MinerTaal)
+	miner.RateLimit = 50 * time.Millisecond
+
+	// On-demand requests are spaced out by the rate limit
+	started := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.FeeQuote(miner); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	}
+	if elapsed := time.Since(started); elapsed < 100*time.Millisecond {
+		t.Fatalf("expected at least %v between requests, took %v", miner.RateLimit, elapsed)
+	}
+}
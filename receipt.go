@@ -0,0 +1,95 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// Receipt is the record of a submission kept in the store (see: SetStore), the proof that a miner
+// accepted (or rejected) a transaction even if the miner later disputes it
+//
+// The original signed response is kept, so the signature of the miner can be verified again at any time
+type Receipt struct {
+	Miner        string          `json:"miner"`
+	MinerID      string          `json:"miner_id"` // The minerId of the payload
+	Payload      string          `json:"payload"`
+	PublicKey    string          `json:"public_key"`
+	ReceivedAt   time.Time       `json:"received_at"`
+	Response     json.RawMessage `json:"response"` // The original signed response
+	ReturnResult string          `json:"return_result"`
+	Signature    string          `json:"signature"`
+	TxID         string          `json:"txid"`
+}
+
+// SubmissionReceipt will return the stored response of a transaction submitted to the miner
+// (or nil if not found or no store is set, see: SetStore)
+//
+// The original signed response is processed again (the signature is validated)
+func (c *Client) SubmissionReceipt(ctx context.Context, miner *Miner, txID string) (*SubmitTransactionResponse, error) {
+
+	// Get the receipt
+	receipt, err := c.Receipt(ctx, miner, txID)
+	if err != nil || receipt == nil {
+		return nil, err
+	}
+
+	// Parse the original response
+	result := &internalResult{Miner: miner, client: c, Response: &RequestResponse{BodyContents: receipt.Response}}
+	var response SubmitTransactionResponse
+	if response, err = result.parseSubmission(); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// Receipt will return the stored receipt of a transaction submitted to the miner
+// (or nil if not found or no store is set, see: SetStore)
+func (c *Client) Receipt(ctx context.Context, miner *Miner, txID string) (*Receipt, error) {
+
+	// Make sure we have a valid miner and store
+	if miner == nil {
+		return nil, errors.New("miner was nil")
+	} else if c.store == nil {
+		return nil, nil
+	}
+
+	// Get the receipt
+	data, err := c.store.Get(ctx, receiptStoreKey(miner, txID))
+	if err != nil || len(data) == 0 {
+		return nil, err
+	}
+	receipt := new(Receipt)
+	if err = json.Unmarshal(data, receipt); err != nil {
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// storeReceipt will save the receipt of the submission in the store (does not expire)
+func (c *Client) storeReceipt(ctx context.Context, miner *Miner, response *SubmitTransactionResponse, body []byte) {
+	if c.store == nil || response.Results == nil || len(response.Results.TxID) == 0 {
+		return
+	}
+	data, err := json.Marshal(&Receipt{
+		Miner:        miner.Name,
+		MinerID:      response.Results.MinerID,
+		Payload:      response.Payload,
+		PublicKey:    response.PublicKey,
+		ReceivedAt:   c.now().UTC(),
+		Response:     body,
+		ReturnResult: response.Results.ReturnResult,
+		Signature:    response.Signature,
+		TxID:         response.Results.TxID,
+	})
+	if err == nil {
+		_ = c.store.Set(ctx, receiptStoreKey(miner, response.Results.TxID), data, 0)
+	}
+}
+
+// receiptStoreKey will return the store key of the submission receipt for the miner and tx
+func receiptStoreKey(miner *Miner, txID string) string {
+	return "receipt:" + strings.ToLower(miner.Name) + ":" + strings.ToLower(txID)
+}
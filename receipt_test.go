@@ -0,0 +1,47 @@
+package minercraft
+
+import (
+	"context"
+	"testing"
+)
+
+// TestClient_Receipt tests the method Receipt()
+func TestClient_Receipt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("submission receipt", func(t *testing.T) {
+		store, err := NewFileStore(t.TempDir())
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		client := newTestClient(&mockHTTPValidSubmission{})
+		client.SetStore(store)
+		miner := client.MinerByName(MinerTaal)
+		if _, err = client.SubmitTransaction(miner, &Transaction{RawTx: testRawTx}); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		var receipt *Receipt
+		if receipt, err = client.Receipt(context.Background(), miner, testSubmittedTx); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if receipt == nil {
+			t.Fatalf("expected a receipt")
+		}
+		if receipt.Miner != MinerTaal || receipt.TxID != testSubmittedTx || receipt.ReturnResult != ReturnResultSuccess {
+			t.Fatalf("unexpected receipt: %+v", receipt)
+		} else if len(receipt.Signature) == 0 || len(receipt.PublicKey) == 0 || len(receipt.Payload) == 0 || len(receipt.MinerID) == 0 {
+			t.Fatalf("expected the signed payload in the receipt: %+v", receipt)
+		} else if receipt.ReceivedAt.IsZero() || len(receipt.Response) == 0 {
+			t.Fatalf("expected the time and original response in the receipt: %+v", receipt)
+		}
+	})
+
+	t.Run("no store", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		if receipt, err := client.Receipt(context.Background(), client.MinerByName(MinerTaal), testSubmittedTx); err != nil || receipt != nil {
+			t.Fatalf("expected no receipt but got: %v %v", receipt, err)
+		} else if _, err = client.Receipt(context.Background(), nil, testSubmittedTx); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
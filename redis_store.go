@@ -0,0 +1,66 @@
+package minercraft
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RedisDoFunc will run a Redis command (IE: "GET", "key") and return the reply
+//
+// A missing key must return a nil reply and no error, example using go-redis:
+//
+//	func(ctx context.Context, args ...interface{}) (interface{}, error) {
+//		reply, err := rdb.Do(ctx, args...).Result()
+//		if err == redis.Nil {
+//			return nil, nil
+//		}
+//		return reply, err
+//	}
+type RedisDoFunc func(ctx context.Context, args ...interface{}) (interface{}, error)
+
+// RedisStore is a Store backed by Redis (shares fee quotes and responses between instances)
+//
+// Any Redis client can be used (IE: go-redis or redigo) by wrapping it in a RedisDoFunc
+type RedisStore struct {
+	do     RedisDoFunc
+	prefix string
+}
+
+// NewRedisStore will return a new Redis store using the Redis client and key prefix (IE: "minercraft:")
+func NewRedisStore(do RedisDoFunc, prefix string) *RedisStore {
+	return &RedisStore{do: do, prefix: prefix}
+}
+
+// Delete will remove the key
+func (r *RedisStore) Delete(ctx context.Context, key string) error {
+	_, err := r.do(ctx, "DEL", r.prefix+key)
+	return err
+}
+
+// Get will return the value of the key (or nil if not found)
+func (r *RedisStore) Get(ctx context.Context, key string) ([]byte, error) {
+	reply, err := r.do(ctx, "GET", r.prefix+key)
+	if err != nil || reply == nil {
+		return nil, err
+	}
+	switch value := reply.(type) {
+	case []byte:
+		return value, nil
+	case string:
+		return []byte(value), nil
+	default:
+		return nil, fmt.Errorf("unexpected redis reply type: %T", reply)
+	}
+}
+
+// Set will store the value of the key, it expires after the ttl (zero means it does not expire)
+func (r *RedisStore) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var err error
+	if ttl > 0 {
+		_, err = r.do(ctx, "SET", r.prefix+key, value, "PX", ttl.Milliseconds())
+	} else {
+		_, err = r.do(ctx, "SET", r.prefix+key, value)
+	}
+	return err
+}
@@ -0,0 +1,197 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockRedis is an in-memory Redis (GET, SET with PX and DEL)
+type mockRedis struct {
+	sync.Mutex
+	commands []string
+	expires  map[string]time.Time
+	values   map[string][]byte
+}
+
+// newMockRedis will return a new empty mock Redis
+func newMockRedis() *mockRedis {
+	return &mockRedis{expires: make(map[string]time.Time), values: make(map[string][]byte)}
+}
+
+// do is a mock RedisDoFunc
+func (m *mockRedis) do(_ context.Context, args ...interface{}) (interface{}, error) {
+	m.Lock()
+	defer m.Unlock()
+	command := args[0].(string)
+	key := args[1].(string)
+	m.commands = append(m.commands, command+" "+key)
+	switch command {
+	case "GET":
+		if expiresAt, ok := m.expires[key]; ok && !time.Now().Before(expiresAt) {
+			delete(m.values, key)
+		}
+		if value, ok := m.values[key]; ok {
+			return string(value), nil
+		}
+		return nil, nil
+	case "SET":
+		m.values[key] = args[2].([]byte)
+		delete(m.expires, key)
+		if len(args) == 5 && args[3] == "PX" {
+			m.expires[key] = time.Now().Add(time.Duration(args[4].(int64)) * time.Millisecond)
+		}
+		return "OK", nil
+	case "DEL":
+		delete(m.values, key)
+		return int64(1), nil
+	}
+	return nil, fmt.Errorf("unknown command: %s", command)
+}
+
+// TestRedisStore tests the RedisStore
+func TestRedisStore(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	t.Run("get, set and delete", func(t *testing.T) {
+		redis := newMockRedis()
+		store := NewRedisStore(redis.do, "test:")
+		if err := store.Set(ctx, "key", []byte("value"), time.Minute); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if value, err := store.Get(ctx, "key"); err != nil || string(value) != "value" {
+			t.Fatalf("expected [value] but got: %s %v", value, err)
+		} else if err = store.Delete(ctx, "key"); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if value, err = store.Get(ctx, "key"); err != nil || value != nil {
+			t.Fatalf("expected no value but got: %s %v", value, err)
+		}
+
+		// The keys are prefixed
+		if !strings.HasPrefix(redis.commands[0], "SET test:key") {
+			t.Fatalf("expected a prefixed key but got: %s", redis.commands[0])
+		}
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		store := NewRedisStore(newMockRedis().do, "")
+		_ = store.Set(ctx, "key", []byte("value"), 10*time.Millisecond)
+		_ = store.Set(ctx, "forever", []byte("value"), 0)
+		time.Sleep(20 * time.Millisecond)
+		if value, _ := store.Get(ctx, "key"); value != nil {
+			t.Fatalf("expected no value but got: %s", value)
+		} else if value, _ = store.Get(ctx, "forever"); string(value) != "value" {
+			t.Fatalf("expected [value] but got: %s", value)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		store := NewRedisStore(func(context.Context, ...interface{}) (interface{}, error) {
+			return nil, errors.New("connection refused")
+		}, "")
+		if _, err := store.Get(ctx, "key"); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if err = store.Set(ctx, "key", nil, 0); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if err = store.Delete(ctx, "key"); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+
+		store = NewRedisStore(func(context.Context, ...interface{}) (interface{}, error) {
+			return int64(1), nil
+		}, "")
+		if _, err := store.Get(ctx, "key"); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestClient_SetStore tests sharing quotes between clients using SetStore()
+func TestClient_SetStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewRedisStore(newMockRedis().do, "minercraft:")
+
+	// The first client requests the quote
+	mockA := &mockHTTPCountingQuote{expiresIn: time.Minute}
+	clientA := newTestCachingClient(mockA)
+	clientA.SetStore(store)
+	if _, err := clientA.FeeQuote(clientA.MinerByName(MinerTaal)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// The second client uses the stored quote
+	mockB := &mockHTTPCountingQuote{expiresIn: time.Minute}
+	clientB := newTestCachingClient(mockB)
+	clientB.SetStore(store)
+	if response, err := clientB.FeeQuote(clientB.MinerByName(MinerTaal)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if response.Quote == nil || len(response.Quote.Fees) != 2 || response.Miner.Name != MinerTaal {
+		t.Fatalf("expected the stored quote but got: %v", response)
+	} else if mockA.count() != 1 || mockB.count() != 0 {
+		t.Fatalf("expected %d/%d requests, got %d/%d", 1, 0, mockA.count(), mockB.count())
+	}
+
+	// A different token is not shared
+	clientB.MinerUpdateToken(MinerTaal, testMinerToken)
+	_, _ = clientB.FeeQuote(clientB.MinerByName(MinerTaal))
+	if mockB.count() != 1 {
+		t.Fatalf("expected %d requests, got %d", 1, mockB.count())
+	}
+
+	// Invalidating removes the stored quote
+	clientA.InvalidateQuote(clientA.MinerByName(MinerTaal))
+	clientC := newTestCachingClient(mockB)
+	clientC.SetStore(store)
+	_, _ = clientC.FeeQuote(clientC.MinerByName(MinerTaal))
+	if mockB.count() != 2 {
+		t.Fatalf("expected %d requests, got %d", 2, mockB.count())
+	}
+}
+
+// TestResponseCache_SetStore tests sharing responses between caches using SetStore()
+func TestResponseCache_SetStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewRedisStore(newMockRedis().do, "")
+	mock := &mockHTTPCountingQuote{expiresIn: time.Minute}
+
+	// Two clients (instances) with their own response cache
+	for i := 0; i < 2; i++ {
+		cache := NewResponseCache(time.Minute)
+		cache.SetStore(store)
+		client := newTestClient(mock)
+		client.Use(cache.Middleware())
+		if response, err := client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Quote == nil || len(response.Quote.Fees) != 2 {
+			t.Fatalf("expected a valid quote, got: %v", response.Quote)
+		}
+	}
+	if mock.count() != 1 {
+		t.Fatalf("expected %d requests, got %d", 1, mock.count())
+	}
+}
+
+// ExampleNewRedisStore example using NewRedisStore()
+func ExampleNewRedisStore() {
+	// Wrap any Redis client (using an in-memory mock vs go-redis)
+	store := NewRedisStore(newMockRedis().do, "minercraft:")
+
+	// Share cached quotes between instances
+	client := newTestCachingClient(&mockHTTPCountingQuote{expiresIn: time.Minute})
+	client.SetStore(store)
+	if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	value, _ := store.Get(context.Background(), quoteStoreKey(client.MinerByName(MinerTaal)))
+	fmt.Printf("quote stored: %v", len(value) > 0)
+	// Output:quote stored: true
+}
@@ -0,0 +1,201 @@
+package minercraft
+
+import (
+	"context"
+	_ "embed" // Used for embedding the known miners registry
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// knownMinersRegistry is the embedded, versioned registry of known miners (see: miners.json)
+//
+// Any pre-filled tokens are for free use only
+// update your custom token with client.MinerUpdateToken("name", "token")
+//
+//go:embed miners.json
+var knownMinersRegistry []byte
+
+// KnownMiners is a pre-filled list of known miners (JSON array, loaded from the embedded registry)
+//
+// Deprecated: use DefaultMinerRegistry() (versioned) instead, this is kept for backwards compatibility
+var KnownMiners = knownMinersJSON()
+
+// knownMinersJSON will return the miners of the embedded registry as a JSON array (the original KnownMiners format)
+func knownMinersJSON() string {
+	registry, err := DefaultMinerRegistry()
+	if err != nil {
+		return "[]"
+	}
+	data, _ := json.MarshalIndent(registry.Miners, "", "  ")
+	return string(data)
+}
+
+/*
+Example miner registry file:
+
+{
+  "version": "1.0.0",
+  "miners": [
+    {
+      "name": "Taal",
+      "miner_id": "03e92d3e5c3f7bd945dfbf48e7a99393b1bfb3f11f380ae30d286e7ff2aec5a270",
+      "token": "",
+      "url": "merchantapi.taal.com"
+    }
+  ]
+}
+*/
+
+// MinerRegistry is a versioned list of miners (the embedded default registry or a remote registry)
+type MinerRegistry struct {
+	Version string   `json:"version"`
+	Miners  []*Miner `json:"miners"`
+}
+
+// RegistryDiff is the difference between a local and a remote miner registry (compared by miner name)
+type RegistryDiff struct {
+	LocalVersion  string   `json:"local_version"`
+	RemoteVersion string   `json:"remote_version"`
+	Added         []*Miner `json:"added"`   // Miners found in the remote registry but not locally
+	Removed       []*Miner `json:"removed"` // Miners found locally but not in the remote registry
+	Changed       []*Miner `json:"changed"` // Miners found in both, but with a different configuration (remote version)
+}
+
+// HasChanges will return true if the registries differ in any way
+func (d *RegistryDiff) HasChanges() bool {
+	return d.LocalVersion != d.RemoteVersion ||
+		len(d.Added) > 0 || len(d.Removed) > 0 || len(d.Changed) > 0
+}
+
+// DefaultMinerRegistry will return the embedded registry of known miners
+func DefaultMinerRegistry() (*MinerRegistry, error) {
+	return parseMinerRegistry(knownMinersRegistry)
+}
+
+// parseMinerRegistry will parse the raw JSON registry and make sure it has a version
+func parseMinerRegistry(data []byte) (registry *MinerRegistry, err error) {
+	if err = json.Unmarshal(data, &registry); err != nil {
+		return nil, err
+	} else if registry == nil || len(registry.Version) == 0 {
+		return nil, errors.New("miner registry is missing a version")
+	}
+	return
+}
+
+// Diff will compare the registry against a remote registry
+func (r *MinerRegistry) Diff(remote *MinerRegistry) *RegistryDiff {
+
+	// Start the diff
+	diff := &RegistryDiff{LocalVersion: r.Version, RemoteVersion: remote.Version}
+
+	// Find all miners that were changed or removed
+	for _, local := range r.Miners {
+		remoteMiner := findMinerByName(remote.Miners, local.Name)
+		if remoteMiner == nil {
+			diff.Removed = append(diff.Removed, local)
+		} else if minerChanged(local, remoteMiner) {
+			diff.Changed = append(diff.Changed, remoteMiner)
+		}
+	}
+
+	// Find all miners that are new
+	for _, remoteMiner := range remote.Miners {
+		if findMinerByName(r.Miners, remoteMiner.Name) == nil {
+			diff.Added = append(diff.Added, remoteMiner)
+		}
+	}
+
+	return diff
+}
+
+// minerChanged will return true if the miner's configuration differs (ignoring the name)
+func minerChanged(local, remote *Miner) bool {
+	return !equalStrings(local.AllowedIPs, remote.AllowedIPs) ||
+		!strings.EqualFold(local.Compression, remote.Compression) ||
+		!strings.EqualFold(local.ConnectAddress, remote.ConnectAddress) ||
+		local.DailyBudget != remote.DailyBudget ||
+		local.HourlyBudget != remote.HourlyBudget ||
+		local.LongPoll != remote.LongPoll ||
+		local.MaxTxSize != remote.MaxTxSize ||
+		!strings.EqualFold(local.MinerID, remote.MinerID) ||
+		!strings.EqualFold(local.GetNetwork(), remote.GetNetwork()) ||
+		local.RateLimit != remote.RateLimit ||
+		local.GetScheme() != remote.GetScheme() ||
+		!strings.EqualFold(local.StatusURL, remote.StatusURL) ||
+		local.Timeout != remote.Timeout ||
+		local.Token != remote.Token ||
+		!strings.EqualFold(local.URL, remote.URL)
+}
+
+// equalStrings will return true if both lists have the same values (in order)
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for index := range a {
+		if a[index] != b[index] {
+			return false
+		}
+	}
+	return true
+}
+
+// findMinerByName will return a miner from the list given a name
+func findMinerByName(miners []*Miner, name string) *Miner {
+	for index, miner := range miners {
+		if strings.EqualFold(name, miner.Name) {
+			return miners[index]
+		}
+	}
+	return nil
+}
+
+// RegistryVersion will return the version of the registry that was used to load the default miners
+func (c *Client) RegistryVersion() string {
+	return c.registryVersion
+}
+
+// FetchMinerRegistry will fire an HTTP request to retrieve a remote miner registry
+//
+// The remote registry must be in the same format as the embedded registry (see: miners.json)
+func (c *Client) FetchMinerRegistry(ctx context.Context, registryURL string) (*MinerRegistry, error) {
+
+	// Make sure we have a url
+	if len(registryURL) == 0 {
+		return nil, errors.New("missing registry url")
+	}
+
+	// Make the HTTP request
+	response := httpRequest(ctx, c, &httpPayload{
+		Method:  http.MethodGet,
+		URL:     registryURL,
+		Timeout: c.Options.RequestTimeout,
+	})
+	if response.Error != nil {
+		return nil, response.Error
+	}
+
+	// Parse the registry
+	return parseMinerRegistry(response.BodyContents)
+}
+
+// DiffMinerRegistry will fetch a remote miner registry and compare it against the embedded registry
+func (c *Client) DiffMinerRegistry(ctx context.Context, registryURL string) (*RegistryDiff, error) {
+
+	// Get the local registry
+	local, err := DefaultMinerRegistry()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the remote registry
+	var remote *MinerRegistry
+	if remote, err = c.FetchMinerRegistry(ctx, registryURL); err != nil {
+		return nil, err
+	}
+
+	// Return the diff
+	return local.Diff(remote), nil
+}
@@ -0,0 +1,271 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testRegistryURL = defaultProtocol + "registry.testminer.com/miners.json"
+
+// mockHTTPValidRegistry for mocking requests
+type mockHTTPValidRegistry struct{}
+
+// Do is a mock http request
+func (m *mockHTTPValidRegistry) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Valid response
+	if req.URL.String() == testRegistryURL {
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(`{
+		"version": "1.0.1",
+		"miners": [
+			{"name": "Taal","miner_id": "03e92d3e5c3f7bd945dfbf48e7a99393b1bfb3f11f380ae30d286e7ff2aec5a270","url": "mapi.taal.com"},
+			{"name": "Matterpool","miner_id": "0211ccfc29e3058b770f3cf3eb34b0b2fd2293057a994d4d275121be4151cdf087","url": "merchantapi.matterpool.io"},
+			{"name": "` + testMinerName + `","miner_id": "` + testMinerID + `","url": "testminer.com"}
+		]}`)))
+	}
+
+	// Default is valid
+	return resp, nil
+}
+
+// mockHTTPMissingRegistryVersion for mocking requests
+type mockHTTPMissingRegistryVersion struct{}
+
+// Do is a mock http request
+func (m *mockHTTPMissingRegistryVersion) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Valid response (missing version)
+	if req.URL.String() == testRegistryURL {
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(`{"miners": []}`)))
+	}
+
+	// Default is valid
+	return resp, nil
+}
+
+// TestDefaultMinerRegistry tests the method DefaultMinerRegistry()
+func TestDefaultMinerRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry, err := DefaultMinerRegistry()
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if registry == nil {
+		t.Fatalf("expected registry to not be nil")
+	}
+
+	if len(registry.Version) == 0 {
+		t.Fatalf("expected registry to have a version")
+	}
+
+	if len(registry.Miners) != 3 {
+		t.Fatalf("expected %d default miners, got %d", 3, len(registry.Miners))
+	}
+}
+
+// ExampleDefaultMinerRegistry example using DefaultMinerRegistry()
+func ExampleDefaultMinerRegistry() {
+	registry, err := DefaultMinerRegistry()
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("registry has %d miners", len(registry.Miners))
+	// Output:registry has 3 miners
+}
+
+// BenchmarkDefaultMinerRegistry benchmarks the method DefaultMinerRegistry()
+func BenchmarkDefaultMinerRegistry(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_, _ = DefaultMinerRegistry()
+	}
+}
+
+// TestKnownMiners tests the (deprecated) KnownMiners list
+func TestKnownMiners(t *testing.T) {
+	t.Parallel()
+
+	var miners []*Miner
+	if err := json.Unmarshal([]byte(KnownMiners), &miners); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if len(miners) != 3 || miners[0].Name != MinerTaal {
+		t.Fatalf("expected %d miners starting with %s, got: %v", 3, MinerTaal, miners)
+	}
+}
+
+// TestClient_RegistryVersion tests the method RegistryVersion()
+func TestClient_RegistryVersion(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPDefaultClient{})
+
+	registry, err := DefaultMinerRegistry()
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	if client.RegistryVersion() != registry.Version {
+		t.Fatalf("expected version %s, got %s", registry.Version, client.RegistryVersion())
+	}
+}
+
+// TestMinerRegistry_Diff tests the method Diff()
+func TestMinerRegistry_Diff(t *testing.T) {
+	t.Parallel()
+
+	local := &MinerRegistry{Version: "1.0.0", Miners: []*Miner{
+		{Name: MinerTaal, URL: "merchantapi.taal.com"},
+		{Name: MinerMempool, URL: "www.ddpurse.com/openapi"},
+	}}
+
+	t.Run("no changes", func(t *testing.T) {
+		diff := local.Diff(local)
+		if diff.HasChanges() {
+			t.Fatalf("expected no changes, got: %v", diff)
+		}
+	})
+
+	t.Run("added, removed and changed", func(t *testing.T) {
+		remote := &MinerRegistry{Version: "1.0.1", Miners: []*Miner{
+			{Name: MinerTaal, URL: "mapi.taal.com"},
+			{Name: MinerMatterpool, URL: "merchantapi.matterpool.io"},
+		}}
+
+		diff := local.Diff(remote)
+		if !diff.HasChanges() {
+			t.Fatalf("expected changes")
+		}
+
+		if len(diff.Added) != 1 || diff.Added[0].Name != MinerMatterpool {
+			t.Fatalf("expected %s to be added, got: %v", MinerMatterpool, diff.Added)
+		}
+		if len(diff.Removed) != 1 || diff.Removed[0].Name != MinerMempool {
+			t.Fatalf("expected %s to be removed, got: %v", MinerMempool, diff.Removed)
+		}
+		if len(diff.Changed) != 1 || diff.Changed[0].URL != "mapi.taal.com" {
+			t.Fatalf("expected %s to be changed, got: %v", MinerTaal, diff.Changed)
+		}
+	})
+
+	t.Run("changed fields", func(t *testing.T) {
+		var tests = []struct {
+			remoteMiner     *Miner
+			expectedChanged bool
+		}{
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com"}, false},
+			{&Miner{Name: MinerTaal, URL: "MerchantAPI.taal.com", Network: NetworkMainnet, Scheme: SchemeHTTPS}, false},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", Scheme: SchemeHTTP}, true},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", Network: NetworkTestnet}, true},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", RateLimit: time.Second}, true},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", Timeout: time.Minute}, true},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", MaxTxSize: DefaultMaxTxSize}, true},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", Token: "token"}, true},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", MinerID: testMinerID}, true},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", Compression: CompressionGzip}, true},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", ConnectAddress: "10.0.0.1"}, true},
+			{&Miner{Name: MinerTaal, URL: "merchantapi.taal.com", AllowedIPs: []string{"10.0.0.0/8"}}, true},
+		}
+
+		for _, test := range tests {
+			remote := &MinerRegistry{Version: local.Version, Miners: []*Miner{test.remoteMiner, local.Miners[1]}}
+			if changed := local.Diff(remote).HasChanges(); changed != test.expectedChanged {
+				t.Errorf("%s Failed: [%v] inputted and [%v] expected but got: %v", t.Name(), test.remoteMiner, test.expectedChanged, changed)
+			}
+		}
+	})
+}
+
+// TestClient_FetchMinerRegistry tests the method FetchMinerRegistry()
+func TestClient_FetchMinerRegistry(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid registry", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidRegistry{})
+		registry, err := client.FetchMinerRegistry(context.Background(), testRegistryURL)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if registry.Version != "1.0.1" {
+			t.Fatalf("expected version %s, got %s", "1.0.1", registry.Version)
+		} else if len(registry.Miners) != 3 {
+			t.Fatalf("expected %d miners, got %d", 3, len(registry.Miners))
+		}
+	})
+
+	t.Run("missing url", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidRegistry{})
+		if _, err := client.FetchMinerRegistry(context.Background(), ""); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("missing version", func(t *testing.T) {
+		client := newTestClient(&mockHTTPMissingRegistryVersion{})
+		if _, err := client.FetchMinerRegistry(context.Background(), testRegistryURL); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("http error", func(t *testing.T) {
+		client := newTestClient(&mockHTTPError{})
+		if _, err := client.FetchMinerRegistry(context.Background(), testRegistryURL); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		client := newTestClient(&mockHTTPInvalidJSON{})
+		if _, err := client.FetchMinerRegistry(context.Background(), testRegistryURL); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestClient_DiffMinerRegistry tests the method DiffMinerRegistry()
+func TestClient_DiffMinerRegistry(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidRegistry{})
+	diff, err := client.DiffMinerRegistry(context.Background(), testRegistryURL)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	if len(diff.Added) != 1 || diff.Added[0].Name != testMinerName {
+		t.Fatalf("expected %s to be added, got: %v", testMinerName, diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Name != MinerMempool {
+		t.Fatalf("expected %s to be removed, got: %v", MinerMempool, diff.Removed)
+	}
+	if len(diff.Changed) != 1 || diff.Changed[0].Name != MinerTaal {
+		t.Fatalf("expected %s to be changed, got: %v", MinerTaal, diff.Changed)
+	}
+
+	// Bad request
+	client = newTestClient(&mockHTTPBadRequest{})
+	if _, err = client.DiffMinerRegistry(context.Background(), testRegistryURL); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
@@ -5,10 +5,20 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
+	"time"
 )
 
+// StatusCodeError is returned when a miner responds with a status code other than 200
+type StatusCodeError struct {
+	StatusCode int // The status code of the response
+}
+
+// Error will return the error message
+func (e *StatusCodeError) Error() string {
+	return fmt.Sprintf("status code: %d does not match %d", e.StatusCode, http.StatusOK)
+}
+
 // RequestResponse is the response from a request
 type RequestResponse struct {
 	BodyContents []byte `json:"body_contents"` // Raw body response
@@ -21,10 +31,17 @@ type RequestResponse struct {
 
 // httpPayload is used for a httpRequest
 type httpPayload struct {
-	Method string `json:"method"`
-	URL    string `json:"url"`
-	Token  string `json:"token"`
-	Data   []byte `json:"data"`
+	Method          string        `json:"method"`
+	URL             string        `json:"url"`
+	Token           string        `json:"token"`
+	Authorization   string        `json:"-"` // Authorization header (IE: SPV channels, sent instead of the token)
+	Data            []byte        `json:"data"`
+	Miner           *Miner        `json:"-"`                // Miner of the request (used by the transport, see: NewTransport)
+	Operation       Operation     `json:"operation"`        // Operation of the request to the miner (see: UsageMetrics)
+	Body            io.Reader     `json:"-"`                // Streamed body (used instead of Data, the request is not retried)
+	ContentEncoding string        `json:"content_encoding"` // Encoding of the Data (IE: CompressionGzip, the PostData is not stored)
+	ContentLength   int64         `json:"content_length"`   // Length of the streamed body
+	Timeout         time.Duration `json:"timeout"`          // Timeout for the entire request (including retries and reading the body)
 }
 
 // httpRequest is a generic request wrapper that can be used without constraints
@@ -36,22 +53,60 @@ func httpRequest(ctx context.Context, client *Client, payload *httpPayload) (res
 	// Start the response
 	response = new(RequestResponse)
 
-	// Add post data if applicable
-	if payload.Method == http.MethodPost || payload.Method == http.MethodPut {
+	// Report the usage of the request (see: OnUsage)
+	defer client.hooks.fireUsage(client.requestTenant(ctx), payload, response)
+
+	// Use the token of the context or the tenant (see: ContextWithToken and SetTenant)
+	if payload.Miner != nil && len(payload.Authorization) == 0 {
+		if payload.Token, response.Error = client.requestToken(ctx, payload.Miner, payload.Token); response.Error != nil {
+			return
+		}
+	}
+
+	// Stay within the request budget of the miner (refused, or queued until the budget resets)
+	if response.Error = client.budgets.wait(ctx, payload.Miner, client.Options.QueueOverBudget, client.clock()); response.Error != nil {
+		return
+	}
+
+	// Set the timeout (a tighter deadline on the context is kept)
+	if payload.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, payload.Timeout)
+		defer cancel()
+	}
+
+	// Add post data if applicable (streamed bodies are not stored)
+	httpClient := client.httpClient
+	if payload.Body != nil {
+		bodyReader = payload.Body
+		httpClient = client.streamClient
+	} else if payload.Method == http.MethodPost || payload.Method == http.MethodPut {
 		bodyReader = bytes.NewBuffer(payload.Data)
-		response.PostData = string(payload.Data)
+		if len(payload.ContentEncoding) == 0 {
+			response.PostData = string(payload.Data)
+		}
 	}
 
 	// Store for debugging purposes
 	response.Method = payload.Method
 	response.URL = payload.URL
 
+	// Set the miner for the transport (connection settings of the miner)
+	if payload.Miner != nil {
+		ctx = contextWithMiner(ctx, payload.Miner)
+	}
+
 	// Start the request
 	var request *http.Request
 	if request, response.Error = http.NewRequestWithContext(ctx, payload.Method, payload.URL, bodyReader); response.Error != nil {
 		return
 	}
 
+	// Set the length of a streamed body
+	if payload.Body != nil {
+		request.ContentLength = payload.ContentLength
+	}
+
 	// Change the header (user agent is in case they block default Go user agents)
 	request.Header.Set("User-Agent", client.Options.UserAgent)
 
@@ -60,14 +115,22 @@ func httpRequest(ctx context.Context, client *Client, payload *httpPayload) (res
 		request.Header.Set("Content-Type", "application/json")
 	}
 
-	// Set a token if supplied
-	if len(payload.Token) > 0 {
+	// Set the encoding of the body (if compressed)
+	if len(payload.ContentEncoding) > 0 {
+		request.Header.Set("Content-Encoding", payload.ContentEncoding)
+	}
+
+	// Set a token (or the authorization) if supplied
+	if len(payload.Authorization) > 0 {
+		request.Header.Set("Authorization", payload.Authorization)
+	} else if len(payload.Token) > 0 {
 		request.Header.Set("token", payload.Token)
 	}
 
 	// Fire the http request
+	client.usage.request(payload.Miner, payload.Operation, client.now())
 	var resp *http.Response
-	if resp, response.Error = client.httpClient.Do(request); response.Error != nil {
+	if resp, response.Error = httpClient.Do(request); response.Error != nil {
 		if resp != nil {
 			response.StatusCode = resp.StatusCode
 		}
@@ -84,12 +147,48 @@ func httpRequest(ctx context.Context, client *Client, payload *httpPayload) (res
 
 	// Check status code
 	if http.StatusOK != resp.StatusCode {
-		response.Error = fmt.Errorf("status code: %d does not match %d", resp.StatusCode, http.StatusOK)
+		response.Error = &StatusCodeError{StatusCode: resp.StatusCode}
 		return
 	}
 
-	// Read the body
-	response.BodyContents, response.Error = ioutil.ReadAll(resp.Body)
+	// Read the body (aborts if the context is cancelled)
+	response.BodyContents, response.Error = readBody(ctx, resp.Body, client.maxEnvelopeSize())
 
 	return
 }
+
+// readBody will read the entire body, aborting if the context is cancelled
+//
+// The body is closed on cancellation, which unblocks a read from a stalled miner
+// (even if the http client does not honor the context while reading), bodies larger than the max size fail
+// with ErrEnvelopeTooLarge (0 = no limit)
+func readBody(ctx context.Context, body io.ReadCloser, maxSize int64) ([]byte, error) {
+
+	// Close the body if the context is cancelled before the read is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = body.Close()
+		case <-done:
+		}
+	}()
+
+	// Read the body into a pooled buffer (the contents are copied out, the buffer is reused)
+	buf := getBuffer()
+	defer putBuffer(buf)
+	var reader io.Reader = body
+	if maxSize > 0 {
+		reader = io.LimitReader(body, maxSize+1)
+	}
+	_, err := buf.ReadFrom(reader)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	} else if maxSize > 0 && int64(buf.Len()) > maxSize {
+		return nil, &EnvelopeError{Offset: maxSize, Reason: ErrEnvelopeTooLarge}
+	}
+	data := make([]byte, buf.Len())
+	copy(data, buf.Bytes())
+	return data, err
+}
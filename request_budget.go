@@ -0,0 +1,96 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned when a request would exceed the hourly or daily request budget of a miner
+// (see: Miner.HourlyBudget and ClientOptions.QueueOverBudget)
+var ErrBudgetExceeded = errors.New("miner request budget exceeded")
+
+// budgetUsage is the number of requests to a miner in the current hour and day (UTC)
+type budgetUsage struct {
+	day       time.Time
+	dayCount  int
+	hour      time.Time
+	hourCount int
+}
+
+// requestBudgets tracks the requests of each miner against its budget (see: Miner.DailyBudget)
+type requestBudgets struct {
+	sync.Mutex
+	usage map[string]*budgetUsage
+}
+
+// newRequestBudgets will return new empty request budgets
+func newRequestBudgets() *requestBudgets {
+	return &requestBudgets{usage: make(map[string]*budgetUsage)}
+}
+
+// take will count a request to the miner if it is within the budget,
+// otherwise it returns when the exhausted budget resets
+func (b *requestBudgets) take(miner *Miner, now time.Time) (resetAt time.Time, ok bool) {
+	if miner == nil || (miner.DailyBudget <= 0 && miner.HourlyBudget <= 0) {
+		return time.Time{}, true
+	}
+
+	b.Lock()
+	defer b.Unlock()
+
+	// Start new windows
+	hour, day := usageWindows(now)
+	key := strings.ToLower(miner.Name)
+	usage := b.usage[key]
+	if usage == nil {
+		usage = new(budgetUsage)
+		b.usage[key] = usage
+	}
+	if !usage.day.Equal(day) {
+		usage.day, usage.dayCount = day, 0
+	}
+	if !usage.hour.Equal(hour) {
+		usage.hour, usage.hourCount = hour, 0
+	}
+
+	// Refuse requests over the budget (the daily budget resets last)
+	if miner.DailyBudget > 0 && usage.dayCount >= miner.DailyBudget {
+		return day.AddDate(0, 0, 1), false
+	} else if miner.HourlyBudget > 0 && usage.hourCount >= miner.HourlyBudget {
+		return hour.Add(time.Hour), false
+	}
+	usage.dayCount++
+	usage.hourCount++
+	return time.Time{}, true
+}
+
+// wait will count a request to the miner, requests over the budget are refused with ErrBudgetExceeded
+// or (if queueing) wait for the budget to reset (or until the context is cancelled)
+func (b *requestBudgets) wait(ctx context.Context, miner *Miner, queue bool, clock Clock) error {
+	for {
+		resetAt, ok := b.take(miner, clock.Now())
+		if ok {
+			return nil
+		} else if !queue {
+			return fmt.Errorf("%w: %s (resets at %s)", ErrBudgetExceeded, miner.Name, resetAt.Format(time.RFC3339))
+		}
+
+		timer := clock.NewTimer(resetAt.Sub(clock.Now()))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C():
+		}
+	}
+}
+
+// usageWindows will return the start of the current hour and day (calendar hours and days in UTC)
+func usageWindows(now time.Time) (hour, day time.Time) {
+	now = now.UTC()
+	return now.Truncate(time.Hour), time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,72 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestRequestBudgets_Take tests the method take()
+func TestRequestBudgets_Take(t *testing.T) {
+	t.Parallel()
+
+	budgets := newRequestBudgets()
+	now := time.Date(2021, 1, 1, 10, 30, 0, 0, time.UTC)
+
+	// No budget
+	if _, ok := budgets.take(&Miner{Name: "Test"}, now); !ok {
+		t.Fatalf("expected no budget")
+	}
+
+	// The hourly budget resets at the next hour
+	miner := &Miner{Name: "Test", DailyBudget: 3, HourlyBudget: 2}
+	for i := 0; i < 2; i++ {
+		if _, ok := budgets.take(miner, now); !ok {
+			t.Fatalf("expected request %d to be within the budget", i)
+		}
+	}
+	if resetAt, ok := budgets.take(miner, now); ok || !resetAt.Equal(now.Truncate(time.Hour).Add(time.Hour)) {
+		t.Fatalf("expected the hourly budget to be exceeded until 11:00, got: %v %s", ok, resetAt)
+	}
+
+	// The daily budget resets at midnight (UTC)
+	now = now.Add(time.Hour)
+	if _, ok := budgets.take(miner, now); !ok {
+		t.Fatalf("expected the hourly budget to be reset")
+	} else if resetAt, ok := budgets.take(miner, now); ok || !resetAt.Equal(time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected the daily budget to be exceeded until midnight, got: %v %s", ok, resetAt)
+	} else if _, ok = budgets.take(miner, now.Add(24*time.Hour)); !ok {
+		t.Fatalf("expected the daily budget to be reset")
+	}
+}
+
+// TestClient_RequestBudget tests the request budget of a miner
+func TestClient_RequestBudget(t *testing.T) {
+	t.Parallel()
+
+	t.Run("refused", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		miner := client.MinerByName(MinerMatterpool)
+		miner.HourlyBudget = 1
+		if _, err := client.QueryTransaction(miner, testTx); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if _, err = client.QueryTransaction(miner, testTx); !errors.Is(err, ErrBudgetExceeded) {
+			t.Fatalf("expected error: %v got: %v", ErrBudgetExceeded, err)
+		}
+	})
+
+	t.Run("queued", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		client.Options.QueueOverBudget = true
+		miner := client.MinerByName(MinerMatterpool)
+		miner.DailyBudget = 1
+		_ = client.budgets.wait(context.Background(), miner, true, RealClock())
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if err := client.budgets.wait(ctx, miner, true, RealClock()); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected error: %v got: %v", context.DeadlineExceeded, err)
+		}
+	})
+}
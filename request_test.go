@@ -0,0 +1,201 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mockHTTPSlow for mocking a slow miner (responds after the delay, unless the request is cancelled)
+type mockHTTPSlow struct {
+	delay time.Duration
+}
+
+// Do is a mock http request
+func (m *mockHTTPSlow) Do(req *http.Request) (*http.Response, error) {
+	select {
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	case <-time.After(m.delay):
+	}
+	return (&mockHTTPValidFeeQuote{}).Do(req)
+}
+
+// TestClientOptions_Timeout tests the method timeout()
+func TestClientOptions_Timeout(t *testing.T) {
+	t.Parallel()
+
+	options := DefaultClientOptions()
+	if timeout := options.timeout(options.QuoteTimeout); timeout != options.QuoteTimeout {
+		t.Fatalf("expected %v, got %v", options.QuoteTimeout, timeout)
+	}
+
+	// Not set, uses the request timeout
+	if timeout := options.timeout(0); timeout != options.RequestTimeout {
+		t.Fatalf("expected %v, got %v", options.RequestTimeout, timeout)
+	}
+}
+
+// TestClient_MinerTimeout tests the method minerTimeout()
+func TestClient_MinerTimeout(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	if timeout := client.minerTimeout(&Miner{}, client.Options.QuoteTimeout); timeout != client.Options.QuoteTimeout {
+		t.Fatalf("expected %v, got %v", client.Options.QuoteTimeout, timeout)
+	}
+	if timeout := client.minerTimeout(&Miner{Timeout: time.Minute}, client.Options.QuoteTimeout); timeout != time.Minute {
+		t.Fatalf("expected %v, got %v", time.Minute, timeout)
+	}
+	if timeout := client.minerTimeout(nil, 0); timeout != client.Options.RequestTimeout {
+		t.Fatalf("expected %v, got %v", client.Options.RequestTimeout, timeout)
+	}
+}
+
+// TestHTTPRequest_Timeout tests the method httpRequest() with a timeout
+func TestHTTPRequest_Timeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("operation timeouts", func(t *testing.T) {
+		client := newTestClient(&mockHTTPSlow{delay: 200 * time.Millisecond})
+		client.Options.QuoteTimeout = 20 * time.Millisecond
+		client.Options.SubmitTimeout = time.Second
+
+		// Quotes time out
+		if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+
+		// Submissions use a different (longer) timeout
+		response := httpRequest(context.Background(), client, &httpPayload{
+			Method:  http.MethodGet,
+			URL:     defaultProtocol + "testminer.com" + routeFeeQuote,
+			Timeout: client.Options.timeout(client.Options.SubmitTimeout),
+		})
+		if response.Error != nil {
+			t.Fatalf("error occurred: %s", response.Error.Error())
+		}
+	})
+
+	t.Run("miner timeout override", func(t *testing.T) {
+		client := newTestClient(&mockHTTPSlow{delay: 50 * time.Millisecond})
+		client.Options.QuoteTimeout = 10 * time.Millisecond
+
+		// Slow (but cheap) miner
+		miner := client.MinerByName(MinerTaal)
+		miner.Timeout = time.Second
+		if _, err := client.FeeQuote(miner); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		// Other miners use the operation timeout
+		if _, err := client.FeeQuote(client.MinerByName(MinerMempool)); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("tighter context deadline", func(t *testing.T) {
+		client := newTestClient(&mockHTTPSlow{delay: 200 * time.Millisecond})
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		response := httpRequest(ctx, client, &httpPayload{
+			Method:  http.MethodGet,
+			URL:     defaultProtocol + "testminer.com" + routeFeeQuote,
+			Timeout: time.Second,
+		})
+		if response.Error == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// stalledBody is a response body that blocks on read until it is closed
+type stalledBody struct {
+	closed chan struct{}
+	once   sync.Once
+}
+
+// Read will block until the body is closed
+func (b *stalledBody) Read([]byte) (int, error) {
+	<-b.closed
+	return 0, errors.New("read on closed body")
+}
+
+// Close will close the body (unblocking any reads)
+func (b *stalledBody) Close() error {
+	b.once.Do(func() { close(b.closed) })
+	return nil
+}
+
+// mockHTTPStalledBody for mocking a miner that stalls while sending the body
+type mockHTTPStalledBody struct{}
+
+// Do is a mock http request
+func (m *mockHTTPStalledBody) Do(_ *http.Request) (*http.Response, error) {
+	return &http.Response{StatusCode: http.StatusOK, Body: &stalledBody{closed: make(chan struct{})}}, nil
+}
+
+// TestReadBody tests the method readBody()
+func TestReadBody(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid body", func(t *testing.T) {
+		data, err := readBody(context.Background(), ioutil.NopCloser(bytes.NewBufferString("body")), 0)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if string(data) != "body" {
+			t.Fatalf("expected [%s] but got: %s", "body", string(data))
+		}
+	})
+
+	t.Run("bodies do not share the pooled buffer", func(t *testing.T) {
+		first, _ := readBody(context.Background(), ioutil.NopCloser(bytes.NewBufferString("first")), 0)
+		second, _ := readBody(context.Background(), ioutil.NopCloser(bytes.NewBufferString("second")), 0)
+		if string(first) != "first" || string(second) != "second" {
+			t.Fatalf("expected [first second] but got: %s %s", string(first), string(second))
+		}
+	})
+
+	t.Run("large buffers are not pooled", func(t *testing.T) {
+		buf := getBuffer()
+		buf.Grow(maxPooledBufferSize + 1)
+		putBuffer(buf)
+
+		buf = getBuffer()
+		defer putBuffer(buf)
+		if buf.Len() != 0 {
+			t.Fatalf("expected an empty buffer, got: %d bytes", buf.Len())
+		}
+	})
+
+	t.Run("cancelled during a stalled read", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if _, err := readBody(ctx, &stalledBody{closed: make(chan struct{})}, 0); !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("expected [%v] but got: %v", context.DeadlineExceeded, err)
+		}
+	})
+
+	t.Run("fan-out does not block on a stalled miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPStalledBody{})
+		client.Options.QuoteTimeout = 20 * time.Millisecond
+		if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// BenchmarkReadBody benchmarks the method readBody() with a typical quote response
+func BenchmarkReadBody(b *testing.B) {
+	body := []byte(testEnvelope)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, _ = readBody(context.Background(), ioutil.NopCloser(bytes.NewReader(body)), 0)
+	}
+}
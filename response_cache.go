@@ -0,0 +1,244 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResponseCache is a middleware (see: Client.Use) that caches successful GET responses
+// (IE: fee quotes, policy quotes and transaction queries) by miner endpoint, path and token
+//
+// Each route can have its own TTL (see: SetTTL), otherwise the default TTL is used.
+// The cache is bounded (see: SetMaxEntries), the least recently used response is evicted when it's full
+type ResponseCache struct {
+	clock      Clock
+	defaultTTL time.Duration
+	mu         sync.RWMutex
+	responses  *lruCache
+	store      Store
+	ttls       map[string]time.Duration
+}
+
+// cachedResponse is a cached HTTP response
+type cachedResponse struct {
+	body       []byte
+	expiresAt  time.Time
+	header     http.Header
+	statusCode int
+}
+
+// NewResponseCache will return a new response cache using the default TTL for all routes
+//
+// A default TTL of zero only caches the routes that have a TTL set (see: SetTTL).
+// The cache holds up to DefaultResponseCacheMaxEntries responses (see: SetMaxEntries)
+func NewResponseCache(defaultTTL time.Duration) *ResponseCache {
+	return &ResponseCache{
+		defaultTTL: defaultTTL,
+		responses:  newLRUCache(DefaultResponseCacheMaxEntries),
+		ttls:       make(map[string]time.Duration),
+	}
+}
+
+// SetMaxEntries will set the max number of cached responses (zero means there is no limit)
+//
+// The least recently used responses over the limit are evicted
+func (r *ResponseCache) SetMaxEntries(maxEntries int) {
+	r.mu.Lock()
+	r.responses.resize(maxEntries)
+	r.mu.Unlock()
+}
+
+// Len will return the number of cached responses (including expired responses that were not evicted yet)
+func (r *ResponseCache) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.responses.len()
+}
+
+// SetTTL will set the TTL for a route (IE: "/mapi/feeQuote" or "/mapi/tx"), zero disables caching of the route
+//
+// The route matches any request path containing it (including miner path prefixes), the longest match is used
+func (r *ResponseCache) SetTTL(route string, ttl time.Duration) {
+	r.mu.Lock()
+	r.ttls[strings.TrimRight(route, "/")] = ttl
+	r.mu.Unlock()
+}
+
+// SetStore will set the store used to share cached responses between instances (IE: dedupe status polling)
+//
+// The store is checked when a response is not found in the local cache (only the body is shared)
+func (r *ResponseCache) SetStore(store Store) {
+	r.mu.Lock()
+	r.store = store
+	r.mu.Unlock()
+}
+
+// SetClock will set the clock used for the expiration of the responses (defaults to the real time)
+func (r *ResponseCache) SetClock(clock Clock) {
+	r.mu.Lock()
+	r.clock = clock
+	r.mu.Unlock()
+}
+
+// now will return the current time of the clock (the real time if not set)
+func (r *ResponseCache) now() time.Time {
+	r.mu.RLock()
+	clock := r.clock
+	r.mu.RUnlock()
+	if clock == nil {
+		return time.Now()
+	}
+	return clock.Now()
+}
+
+// Purge will remove all cached responses (from the local cache, stored responses expire using their TTL)
+func (r *ResponseCache) Purge() {
+	r.mu.Lock()
+	r.responses = newLRUCache(r.responses.maxEntries)
+	r.mu.Unlock()
+}
+
+// Middleware will return the middleware for the client (see: Client.Use)
+func (r *ResponseCache) Middleware() Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return RequestHandlerFunc(func(req *http.Request) (*http.Response, error) {
+
+			// Only cache GET requests with a TTL
+			ttl := r.ttl(req)
+			if ttl <= 0 {
+				return next.Do(req)
+			}
+
+			// Use the cached response (if found)
+			key := responseCacheKey(req)
+			if cached := r.get(key); cached != nil {
+				return cached.response(req), nil
+			} else if cached = r.getStored(req.Context(), key); cached != nil {
+				return cached.response(req), nil
+			}
+
+			// Fire the request
+			resp, err := next.Do(req)
+			if err != nil || resp == nil || resp.StatusCode != http.StatusOK || resp.Body == nil {
+				return resp, err
+			}
+
+			// Read and store the body
+			var body []byte
+			body, err = ioutil.ReadAll(resp.Body)
+			_ = resp.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			cached := &cachedResponse{
+				body:       body,
+				expiresAt:  r.now().Add(ttl),
+				header:     resp.Header.Clone(),
+				statusCode: resp.StatusCode,
+			}
+			r.mu.Lock()
+			r.responses.set(key, cached)
+			r.mu.Unlock()
+			r.setStored(req.Context(), key, cached, ttl)
+
+			return cached.response(req), nil
+		})
+	}
+}
+
+// get will return an unexpired cached response (or nil if not found), expired responses are removed
+func (r *ResponseCache) get(key string) *cachedResponse {
+	now := r.now()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.responses.get(key)
+	if !ok {
+		return nil
+	} else if cached := value.(*cachedResponse); now.Before(cached.expiresAt) {
+		return cached
+	}
+	r.responses.delete(key)
+	return nil
+}
+
+// getStored will return an unexpired response from the store (or nil if not found or no store is set)
+//
+// The stored value is the expiration time (unix nanoseconds) followed by the body
+func (r *ResponseCache) getStored(ctx context.Context, key string) *cachedResponse {
+	r.mu.RLock()
+	store := r.store
+	r.mu.RUnlock()
+	if store == nil {
+		return nil
+	}
+	data, err := store.Get(ctx, storeKey("response", key))
+	if err != nil || len(data) < 8 {
+		return nil
+	}
+	cached := &cachedResponse{
+		body:       data[8:],
+		expiresAt:  time.Unix(0, int64(binary.BigEndian.Uint64(data[:8]))),
+		header:     make(http.Header),
+		statusCode: http.StatusOK,
+	}
+	if !r.now().Before(cached.expiresAt) {
+		return nil
+	}
+	r.mu.Lock()
+	r.responses.set(key, cached)
+	r.mu.Unlock()
+	return cached
+}
+
+// setStored will save the response in the store (if set)
+func (r *ResponseCache) setStored(ctx context.Context, key string, cached *cachedResponse, ttl time.Duration) {
+	r.mu.RLock()
+	store := r.store
+	r.mu.RUnlock()
+	if store == nil {
+		return
+	}
+	data := make([]byte, 8, 8+len(cached.body))
+	binary.BigEndian.PutUint64(data, uint64(cached.expiresAt.UnixNano()))
+	_ = store.Set(ctx, storeKey("response", key), append(data, cached.body...), ttl)
+}
+
+// ttl will return the TTL for the request (zero if the request is not cached)
+func (r *ResponseCache) ttl(req *http.Request) time.Duration {
+	if req.Method != http.MethodGet {
+		return 0
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	ttl, matched := r.defaultTTL, ""
+	for route, routeTTL := range r.ttls {
+		if len(route) > len(matched) && strings.Contains(req.URL.Path, route) {
+			ttl, matched = routeTTL, route
+		}
+	}
+	return ttl
+}
+
+// response will return a new HTTP response for the cached response
+func (c *cachedResponse) response(req *http.Request) *http.Response {
+	return &http.Response{
+		Body:          ioutil.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Header:        c.header.Clone(),
+		Request:       req,
+		Status:        fmt.Sprintf("%d %s", c.statusCode, http.StatusText(c.statusCode)),
+		StatusCode:    c.statusCode,
+	}
+}
+
+// responseCacheKey will return the cache key for the request (miner endpoint, path and token)
+func responseCacheKey(req *http.Request) string {
+	return req.URL.String() + "|" + req.Header.Get("token")
+}
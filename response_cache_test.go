@@ -0,0 +1,146 @@
+package minercraft
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestResponseCache_Middleware tests the method Middleware()
+func TestResponseCache_Middleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cache fee quotes", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: time.Minute}
+		client := newTestClient(mock)
+		client.Use(NewResponseCache(time.Minute).Middleware())
+
+		for i := 0; i < 3; i++ {
+			if response, err := client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			} else if response.Quote == nil || len(response.Quote.Fees) != 2 {
+				t.Fatalf("expected a valid quote, got: %v", response.Quote)
+			}
+		}
+		if mock.count() != 1 {
+			t.Fatalf("expected %d requests, got %d", 1, mock.count())
+		}
+
+		// Another miner is not cached
+		if _, err := client.FeeQuote(client.MinerByName(MinerMempool)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if mock.count() != 2 {
+			t.Fatalf("expected %d requests, got %d", 2, mock.count())
+		}
+
+		// A new token is not cached
+		client.MinerUpdateToken(MinerTaal, testMinerToken)
+		if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if mock.count() != 3 {
+			t.Fatalf("expected %d requests, got %d", 3, mock.count())
+		}
+	})
+
+	t.Run("route ttl", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: time.Minute}
+		client := newTestClient(mock)
+		cache := NewResponseCache(time.Minute)
+		cache.SetTTL("/mapi", time.Minute)
+		cache.SetTTL("/mapi/feeQuote/", 0)
+		client.Use(cache.Middleware())
+
+		for i := 0; i < 2; i++ {
+			if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); err != nil {
+				t.Fatalf("error occurred: %s", err.Error())
+			}
+		}
+		if mock.count() != 2 {
+			t.Fatalf("expected %d requests, got %d", 2, mock.count())
+		}
+	})
+
+	t.Run("expired and purged", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: time.Minute}
+		client := newTestClient(mock)
+		cache := NewResponseCache(0)
+		cache.SetTTL(routeFeeQuote, 10*time.Millisecond)
+		client.Use(cache.Middleware())
+
+		_, _ = client.FeeQuote(client.MinerByName(MinerTaal))
+		time.Sleep(20 * time.Millisecond)
+		_, _ = client.FeeQuote(client.MinerByName(MinerTaal))
+		if mock.count() != 2 {
+			t.Fatalf("expected %d requests, got %d", 2, mock.count())
+		}
+
+		cache.SetTTL(routeFeeQuote, time.Minute)
+		cache.Purge()
+		_, _ = client.FeeQuote(client.MinerByName(MinerTaal))
+		_, _ = client.FeeQuote(client.MinerByName(MinerTaal))
+		if mock.count() != 3 {
+			t.Fatalf("expected %d requests, got %d", 3, mock.count())
+		}
+	})
+
+	t.Run("only successful GET requests", func(t *testing.T) {
+		var requests int
+		client := newTestClient(RequestHandlerFunc(func(req *http.Request) (*http.Response, error) {
+			requests++
+			return (&mockHTTPCustomEndpoint{}).Do(req)
+		}))
+		client.Use(NewResponseCache(time.Minute).Middleware())
+
+		for i := 0; i < 2; i++ {
+			_, _ = client.Do(context.Background(), client.MinerByName(MinerTaal), http.MethodPost, "/mapi/custom/ext", nil, nil)
+			_, _ = client.Do(context.Background(), client.MinerByName(MinerTaal), http.MethodGet, "/mapi/unknown", nil, nil)
+		}
+		if requests != 4 {
+			t.Fatalf("expected %d requests, got %d", 4, requests)
+		}
+	})
+}
+
+// TestResponseCache_SetMaxEntries tests the method SetMaxEntries()
+func TestResponseCache_SetMaxEntries(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCountingQuote{expiresIn: time.Minute}
+	client := newTestClient(mock)
+	cache := NewResponseCache(time.Minute)
+	cache.SetMaxEntries(2)
+	client.Use(cache.Middleware())
+
+	// The least recently used response (Taal) is evicted
+	for _, name := range []string{MinerTaal, MinerMempool, MinerTaal, MinerMatterpool, MinerTaal, MinerMempool} {
+		if _, err := client.FeeQuote(client.MinerByName(name)); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	}
+	if mock.count() != 4 {
+		t.Fatalf("expected %d requests, got %d", 4, mock.count())
+	} else if cache.Len() != 2 {
+		t.Fatalf("expected %d entries, got %d", 2, cache.Len())
+	}
+
+	// Shrinking the cache evicts responses
+	cache.SetMaxEntries(1)
+	if cache.Len() != 1 {
+		t.Fatalf("expected %d entries, got %d", 1, cache.Len())
+	}
+	cache.Purge()
+	if cache.Len() != 0 {
+		t.Fatalf("expected %d entries, got %d", 0, cache.Len())
+	}
+}
+
+// BenchmarkResponseCache_Middleware benchmarks the method Middleware()
+func BenchmarkResponseCache_Middleware(b *testing.B) {
+	client := newTestClient(&mockHTTPCountingQuote{expiresIn: time.Minute})
+	client.Use(NewResponseCache(time.Minute).Middleware())
+	miner := client.MinerByName(MinerTaal)
+	for i := 0; i < b.N; i++ {
+		_, _ = client.FeeQuote(miner)
+	}
+}
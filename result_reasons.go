@@ -0,0 +1,151 @@
+package minercraft
+
+import "strings"
+
+// ResultReason is the typed reason of a resultDescription of a miner (see: ClassifyResult)
+type ResultReason string
+
+const (
+
+	// ResultReasonAlreadyKnown is a transaction the miner already has (IE: "257: txn-already-known")
+	ResultReasonAlreadyKnown ResultReason = "already_known"
+
+	// ResultReasonAlreadyMined is a transaction that is already in a block
+	ResultReasonAlreadyMined ResultReason = "already_mined"
+
+	// ResultReasonDoubleSpend is a transaction that spends inputs spent by another transaction
+	ResultReasonDoubleSpend ResultReason = "double_spend"
+
+	// ResultReasonFeeTooLow is a transaction with a fee below the quote of the miner (IE: "Insufficient fee")
+	ResultReasonFeeTooLow ResultReason = "fee_too_low"
+
+	// ResultReasonInMempool is a queried transaction that is not yet in a block
+	ResultReasonInMempool ResultReason = "in_mempool"
+
+	// ResultReasonInvalidTx is a transaction that failed validation (IE: a script or a malformed transaction)
+	ResultReasonInvalidTx ResultReason = "invalid_tx"
+
+	// ResultReasonMempoolFull is a transaction rejected because the mempool of the miner is full
+	ResultReasonMempoolFull ResultReason = "mempool_full"
+
+	// ResultReasonMissingInputs is a transaction with inputs the miner does not know (IE: the parent is missing)
+	ResultReasonMissingInputs ResultReason = "missing_inputs"
+
+	// ResultReasonNonFinal is a transaction that is not final (IE: a future lock time)
+	ResultReasonNonFinal ResultReason = "non_final"
+
+	// ResultReasonNotFound is a queried transaction the miner does not know
+	ResultReasonNotFound ResultReason = "not_found"
+
+	// ResultReasonTooLarge is a transaction larger than the policy of the miner
+	ResultReasonTooLarge ResultReason = "too_large"
+
+	// ResultReasonUnknown is a resultDescription that is not classified
+	ResultReasonUnknown ResultReason = "unknown"
+)
+
+// resultReasons are the (lowercase) fragments of the resultDescription of each reason (the first match wins)
+var resultReasons = []struct {
+	reason    ResultReason
+	fragments []string
+}{
+	{ResultReasonAlreadyMined, []string{"already in block", "already mined", "already confirmed", "txn-already-in-block"}},
+	{ResultReasonDoubleSpend, []string{"txn-mempool-conflict", "double spend", "double-spend", "conflict", "inputs-spent"}},
+	{ResultReasonAlreadyKnown, []string{
+		"txn-already-known", "already known", "already in the mempool", "already in mempool", "txn-already-in-mempool",
+		"already submitted",
+	}},
+	{ResultReasonInMempool, []string{queryInMempoolDescription}},
+	{ResultReasonNotFound, []string{"no such mempool or blockchain transaction", "transaction not found", "unknown transaction"}},
+	{ResultReasonMissingInputs, []string{"missing inputs", "missing-inputs", "missingorspent", "missing parent"}},
+	{ResultReasonFeeTooLow, []string{
+		"insufficient fee", "not enough fee", "insufficient priority", "fee not met", "fee too low", "min relay fee",
+		"low fee",
+	}},
+	{ResultReasonMempoolFull, []string{"mempool full", "mempool-full", "mempool is full"}},
+	{ResultReasonNonFinal, []string{"non-final", "non-bip68-final", "not final"}},
+	{ResultReasonTooLarge, []string{"tx-size", "too large", "too big", "exceeds the max"}},
+	{ResultReasonInvalidTx, []string{
+		"bad-txns", "script-verify", "scriptsig", "dust", "decode failed", "invalid transaction", "invalid tx",
+		"non-standard", "nonstandard", "scriptpubkey",
+	}},
+}
+
+// ClassifyResult will return the reason of a resultDescription of a miner, IE: "Missing inputs" or
+// "257: txn-already-known" (empty if the description is empty, ResultReasonUnknown if not classified)
+func ClassifyResult(description string) ResultReason {
+	description = strings.ToLower(strings.TrimSpace(description))
+	if len(description) == 0 {
+		return ""
+	}
+	for _, rule := range resultReasons {
+		for _, fragment := range rule.fragments {
+			if strings.Contains(description, fragment) {
+				return rule.reason
+			}
+		}
+	}
+	return ResultReasonUnknown
+}
+
+// ResultReasoner is a response with a result reason
+// (SubmitTransactionResponse, QueryTransactionResponse and SubmissionStatus)
+type ResultReasoner interface {
+	ResultReason() ResultReason
+}
+
+// ResultReason will return the reason of the result description (empty if there is no payload)
+func (r *SubmitTransactionResponse) ResultReason() ResultReason {
+	if r == nil || r.Results == nil {
+		return ""
+	}
+	return r.Results.Reason
+}
+
+// ResultReason will return the reason of the result description (empty if there is no payload)
+func (q *QueryTransactionResponse) ResultReason() ResultReason {
+	if q == nil || q.Query == nil {
+		return ""
+	}
+	return q.Query.Reason
+}
+
+// ResultReason will return the reason of the result description
+func (s *SubmissionStatus) ResultReason() ResultReason {
+	if s == nil {
+		return ""
+	}
+	return s.Reason
+}
+
+// IsAlreadyKnown will return true if the miner already has the transaction (in the mempool or in a block),
+// a rejected submission of a known transaction does not need to be broadcast again
+func IsAlreadyKnown(resp ResultReasoner) bool {
+	reason := resultReason(resp)
+	return reason == ResultReasonAlreadyKnown || reason == ResultReasonAlreadyMined
+}
+
+// IsDoubleSpend will return true if the transaction spends inputs that are spent by another transaction
+func IsDoubleSpend(resp ResultReasoner) bool {
+	return resultReason(resp) == ResultReasonDoubleSpend
+}
+
+// IsFeeTooLow will return true if the fee of the transaction is below the quote of the miner
+// (IE: get a new fee quote and rebuild the transaction)
+func IsFeeTooLow(resp ResultReasoner) bool {
+	return resultReason(resp) == ResultReasonFeeTooLow
+}
+
+// IsMissingInputs will return true if the miner does not know the inputs of the transaction
+// (IE: the parent transaction has to be broadcast first)
+func IsMissingInputs(resp ResultReasoner) bool {
+	return resultReason(resp) == ResultReasonMissingInputs
+}
+
+// resultReason will return the reason of the response (empty if the response is nil)
+func resultReason(resp ResultReasoner) ResultReason {
+	if resp == nil {
+		return ""
+	}
+	return resp.ResultReason()
+}
@@ -0,0 +1,94 @@
+package minercraft
+
+import (
+	"testing"
+)
+
+// TestClassifyResult will test the method ClassifyResult()
+func TestClassifyResult(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		description string
+		expected    ResultReason
+	}{
+		{"", ""},
+		{"Transaction already in the mempool", ResultReasonAlreadyKnown},
+		{"257: txn-already-known", ResultReasonAlreadyKnown},
+		{"Transaction already in block", ResultReasonAlreadyMined},
+		{"Missing inputs", ResultReasonMissingInputs},
+		{"16: bad-txns-inputs-missingorspent", ResultReasonMissingInputs},
+		{"258: txn-mempool-conflict", ResultReasonDoubleSpend},
+		{"Insufficient fee", ResultReasonFeeTooLow},
+		{"Not enough fees", ResultReasonFeeTooLow},
+		{"66: mempool min fee not met", ResultReasonFeeTooLow},
+		{"64: non-final", ResultReasonNonFinal},
+		{"16: mandatory-script-verify-flag-failed", ResultReasonInvalidTx},
+		{"Transaction in mempool but not yet in block", ResultReasonInMempool},
+		{"No such mempool or blockchain transaction", ResultReasonNotFound},
+		{"something went wrong", ResultReasonUnknown},
+	}
+	for _, test := range tests {
+		if output := ClassifyResult(test.description); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%s] expected, received: [%s]",
+				t.Name(), test.description, test.expected, output)
+		}
+	}
+}
+
+// TestClient_ResultReason will test the reason of the responses
+func TestClient_ResultReason(t *testing.T) {
+	t.Parallel()
+
+	t.Run("rejected submission", func(t *testing.T) {
+		client := newTestClient(&mockHTTPRejectedSubmission{})
+		response, err := client.SubmitTransaction(client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Results.Reason != ResultReasonFeeTooLow {
+			t.Fatalf("expected reason: %s got: %s", ResultReasonFeeTooLow, response.Results.Reason)
+		}
+	})
+
+	t.Run("successful query", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		response, err := client.QueryTransaction(client.MinerByName(MinerMatterpool), testTx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Query.Reason != "" {
+			t.Fatalf("expected no reason, got: %s", response.Query.Reason)
+		}
+	})
+}
+
+// TestIsAlreadyKnown will test the methods IsAlreadyKnown(), IsDoubleSpend(), IsFeeTooLow() and IsMissingInputs()
+func TestIsAlreadyKnown(t *testing.T) {
+	t.Parallel()
+
+	submission := func(description string) *SubmitTransactionResponse {
+		return &SubmitTransactionResponse{Results: &SubmissionPayload{Reason: ClassifyResult(description)}}
+	}
+
+	var tests = []struct {
+		resp      ResultReasoner
+		predicate func(ResultReasoner) bool
+		expected  bool
+	}{
+		{submission("257: txn-already-known"), IsAlreadyKnown, true},
+		{submission("Transaction already in block"), IsAlreadyKnown, true},
+		{&SubmissionStatus{Reason: ResultReasonAlreadyKnown}, IsAlreadyKnown, true},
+		{submission("Missing inputs"), IsAlreadyKnown, false},
+		{submission("Missing inputs"), IsMissingInputs, true},
+		{submission("Insufficient fee"), IsFeeTooLow, true},
+		{submission("258: txn-mempool-conflict"), IsDoubleSpend, true},
+		{&QueryTransactionResponse{}, IsMissingInputs, false},
+		{(*SubmitTransactionResponse)(nil), IsAlreadyKnown, false},
+		{nil, IsFeeTooLow, false},
+	}
+	for _, test := range tests {
+		if output := test.predicate(test.resp); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%t] expected, received: [%t]",
+				t.Name(), resultReason(test.resp), test.expected, output)
+		}
+	}
+}
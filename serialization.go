@@ -0,0 +1,122 @@
+package minercraft
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+)
+
+// envelopeRecordVersion is the version of the binary encoding of a response
+const envelopeRecordVersion = 1
+
+// envelopeRecord is the binary (gob) encoding of a response: the signed envelope and the miner
+//
+// The decoded payload is not encoded, it's decoded again from the signed payload
+type envelopeRecord struct {
+	Encoding  string
+	Miner     *Miner
+	MimeType  string
+	Payload   string // The signed payload (unescaped)
+	PublicKey string
+	Signature string
+	Version   uint8
+}
+
+// MarshalBinary will encode the response (the signed envelope and the miner) for caching or archiving
+func (f *FeeQuoteResponse) MarshalBinary() ([]byte, error) {
+	return f.JSONEnvelope.marshalRecord()
+}
+
+// UnmarshalBinary will decode the response, the signature is validated again (see: Validated)
+// and the quote is decoded from the signed payload
+func (f *FeeQuoteResponse) UnmarshalBinary(data []byte) error {
+	if err := f.JSONEnvelope.unmarshalRecord(data); err != nil {
+		return err
+	}
+	if err := f.decodePayload(&f.Quote); err != nil {
+		return err
+	} else if f.Quote != nil {
+		f.checkPayloadMinerID(f.Quote.MinerID)
+	}
+	return nil
+}
+
+// MarshalBinary will encode the response (the signed envelope and the miner) for caching or archiving
+func (q *QueryTransactionResponse) MarshalBinary() ([]byte, error) {
+	return q.JSONEnvelope.marshalRecord()
+}
+
+// UnmarshalBinary will decode the response, the signature is validated again (see: Validated)
+// and the query is decoded from the signed payload
+func (q *QueryTransactionResponse) UnmarshalBinary(data []byte) error {
+	if err := q.JSONEnvelope.unmarshalRecord(data); err != nil {
+		return err
+	}
+	if err := q.decodePayload(&q.Query); err != nil {
+		return err
+	} else if q.Query != nil {
+		q.checkPayloadMinerID(q.Query.MinerID)
+	}
+	return nil
+}
+
+// MarshalBinary will encode the response (the signed envelope and the miner) for caching or archiving
+func (s *SubmitTransactionResponse) MarshalBinary() ([]byte, error) {
+	return s.JSONEnvelope.marshalRecord()
+}
+
+// UnmarshalBinary will decode the response, the signature is validated again (see: Validated)
+// and the results are decoded from the signed payload
+func (s *SubmitTransactionResponse) UnmarshalBinary(data []byte) error {
+	if err := s.JSONEnvelope.unmarshalRecord(data); err != nil {
+		return err
+	}
+	if err := s.decodePayload(&s.Results); err != nil {
+		return err
+	} else if s.Results != nil {
+		s.checkPayloadMinerID(s.Results.MinerID)
+	}
+	return nil
+}
+
+// marshalRecord will encode the envelope and miner
+func (p *JSONEnvelope) marshalRecord() ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(&envelopeRecord{
+		Encoding:  p.Encoding,
+		Miner:     p.Miner,
+		MimeType:  p.MimeType,
+		Payload:   p.Payload,
+		PublicKey: p.PublicKey,
+		Signature: p.Signature,
+		Version:   envelopeRecordVersion,
+	}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalRecord will decode the envelope and miner, and validate the signature of the payload
+//
+// The payload bytes are kept for decoding the payload (see: decodePayload)
+func (p *JSONEnvelope) unmarshalRecord(data []byte) (err error) {
+	var record envelopeRecord
+	if err = gob.NewDecoder(bytes.NewReader(data)).Decode(&record); err != nil {
+		return
+	} else if record.Version != envelopeRecordVersion {
+		return fmt.Errorf("unsupported record version: %d", record.Version)
+	}
+
+	// Restore the envelope
+	*p = JSONEnvelope{
+		Encoding:  record.Encoding,
+		Miner:     record.Miner,
+		MimeType:  record.MimeType,
+		Payload:   record.Payload,
+		PublicKey: record.PublicKey,
+		Signature: record.Signature,
+	}
+
+	// Verify using DER format (the payload is the exact signed bytes)
+	return p.revalidate(nil)
+}
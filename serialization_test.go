@@ -0,0 +1,150 @@
+package minercraft
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestFeeQuoteResponse_MarshalBinary tests the methods MarshalBinary() and UnmarshalBinary()
+func TestFeeQuoteResponse_MarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if !response.Validated {
+		t.Fatalf("expected the signature to be validated")
+	}
+
+	t.Run("gob round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err = gob.NewEncoder(&buf).Encode(response); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		var decoded FeeQuoteResponse
+		if err = gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !decoded.Validated || decoded.Payload != response.Payload || decoded.Signature != response.Signature {
+			t.Fatalf("expected the validated envelope but got: %v", decoded.JSONEnvelope)
+		} else if decoded.Miner == nil || decoded.Miner.Name != MinerTaal || decoded.Miner.URL != response.Miner.URL {
+			t.Fatalf("expected the miner [%s] but got: %v", MinerTaal, decoded.Miner)
+		} else if decoded.Quote == nil || len(decoded.Quote.Fees) != 2 || decoded.Quote.MinerID != response.Quote.MinerID {
+			t.Fatalf("expected the quote to be decoded but got: %v", decoded.Quote)
+		}
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		tampered := *response
+		tampered.Payload = strings.Replace(tampered.Payload, `"satoshis":500`, `"satoshis":5`, 1)
+		data, err := tampered.MarshalBinary()
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		var decoded FeeQuoteResponse
+		if err = decoded.UnmarshalBinary(data); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if decoded.Validated {
+			t.Fatalf("expected the signature to not be validated")
+		}
+	})
+
+	t.Run("invalid data", func(t *testing.T) {
+		var decoded FeeQuoteResponse
+		if err = decoded.UnmarshalBinary([]byte("invalid")); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+
+		// Unsupported version
+		var buf bytes.Buffer
+		_ = gob.NewEncoder(&buf).Encode(&envelopeRecord{Payload: "{}", Version: envelopeRecordVersion + 1})
+		if err = decoded.UnmarshalBinary(buf.Bytes()); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestQueryTransactionResponse_MarshalBinary tests the methods MarshalBinary() and UnmarshalBinary()
+func TestQueryTransactionResponse_MarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidQuery{})
+	response, err := client.QueryTransaction(client.MinerByName(MinerTaal), testTx)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	var data []byte
+	if data, err = response.MarshalBinary(); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var decoded QueryTransactionResponse
+	if err = decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if decoded.Validated != response.Validated || decoded.Payload != response.Payload {
+		t.Fatalf("expected the envelope but got: %v", decoded.JSONEnvelope)
+	} else if decoded.Query == nil || decoded.Query.TxID != response.Query.TxID {
+		t.Fatalf("expected the query to be decoded but got: %v", decoded.Query)
+	}
+}
+
+// TestSubmitTransactionResponse_MarshalBinary tests the methods MarshalBinary() and UnmarshalBinary()
+func TestSubmitTransactionResponse_MarshalBinary(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidSubmission{})
+	response, err := client.SubmitTransaction(client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx})
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	var data []byte
+	if data, err = response.MarshalBinary(); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var decoded SubmitTransactionResponse
+	if err = decoded.UnmarshalBinary(data); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if decoded.Validated != response.Validated || decoded.Results == nil || decoded.Results.TxID != response.Results.TxID {
+		t.Fatalf("expected the results to be decoded but got: %v", decoded.Results)
+	}
+}
+
+// ExampleFeeQuoteResponse_MarshalBinary example using MarshalBinary()
+func ExampleFeeQuoteResponse_MarshalBinary() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	// Archive the response and verify it again later
+	var data []byte
+	if data, err = response.MarshalBinary(); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	var archived FeeQuoteResponse
+	if err = archived.UnmarshalBinary(data); err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("validated: %v miner: %s", archived.Validated, archived.Miner.Name)
+	// Output:validated: true miner: Taal
+}
+
+// BenchmarkFeeQuoteResponse_UnmarshalBinary benchmarks the method UnmarshalBinary()
+func BenchmarkFeeQuoteResponse_UnmarshalBinary(b *testing.B) {
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	response, _ := client.FeeQuote(client.MinerByName(MinerTaal))
+	data, _ := response.MarshalBinary()
+	for i := 0; i < b.N; i++ {
+		var decoded FeeQuoteResponse
+		_ = decoded.UnmarshalBinary(data)
+	}
+}
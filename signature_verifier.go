@@ -0,0 +1,56 @@
+package minercraft
+
+import (
+	"crypto/sha256"
+
+	"github.com/bitcoinschema/go-bitcoin"
+)
+
+// SignatureVerifier verifies the signature of a response envelope (see: JSONEnvelope)
+//
+// Implementations can be swapped using SetSignatureVerifier() (IE: libsecp256k1 bindings for speed,
+// HSM-backed verification or a stub in tests)
+type SignatureVerifier interface {
+
+	// VerifySignature will return true if the DER signature (hex) of the hash is valid for the public key (hex)
+	//
+	// The hash is the sha256 of the (unescaped) payload of the envelope
+	VerifySignature(hash [32]byte, pubKey, signature string) (bool, error)
+}
+
+// DERSignatureVerifier is the default SignatureVerifier (using go-bitcoin)
+type DERSignatureVerifier struct{}
+
+// VerifySignature will verify the DER signature using go-bitcoin
+func (v *DERSignatureVerifier) VerifySignature(hash [32]byte, pubKey, signature string) (bool, error) {
+	return bitcoin.VerifyMessageDER(hash, pubKey, signature)
+}
+
+// defaultSignatureVerifier is used if a verifier is not set
+var defaultSignatureVerifier SignatureVerifier = &DERSignatureVerifier{}
+
+// SetSignatureVerifier will set the verifier used for validating the signatures of all responses
+// (nil restores the default DERSignatureVerifier)
+func (c *Client) SetSignatureVerifier(verifier SignatureVerifier) {
+	c.verifier = verifier
+}
+
+// signatureVerifier will return the verifier of the client (or the default if not set, or the client is nil)
+func (c *Client) signatureVerifier() SignatureVerifier {
+	if c == nil || c.verifier == nil {
+		return defaultSignatureVerifier
+	}
+	return c.verifier
+}
+
+// validateSignature will check the data against the pubkey + signature (using the default verifier if nil)
+func validateSignature(verifier SignatureVerifier, signature, pubKey string, data []byte) (bool, error) {
+	// Only if we have a signature and pubkey
+	if len(signature) > 0 && len(pubKey) > 0 {
+		if verifier == nil {
+			verifier = defaultSignatureVerifier
+		}
+		return verifier.VerifySignature(sha256.Sum256(data), pubKey, signature)
+	}
+	return false, nil
+}
@@ -0,0 +1,99 @@
+package minercraft
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// stubVerifier is a SignatureVerifier that returns a fixed result (and counts the calls)
+type stubVerifier struct {
+	calls int
+	err   error
+	valid bool
+}
+
+// VerifySignature will return the fixed result
+func (s *stubVerifier) VerifySignature(_ [32]byte, _, _ string) (bool, error) {
+	s.calls++
+	return s.valid, s.err
+}
+
+// TestClient_SetSignatureVerifier tests the method SetSignatureVerifier()
+func TestClient_SetSignatureVerifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stubbed verifier", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		verifier := &stubVerifier{valid: false}
+		client.SetSignatureVerifier(verifier)
+
+		response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Validated {
+			t.Fatalf("expected the stubbed verifier to reject the signature")
+		} else if verifier.calls != 1 {
+			t.Fatalf("expected 1 call, got: %d", verifier.calls)
+		}
+	})
+
+	t.Run("verifier error", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		client.SetSignatureVerifier(&stubVerifier{err: errors.New("hsm unavailable")})
+
+		if _, err := client.FeeQuote(client.MinerByName(MinerTaal)); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("nil restores the default", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidFeeQuote{})
+		client.SetSignatureVerifier(&stubVerifier{})
+		client.SetSignatureVerifier(nil)
+
+		response, err := client.FeeQuote(client.MinerByName(MinerTaal))
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !response.Validated {
+			t.Fatalf("expected the signature to be validated")
+		}
+	})
+}
+
+// TestDERSignatureVerifier_VerifySignature tests the method VerifySignature()
+func TestDERSignatureVerifier_VerifySignature(t *testing.T) {
+	t.Parallel()
+
+	var envelope JSONEnvelope
+	if err := envelope.process(nil, nil, []byte(testEnvelope)); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	verifier := &DERSignatureVerifier{}
+	valid, err := verifier.VerifySignature(sha256.Sum256([]byte(envelope.Payload)), envelope.PublicKey, envelope.Signature)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if !valid {
+		t.Fatalf("expected the signature to be valid")
+	}
+
+	valid, _ = verifier.VerifySignature(sha256.Sum256([]byte("tampered")), envelope.PublicKey, envelope.Signature)
+	if valid {
+		t.Fatalf("expected the signature to be invalid")
+	}
+}
+
+// ExampleClient_SetSignatureVerifier example using SetSignatureVerifier()
+func ExampleClient_SetSignatureVerifier() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+
+	// Swap the verifier (IE: HSM-backed verification)
+	client.SetSignatureVerifier(&DERSignatureVerifier{})
+
+	response, _ := client.FeeQuote(client.MinerByName(MinerTaal))
+	fmt.Printf("validated: %t", response.Validated)
+	// Output:validated: true
+}
@@ -0,0 +1,218 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+/*
+Example SPV channel (create channel response):
+
+{
+  "id": "H3mNdK-IL_-5OdLG4jymMwlJCW7NlhsNhxd_XrnKlv7J4hyR6EH2NIOaPmWlU7Rs0Zkgv_1yD0qcW7h29BGxbA",
+  "href": "https://spvchannels.example.com/api/v1/channel/H3mNdK-IL_-5OdLG4jymMwlJCW7NlhsNhxd_XrnKlv7J4hyR6EH2NIOaPmWlU7Rs0Zkgv_1yD0qcW7h29BGxbA",
+  "public_read": false,
+  "public_write": true,
+  "sequenced": true,
+  "locked": false,
+  "head": 0,
+  "retention": {"min_age_days": 0, "max_age_days": 7, "auto_prune": true},
+  "access_tokens": [{"id": "1", "token": "OEuSs7pz...", "description": "Owner", "can_read": true, "can_write": true}]
+}
+*/
+
+// SPVChannelsAccount is the account of an SPV channels server (see: Client.SPVChannels)
+//
+// Specs: https://github.com/bitcoin-sv-specs/brfc-spvchannels
+type SPVChannelsAccount struct {
+	AccountID string           `json:"account_id"`
+	Keys      *CallbackKeyPair `json:"-"` // Decrypts encrypted messages (optional, see: CallbackKeyPair.SetEncryption)
+	Password  string           `json:"password"`
+	URL       string           `json:"url"` // The url of the server (IE: https://spvchannels.example.com)
+	Username  string           `json:"username"`
+}
+
+// SPVChannelsClient is the client of an SPV channels account: channels receive the callbacks (merkle proofs and
+// double spends) of miners, so a wallet doesn't need to operate a webhook endpoint (see: SPVChannel.SetCallback)
+type SPVChannelsClient struct {
+	account SPVChannelsAccount
+	client  *Client
+	server  *Miner // The server as a miner (url building and the transport)
+}
+
+// SPVChannelRetention is the retention policy of the messages of a channel
+type SPVChannelRetention struct {
+	AutoPrune  bool   `json:"auto_prune"`
+	MaxAgeDays uint64 `json:"max_age_days,omitempty"`
+	MinAgeDays uint64 `json:"min_age_days,omitempty"`
+}
+
+// SPVChannelOptions are the options of a new channel
+type SPVChannelOptions struct {
+	PublicRead  bool                 `json:"public_read"`
+	PublicWrite bool                 `json:"public_write"` // Miners can write to the channel without a token
+	Retention   *SPVChannelRetention `json:"retention,omitempty"`
+	Sequenced   bool                 `json:"sequenced"`
+}
+
+// SPVChannel is a channel of an SPV channels account
+type SPVChannel struct {
+	AccessTokens []*SPVChannelToken   `json:"access_tokens"`
+	Head         uint64               `json:"head"` // Sequence of the last message
+	Href         string               `json:"href"` // The url of the channel (the callback url for miners)
+	ID           string               `json:"id"`
+	Locked       bool                 `json:"locked"`
+	PublicRead   bool                 `json:"public_read"`
+	PublicWrite  bool                 `json:"public_write"`
+	Retention    *SPVChannelRetention `json:"retention"`
+	Sequenced    bool                 `json:"sequenced"`
+}
+
+// SPVChannelToken is an API token of a channel (sent as a bearer token)
+type SPVChannelToken struct {
+	CanRead     bool   `json:"can_read"`
+	CanWrite    bool   `json:"can_write"`
+	Description string `json:"description"`
+	ID          string `json:"id"`
+	Token       string `json:"token"`
+}
+
+// SPVChannelMessage is a message of a channel (IE: a callback of a miner)
+type SPVChannelMessage struct {
+	ContentType string          `json:"content_type"`
+	Payload     json.RawMessage `json:"payload"` // JSON, or a base64 string for other content types (see: Data)
+	Received    string          `json:"received"`
+	Sequence    uint64          `json:"sequence"`
+}
+
+// Data will return the payload of the message (base64 payloads are decoded)
+func (m *SPVChannelMessage) Data() ([]byte, error) {
+	var encoded string
+	if err := json.Unmarshal(m.Payload, &encoded); err != nil {
+		return m.Payload, nil // Not a string (a JSON payload)
+	} else if strings.HasPrefix(strings.ToLower(m.ContentType), "application/json") && json.Valid([]byte(encoded)) {
+		return []byte(encoded), nil
+	}
+	return base64.StdEncoding.DecodeString(encoded)
+}
+
+// SetCallback will set the callback of the transaction to the channel, the token must be able to write
+func (c *SPVChannel) SetCallback(tx *Transaction, token string) {
+	tx.CallBackURL = c.Href
+	tx.CallBackToken = "Bearer " + token
+}
+
+// SPVChannels will return a client of the SPV channels account (uses the http client of the client)
+func (c *Client) SPVChannels(account SPVChannelsAccount) *SPVChannelsClient {
+	return &SPVChannelsClient{account: account, client: c, server: &Miner{Name: "spv channels", URL: account.URL}}
+}
+
+// CreateChannel will create a new channel (with an owner token that can read and write)
+func (s *SPVChannelsClient) CreateChannel(ctx context.Context, options *SPVChannelOptions) (*SPVChannel, error) {
+	if options == nil {
+		options = &SPVChannelOptions{PublicWrite: true, Sequenced: true}
+	}
+	data, err := json.Marshal(options)
+	if err != nil {
+		return nil, err
+	}
+	channel := new(SPVChannel)
+	if err = s.request(ctx, http.MethodPost, "", "", data, channel, routeSPVAccount, s.account.AccountID, "channel"); err != nil {
+		return nil, err
+	} else if len(channel.ID) == 0 {
+		return nil, errors.New("failed creating the channel")
+	}
+	return channel, nil
+}
+
+// ListChannels will return the channels of the account
+func (s *SPVChannelsClient) ListChannels(ctx context.Context) ([]*SPVChannel, error) {
+	var list struct {
+		Channels []*SPVChannel `json:"channels"`
+	}
+	if err := s.request(ctx, http.MethodGet, "", "", nil, &list, routeSPVAccount, s.account.AccountID, "channel", "list"); err != nil {
+		return nil, err
+	}
+	return list.Channels, nil
+}
+
+// CreateToken will create a new API token of the channel
+func (s *SPVChannelsClient) CreateToken(ctx context.Context, channelID, description string,
+	canRead, canWrite bool) (*SPVChannelToken, error) {
+
+	data, err := json.Marshal(&SPVChannelToken{CanRead: canRead, CanWrite: canWrite, Description: description})
+	if err != nil {
+		return nil, err
+	}
+	token := new(SPVChannelToken)
+	if err = s.request(ctx, http.MethodPost, "", "", data, token,
+		routeSPVAccount, s.account.AccountID, "channel", channelID, "api-token"); err != nil {
+		return nil, err
+	} else if len(token.Token) == 0 {
+		return nil, errors.New("failed creating the token")
+	}
+	return token, nil
+}
+
+// Messages will return the messages of the channel (only the unread messages if unread is set),
+// the token must be able to read
+func (s *SPVChannelsClient) Messages(ctx context.Context, channelID, token string,
+	unread bool) ([]*SPVChannelMessage, error) {
+
+	var query string
+	if unread {
+		query = "?unread=true"
+	}
+	messages := make([]*SPVChannelMessage, 0)
+	if err := s.request(ctx, http.MethodGet, token, query, nil, &messages, routeSPVChannel, channelID); err != nil {
+		return nil, err
+	}
+	return messages, nil
+}
+
+// MarkRead will mark the message of the channel as read (and all older messages if older is set)
+func (s *SPVChannelsClient) MarkRead(ctx context.Context, channelID, token string, sequence uint64, older bool) error {
+	var query string
+	if older {
+		query = "?older=true"
+	}
+	return s.request(ctx, http.MethodPost, token, query, []byte(`{"read":true}`), nil,
+		routeSPVChannel, channelID, strconv.FormatUint(sequence, 10))
+}
+
+// request will fire the request and decode the response, using the bearer token (or the account if no token)
+func (s *SPVChannelsClient) request(ctx context.Context, method, token, query string, data []byte,
+	result interface{}, route string, params ...string) error {
+
+	// Build the url
+	endpoint, err := buildURL(s.server, route, params...)
+	if err != nil {
+		return err
+	}
+
+	// Authorize with the token or the account
+	authorization := "Bearer " + token
+	if len(token) == 0 {
+		authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(s.account.Username+":"+s.account.Password))
+	}
+
+	response := httpRequest(ctx, s.client, &httpPayload{
+		Authorization: authorization,
+		Data:          data,
+		Method:        method,
+		Miner:         s.server,
+		Timeout:       s.client.Options.RequestTimeout,
+		URL:           endpoint + query,
+	})
+	if response.Error != nil {
+		return response.Error
+	} else if result == nil {
+		return nil
+	}
+	return json.Unmarshal(response.BodyContents, result)
+}
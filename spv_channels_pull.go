@@ -0,0 +1,133 @@
+package minercraft
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// SPVPullOptions are the options of a pull loop of a channel (see: SPVChannelsClient.PullLoop)
+type SPVPullOptions struct {
+	Interval time.Duration        // Time between pulls (defaults to ClientOptions.StatusPollInterval)
+	MaxAge   time.Duration        // Messages received longer ago are acknowledged without delivery (0 = no max age)
+	OnError  func(err error)      // Called with the error of a failed pull (the messages are pulled again)
+	OnResult func(*SPVPullResult) // Called with the result of each pull (optional)
+}
+
+// SPVPullResult is the result of pulling the unread messages of a channel into the notifications
+type SPVPullResult struct {
+	Acknowledged uint64 `json:"acknowledged"` // Sequence of the last message marked as read (0 if none)
+	Delivered    int    `json:"delivered"`    // Messages sent to the notifications (or already sent)
+	Expired      int    `json:"expired"`      // Messages older than the max age
+	Pending      int    `json:"pending"`      // Messages not delivered (a subscriber's buffer was full)
+	Skipped      int    `json:"skipped"`      // Messages that are not callbacks
+}
+
+// Pull will send the unread messages of the channel (mAPI and ARC callbacks) to the notifications
+// (see: SubscribeNotifications) and mark them as read
+//
+// Delivery is at-least-once: a message is only marked as read after it was delivered to every subscriber,
+// and the messages after a message that was not delivered are pulled again (in order). Messages that are not
+// callbacks, or that were received longer ago than the max age (0 = no max age), are marked as read
+func (s *SPVChannelsClient) Pull(ctx context.Context, channelID, token string, maxAge time.Duration) (*SPVPullResult, error) {
+
+	// Get the unread messages (in order)
+	messages, err := s.Messages(ctx, channelID, token, true)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].Sequence < messages[j].Sequence
+	})
+
+	// Deliver the messages (until a message is not delivered)
+	result := new(SPVPullResult)
+	for index, message := range messages {
+		if maxAge > 0 && message.age(s.client.now()) > maxAge {
+			result.Expired++
+		} else if notifications := s.client.channelNotifications(message, s.account.Keys); notifications == nil {
+			result.Skipped++
+		} else if !s.client.publishAll(notifications) {
+			result.Pending = len(messages) - index
+			break
+		} else {
+			result.Delivered++
+		}
+		result.Acknowledged = message.Sequence
+	}
+
+	// Acknowledge the delivered messages
+	if result.Acknowledged > 0 {
+		if err = s.MarkRead(ctx, channelID, token, result.Acknowledged, true); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// PullLoop will pull the messages of the channel at the interval until the context is done (see: Pull)
+func (s *SPVChannelsClient) PullLoop(ctx context.Context, channelID, token string, options *SPVPullOptions) error {
+	if options == nil {
+		options = new(SPVPullOptions)
+	}
+	interval := options.Interval
+	if interval <= 0 {
+		interval = s.client.Options.StatusPollInterval
+	}
+	ticker := s.client.clock().NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if result, err := s.Pull(ctx, channelID, token, options.MaxAge); err != nil && ctx.Err() == nil {
+			if options.OnError != nil {
+				options.OnError(err)
+			}
+		} else if err == nil && options.OnResult != nil {
+			options.OnResult(result)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C():
+		}
+	}
+}
+
+// age will return how long before now the message was received (0 if unknown)
+func (m *SPVChannelMessage) age(now time.Time) time.Duration {
+	received, err := time.Parse(time.RFC3339Nano, m.Received)
+	if err != nil {
+		return 0
+	}
+	return now.Sub(received)
+}
+
+// channelNotifications will return the notifications of a channel message (nil if it is not a callback),
+// encrypted messages are decrypted using the keys (if set, messages that can't be decrypted are skipped)
+func (c *Client) channelNotifications(message *SPVChannelMessage, keys *CallbackKeyPair) []*Notification {
+	data, err := message.Data()
+	if err != nil {
+		return nil
+	} else if keys != nil {
+		if data, err = keys.Decrypt(data); err != nil {
+			return nil
+		}
+	}
+	if callback, parseErr := c.parseCallback(data); parseErr == nil {
+		return callbackNotifications(callback, NotificationSourceSPVChannel)
+	} else if arcCallback, decodeErr := decodeARCCallback(data); decodeErr == nil {
+		return arcCallback.notifications(nil, NotificationSourceSPVChannel)
+	}
+	return nil
+}
+
+// publishAll will send the notifications, returning false if one was not delivered
+func (c *Client) publishAll(notifications []*Notification) bool {
+	for _, notification := range notifications {
+		if !c.notifications.publish(notification, c.now()) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,239 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const (
+	testChannelID    = "H3mNdK-IL_-5OdLG4jymMwlJCW7NlhsNhxd_XrnKlv7J4hyR6EH2NIOaPmWlU7Rs0Zkgv_1yD0qcW7h29BGxbA"
+	testChannelToken = "OEuSs7pzRqXL0DHhkjmZyxKzj2qBRmzj12tVwHCkwXbx"
+	testChannelsURL  = "https://spvchannels.example.com"
+)
+
+// testChannelsAccount is the SPV channels account of the mocks
+var testChannelsAccount = SPVChannelsAccount{AccountID: "1", Password: "secret", URL: testChannelsURL, Username: "wallet"}
+
+// mockHTTPSPVChannels for mocking requests (an SPV channels server)
+type mockHTTPSPVChannels struct {
+	read string // The url of the last mark read request
+}
+
+// Do is a mock http request
+func (m *mockHTTPSPVChannels) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusUnauthorized
+	resp.Body = ioutil.NopCloser(bytes.NewBufferString(``))
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Account requests (basic auth) and channel requests (bearer token)
+	username, password, basic := req.BasicAuth()
+	account := basic && username == testChannelsAccount.Username && password == testChannelsAccount.Password
+	bearer := req.Header.Get("Authorization") == "Bearer "+testChannelToken
+	channel := `{"id":"` + testChannelID + `","href":"` + testChannelsURL + `/api/v1/channel/` + testChannelID + `",
+"public_write":true,"sequenced":true,"head":2,"retention":{"max_age_days":7,"auto_prune":true},
+"access_tokens":[{"id":"1","token":"` + testChannelToken + `","description":"Owner","can_read":true,"can_write":true}]}`
+
+	var body string
+	switch url := req.URL.String(); {
+	case account && req.Method == http.MethodPost && url == testChannelsURL+"/api/v1/account/1/channel":
+		body = channel
+	case account && req.Method == http.MethodGet && url == testChannelsURL+"/api/v1/account/1/channel/list":
+		body = `{"channels":[` + channel + `]}`
+	case account && req.Method == http.MethodPost && url == testChannelsURL+"/api/v1/account/1/channel/"+testChannelID+"/api-token":
+		body = `{"id":"2","token":"` + testChannelToken + `","description":"Miner","can_read":false,"can_write":true}`
+	case bearer && req.Method == http.MethodGet && url == testChannelsURL+"/api/v1/channel/"+testChannelID+"?unread=true":
+		body = `[{"sequence":1,"received":"2021-03-04T12:00:00Z","content_type":"application/json","payload":` + testCallback + `},
+{"sequence":2,"received":"2021-03-04T12:00:01Z","content_type":"text/plain","payload":"` + base64.StdEncoding.EncodeToString([]byte("hello")) + `"}]`
+	case bearer && req.Method == http.MethodPost && req.URL.Path == "/api/v1/channel/"+testChannelID+"/2":
+		m.read = url
+		body = `{}`
+	default:
+		return resp, nil
+	}
+	resp.StatusCode = http.StatusOK
+	resp.Body = ioutil.NopCloser(bytes.NewBufferString(body))
+	return resp, nil
+}
+
+// TestSPVChannelsClient tests the methods of the SPVChannelsClient
+func TestSPVChannelsClient(t *testing.T) {
+	t.Parallel()
+
+	t.Run("channels and tokens", func(t *testing.T) {
+		channels := newTestClient(&mockHTTPSPVChannels{}).SPVChannels(testChannelsAccount)
+
+		channel, err := channels.CreateChannel(context.Background(), nil)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if channel.ID != testChannelID || len(channel.AccessTokens) != 1 || channel.Retention.MaxAgeDays != 7 {
+			t.Fatalf("expected the created channel, got: %+v", channel)
+		}
+
+		var list []*SPVChannel
+		if list, err = channels.ListChannels(context.Background()); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if len(list) != 1 || list[0].Head != 2 {
+			t.Fatalf("expected 1 channel, got: %d", len(list))
+		}
+
+		var token *SPVChannelToken
+		if token, err = channels.CreateToken(context.Background(), testChannelID, "Miner", false, true); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if token.Token != testChannelToken || !token.CanWrite || token.CanRead {
+			t.Fatalf("expected a write token, got: %+v", token)
+		}
+
+		tx := &Transaction{RawTx: testRawTx}
+		channel.SetCallback(tx, token.Token)
+		if tx.CallBackURL != channel.Href || tx.CallBackToken != "Bearer "+testChannelToken {
+			t.Fatalf("expected the callback of the channel, got: %s %s", tx.CallBackURL, tx.CallBackToken)
+		}
+	})
+
+	t.Run("messages", func(t *testing.T) {
+		mock := &mockHTTPSPVChannels{}
+		channels := newTestClient(mock).SPVChannels(testChannelsAccount)
+
+		messages, err := channels.Messages(context.Background(), testChannelID, testChannelToken, true)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if len(messages) != 2 {
+			t.Fatalf("expected 2 messages, got: %d", len(messages))
+		}
+
+		var data []byte
+		if data, err = messages[0].Data(); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !bytes.Contains(data, []byte(`"payload"`)) {
+			t.Fatalf("expected the callback, got: %s", data)
+		} else if data, err = messages[1].Data(); err != nil || string(data) != "hello" {
+			t.Fatalf("expected the decoded payload, got: %s %v", data, err)
+		}
+
+		if err = channels.MarkRead(context.Background(), testChannelID, testChannelToken, 2, true); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if mock.read != testChannelsURL+"/api/v1/channel/"+testChannelID+"/2?older=true" {
+			t.Fatalf("expected the older messages to be marked, got: %s", mock.read)
+		}
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		account := testChannelsAccount
+		account.Password = "wrong"
+		channels := newTestClient(&mockHTTPSPVChannels{}).SPVChannels(account)
+
+		if _, err := channels.CreateChannel(context.Background(), &SPVChannelOptions{}); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = channels.ListChannels(context.Background()); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = channels.CreateToken(context.Background(), testChannelID, "", true, true); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = channels.Messages(context.Background(), testChannelID, "wrong", false); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if err = channels.MarkRead(context.Background(), testChannelID, "wrong", 1, false); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestSPVChannelsClient_Pull tests the methods Pull() and PullLoop()
+func TestSPVChannelsClient_Pull(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delivered and acknowledged", func(t *testing.T) {
+		mock := &mockHTTPSPVChannels{}
+		client := addTestCallbackMiner(newTestClient(mock))
+		notifications, unsubscribe := client.SubscribeNotifications("", 10)
+		defer unsubscribe()
+
+		result, err := client.SPVChannels(testChannelsAccount).Pull(context.Background(), testChannelID, testChannelToken, 0)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if result.Delivered != 1 || result.Skipped != 1 || result.Acknowledged != 2 || result.Pending != 0 {
+			t.Fatalf("expected 1 delivered and 1 skipped message, got: %+v", result)
+		} else if mock.read != testChannelsURL+"/api/v1/channel/"+testChannelID+"/2?older=true" {
+			t.Fatalf("expected the messages to be marked as read, got: %s", mock.read)
+		}
+		received := receiveNotifications(notifications, 2)
+		if len(received) != 2 || received[1].Type != NotificationMerkleProof || received[1].Source != NotificationSourceSPVChannel {
+			t.Fatalf("expected the merkle proof from the channel, got: %d notifications", len(received))
+		}
+	})
+
+	t.Run("pending until delivered", func(t *testing.T) {
+		mock := &mockHTTPSPVChannels{}
+		client := addTestCallbackMiner(newTestClient(mock))
+		channels := client.SPVChannels(testChannelsAccount)
+		notifications, unsubscribe := client.SubscribeNotifications("", 1)
+		defer unsubscribe()
+
+		result, err := channels.Pull(context.Background(), testChannelID, testChannelToken, 0)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if result.Pending != 2 || result.Acknowledged != 0 || len(mock.read) > 0 {
+			t.Fatalf("expected 2 pending messages without an acknowledgement, got: %+v", result)
+		} else if notification := <-notifications; notification.Type != NotificationMined {
+			t.Fatalf("expected the mined notification, got: %s", notification.Type)
+		}
+
+		if result, err = channels.Pull(context.Background(), testChannelID, testChannelToken, 0); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if result.Delivered != 1 || result.Acknowledged != 2 {
+			t.Fatalf("expected the message to be delivered again, got: %+v", result)
+		} else if notification := <-notifications; notification.Type != NotificationMerkleProof || notification.Sequence != 2 {
+			t.Fatalf("expected the merkle proof, got: %s", notification.Type)
+		}
+	})
+
+	t.Run("expired messages", func(t *testing.T) {
+		client := newTestClient(&mockHTTPSPVChannels{})
+		notifications, unsubscribe := client.SubscribeNotifications("", 10)
+		defer unsubscribe()
+
+		result, err := client.SPVChannels(testChannelsAccount).Pull(context.Background(), testChannelID, testChannelToken, time.Hour)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if result.Expired != 2 || result.Delivered != 0 || result.Acknowledged != 2 {
+			t.Fatalf("expected 2 expired messages, got: %+v", result)
+		} else if len(notifications) != 0 {
+			t.Fatalf("expected no notifications, got: %d", len(notifications))
+		}
+	})
+
+	t.Run("pull loop", func(t *testing.T) {
+		channels := newTestClient(&mockHTTPSPVChannels{}).SPVChannels(testChannelsAccount)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		var results, failures int
+		err := channels.PullLoop(ctx, testChannelID, testChannelToken, &SPVPullOptions{
+			Interval: 5 * time.Millisecond,
+			OnResult: func(*SPVPullResult) { results++ },
+		})
+		if !errors.Is(err, context.DeadlineExceeded) || results == 0 {
+			t.Fatalf("expected pulls until the deadline, got: %d %v", results, err)
+		}
+
+		ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_ = channels.PullLoop(ctx, testChannelID, "wrong", &SPVPullOptions{
+			Interval: 5 * time.Millisecond,
+			OnError:  func(error) { failures++ },
+		})
+		if failures == 0 {
+			t.Fatalf("expected failed pulls")
+		}
+	})
+}
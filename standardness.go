@@ -0,0 +1,113 @@
+package minercraft
+
+import (
+	"fmt"
+
+	"github.com/libsv/libsv/transaction"
+)
+
+// PolicyViolation is a policy of a miner that a transaction does not meet (see: CheckStandardness)
+type PolicyViolation struct {
+	Actual  uint64 `json:"actual"`  // The value of the transaction (IE: the size in bytes)
+	Index   int    `json:"index"`   // The input or output index (-1 for the transaction)
+	Limit   uint64 `json:"limit"`   // The value allowed by the policy
+	Message string `json:"message"` // What to change (IE: "output 1 is dust, use at least 1 satoshis")
+	Policy  string `json:"policy"`  // The policy key (IE: "maxtxsizepolicy")
+}
+
+// Error will return the message of the violation
+func (v *PolicyViolation) Error() string {
+	return v.Policy + ": " + v.Message
+}
+
+// CheckStandardness will return the policy violations of the transaction (empty if the policies accept it),
+// so the transaction can be fixed before it is submitted and rejected
+//
+// Checked (if the policy is reported): maxtxsizepolicy, datacarriersize, maxscriptsizepolicy (each unlocking
+// script and each locking script that is not a data output), dust (dustrelayfee and dustlimitfactor, or a
+// minimum of 1 satoshi) and limitancestorcount (ancestors is the number of unconfirmed ancestors, 0 if unknown)
+func (p *Policies) CheckStandardness(tx *transaction.Transaction, ancestors uint64) []*PolicyViolation {
+
+	// Make sure we have a tx
+	violations := make([]*PolicyViolation, 0)
+	if tx == nil {
+		return append(violations, &PolicyViolation{Index: -1, Message: "tx was nil", Policy: "tx"})
+	}
+
+	// Check the size of the tx
+	if txSize := uint64(len(tx.ToBytes())); !PolicyAllowsTxSize(p, txSize) {
+		violations = append(violations, &PolicyViolation{
+			Actual: txSize, Index: -1, Limit: p.MaxTxSizePolicy, Policy: "maxtxsizepolicy",
+			Message: fmt.Sprintf("tx is %d bytes, reduce it to %d bytes", txSize, p.MaxTxSizePolicy),
+		})
+	}
+
+	// Check the ancestors (if known)
+	if p.LimitAncestorCount > 0 && ancestors > p.LimitAncestorCount {
+		violations = append(violations, &PolicyViolation{
+			Actual: ancestors, Index: -1, Limit: p.LimitAncestorCount, Policy: "limitancestorcount",
+			Message: fmt.Sprintf("tx has %d unconfirmed ancestors, wait for confirmations or spend confirmed outputs", ancestors),
+		})
+	}
+
+	// Check the unlocking scripts
+	for index, in := range tx.Inputs {
+		if in.UnlockingScript == nil {
+			continue
+		}
+		if size := uint64(len(*in.UnlockingScript)); !PolicyAllowsScriptSize(p, size) {
+			violations = append(violations, &PolicyViolation{
+				Actual: size, Index: index, Limit: p.MaxScriptSizePolicy, Policy: "maxscriptsizepolicy",
+				Message: fmt.Sprintf("input %d unlocking script is %d bytes, reduce it to %d bytes", index, size, p.MaxScriptSizePolicy),
+			})
+		}
+	}
+
+	// Check the outputs (data size, script size and dust)
+	var dataBytes uint64
+	for index, out := range tx.Outputs {
+		var script []byte
+		if out.LockingScript != nil {
+			script = *out.LockingScript
+		}
+		if isDataScript(script) {
+			dataBytes += uint64(len(script))
+			continue
+		}
+		if size := uint64(len(script)); !PolicyAllowsScriptSize(p, size) {
+			violations = append(violations, &PolicyViolation{
+				Actual: size, Index: index, Limit: p.MaxScriptSizePolicy, Policy: "maxscriptsizepolicy",
+				Message: fmt.Sprintf("output %d locking script is %d bytes, reduce it to %d bytes", index, size, p.MaxScriptSizePolicy),
+			})
+		}
+		if threshold := p.DustThreshold(uint64(len(out.ToBytes()))); out.Satoshis < threshold {
+			violations = append(violations, &PolicyViolation{
+				Actual: out.Satoshis, Index: index, Limit: threshold, Policy: "dustlimitfactor",
+				Message: fmt.Sprintf("output %d is dust, use at least %d satoshis", index, threshold),
+			})
+		}
+	}
+	if !PolicyAllowsDataSize(p, dataBytes) {
+		violations = append(violations, &PolicyViolation{
+			Actual: dataBytes, Index: -1, Limit: p.DataCarrierSize, Policy: "datacarriersize",
+			Message: fmt.Sprintf("data outputs are %d bytes, reduce them to %d bytes", dataBytes, p.DataCarrierSize),
+		})
+	}
+
+	return violations
+}
+
+// DustThreshold will return the minimum satoshis of an output (that is not a data output) of the given size
+//
+// The threshold is the dustrelayfee (satoshis per 1000 bytes) of the output and the input spending it, multiplied
+// by the dustlimitfactor (percent). If either policy is not reported, the threshold is 1 satoshi
+func (p *Policies) DustThreshold(outputSize uint64) uint64 {
+	if p.DustRelayFee == 0 || p.DustLimitFactor == 0 {
+		return 1
+	}
+	threshold := (outputSize + P2PKHInputSize) * p.DustRelayFee * p.DustLimitFactor / 100000
+	if threshold == 0 {
+		return 1
+	}
+	return threshold
+}
@@ -0,0 +1,96 @@
+package minercraft
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/libsv/libsv/script"
+)
+
+// TestPolicies_CheckStandardness tests the method CheckStandardness()
+func TestPolicies_CheckStandardness(t *testing.T) {
+	t.Parallel()
+
+	policies := &Policies{DataCarrierSize: 100, LimitAncestorCount: 25, MaxScriptSizePolicy: 200, MaxTxSizePolicy: 1000}
+
+	t.Run("standard tx", func(t *testing.T) {
+		if violations := policies.CheckStandardness(testDataTx(t, []byte("hello")), 10); len(violations) != 0 {
+			t.Fatalf("expected no violations, got: %v", violations)
+		}
+	})
+
+	var tests = []struct {
+		name      string
+		policies  *Policies
+		data      []byte
+		ancestors uint64
+		unlocking int
+		satoshis  uint64
+		policy    string
+		index     int
+	}{
+		{"large data", policies, bytes.Repeat([]byte{0x01}, 101), 0, 0, 500, "datacarriersize", -1},
+		{"large tx", &Policies{MaxTxSizePolicy: 1000}, nil, 0, 1000, 500, "maxtxsizepolicy", -1},
+		{"large unlocking script", policies, nil, 0, 201, 500, "maxscriptsizepolicy", 0},
+		{"ancestors", policies, nil, 26, 0, 500, "limitancestorcount", -1},
+		{"zero value output", policies, nil, 0, 0, 0, "dustlimitfactor", 0},
+		{"dust output", &Policies{DustLimitFactor: 300, DustRelayFee: 150}, nil, 0, 0, 80, "dustlimitfactor", 0},
+	}
+	for _, test := range tests {
+		tx := testDataTx(t, test.data)
+		tx.Inputs[0].UnlockingScript = script.NewFromBytes(bytes.Repeat([]byte{0x01}, test.unlocking))
+		tx.Outputs[0].Satoshis = test.satoshis
+		violations := test.policies.CheckStandardness(tx, test.ancestors)
+		if len(violations) != 1 || violations[0].Policy != test.policy || violations[0].Index != test.index {
+			t.Errorf("%s Failed: [%s] inputted and [%s %d] expected, received: [%v]",
+				t.Name(), test.name, test.policy, test.index, violations)
+		}
+	}
+
+	t.Run("dust threshold", func(t *testing.T) {
+		tx := testDataTx(t, nil)
+		tx.Outputs[0].Satoshis = 81
+		if violations := (&Policies{DustLimitFactor: 300, DustRelayFee: 150}).CheckStandardness(tx, 0); len(violations) != 0 {
+			t.Fatalf("expected no violations, got: %v", violations)
+		}
+	})
+
+	t.Run("nil tx", func(t *testing.T) {
+		if violations := policies.CheckStandardness(nil, 0); len(violations) != 1 {
+			t.Fatalf("expected a violation, got: %v", violations)
+		}
+	})
+}
+
+// TestPolicies_DustThreshold tests the method DustThreshold()
+func TestPolicies_DustThreshold(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		policies *Policies
+		size     uint64
+		expected uint64
+	}{
+		{&Policies{}, P2PKHOutputSize, 1},
+		{&Policies{DustRelayFee: 150}, P2PKHOutputSize, 1},
+		{&Policies{DustLimitFactor: 300, DustRelayFee: 150}, P2PKHOutputSize, 81},
+		{&Policies{DustLimitFactor: 1, DustRelayFee: 1}, P2PKHOutputSize, 1},
+		{&Policies{DustLimitFactor: 300, DustRelayFee: 1000}, P2PKHOutputSize, 546},
+	}
+	for _, test := range tests {
+		if output := test.policies.DustThreshold(test.size); output != test.expected {
+			t.Errorf("%s Failed: [%+v, %d] inputted and [%d] expected, received: [%d]",
+				t.Name(), test.policies, test.size, test.expected, output)
+		}
+	}
+}
+
+// ExamplePolicies_CheckStandardness example using CheckStandardness()
+func ExamplePolicies_CheckStandardness() {
+	policies := &Policies{DataCarrierSize: 10}
+	for _, violation := range policies.CheckStandardness(testDataTx(&testing.T{}, []byte("this data is too large")), 0) {
+		fmt.Println(violation.Error())
+	}
+	// Output:datacarriersize: data outputs are 25 bytes, reduce them to 10 bytes
+}
@@ -0,0 +1,79 @@
+package minercraft
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Store is a key/value store with expiration (IE: Redis) used to share cached fee quotes and responses
+// between instances of a service (see: Client.SetStore and ResponseCache.SetStore)
+type Store interface {
+	Delete(ctx context.Context, key string) error
+	Get(ctx context.Context, key string) ([]byte, error) // Returns nil (and no error) if the key is not found
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// SetStore will set the store used to share (or persist) cached fee quotes and submission receipts
+//
+// Quotes (requires QuoteCacheEnabled) are stored as the original signed response (re-validated when loaded)
+// until they expire, the store is checked when a quote is not found in the local quote cache.
+// Submission receipts are stored with the original signed response (see: SubmissionReceipt and Receipt)
+// Callback and channel tokens are stored until they are revoked (see: IssueCallbackToken)
+func (c *Client) SetStore(store Store) {
+	c.store = store
+}
+
+// storedQuote will return an unexpired quote for the miner from the store (or nil if not found)
+func (c *Client) storedQuote(ctx context.Context, miner *Miner) *internalResult {
+
+	// Get the original response
+	data, err := c.store.Get(ctx, quoteStoreKey(miner))
+	if err != nil || len(data) == 0 {
+		return nil
+	}
+
+	// Parse the quote (the signature is validated again)
+	endpoint, _ := buildURL(miner, routeFeeQuote)
+	result := &internalResult{Miner: miner, client: c, Response: &RequestResponse{
+		BodyContents: data,
+		Method:       http.MethodGet,
+		StatusCode:   http.StatusOK,
+		URL:          endpoint,
+	}}
+	quote, err := result.parseQuote()
+	if err != nil || quote.Quote == nil || quote.Quote.IsExpiredAt(c.now()) {
+		return nil
+	}
+	result.quote = &quote
+
+	// Keep a local copy
+	c.quoteCache.set(miner, &quote, result.Response, c.now())
+	return result
+}
+
+// storeQuote will save the original response of the quote in the store (until the quote expires)
+func (c *Client) storeQuote(ctx context.Context, quote *FeeQuoteResponse, response *RequestResponse) {
+	if quote.Quote == nil || response == nil {
+		return
+	}
+	expiresAt, err := quote.Quote.ExpiresAt()
+	if ttl := expiresAt.Sub(c.now()); err == nil && ttl > 0 {
+		_ = c.store.Set(ctx, quoteStoreKey(quote.Miner), response.BodyContents, ttl)
+	}
+}
+
+// quoteStoreKey will return the store key of the quote for the miner
+//
+// The endpoint (including the token) is hashed, so the token is not exposed in the store
+func quoteStoreKey(miner *Miner) string {
+	return storeKey("quote", quoteCacheEndpoint(miner))
+}
+
+// storeKey will return the store key (prefix and the hash of the value)
+func storeKey(prefix, value string) string {
+	hash := sha256.Sum256([]byte(value))
+	return prefix + ":" + hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,93 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// SubmitOutcome is the outcome of a submission for retry and fallback logic (see: ClassifySubmission)
+type SubmitOutcome string
+
+const (
+
+	// SubmitAccepted is a transaction accepted by the miner
+	SubmitAccepted SubmitOutcome = "accepted"
+
+	// SubmitAlreadyKnown is a transaction the miner already has (in the mempool or in a block),
+	// the transaction does not need to be submitted again
+	SubmitAlreadyKnown SubmitOutcome = "already_known"
+
+	// SubmitRetryableFailure is a failure that may succeed later or with another miner
+	// (IE: the miner is unreachable or busy, or the parent transaction is not yet known)
+	SubmitRetryableFailure SubmitOutcome = "retryable_failure"
+
+	// SubmitTerminalFailure is a failure that will not succeed by submitting the same transaction again
+	// (IE: the fee is too low, a double spend or an invalid transaction)
+	SubmitTerminalFailure SubmitOutcome = "terminal_failure"
+)
+
+// Retryable will return true if the submission can be retried (later or with another miner)
+func (o SubmitOutcome) Retryable() bool {
+	return o == SubmitRetryableFailure
+}
+
+// Outcome will return the outcome of the submission (see: ClassifySubmission)
+func (r *SubmitTransactionResponse) Outcome() SubmitOutcome {
+	return ClassifySubmission(r, nil)
+}
+
+// ClassifySubmission will return the outcome of a submission from the response and error of SubmitTransaction,
+// derived from the status code, the returnResult, the failureRetryable flag and the result reason
+//
+// Failures are retryable if the miner was unreachable, responded with a 408, 429 or 5xx status code,
+// flagged the failure as retryable, its mempool is full or the inputs are missing (the parent may not have
+// propagated yet). Other failures are terminal
+func ClassifySubmission(resp *SubmitTransactionResponse, err error) SubmitOutcome {
+
+	// The request failed
+	if err != nil {
+		return classifySubmissionError(err)
+	} else if resp == nil || resp.Results == nil {
+		return SubmitRetryableFailure
+	}
+
+	// The miner responded
+	switch {
+	case strings.EqualFold(resp.Results.ReturnResult, ReturnResultSuccess):
+		return SubmitAccepted
+	case IsAlreadyKnown(resp):
+		return SubmitAlreadyKnown
+	case resp.Results.FailureRetryable,
+		resp.Results.Reason == ResultReasonMempoolFull,
+		resp.Results.Reason == ResultReasonMissingInputs:
+		return SubmitRetryableFailure
+	}
+	return SubmitTerminalFailure
+}
+
+// classifySubmissionError will return the outcome of a failed submission request: the transaction or the
+// response is invalid (terminal), the miner responded with a status code, or the miner was unreachable (retryable)
+func classifySubmissionError(err error) SubmitOutcome {
+	var statusErr *StatusCodeError
+	var envelopeErr *EnvelopeError
+	var validationErr *MinerValidationError
+	switch {
+	case errors.As(err, &statusErr):
+		if statusErr.StatusCode == http.StatusRequestTimeout || statusErr.StatusCode == http.StatusTooManyRequests ||
+			statusErr.StatusCode >= http.StatusInternalServerError {
+			return SubmitRetryableFailure
+		}
+		return SubmitTerminalFailure
+	case errors.Is(err, context.Canceled),
+		errors.Is(err, ErrInvalidRawTx),
+		errors.Is(err, ErrNetworkMismatch),
+		errors.Is(err, ErrPreflightFailed),
+		errors.Is(err, ErrTxTooLarge),
+		errors.As(err, &envelopeErr),
+		errors.As(err, &validationErr):
+		return SubmitTerminalFailure
+	}
+	return SubmitRetryableFailure
+}
@@ -0,0 +1,61 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+// TestClassifySubmission will test the method ClassifySubmission()
+func TestClassifySubmission(t *testing.T) {
+	t.Parallel()
+
+	submission := func(returnResult, description string, retryable bool) *SubmitTransactionResponse {
+		return &SubmitTransactionResponse{Results: &SubmissionPayload{
+			FailureRetryable:  retryable,
+			Reason:            ClassifyResult(description),
+			ResultDescription: description,
+			ReturnResult:      returnResult,
+		}}
+	}
+
+	var tests = []struct {
+		name     string
+		resp     *SubmitTransactionResponse
+		err      error
+		expected SubmitOutcome
+	}{
+		{"success", submission(ReturnResultSuccess, "", false), nil, SubmitAccepted},
+		{"already known", submission(ReturnResultFailure, "257: txn-already-known", false), nil, SubmitAlreadyKnown},
+		{"flagged retryable", submission(ReturnResultFailure, "Miner is busy", true), nil, SubmitRetryableFailure},
+		{"missing inputs", submission(ReturnResultFailure, "Missing inputs", false), nil, SubmitRetryableFailure},
+		{"fee too low", submission(ReturnResultFailure, "Not enough fees", false), nil, SubmitTerminalFailure},
+		{"unknown failure", submission(ReturnResultFailure, "something went wrong", false), nil, SubmitTerminalFailure},
+		{"no payload", &SubmitTransactionResponse{}, nil, SubmitRetryableFailure},
+		{"bad gateway", nil, &StatusCodeError{StatusCode: http.StatusBadGateway}, SubmitRetryableFailure},
+		{"too many requests", nil, &StatusCodeError{StatusCode: http.StatusTooManyRequests}, SubmitRetryableFailure},
+		{"unauthorized", nil, &StatusCodeError{StatusCode: http.StatusUnauthorized}, SubmitTerminalFailure},
+		{"invalid tx", nil, fmt.Errorf("%w: missing raw tx", ErrInvalidRawTx), SubmitTerminalFailure},
+		{"malformed envelope", nil, &EnvelopeError{Reason: ErrEnvelopeTruncated}, SubmitTerminalFailure},
+		{"cancelled", nil, context.Canceled, SubmitTerminalFailure},
+		{"unreachable", nil, errors.New("connection refused"), SubmitRetryableFailure},
+	}
+	for _, test := range tests {
+		if output := ClassifySubmission(test.resp, test.err); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%s] expected, received: [%s]",
+				t.Name(), test.name, test.expected, output)
+		}
+	}
+
+	t.Run("rejected submission", func(t *testing.T) {
+		client := newTestClient(&mockHTTPRejectedSubmission{})
+		response, err := client.SubmitTransaction(client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if outcome := response.Outcome(); outcome != SubmitTerminalFailure || outcome.Retryable() {
+			t.Fatalf("expected outcome: %s got: %s", SubmitTerminalFailure, outcome)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package minercraft
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+)
+
+// SubmissionStatus is the acceptance (or rejection) of a submitted transaction (see: SubmitTransactionStatus)
+type SubmissionStatus struct {
+	Accepted          bool         `json:"accepted"`
+	Miner             *Miner       `json:"miner"`
+	Reason            ResultReason `json:"reason,omitempty"`   // The reason of the result description (see: ClassifyResult)
+	ResultDescription string       `json:"result_description"` // The reason if the transaction was rejected
+	TxID              string       `json:"txid"`
+}
+
+// submissionEssentials are the only payload fields decoded for a submission status
+type submissionEssentials struct {
+	ResultDescription string `json:"resultDescription"`
+	ReturnResult      string `json:"returnResult"`
+	TxID              string `json:"txid"`
+}
+
+// SubmitTransactionStatus will submit the transaction and only return whether the miner accepted it
+// (IE: fire-and-forget broadcasting)
+//
+// Only the essentials of the payload are decoded and the signature is not validated, which saves the
+// latency of parsing the full response. Use SubmitTransaction() if the signed response is needed
+// (receipts and submit result hooks are skipped)
+func (c *Client) SubmitTransactionStatus(miner *Miner, tx *Transaction) (*SubmissionStatus, error) {
+
+	// Make sure we have a valid miner and transaction
+	if err := c.checkSubmission(context.Background(), miner, tx); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	result := submitTransaction(context.Background(), c, miner, tx)
+	if result.Response.Error != nil {
+		return nil, result.Response.Error
+	}
+
+	// Decode the essentials (no signature validation)
+	var body envelopeBody
+	var essentials submissionEssentials
+	if err := json.Unmarshal(result.Response.BodyContents, &body); err != nil {
+		return nil, err
+	} else if err = json.Unmarshal(body.Payload, &essentials); err != nil {
+		return nil, err
+	} else if len(essentials.ReturnResult) == 0 {
+		return nil, errors.New("failed getting submission response from: " + miner.Name)
+	}
+
+	status := &SubmissionStatus{
+		Accepted:          essentials.ReturnResult == ReturnResultSuccess,
+		Miner:             miner,
+		Reason:            ClassifyResult(essentials.ResultDescription),
+		ResultDescription: essentials.ResultDescription,
+		TxID:              essentials.TxID,
+	}
+	if status.Accepted {
+		c.confirmations.submitted(miner, status.TxID, c.now())
+	}
+	return status, nil
+}
@@ -0,0 +1,100 @@
+package minercraft
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// mockHTTPRejectedSubmission for mocking requests (the transaction is rejected)
+type mockHTTPRejectedSubmission struct{}
+
+// Do is a mock http request
+func (m *mockHTTPRejectedSubmission) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Valid response
+	if strings.Contains(req.URL.String(), "/mapi/tx") {
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer([]byte(`{
+    	"payload": "{\"apiVersion\":\"` + testAPIVersion + `\",\"timestamp\":\"2020-01-15T11:40:29.826Z\",\"txid\":\"\",\"returnResult\":\"failure\",\"resultDescription\":\"Not enough fees\",\"minerId\":\"03fcfcfcd0841b0a6ed2057fa8ed404788de47ceb3390c53e79c4ecd1e05819031\",\"currentHighestBlockHash\":\"71a7374389afaec80fcabbbf08dcd82d392cf68c9a13fe29da1a0c853facef01\",\"currentHighestBlockHeight\":207,\"txSecondMempoolExpiry\":0}",
+    	"signature": null,"publicKey": null,"encoding": "` + testEncoding + `","mimetype": "` + testMimeType + `"}`)))
+	}
+
+	// Default is valid
+	return resp, nil
+}
+
+// TestClient_SubmitTransactionStatus tests the method SubmitTransactionStatus()
+func TestClient_SubmitTransactionStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("accepted", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidSubmission{})
+		status, err := client.SubmitTransactionStatus(client.MinerByName(MinerMatterpool), &Transaction{RawTx: testRawTx})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !status.Accepted || status.TxID != testSubmittedTx || status.Miner.Name != MinerMatterpool {
+			t.Fatalf("unexpected status: %+v", status)
+		}
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		client := newTestClient(&mockHTTPRejectedSubmission{})
+		status, err := client.SubmitTransactionStatus(client.MinerByName(MinerMatterpool), &Transaction{RawTx: testRawTx})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if status.Accepted || status.ResultDescription != "Not enough fees" {
+			t.Fatalf("unexpected status: %+v", status)
+		}
+	})
+
+	t.Run("invalid requests and responses", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBadSubmission{})
+		miner := client.MinerByName(MinerMatterpool)
+		if _, err := client.SubmitTransactionStatus(nil, &Transaction{RawTx: testRawTx}); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.SubmitTransactionStatus(miner, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.SubmitTransactionStatus(miner, &Transaction{RawTx: "invalid"}); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.SubmitTransactionStatus(miner, &Transaction{RawTx: testRawTx}); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+
+		client.httpClient = &mockHTTPInvalidJSON{}
+		if _, err := client.SubmitTransactionStatus(miner, &Transaction{RawTx: testRawTx}); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleClient_SubmitTransactionStatus example using SubmitTransactionStatus()
+func ExampleClient_SubmitTransactionStatus() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPValidSubmission{})
+
+	// Fire-and-forget broadcast
+	status, _ := client.SubmitTransactionStatus(client.MinerByName(MinerMatterpool), &Transaction{RawTx: testRawTx})
+	fmt.Printf("accepted: %t", status.Accepted)
+	// Output:accepted: true
+}
+
+// BenchmarkClient_SubmitTransactionStatus benchmarks the method SubmitTransactionStatus()
+func BenchmarkClient_SubmitTransactionStatus(b *testing.B) {
+	client := newTestClient(&mockHTTPValidSubmission{})
+	miner := client.MinerByName(MinerMatterpool)
+	tx := &Transaction{RawTx: testRawTx}
+	for i := 0; i < b.N; i++ {
+		_, _ = client.SubmitTransactionStatus(miner, tx)
+	}
+}
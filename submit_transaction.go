@@ -1,10 +1,13 @@
 package minercraft
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strings"
 )
 
 /*
@@ -27,6 +30,7 @@ type Transaction struct {
 	MerkleProof        string `json:"merkleProof,omitempty"`
 	DsCheck            string `json:"dsCheck,omitempty"`
 	CallBackEncryption string `json:"callBackEncryption,omitempty"`
+	InputSatoshis      uint64 `json:"-"` // Custom field: total satoshis of the inputs (checks the fee, see: Preflight)
 }
 
 /*
@@ -75,9 +79,16 @@ type SubmissionPayload struct {
 	ResultDescription         string `json:"resultDescription"`
 	MinerID                   string `json:"minerId"`
 	CurrentHighestBlockHash   string `json:"currentHighestBlockHash"`
-	ConflictedWith            string `json:"conflictedWith"`
+	ConflictedWith            string `json:"conflictedWith,omitempty"` // The conflicting txid (mAPI 1.4: the txids, comma separated)
 	CurrentHighestBlockHeight int64  `json:"currentHighestBlockHeight"`
 	TxSecondMempoolExpiry     int64  `json:"txSecondMempoolExpiry"`
+
+	// mAPI 1.4 (see: payload_versions.go)
+	ConflictedTxs    []*ConflictedTx `json:"-"`                          // The conflicting transactions
+	FailureRetryable bool            `json:"failureRetryable,omitempty"` // The submission can be retried (IE: the miner is busy)
+	Warnings         []string        `json:"warnings,omitempty"`         // Warnings of an accepted submission
+
+	Reason ResultReason `json:"-"` // Custom field for the reason of the resultDescription (see: ClassifyResult)
 }
 
 // SubmitTransaction will fire a Merchant API request to submit a given transaction
@@ -90,26 +101,71 @@ type SubmissionPayload struct {
 // Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi/tree/v1.2-beta#Submit-transaction
 func (c *Client) SubmitTransaction(miner *Miner, tx *Transaction) (*SubmitTransactionResponse, error) {
 
-	// Make sure we have a valid miner
-	if miner == nil {
-		return nil, errors.New("miner was nil")
+	// Make sure we have a valid miner and transaction
+	if err := c.checkSubmission(context.Background(), miner, tx); err != nil {
+		return nil, err
 	}
 
 	// Make the HTTP request
 	result := submitTransaction(context.Background(), c, miner, tx)
-	if result.Response.Error != nil {
-		return nil, result.Response.Error
+	return c.submissionResult(result, tx)
+}
+
+// checkSubmission will make sure the miner and transaction are valid before submitting
+// (and run the pre-flight validation if enabled, see: WithPreflight)
+func (c *Client) checkSubmission(ctx context.Context, miner *Miner, tx *Transaction) error {
+
+	// Make sure we have a valid miner
+	if miner == nil {
+		return errors.New("miner was nil")
+	} else if tx == nil {
+		return errors.New("transaction was nil")
+	} else if err := c.checkNetwork(miner); err != nil {
+		return err
+	}
+
+	// Fail fast if the transaction is too large (hex is 2 chars per byte)
+	if err := c.checkTxSize(miner, int64(len(tx.RawTx)/2)); err != nil {
+		return err
+	}
+
+	// Fail fast if the raw tx does not decode (without wasting a miner request)
+	if err := ValidateRawTx(tx.RawTx); err != nil {
+		return err
+	} else if c.Options.Preflight {
+		return c.checkPreflight(ctx, miner, tx)
+	}
+	return nil
+}
+
+// submissionResult will parse the result of a submission, keep the receipt and fire the submit result hooks
+func (c *Client) submissionResult(result *internalResult, tx *Transaction) (*SubmitTransactionResponse, error) {
+	response, err := result.parseSubmissionResult()
+	if err == nil {
+		c.storeReceipt(context.Background(), result.Miner, response, result.Response.BodyContents)
+		if response.Results.ReturnResult == ReturnResultSuccess {
+			c.confirmations.submitted(result.Miner, response.Results.TxID, c.now())
+		}
+	}
+	c.hooks.fireSubmitResult(&SubmitResult{Error: err, Miner: result.Miner, Response: response, Tx: tx})
+	return response, err
+}
+
+// parseSubmissionResult will return the fully parsed response (or the request error)
+func (i *internalResult) parseSubmissionResult() (*SubmitTransactionResponse, error) {
+	if i.Response.Error != nil {
+		return nil, i.Response.Error
 	}
 
 	// Parse the response
-	response, err := result.parseSubmission()
+	response, err := i.parseSubmission()
 	if err != nil {
 		return nil, err
 	}
 
 	// Valid query?
 	if response.Results == nil || len(response.Results.ReturnResult) == 0 {
-		return nil, errors.New("failed getting submission response from: " + miner.Name)
+		return nil, errors.New("failed getting submission response from: " + i.Miner.Name)
 	}
 
 	// Return the fully parsed response
@@ -120,26 +176,63 @@ func (c *Client) SubmitTransaction(miner *Miner, tx *Transaction) (*SubmitTransa
 func (i *internalResult) parseSubmission() (response SubmitTransactionResponse, err error) {
 
 	// Process the initial response payload
-	if err = response.process(i.Miner, i.Response.BodyContents); err != nil {
+	if err = response.process(i.client, i.Miner, i.Response.BodyContents); err != nil {
 		return
 	}
 
 	// If we have a valid payload
-	if len(response.Payload) > 0 {
-		err = json.Unmarshal([]byte(response.Payload), &response.Results)
+	if err = response.decodePayload(&response.Results); err == nil && response.Results != nil {
+		response.checkPayloadMinerID(response.Results.MinerID)
 	}
 	return
 }
 
 // submitTransaction will fire the HTTP request to submit a transaction
 func submitTransaction(ctx context.Context, client *Client, miner *Miner, tx *Transaction) (result *internalResult) {
-	result = &internalResult{Miner: miner}
+	result = &internalResult{Miner: miner, client: client}
 	data, _ := json.Marshal(tx) // Ignoring error - if it fails, the submission would also fail
+
+	// Build the endpoint url
+	endpoint, err := buildURL(miner, routeSubmitTx)
+	if err != nil {
+		result.Response = &RequestResponse{Error: err, Method: http.MethodPost}
+		return
+	}
+
+	// Compress large bodies (if the miner supports it)
+	var encoding string
+	if data, encoding, err = client.compressBody(miner, data); err != nil {
+		result.Response = &RequestResponse{Error: err, Method: http.MethodPost}
+		return
+	}
+
 	result.Response = httpRequest(ctx, client, &httpPayload{
-		Method: http.MethodPost,
-		URL:    defaultProtocol + miner.URL + routeSubmitTx,
-		Token:  miner.Token,
-		Data:   data,
+		Miner:           miner,
+		Method:          http.MethodPost,
+		Operation:       OperationSubmitTx,
+		URL:             endpoint,
+		Token:           miner.Token,
+		Timeout:         client.minerTimeout(miner, client.Options.SubmitTimeout),
+		Data:            data,
+		ContentEncoding: encoding,
 	})
 	return
 }
+
+// compressBody will gzip the body if the miner supports it and the body is at least the CompressMinSize,
+// returning the (compressed) body and the content encoding (empty if not compressed)
+func (c *Client) compressBody(miner *Miner, data []byte) ([]byte, string, error) {
+	if c.Options.CompressMinSize <= 0 || int64(len(data)) < c.Options.CompressMinSize ||
+		!strings.EqualFold(miner.Compression, CompressionGzip) {
+		return data, "", nil
+	}
+
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write(data); err != nil {
+		return nil, "", err
+	} else if err = writer.Close(); err != nil {
+		return nil, "", err
+	}
+	return buf.Bytes(), CompressionGzip, nil
+}
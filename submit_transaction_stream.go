@@ -0,0 +1,119 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// SubmitTransactionReader will fire a Merchant API request to submit a transaction read from an io.Reader
+//
+// The raw transaction (binary, not hex) is streamed to the miner (hex encoded on the fly) without
+// buffering the entire transaction in memory, useful for very large data transactions.
+// The size is the number of raw transaction bytes that will be read (used for the Content-Length).
+//
+// Any submission options (callbacks, proofs, etc) are taken from the tx (the RawTx is ignored), tx can be nil.
+// Note: streamed submissions are not retried (the reader can't be replayed) and the raw tx
+// is not validated locally (see: ValidateRawTx)
+//
+// Specs: https://github.com/bitcoin-sv-specs/brfc-merchantapi/tree/v1.2-beta#Submit-transaction
+func (c *Client) SubmitTransactionReader(miner *Miner, rawTx io.Reader, size int64,
+	tx *Transaction) (*SubmitTransactionResponse, error) {
+
+	// Make sure we have a valid miner and transaction
+	if miner == nil {
+		return nil, errors.New("miner was nil")
+	} else if rawTx == nil {
+		return nil, errors.New("raw transaction reader was nil")
+	} else if size <= 0 {
+		return nil, errors.New("raw transaction size must be greater than zero")
+	} else if err := c.checkNetwork(miner); err != nil {
+		return nil, err
+	}
+
+	// Fail fast if the transaction is too large
+	if err := c.checkTxSize(miner, size); err != nil {
+		return nil, err
+	}
+
+	// Make the HTTP request
+	result := submitTransactionReader(context.Background(), c, miner, rawTx, size, tx)
+	return c.submissionResult(result, tx)
+}
+
+// submitTransactionReader will fire the HTTP request to submit a streamed transaction
+func submitTransactionReader(ctx context.Context, client *Client, miner *Miner, rawTx io.Reader,
+	size int64, tx *Transaction) (result *internalResult) {
+	result = &internalResult{Miner: miner, client: client}
+
+	// Build the endpoint url
+	endpoint, err := buildURL(miner, routeSubmitTx)
+	if err != nil {
+		result.Response = &RequestResponse{Error: err, Method: http.MethodPost}
+		return
+	}
+
+	// Build the body (the hex is streamed between the JSON prefix and suffix)
+	var prefix, suffix []byte
+	if prefix, suffix, err = streamedTxEnvelope(tx); err != nil {
+		result.Response = &RequestResponse{Error: err, Method: http.MethodPost}
+		return
+	}
+
+	result.Response = httpRequest(ctx, client, &httpPayload{
+		Miner:         miner,
+		Method:        http.MethodPost,
+		Operation:     OperationSubmitTx,
+		URL:           endpoint,
+		Token:         miner.Token,
+		Timeout:       client.minerTimeout(miner, client.Options.SubmitTimeout),
+		ContentLength: int64(len(prefix)) + 2*size + int64(len(suffix)), // Hex is 2 chars per byte
+		Body: io.MultiReader(
+			bytes.NewReader(prefix),
+			&hexReader{src: io.LimitReader(rawTx, size)},
+			bytes.NewReader(suffix),
+		),
+	})
+	return
+}
+
+// streamedTxEnvelope will return the JSON body before and after the (streamed) raw tx hex
+func streamedTxEnvelope(tx *Transaction) (prefix, suffix []byte, err error) {
+	var options Transaction
+	if tx != nil {
+		options = *tx
+	}
+	options.RawTx = ""
+
+	// RawTx is the first field: {"rawtx":"", ...}
+	var data []byte
+	if data, err = json.Marshal(options); err != nil {
+		return
+	}
+	prefix = []byte(`{"rawtx":"`)
+	suffix = data[len(prefix):] // Starts with the closing quote of the (empty) raw tx
+	return
+}
+
+// hexReader is an io.Reader that hex encodes the source reader on the fly
+type hexReader struct {
+	buf []byte
+	src io.Reader
+}
+
+// Read will read from the source and write the hex encoding into p
+func (h *hexReader) Read(p []byte) (int, error) {
+	if len(p) < 2 {
+		return 0, io.ErrShortBuffer
+	}
+	if cap(h.buf) < len(p)/2 {
+		h.buf = make([]byte, len(p)/2)
+	}
+	n, err := h.src.Read(h.buf[:len(p)/2])
+	hex.Encode(p, h.buf[:n])
+	return n * 2, err
+}
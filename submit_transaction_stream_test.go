@@ -0,0 +1,121 @@
+package minercraft
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+// mockHTTPStreamedSubmission for mocking requests (checks the streamed body)
+type mockHTTPStreamedSubmission struct {
+	body          []byte
+	contentLength int64
+}
+
+// Do is a mock http request
+func (m *mockHTTPStreamedSubmission) Do(req *http.Request) (*http.Response, error) {
+	var err error
+	if m.body, err = ioutil.ReadAll(req.Body); err != nil {
+		return nil, err
+	}
+	m.contentLength = req.ContentLength
+	return (&mockHTTPValidSubmission{}).Do(req)
+}
+
+// TestClient_SubmitTransactionReader tests the method SubmitTransactionReader()
+func TestClient_SubmitTransactionReader(t *testing.T) {
+	t.Parallel()
+
+	rawTx := bytes.Repeat([]byte{0x01, 0xab, 0xff}, 10000)
+
+	t.Run("valid submission", func(t *testing.T) {
+		mock := &mockHTTPStreamedSubmission{}
+		client := newTestClient(mock)
+
+		response, err := client.SubmitTransactionReader(
+			client.MinerByName(MinerTaal), bytes.NewReader(rawTx), int64(len(rawTx)),
+			&Transaction{CallBackURL: "https://your.service.callback/endpoint", MerkleProof: "true"},
+		)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if response.Results.ReturnResult != "success" {
+			t.Fatalf("expected [success] but got: %s", response.Results.ReturnResult)
+		}
+
+		// Check the streamed body
+		var tx Transaction
+		if err = json.Unmarshal(mock.body, &tx); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if tx.RawTx != hex.EncodeToString(rawTx) {
+			t.Fatalf("expected the raw tx to be hex encoded")
+		} else if tx.CallBackURL != "https://your.service.callback/endpoint" || tx.MerkleProof != "true" {
+			t.Fatalf("expected the submission options, got: %v", tx)
+		} else if mock.contentLength != int64(len(mock.body)) {
+			t.Fatalf("expected content length %d, got %d", len(mock.body), mock.contentLength)
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		client := newTestClient(&mockHTTPStreamedSubmission{})
+		if _, err := client.SubmitTransactionReader(nil, bytes.NewReader(rawTx), 1, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.SubmitTransactionReader(client.MinerByName(MinerTaal), nil, 1, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.SubmitTransactionReader(client.MinerByName(MinerTaal), bytes.NewReader(rawTx), 0, nil); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("bad submission", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBadSubmission{})
+		if _, err := client.SubmitTransactionReader(client.MinerByName(MinerTaal), bytes.NewReader(rawTx), int64(len(rawTx)), nil); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// ExampleClient_SubmitTransactionReader example using SubmitTransactionReader()
+func ExampleClient_SubmitTransactionReader() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPStreamedSubmission{})
+
+	// Stream the raw tx (IE: from a file)
+	rawTx, _ := hex.DecodeString("01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff1c03d7c6082f7376706f6f6c2e636f6d2f3edff034600055b8467f0040ffffffff01247e814a000000001976a914492558fb8ca71a3591316d095afc0f20ef7d42f788ac00000000")
+	response, err := client.SubmitTransactionReader(client.MinerByName(MinerTaal), bytes.NewReader(rawTx), int64(len(rawTx)), nil)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+	fmt.Printf("submitted tx to %s: %s", response.Miner.Name, response.Results.ReturnResult)
+	// Output:submitted tx to Taal: success
+}
+
+// TestStreamedTxEnvelope tests the method streamedTxEnvelope()
+func TestStreamedTxEnvelope(t *testing.T) {
+	t.Parallel()
+
+	prefix, suffix, err := streamedTxEnvelope(nil)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if string(prefix)+"00"+string(suffix) != `{"rawtx":"00"}` {
+		t.Fatalf("expected [%s] but got: %s", `{"rawtx":"00"}`, string(prefix)+"00"+string(suffix))
+	}
+
+	// RawTx is ignored
+	prefix, suffix, _ = streamedTxEnvelope(&Transaction{RawTx: "ff", DsCheck: "true"})
+	if body := string(prefix) + "00" + string(suffix); body != `{"rawtx":"00","dsCheck":"true"}` {
+		t.Fatalf("expected [%s] but got: %s", `{"rawtx":"00","dsCheck":"true"}`, body)
+	}
+}
+
+// BenchmarkHexReader benchmarks the hexReader
+func BenchmarkHexReader(b *testing.B) {
+	rawTx := bytes.Repeat([]byte{0x01}, 1024*1024)
+	for i := 0; i < b.N; i++ {
+		_, _ = ioutil.ReadAll(&hexReader{src: bytes.NewReader(rawTx)})
+	}
+}
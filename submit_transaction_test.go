@@ -2,6 +2,7 @@ package minercraft
 
 import (
 	"bytes"
+	"compress/gzip"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -294,3 +295,58 @@ func TestClient_SubmitTransactionBadSubmission(t *testing.T) {
 		t.Fatalf("expected response to be nil")
 	}
 }
+
+// mockHTTPCompressedSubmission for mocking requests (decompresses and records the submitted body)
+type mockHTTPCompressedSubmission struct {
+	mockHTTPValidSubmission
+	body     []byte
+	encoding string
+}
+
+// Do is a mock http request
+func (m *mockHTTPCompressedSubmission) Do(req *http.Request) (*http.Response, error) {
+	m.encoding = req.Header.Get("Content-Encoding")
+	reader := req.Body
+	if m.encoding == CompressionGzip {
+		gzipReader, err := gzip.NewReader(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		reader = gzipReader
+	}
+	m.body, _ = ioutil.ReadAll(reader)
+	return m.mockHTTPValidSubmission.Do(req)
+}
+
+// TestClient_SubmitTransactionCompressed tests the method SubmitTransaction() with body compression
+func TestClient_SubmitTransactionCompressed(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		compression      string
+		compressMinSize  int64
+		expectedEncoding string
+	}{
+		{CompressionGzip, 10, CompressionGzip},
+		{"GZIP", 10, CompressionGzip},
+		{CompressionGzip, DefaultCompressMinSize, ""},
+		{CompressionGzip, 0, ""},
+		{"", 10, ""},
+	}
+
+	for _, test := range tests {
+		mock := &mockHTTPCompressedSubmission{}
+		client := newTestClient(mock)
+		client.Options.CompressMinSize = test.compressMinSize
+		miner := client.MinerByName(MinerMatterpool)
+		miner.Compression = test.compression
+
+		if _, err := client.SubmitTransaction(miner, &Transaction{RawTx: testRawTx}); err != nil {
+			t.Fatalf("%s Failed: [%s, %d] inputted and error not expected: %s", t.Name(), test.compression, test.compressMinSize, err.Error())
+		} else if mock.encoding != test.expectedEncoding {
+			t.Errorf("%s Failed: [%s, %d] inputted and [%s] expected, but got: %s", t.Name(), test.compression, test.compressMinSize, test.expectedEncoding, mock.encoding)
+		} else if !strings.Contains(string(mock.body), testRawTx) {
+			t.Errorf("%s Failed: [%s, %d] inputted and the raw tx was expected in the body", t.Name(), test.compression, test.compressMinSize)
+		}
+	}
+}
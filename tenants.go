@@ -0,0 +1,102 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+// tenantContextKey is the context key of the tenant of the requests (see: ContextWithTenant)
+type tenantContextKey struct{}
+
+// TenantMiner are the settings of a miner for a tenant (IE: the tenant has its own API plan with the miner)
+type TenantMiner struct {
+	RateLimit time.Duration `json:"rate_limit,omitempty"` // Overrides the miner's RateLimit (limited separately from other tenants)
+	Token     string        `json:"token,omitempty"`      // Overrides the miner's Token
+}
+
+// tenantRegistry is the miner settings of each tenant (by tenant and miner name)
+type tenantRegistry struct {
+	sync.RWMutex
+	tenants map[string]map[string]*TenantMiner
+}
+
+// newTenantRegistry will return a new registry without tenants
+func newTenantRegistry() *tenantRegistry {
+	return &tenantRegistry{tenants: make(map[string]map[string]*TenantMiner)}
+}
+
+// ContextWithTenant will return a context with the tenant of the requests (overrides the tenant of the client)
+func ContextWithTenant(ctx context.Context, tenant string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenant)
+}
+
+// TenantFromContext will return the tenant of the context (empty if not set)
+func TenantFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	tenant, _ := ctx.Value(tenantContextKey{}).(string)
+	return tenant
+}
+
+// SetTenant will set the miner settings of the tenant (by miner name, replacing the previous settings)
+//
+// One client serves all tenants (sharing the connection pools): requests of the tenant (see: ContextWithTenant
+// and WithTenant) use the tenant's token and rate limit of the miner. Quotes of a miner with a tenant token are
+// not cached (the quote may be specific to the tenant's plan)
+func (c *Client) SetTenant(tenant string, miners map[string]*TenantMiner) error {
+	if len(strings.TrimSpace(tenant)) == 0 {
+		return errors.New("missing tenant")
+	}
+	settings := make(map[string]*TenantMiner, len(miners))
+	for name, miner := range miners {
+		if miner != nil {
+			copied := *miner
+			settings[strings.ToLower(name)] = &copied
+		}
+	}
+
+	c.tenants.Lock()
+	defer c.tenants.Unlock()
+	c.tenants.tenants[tenant] = settings
+	return nil
+}
+
+// RemoveTenant will remove the miner settings of the tenant (requests of the tenant use the miner settings)
+func (c *Client) RemoveTenant(tenant string) {
+	c.tenants.Lock()
+	defer c.tenants.Unlock()
+	delete(c.tenants.tenants, tenant)
+}
+
+// requestTenant will return the tenant of a request (the context overrides the tenant of the client)
+func (c *Client) requestTenant(ctx context.Context) string {
+	if tenant := TenantFromContext(ctx); len(tenant) > 0 {
+		return tenant
+	}
+	return c.tenant
+}
+
+// tenantMiner will return the settings of the miner for the tenant of the request (nil if not set)
+func (c *Client) tenantMiner(ctx context.Context, miner *Miner) *TenantMiner {
+	tenant := c.requestTenant(ctx)
+	if len(tenant) == 0 || miner == nil {
+		return nil
+	}
+	c.tenants.RLock()
+	defer c.tenants.RUnlock()
+	return c.tenants.tenants[tenant][strings.ToLower(miner.Name)]
+}
+
+// rateLimitedMiner will return the miner to rate limit: the miner, or the miner of the tenant if the tenant
+// has its own rate limit
+func (c *Client) rateLimitedMiner(ctx context.Context, miner *Miner) *Miner {
+	settings := c.tenantMiner(ctx, miner)
+	if settings == nil || settings.RateLimit <= 0 {
+		return miner
+	}
+	return &Miner{Name: c.requestTenant(ctx) + "/" + miner.Name, RateLimit: settings.RateLimit}
+}
@@ -0,0 +1,65 @@
+package minercraft
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestClient_SetTenant tests the methods SetTenant() and RemoveTenant()
+func TestClient_SetTenant(t *testing.T) {
+	t.Parallel()
+
+	// Record the tokens of the requests
+	var mu sync.Mutex
+	var tokens []string
+	quotes := &mockHTTPCountingQuote{expiresIn: time.Minute}
+	client := newTestCachingClient(RequestHandlerFunc(func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		tokens = append(tokens, req.Header.Get("token"))
+		mu.Unlock()
+		return quotes.Do(req)
+	}))
+	miner := client.MinerByName(MinerTaal)
+	miner.Token = "miner-token"
+
+	if err := client.SetTenant(" ", nil); err == nil {
+		t.Fatalf("error should have occurred")
+	} else if err = client.SetTenant("tenant", map[string]*TenantMiner{
+		MinerTaal: {RateLimit: time.Millisecond, Token: "tenant-token"},
+	}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	// Quotes of the tenant token are not cached, quotes of the miner token are
+	tenant := client.With(WithTenant("tenant"))
+	for i := 0; i < 2; i++ {
+		if _, err := tenant.FeeQuote(miner); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if _, err = client.FeeQuote(miner); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	}
+	if result := getQuote(ContextWithTenant(context.Background(), "tenant"), client, miner); result.Response.Error != nil {
+		t.Fatalf("error occurred: %s", result.Response.Error.Error())
+	}
+	expected := []string{"tenant-token", "miner-token", "tenant-token", "tenant-token"}
+	if len(tokens) != len(expected) {
+		t.Fatalf("expected %d requests, got: %v", len(expected), tokens)
+	}
+	for index, token := range expected {
+		if tokens[index] != token {
+			t.Fatalf("expected the tokens %v, got: %v", expected, tokens)
+		}
+	}
+
+	// Removed tenants use the token of the miner
+	client.RemoveTenant("tenant")
+	if client.tenantMiner(ContextWithTenant(context.Background(), "tenant"), miner) != nil {
+		t.Fatalf("expected the tenant to be removed")
+	} else if TenantFromContext(context.Background()) != "" || tenant.Tenant() != "tenant" {
+		t.Fatalf("expected only the tenant of the clone")
+	}
+}
@@ -0,0 +1,239 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrIPNotAllowed is returned when the transport connects to an IP that is not allowed for the miner (see: Miner.AllowedIPs)
+var ErrIPNotAllowed = errors.New("miner ip not allowed")
+
+// NewTransport will return the tuned transport used by NewClient() when a custom HTTP client is not given
+// (keep-alives, idle connection pools per miner, timeouts and the dns cache from the options, or the default options if nil)
+//
+// The client uses a copy of the transport for each miner (see: minerTransports), so connections to each miner
+// are reused and never shared with another miner. Use it to build a custom HTTP client that keeps the same tuning
+// (the connect address and allowed IPs of a miner are only honored by this transport, see: Miner.ConnectAddress).
+// Note: a single transport pools the connections by host, miners sharing a host should not have different
+// connection settings on a custom HTTP client
+//
+// Under js/wasm the transport uses the fetch API of the browser: the dialer options, dns cache, connect address,
+// allowed IPs and client certificates do not apply (the browser manages the connections)
+func NewTransport(options *ClientOptions) *http.Transport {
+	if options == nil {
+		options = DefaultClientOptions()
+	}
+
+	transport := &http.Transport{
+		ExpectContinueTimeout: options.TransportExpectContinueTimeout,
+		IdleConnTimeout:       options.TransportIdleTimeout,
+		MaxIdleConns:          options.TransportMaxIdleConnections,
+		MaxIdleConnsPerHost:   options.TransportMaxIdleConnectionsPerHost,
+		Proxy:                 minerProxy,
+		TLSHandshakeTimeout:   options.TransportTLSHandshakeTimeout,
+	}
+	setTransportDialer(transport, options)
+	return transport
+}
+
+// newTransportDialer will return the net dialer for the transport (resolving miner hostnames using the dns cache
+// if enabled, connecting to the connect address of the miner if set)
+func newTransportDialer(options *ClientOptions) dialFunc {
+	dialer := &net.Dialer{KeepAlive: options.DialerKeepAlive, Timeout: options.DialerTimeout}
+	dial := dialer.DialContext
+	if options.DNSCacheTTL > 0 {
+		dial = newDNSCache(options.DNSCacheTTL, net.DefaultResolver.LookupHost, options.clock()).dialContext(dial)
+	}
+	return minerDialContext(dial)
+}
+
+// Transport will return the shared transport of the client (nil if a custom HTTP client was given)
+//
+// The transports of the miners are copied from it on their first request (see: minerTransports)
+func (c *Client) Transport() *http.Transport {
+	return c.transport
+}
+
+// minerTransports is the transport of each miner (by name), copied from the shared transport
+//
+// Connections are pooled by the transport, so a connection checked against the settings of a miner
+// (see: Miner.AllowedIPs) is never reused by another miner. Requests without a miner use the shared transport
+type minerTransports struct {
+	sync.Mutex
+	base         *http.Transport
+	certificates *clientCertificates
+	transports   map[string]*minerTransport
+}
+
+// minerTransport is the transport of a miner and the connection settings it was created with
+type minerTransport struct {
+	settings  string
+	transport *http.Transport
+}
+
+// newMinerTransports will return the transports of the miners using the shared transport
+// and the client certificates of the miners (see: SetClientCertificate)
+func newMinerTransports(base *http.Transport, certificates *clientCertificates) *minerTransports {
+	return &minerTransports{base: base, certificates: certificates, transports: make(map[string]*minerTransport)}
+}
+
+// RoundTrip will send the request using the transport of the miner of the request (see: http.RoundTripper)
+func (m *minerTransports) RoundTrip(req *http.Request) (*http.Response, error) {
+	miner := minerFromContext(req.Context())
+	if miner == nil {
+		return m.base.RoundTrip(req)
+	}
+	return m.transport(miner).RoundTrip(req)
+}
+
+// transport will return the transport of the miner, a new transport replaces the transport of a miner
+// whose connection settings changed (the idle connections of the previous transport are closed)
+func (m *minerTransports) transport(miner *Miner) *http.Transport {
+	key, settings := strings.ToLower(miner.Name), minerTransportSettings(miner)
+
+	m.Lock()
+	defer m.Unlock()
+	if existing, ok := m.transports[key]; ok {
+		if existing.settings == settings {
+			return existing.transport
+		}
+		existing.transport.CloseIdleConnections()
+	}
+	transport := m.base.Clone()
+	if hasConnectionSettings(miner) {
+		transport.Proxy = nil // Connect to the miner directly (the settings are checked against the miner)
+	}
+	if transport.TLSClientConfig != nil && m.certificates != nil {
+		minerCopy := *miner
+		transport.TLSClientConfig.GetClientCertificate = m.certificates.getClientCertificate(&minerCopy)
+	}
+	m.transports[key] = &minerTransport{settings: settings, transport: transport}
+	return transport
+}
+
+// closeIdleConnections will close the idle connections of all transports
+func (m *minerTransports) closeIdleConnections() {
+	m.Lock()
+	defer m.Unlock()
+	m.base.CloseIdleConnections()
+	for _, existing := range m.transports {
+		existing.transport.CloseIdleConnections()
+	}
+}
+
+// minerTransportSettings will return the connection settings of the miner (a changed value needs a new transport)
+func minerTransportSettings(miner *Miner) string {
+	return miner.GetScheme() + "|" + miner.URL + "|" + miner.ConnectAddress + "|" + strings.Join(miner.AllowedIPs, ",")
+}
+
+// hasConnectionSettings will return true if the miner has a connect address or allowed IPs
+func hasConnectionSettings(miner *Miner) bool {
+	return len(miner.ConnectAddress) > 0 || len(miner.AllowedIPs) > 0
+}
+
+// minerProxy will return the proxy of the environment for the request (see: http.ProxyFromEnvironment),
+// requests to a miner with a connect address or allowed IPs are never proxied (the proxy would be checked instead)
+func minerProxy(req *http.Request) (*url.URL, error) {
+	if miner := minerFromContext(req.Context()); miner != nil && hasConnectionSettings(miner) {
+		return nil, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// minerContextKey is the context key of the miner of a request (see: httpPayload.Miner)
+type minerContextKey struct{}
+
+// contextWithMiner will return the context with the miner of the request
+func contextWithMiner(ctx context.Context, miner *Miner) context.Context {
+	return context.WithValue(ctx, minerContextKey{}, miner)
+}
+
+// minerFromContext will return the miner of the request (nil if not set)
+func minerFromContext(ctx context.Context) *Miner {
+	miner, _ := ctx.Value(minerContextKey{}).(*Miner)
+	return miner
+}
+
+// minerDialContext will return a dialer that applies the connection settings of the miner of the request
+//
+// The connect address of the miner is dialed instead of the url host (the Host header and TLS server name (SNI)
+// are still the host of the miner url). If the miner has allowed IPs, a connection to any other IP is closed
+// before any data is sent
+func minerDialContext(dial dialFunc) dialFunc {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		miner := minerFromContext(ctx)
+		if miner == nil {
+			return dial(ctx, network, address)
+		} else if len(miner.ConnectAddress) > 0 {
+			address = connectAddress(miner.ConnectAddress, address)
+		}
+		conn, err := dial(ctx, network, address)
+		if err != nil || len(miner.AllowedIPs) == 0 {
+			return conn, err
+		}
+
+		// Check the IP that was connected to (after any dns resolution)
+		if tcpAddr, ok := conn.RemoteAddr().(*net.TCPAddr); !ok || !isAllowedIP(miner.AllowedIPs, tcpAddr.IP) {
+			_ = conn.Close()
+			return nil, fmt.Errorf("%w: %s connected to: %s", ErrIPNotAllowed, miner.Name, conn.RemoteAddr().String())
+		}
+		return conn, nil
+	}
+}
+
+// isAllowedIP will return true if the ip matches any of the allowed IPs or CIDRs
+func isAllowedIP(allowed []string, ip net.IP) bool {
+	for _, entry := range allowed {
+		if network, err := parseAllowedIP(entry); err == nil && network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAllowedIP will parse an allowed IP (IE: 10.0.0.1) or CIDR (IE: 10.0.0.0/8) as a network
+func parseAllowedIP(entry string) (*net.IPNet, error) {
+	if strings.Contains(entry, "/") {
+		_, network, err := net.ParseCIDR(entry)
+		return network, err
+	}
+	ip := net.ParseIP(entry)
+	if ip == nil {
+		return nil, errors.New("invalid ip: " + entry)
+	} else if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+// connectAddress will return the connect address, using the port of the dialed address if it has no port
+func connectAddress(connect, address string) string {
+	if _, _, err := net.SplitHostPort(connect); err == nil {
+		return connect
+	}
+	_, port, _ := net.SplitHostPort(address)
+	return net.JoinHostPort(strings.Trim(connect, "[]"), port)
+}
+
+// validConnectAddress will return true if the address is a host or ip with an optional port (IE: 10.0.0.1:443)
+func validConnectAddress(address string) bool {
+	host := address
+	if h, port, err := net.SplitHostPort(address); err == nil {
+		if _, err = strconv.ParseUint(port, 10, 16); err != nil {
+			return false
+		}
+		host = h
+	}
+	host = strings.Trim(host, "[]")
+	if len(host) == 0 || strings.ContainsAny(host, "/?#@ ") {
+		return false
+	}
+	return net.ParseIP(host) != nil || !strings.Contains(host, ":")
+}
@@ -0,0 +1,12 @@
+//go:build !js || !wasm
+// +build !js !wasm
+
+package minercraft
+
+import "net/http"
+
+// setTransportDialer will set the net dialer of the transport (see: newTransportDialer)
+func setTransportDialer(transport *http.Transport, options *ClientOptions) {
+	transport.DialContext = newTransportDialer(options)
+	transport.ForceAttemptHTTP2 = true // A custom dialer disables HTTP/2 unless forced
+}
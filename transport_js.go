@@ -0,0 +1,10 @@
+//go:build js && wasm
+// +build js,wasm
+
+package minercraft
+
+import "net/http"
+
+// setTransportDialer will keep the transport without a dialer, so requests use the fetch API of the browser
+// (a transport with a dialer tries to dial, which is not supported under js/wasm)
+func setTransportDialer(_ *http.Transport, _ *ClientOptions) {}
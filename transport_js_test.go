@@ -0,0 +1,16 @@
+//go:build js && wasm
+// +build js,wasm
+
+package minercraft
+
+import "testing"
+
+// TestNewTransport_Fetch tests the method NewTransport() under js/wasm
+func TestNewTransport_Fetch(t *testing.T) {
+	t.Parallel()
+
+	// A transport without a dialer uses the fetch API of the browser
+	if transport := NewTransport(nil); transport.DialContext != nil || transport.Dial != nil {
+		t.Fatalf("expected a transport without a dialer")
+	}
+}
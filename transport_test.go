@@ -0,0 +1,278 @@
+package minercraft
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+// skipDialer will skip a test of the dialer of the transport under js/wasm (see: setTransportDialer)
+func skipDialer(t *testing.T) {
+	if runtime.GOOS == "js" {
+		t.Skip("the transport has no dialer under js/wasm")
+	}
+}
+
+// TestNewTransport tests the method NewTransport()
+func TestNewTransport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default options", func(t *testing.T) {
+		skipDialer(t)
+		transport := NewTransport(nil)
+		options := DefaultClientOptions()
+		if transport.MaxIdleConns != options.TransportMaxIdleConnections {
+			t.Fatalf("expected value: %v got: %v", options.TransportMaxIdleConnections, transport.MaxIdleConns)
+		} else if transport.MaxIdleConnsPerHost != options.TransportMaxIdleConnectionsPerHost {
+			t.Fatalf("expected value: %v got: %v", options.TransportMaxIdleConnectionsPerHost, transport.MaxIdleConnsPerHost)
+		} else if !transport.ForceAttemptHTTP2 || transport.DialContext == nil || transport.Proxy == nil {
+			t.Fatalf("expected a tuned transport")
+		}
+	})
+
+	t.Run("custom options", func(t *testing.T) {
+		options := DefaultClientOptions()
+		options.TransportIdleTimeout = time.Minute
+		options.TransportMaxIdleConnectionsPerHost = 50
+		transport := NewTransport(options)
+		if transport.IdleConnTimeout != time.Minute || transport.MaxIdleConnsPerHost != 50 {
+			t.Fatalf("expected the options to be used")
+		}
+	})
+}
+
+// TestClient_Transport tests the method Transport()
+func TestClient_Transport(t *testing.T) {
+	t.Parallel()
+
+	t.Run("shared transport", func(t *testing.T) {
+		client, err := NewClient(nil, nil)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if client.Transport() == nil {
+			t.Fatalf("expected a transport")
+		} else if stream, ok := client.streamClient.(*http.Client); !ok || stream.Transport != client.transports {
+			t.Fatalf("expected the stream client to share the transports")
+		} else if client.transports.base != client.Transport() {
+			t.Fatalf("expected the transports of the miners to copy the shared transport")
+		}
+
+		// Clones share the transport
+		if client.With(WithMiners(MinerTaal)).Transport() != client.Transport() {
+			t.Fatalf("expected the clone to share the transport")
+		}
+	})
+
+	t.Run("custom http client", func(t *testing.T) {
+		client, err := NewClient(nil, &http.Client{})
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if client.Transport() != nil {
+			t.Fatalf("expected no transport")
+		}
+	})
+}
+
+// TestConnectAddress tests the method connectAddress()
+func TestConnectAddress(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		connect  string
+		address  string
+		expected string
+	}{
+		{"10.0.0.1", "merchantapi.taal.com:443", "10.0.0.1:443"},
+		{"10.0.0.1:8443", "merchantapi.taal.com:443", "10.0.0.1:8443"},
+		{"[::1]", "merchantapi.taal.com:443", "[::1]:443"},
+		{"internal.taal.lan", "merchantapi.taal.com:80", "internal.taal.lan:80"},
+	}
+	for _, test := range tests {
+		if output := connectAddress(test.connect, test.address); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%s] expected, received: [%s]", t.Name(), test.connect, test.expected, output)
+		}
+	}
+}
+
+// TestMinerDialContext tests the method minerDialContext()
+func TestMinerDialContext(t *testing.T) {
+	t.Parallel()
+
+	var dialed string
+	dial := minerDialContext(func(_ context.Context, _, address string) (net.Conn, error) {
+		dialed = address
+		client, _ := net.Pipe()
+		return client, nil
+	})
+
+	// Without a miner (or connect address) the address is dialed
+	_, _ = dial(context.Background(), "tcp", "merchantapi.taal.com:443")
+	if dialed != "merchantapi.taal.com:443" {
+		t.Fatalf("expected the address to be dialed, got: %s", dialed)
+	}
+	_, _ = dial(contextWithMiner(context.Background(), &Miner{Name: MinerTaal}), "tcp", "merchantapi.taal.com:443")
+	if dialed != "merchantapi.taal.com:443" {
+		t.Fatalf("expected the address to be dialed, got: %s", dialed)
+	}
+
+	// The connect address of the miner is dialed
+	_, _ = dial(contextWithMiner(context.Background(), &Miner{Name: MinerTaal, ConnectAddress: "10.0.0.1"}), "tcp", "merchantapi.taal.com:443")
+	if dialed != "10.0.0.1:443" {
+		t.Fatalf("expected the connect address to be dialed, got: %s", dialed)
+	}
+}
+
+// TestClient_ConnectAddress tests requests to a miner with a connect address
+func TestClient_ConnectAddress(t *testing.T) {
+	t.Parallel()
+	skipDialer(t)
+
+	var host string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host = r.Host
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(nil, nil)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	miner := &Miner{
+		ConnectAddress: strings.TrimPrefix(server.URL, "http://"),
+		Name:           "Private",
+		Scheme:         SchemeHTTP,
+		URL:            "merchantapi.private.invalid",
+	}
+	response := httpRequest(context.Background(), client, &httpPayload{
+		Method: http.MethodGet,
+		Miner:  miner,
+		URL:    "http://merchantapi.private.invalid/mapi/feeQuote",
+	})
+	if response.Error != nil {
+		t.Fatalf("error occurred: %s", response.Error.Error())
+	} else if host != "merchantapi.private.invalid" {
+		t.Fatalf("expected the miner host to be kept, got: %s", host)
+	}
+}
+
+// TestIsAllowedIP tests the method isAllowedIP()
+func TestIsAllowedIP(t *testing.T) {
+	t.Parallel()
+
+	allowed := []string{"10.0.0.1", "192.168.0.0/16", "::1"}
+	var tests = []struct {
+		ip       string
+		expected bool
+	}{
+		{"10.0.0.1", true},
+		{"10.0.0.2", false},
+		{"192.168.1.20", true},
+		{"::1", true},
+		{"::2", false},
+		{"8.8.8.8", false},
+	}
+	for _, test := range tests {
+		if output := isAllowedIP(allowed, net.ParseIP(test.ip)); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%v] expected, received: [%v]", t.Name(), test.ip, test.expected, output)
+		}
+	}
+}
+
+// TestClient_AllowedIPs tests requests to a miner with allowed IPs
+func TestClient_AllowedIPs(t *testing.T) {
+	t.Parallel()
+	skipDialer(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(nil, nil)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	t.Run("allowed ip", func(t *testing.T) {
+		response := httpRequest(context.Background(), client, &httpPayload{
+			Method: http.MethodGet,
+			Miner:  &Miner{AllowedIPs: []string{"127.0.0.0/8"}, Name: "Private", URL: server.URL},
+			URL:    server.URL,
+		})
+		if response.Error != nil {
+			t.Fatalf("error occurred: %s", response.Error.Error())
+		}
+	})
+
+	t.Run("ip not allowed", func(t *testing.T) {
+		response := httpRequest(context.Background(), client, &httpPayload{
+			Method: http.MethodGet,
+			Miner:  &Miner{AllowedIPs: []string{"10.0.0.0/8"}, Name: "Private", URL: server.URL},
+			URL:    server.URL,
+		})
+		if response.Error == nil || !strings.Contains(response.Error.Error(), ErrIPNotAllowed.Error()) {
+			t.Fatalf("expected error: %v got: %v", ErrIPNotAllowed, response.Error)
+		}
+	})
+
+	t.Run("pooled connection of another miner", func(t *testing.T) {
+		response := httpRequest(context.Background(), client, &httpPayload{
+			Method: http.MethodGet,
+			Miner:  &Miner{Name: "Open", URL: server.URL},
+			URL:    server.URL,
+		})
+		if response.Error != nil {
+			t.Fatalf("error occurred: %s", response.Error.Error())
+		}
+		response = httpRequest(context.Background(), client, &httpPayload{
+			Method: http.MethodGet,
+			Miner:  &Miner{AllowedIPs: []string{"10.0.0.0/8"}, Name: "Restricted", URL: server.URL},
+			URL:    server.URL,
+		})
+		if response.Error == nil || !strings.Contains(response.Error.Error(), ErrIPNotAllowed.Error()) {
+			t.Fatalf("expected error: %v got: %v", ErrIPNotAllowed, response.Error)
+		}
+	})
+}
+
+// TestMinerProxy tests the method minerProxy()
+func TestMinerProxy(t *testing.T) {
+	t.Parallel()
+
+	request := func(miner *Miner) *http.Request {
+		req, _ := http.NewRequestWithContext(contextWithMiner(context.Background(), miner), http.MethodGet, "https://example.com", nil)
+		return req
+	}
+	if proxy, err := minerProxy(request(&Miner{AllowedIPs: []string{"10.0.0.1"}})); err != nil || proxy != nil {
+		t.Fatalf("expected no proxy for a miner with allowed IPs, got: %v %v", proxy, err)
+	} else if proxy, err = minerProxy(request(&Miner{ConnectAddress: "10.0.0.1"})); err != nil || proxy != nil {
+		t.Fatalf("expected no proxy for a miner with a connect address, got: %v %v", proxy, err)
+	}
+
+	// Miners with different connection settings never share a transport
+	transports := newMinerTransports(NewTransport(nil), newClientCertificates())
+	open := transports.transport(&Miner{Name: "Test"})
+	if transports.transport(&Miner{Name: "test"}) != open {
+		t.Fatalf("expected the transport of the miner to be reused")
+	} else if restricted := transports.transport(&Miner{AllowedIPs: []string{"10.0.0.1"}, Name: "Test"}); restricted == open {
+		t.Fatalf("expected a new transport for changed connection settings")
+	} else if restricted.Proxy != nil {
+		t.Fatalf("expected no proxy for a miner with allowed IPs")
+	}
+}
+
+// ExampleNewTransport example using NewTransport()
+func ExampleNewTransport() {
+	// Build a custom HTTP client with the same tuning
+	httpClient := &http.Client{Transport: NewTransport(DefaultClientOptions())}
+	client, _ := NewClient(nil, httpClient)
+	fmt.Printf("miners: %d", len(client.Miners))
+	// Output:miners: 3
+}
@@ -0,0 +1,60 @@
+package minercraft
+
+import (
+	"errors"
+
+	"github.com/bitcoinschema/go-bitcoin"
+	"github.com/libsv/libsv/transaction"
+)
+
+// TxSize is the breakdown of a transaction's size (in bytes) by fee type
+//
+// Spec: https://github.com/bitcoin-sv-specs/brfc-misc/tree/master/feespec#deterministic-transaction-fee-calculation-dtfc
+type TxSize struct {
+	DataBytes     uint64 `json:"data_bytes"`     // Bytes that are charged using the data fee type (OP_RETURN outputs)
+	StandardBytes uint64 `json:"standard_bytes"` // Bytes that are charged using the standard fee type
+}
+
+// TotalBytes will return the total size of the transaction
+func (t *TxSize) TotalBytes() uint64 {
+	return t.DataBytes + t.StandardBytes
+}
+
+// TxSizeFromTx will return the size breakdown of the given transaction
+//
+// All data outputs (OP_RETURN or OP_FALSE OP_RETURN) are counted as data bytes
+func TxSizeFromTx(tx *transaction.Transaction) (*TxSize, error) {
+
+	// Make sure we have a tx
+	if tx == nil {
+		return nil, errors.New("tx was nil")
+	}
+
+	// Get the total bytes of the tx
+	totalBytes := uint64(len(tx.ToBytes()))
+
+	// Loop all outputs and accumulate the size of the data outputs
+	var dataBytes uint64
+	for _, out := range tx.GetOutputs() {
+		if out.LockingScript != nil && isDataScript(*out.LockingScript) {
+			dataBytes += uint64(len(out.ToBytes()))
+		}
+	}
+
+	return &TxSize{DataBytes: dataBytes, StandardBytes: totalBytes - dataBytes}, nil
+}
+
+// TxSizeFromHex will return the size breakdown of the given raw transaction hex
+func TxSizeFromHex(rawTx string) (*TxSize, error) {
+	tx, err := bitcoin.TxFromHex(rawTx)
+	if err != nil {
+		return nil, err
+	}
+	return TxSizeFromTx(tx)
+}
+
+// isDataScript will return true if the script starts with OP_RETURN or OP_FALSE OP_RETURN
+func isDataScript(script []byte) bool {
+	return (len(script) > 0 && script[0] == opReturn) ||
+		(len(script) > 1 && script[0] == opFalse && script[1] == opReturn)
+}
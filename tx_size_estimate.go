@@ -0,0 +1,37 @@
+package minercraft
+
+// VarIntSize will return the size of the variable length integer encoding of the number
+// (used for the input count, output count and script lengths)
+func VarIntSize(n uint64) uint64 {
+	switch {
+	case n < 0xfd:
+		return 1
+	case n <= 0xffff:
+		return 3
+	case n <= 0xffffffff:
+		return 5
+	default:
+		return 9
+	}
+}
+
+// InputSize will return the size of an input with an unlocking script of the given length
+// (outpoint, script length, script and sequence)
+func InputSize(unlockingScriptLength int) uint64 {
+	return 32 + 4 + VarIntSize(uint64(unlockingScriptLength)) + uint64(unlockingScriptLength) + 4
+}
+
+// OutputSize will return the size of an output with a locking script of the given length
+// (value, script length and script)
+func OutputSize(lockingScriptLength int) uint64 {
+	return 8 + VarIntSize(uint64(lockingScriptLength)) + uint64(lockingScriptLength)
+}
+
+// EstimateP2PKHTxSize will return the estimated size of a transaction spending P2PKH inputs to P2PKH outputs
+//
+// Signatures vary in size, the estimate uses the largest low-S signature (72 bytes with the sighash flag)
+// so the fee is never less than what the miner measures
+func EstimateP2PKHTxSize(inputs, outputs int) uint64 {
+	return TxOverheadSize + VarIntSize(uint64(inputs)) + uint64(inputs)*P2PKHInputSize +
+		VarIntSize(uint64(outputs)) + uint64(outputs)*P2PKHOutputSize
+}
@@ -0,0 +1,71 @@
+package minercraft
+
+import (
+	"testing"
+
+	"github.com/bitcoinschema/go-bitcoin"
+	"github.com/libsv/libsv/utils"
+)
+
+// TestVarIntSize tests the method VarIntSize()
+func TestVarIntSize(t *testing.T) {
+	t.Parallel()
+
+	for _, n := range []uint64{0, 1, 0xfc, 0xfd, 0xffff, 0x10000, 0xffffffff, 0x100000000} {
+		if output := VarIntSize(n); output != uint64(len(utils.VarInt(n))) {
+			t.Errorf("%s Failed: [%d] inputted and [%d] expected, received: [%d]", t.Name(), n, len(utils.VarInt(n)), output)
+		}
+	}
+}
+
+// TestInputOutputSize tests the methods InputSize() and OutputSize()
+func TestInputOutputSize(t *testing.T) {
+	t.Parallel()
+
+	if size := InputSize(P2PKHUnlockingScriptSize); size != P2PKHInputSize {
+		t.Fatalf("expected value: %d got: %d", P2PKHInputSize, size)
+	} else if size = OutputSize(P2PKHLockingScriptSize); size != P2PKHOutputSize {
+		t.Fatalf("expected value: %d got: %d", P2PKHOutputSize, size)
+	} else if size = OutputSize(300); size != 8+3+300 {
+		t.Fatalf("expected value: %d got: %d", 8+3+300, size)
+	}
+}
+
+// TestEstimateP2PKHTxSize tests the method EstimateP2PKHTxSize() against signed transactions
+func TestEstimateP2PKHTxSize(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := bitcoin.PrivateKeyFromString(testMinerIDKeyOld)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var address string
+	if address, err = bitcoin.GetAddressFromPrivateKey(testMinerIDKeyOld); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	var script string
+	if script, err = bitcoin.ScriptFromAddress(address); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	for _, shape := range [][2]int{{1, 1}, {1, 2}, {3, 2}} {
+		var utxos []*bitcoin.Utxo
+		for index := 0; index < shape[0]; index++ {
+			utxos = append(utxos, &bitcoin.Utxo{Satoshis: 10000, ScriptSig: script, TxID: testTx, Vout: uint32(index)})
+		}
+		var payTo []*bitcoin.PayToAddress
+		for index := 0; index < shape[1]; index++ {
+			payTo = append(payTo, &bitcoin.PayToAddress{Address: address, Satoshis: 1000})
+		}
+		tx, txErr := bitcoin.CreateTx(utxos, payTo, nil, privateKey)
+		if txErr != nil {
+			t.Fatalf("error occurred: %s", txErr.Error())
+		}
+
+		// The estimate is never less than the signed size (at most 1 byte per input more)
+		actual := uint64(len(tx.ToBytes()))
+		if estimate := EstimateP2PKHTxSize(shape[0], shape[1]); estimate < actual || estimate-actual > uint64(shape[0]) {
+			t.Errorf("%s Failed: [%v] inputted and [%d] expected, received: [%d]", t.Name(), shape, actual, estimate)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrTxTooLarge is returned when a raw transaction exceeds the max tx size (see: ClientOptions.MaxTxSize)
+var ErrTxTooLarge = errors.New("transaction exceeds the max tx size")
+
+// MaxTxSize will return the max raw tx size (bytes) for the miner (the miner's MaxTxSize overrides the client option)
+//
+// Zero means there is no limit
+func (c *Client) MaxTxSize(miner *Miner) int64 {
+	if miner != nil && miner.MaxTxSize > 0 {
+		return miner.MaxTxSize
+	} else if c.Options.MaxTxSize > 0 {
+		return c.Options.MaxTxSize
+	}
+	return 0
+}
+
+// checkTxSize will return an error if the raw tx size (bytes) exceeds the max tx size for the miner
+func (c *Client) checkTxSize(miner *Miner, txBytes int64) error {
+	if maxSize := c.MaxTxSize(miner); maxSize > 0 && txBytes > maxSize {
+		return fmt.Errorf("%w: %d bytes (max: %d bytes)", ErrTxTooLarge, txBytes, maxSize)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package minercraft
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestClient_MaxTxSize tests the method MaxTxSize()
+func TestClient_MaxTxSize(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidSubmission{})
+
+	var tests = []struct {
+		inputMiner    *Miner
+		inputOption   int64
+		expectedLimit int64
+	}{
+		{&Miner{}, DefaultMaxTxSize, DefaultMaxTxSize},
+		{nil, DefaultMaxTxSize, DefaultMaxTxSize},
+		{&Miner{MaxTxSize: 100}, DefaultMaxTxSize, 100},
+		{&Miner{MaxTxSize: 100}, 0, 100},
+		{&Miner{}, 0, 0},
+	}
+
+	for _, test := range tests {
+		client.Options.MaxTxSize = test.inputOption
+		if limit := client.MaxTxSize(test.inputMiner); limit != test.expectedLimit {
+			t.Errorf("%s Failed: [%v] [%d] inputted and [%d] expected, but got: %d", t.Name(), test.inputMiner, test.inputOption, test.expectedLimit, limit)
+		}
+	}
+}
+
+// TestClient_SubmitTransactionTooLarge tests the max tx size guard in SubmitTransaction() and SubmitTransactionReader()
+func TestClient_SubmitTransactionTooLarge(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCountingQuote{}
+	client := newTestClient(mock)
+	miner := client.MinerByName(MinerTaal)
+	miner.MaxTxSize = 10
+
+	// Too large (11 bytes)
+	if _, err := client.SubmitTransaction(miner, &Transaction{RawTx: strings.Repeat("00", 11)}); !errors.Is(err, ErrTxTooLarge) {
+		t.Fatalf("expected [%v] but got: %v", ErrTxTooLarge, err)
+	}
+	if _, err := client.SubmitTransactionReader(miner, bytes.NewReader(make([]byte, 11)), 11, nil); !errors.Is(err, ErrTxTooLarge) {
+		t.Fatalf("expected [%v] but got: %v", ErrTxTooLarge, err)
+	}
+
+	// No requests were made
+	if mock.count() != 0 {
+		t.Fatalf("expected %d requests, got %d", 0, mock.count())
+	}
+
+	// Within the limit
+	client = newTestClient(&mockHTTPValidSubmission{})
+	client.MinerByName(MinerTaal).MaxTxSize = int64(len(testRawTx) / 2)
+	if _, err := client.SubmitTransaction(client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx}); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+}
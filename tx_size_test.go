@@ -0,0 +1,110 @@
+package minercraft
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/libsv/libsv/transaction"
+	"github.com/libsv/libsv/transaction/output"
+)
+
+// testDataTx will return a tx with one P2PKH output and one OP_RETURN output
+func testDataTx(t testing.TB, data []byte) *transaction.Transaction {
+	tx := transaction.New()
+	if err := tx.From(testTx, 0, "76a914eb0bd5edba389198e73f8efabddfc61666969ff788ac", 1000); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+
+	out, err := output.NewP2PkhFromPubKeyHash("eb0bd5edba389198e73f8efabddfc61666969ff7", 500)
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	tx.AddOutput(out)
+
+	if len(data) > 0 {
+		if out, err = output.NewOpReturn(data); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		tx.AddOutput(out)
+	}
+	return tx
+}
+
+// TestTxSizeFromTx tests the method TxSizeFromTx()
+func TestTxSizeFromTx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("standard tx", func(t *testing.T) {
+		tx := testDataTx(t, nil)
+		txSize, err := TxSizeFromTx(tx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if txSize.DataBytes != 0 {
+			t.Fatalf("expected %d data bytes, got %d", 0, txSize.DataBytes)
+		} else if txSize.TotalBytes() != uint64(len(tx.ToBytes())) {
+			t.Fatalf("expected %d total bytes, got %d", len(tx.ToBytes()), txSize.TotalBytes())
+		}
+	})
+
+	t.Run("data tx", func(t *testing.T) {
+		tx := testDataTx(t, make([]byte, 100))
+		txSize, err := TxSizeFromTx(tx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+
+		// 8 (satoshis) + 1 (script length) + 1 (OP_FALSE) + 1 (OP_RETURN) + 2 (OP_PUSHDATA1) + 100 (data)
+		if txSize.DataBytes != 113 {
+			t.Fatalf("expected %d data bytes, got %d", 113, txSize.DataBytes)
+		} else if txSize.TotalBytes() != uint64(len(tx.ToBytes())) {
+			t.Fatalf("expected %d total bytes, got %d", len(tx.ToBytes()), txSize.TotalBytes())
+		}
+	})
+
+	t.Run("nil tx", func(t *testing.T) {
+		if _, err := TxSizeFromTx(nil); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+}
+
+// TestTxSizeFromHex tests the method TxSizeFromHex()
+func TestTxSizeFromHex(t *testing.T) {
+	t.Parallel()
+
+	tx := testDataTx(t, []byte("test data"))
+	txSize, err := TxSizeFromHex(tx.ToString())
+	if err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	} else if txSize.DataBytes == 0 {
+		t.Fatalf("expected data bytes to be found")
+	}
+
+	if _, err = TxSizeFromHex("invalid-hex"); err == nil {
+		t.Fatalf("error should have occurred")
+	}
+}
+
+// ExampleTxSizeFromTx example using TxSizeFromTx()
+func ExampleTxSizeFromTx() {
+	tx := transaction.New()
+	out, _ := output.NewOpReturn([]byte("hello world"))
+	tx.AddOutput(out)
+
+	txSize, err := TxSizeFromTx(tx)
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("tx has %d data bytes and %d standard bytes", txSize.DataBytes, txSize.StandardBytes)
+	// Output:tx has 23 data bytes and 10 standard bytes
+}
+
+// BenchmarkTxSizeFromTx benchmarks the method TxSizeFromTx()
+func BenchmarkTxSizeFromTx(b *testing.B) {
+	tx := testDataTx(b, make([]byte, 100))
+	for i := 0; i < b.N; i++ {
+		_, _ = TxSizeFromTx(tx)
+	}
+}
@@ -0,0 +1,258 @@
+package minercraft
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TxStatus is the status of a transaction reported by a miner (see: SubscribeTxStatus)
+type TxStatus string
+
+const (
+
+	// TxStatusMempool is the status of a transaction that the miner accepted but is not yet in a block
+	TxStatusMempool TxStatus = "mempool"
+
+	// TxStatusMined is the status of a transaction in a block
+	TxStatusMined TxStatus = "mined"
+
+	// TxStatusRejected is the status of a transaction that the miner rejected (IE: a double spend)
+	TxStatusRejected TxStatus = "rejected"
+
+	// TxStatusUnknown is the status of a transaction that the miner does not know (yet)
+	TxStatusUnknown TxStatus = "unknown"
+)
+
+// Sources of the transaction status events
+const (
+	TxStatusSourcePoll = "poll" // Queried from the miner (see: QueryTransaction)
+	TxStatusSourcePush = "push" // Pushed by the status stream of the miner (see: Miner.StatusURL)
+)
+
+// statusURLTxID is replaced with the txid in the status url of a miner
+const statusURLTxID = "{txid}"
+
+/*
+Example status event from a status stream (server-sent events, ARC format):
+
+event: status
+data: {"txid":"6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0","txStatus":"MINED","blockHash":"0000000000000000050a09fe90b0e8542bba9e712edb8cc9349e61888fe45ac5","blockHeight":612530}
+*/
+
+// TxStatusEvent is a status of a transaction from a miner (see: SubscribeTxStatus)
+type TxStatusEvent struct {
+	BlockHash     string    `json:"block_hash,omitempty"`
+	BlockHeight   int64     `json:"block_height,omitempty"`
+	Confirmations int64     `json:"confirmations,omitempty"` // Only reported by polling
+	Error         string    `json:"error,omitempty"`         // A failed poll (the status is the last known status)
+	Miner         *Miner    `json:"miner"`
+	Source        string    `json:"source"` // TxStatusSourcePush or TxStatusSourcePoll
+	Status        TxStatus  `json:"status"`
+	Time          time.Time `json:"time"`
+	TxID          string    `json:"txid"`
+}
+
+// IsFinal will return true if the status will not change (mined or rejected)
+func (e *TxStatusEvent) IsFinal() bool {
+	return e.Status == TxStatusMined || e.Status == TxStatusRejected
+}
+
+// statusStreamPayload is the data of a status event from a status stream
+type statusStreamPayload struct {
+	BlockHash   string `json:"blockHash"`
+	BlockHeight int64  `json:"blockHeight"`
+	TxID        string `json:"txid"`
+	TxStatus    string `json:"txStatus"`
+}
+
+// SubscribeTxStatus will return a channel that receives the status events of the transaction, the channel
+// is closed when the status is final (mined or rejected) or the context is done
+//
+// If the miner has a status stream (see: Miner.StatusURL) the statuses are pushed by the miner, otherwise
+// (or if the stream fails) the status is polled using QueryTransaction (see: ClientOptions.StatusPollInterval).
+// An event is sent each time the status changes (and for each failed poll)
+func (c *Client) SubscribeTxStatus(ctx context.Context, miner *Miner, txID string) (<-chan *TxStatusEvent, error) {
+
+	// Make sure we have a valid miner and txid
+	if miner == nil {
+		return nil, errors.New("miner was nil")
+	} else if !IsValidTxID(txID) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTxID, txID)
+	} else if err := c.checkNetwork(miner); err != nil {
+		return nil, err
+	}
+
+	events := make(chan *TxStatusEvent, 1)
+	go func() {
+		defer close(events)
+		last := TxStatusUnknown
+
+		// Push (falls back to polling if the stream fails or ends before the final status)
+		if len(miner.StatusURL) > 0 {
+			var final bool
+			if last, final = c.streamTxStatus(ctx, miner, txID, events); final {
+				return
+			}
+		}
+		c.pollTxStatus(ctx, miner, txID, last, events)
+	}()
+	return events, nil
+}
+
+// streamTxStatus will send the status events of the status stream of the miner, returning the last status
+// and true if the status is final
+func (c *Client) streamTxStatus(ctx context.Context, miner *Miner, txID string,
+	events chan<- *TxStatusEvent) (TxStatus, bool) {
+
+	// Connect to the stream
+	last := TxStatusUnknown
+	request, err := http.NewRequestWithContext(
+		contextWithMiner(ctx, miner), http.MethodGet, strings.ReplaceAll(miner.StatusURL, statusURLTxID, txID), nil,
+	)
+	if err != nil {
+		return last, false
+	}
+	request.Header.Set("Accept", "text/event-stream")
+	request.Header.Set("User-Agent", c.Options.UserAgent)
+	if len(miner.Token) > 0 {
+		request.Header.Set("Authorization", "Bearer "+miner.Token)
+	}
+	var response *http.Response
+	if response, err = c.streamClient.Do(request); err != nil {
+		return last, false
+	} else if response.Body == nil {
+		return last, false
+	}
+	defer func() {
+		_ = response.Body.Close()
+	}()
+	if response.StatusCode != http.StatusOK {
+		return last, false
+	}
+
+	// Read the events (the data lines of an event are joined, an empty line ends the event)
+	var data []string
+	scanner := bufio.NewScanner(response.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "data:") {
+			data = append(data, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+			continue
+		} else if len(line) > 0 || len(data) == 0 {
+			continue
+		}
+		payload := new(statusStreamPayload)
+		err = json.Unmarshal([]byte(strings.Join(data, "\n")), payload)
+		if data = nil; err != nil || !strings.EqualFold(payload.TxID, txID) {
+			continue
+		}
+		status := parseStreamTxStatus(payload.TxStatus)
+		if status == last {
+			continue
+		}
+		last = status
+		event := &TxStatusEvent{
+			BlockHash: payload.BlockHash, BlockHeight: payload.BlockHeight, Miner: miner,
+			Source: TxStatusSourcePush, Status: status, Time: c.now().UTC(), TxID: txID,
+		}
+		if !sendTxStatus(ctx, events, event) || event.IsFinal() {
+			return last, true
+		}
+	}
+	return last, ctx.Err() != nil
+}
+
+// pollTxStatus will query the status of the transaction at the poll interval until the status is final
+func (c *Client) pollTxStatus(ctx context.Context, miner *Miner, txID string, last TxStatus,
+	events chan<- *TxStatusEvent) {
+
+	interval := c.Options.StatusPollInterval
+	if interval <= 0 {
+		interval = DefaultClientOptions().StatusPollInterval
+	}
+	ticker := c.clock().NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+
+		// Query the status (failed polls are sent with the last known status)
+		event := &TxStatusEvent{Miner: miner, Source: TxStatusSourcePoll, Status: last, TxID: txID}
+		if response, err := c.queryTransactionStatus(ctx, miner, txID); err != nil {
+			event.Error = err.Error()
+		} else {
+			event.BlockHash = response.Query.BlockHash
+			event.BlockHeight = response.Query.BlockHeight
+			event.Confirmations = response.Confirmations()
+			event.Status = queryTxStatus(response)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		// Send the event (if the status changed)
+		if len(event.Error) > 0 || event.Status != last {
+			last = event.Status
+			event.Time = c.now().UTC()
+			if !sendTxStatus(ctx, events, event) || event.IsFinal() {
+				return
+			}
+		}
+
+		// Wait for the next poll (a long-poll query already waited for a change)
+		if len(event.Error) == 0 && c.queryWait(miner) > 0 {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// sendTxStatus will send the event, returning false if the context is done
+func sendTxStatus(ctx context.Context, events chan<- *TxStatusEvent, event *TxStatusEvent) bool {
+	select {
+	case events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// queryTxStatus will return the status of the query response
+func queryTxStatus(response *QueryTransactionResponse) TxStatus {
+	if response.IsMined() {
+		return TxStatusMined
+	} else if response.IsInMempool() {
+		return TxStatusMempool
+	}
+	return TxStatusUnknown
+}
+
+// parseStreamTxStatus will return the status of a status stream event (ARC statuses)
+func parseStreamTxStatus(status string) TxStatus {
+	switch strings.ToUpper(status) {
+	case "MINED", "CONFIRMED":
+		return TxStatusMined
+	case "REJECTED", "DOUBLE_SPEND_ATTEMPTED":
+		return TxStatusRejected
+	case "STORED", "ANNOUNCED_TO_NETWORK", "REQUESTED_BY_NETWORK", "SENT_TO_NETWORK",
+		"ACCEPTED_BY_NETWORK", "SEEN_ON_NETWORK", "SEEN_IN_ORPHAN_MEMPOOL":
+		return TxStatusMempool
+	}
+	return TxStatusUnknown
+}
+
+// validStatusURL will return true if the status url is an absolute http(s) url
+func validStatusURL(statusURL string) bool {
+	parsed, err := url.Parse(strings.ReplaceAll(statusURL, statusURLTxID, "txid"))
+	return err == nil && (parsed.Scheme == SchemeHTTP || parsed.Scheme == SchemeHTTPS) && len(parsed.Host) > 0
+}
@@ -0,0 +1,192 @@
+package minercraft
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testStatusURL = "https://arc.example.com/v1/tx/{txid}/events"
+
+// mockHTTPStatusStream for mocking requests (a status stream of the testTx)
+type mockHTTPStatusStream struct{}
+
+// Do is a mock http request
+func (m *mockHTTPStatusStream) Do(req *http.Request) (*http.Response, error) {
+	resp := new(http.Response)
+	resp.StatusCode = http.StatusBadRequest
+
+	// No req found
+	if req == nil {
+		return resp, fmt.Errorf("missing request")
+	}
+
+	// Valid stream (an event of another tx, a duplicate status and a multi-line event)
+	if req.URL.String() == strings.ReplaceAll(testStatusURL, statusURLTxID, testTx) &&
+		req.Header.Get("Accept") == "text/event-stream" {
+		resp.StatusCode = http.StatusOK
+		resp.Body = ioutil.NopCloser(bytes.NewBufferString(`: keep-alive
+
+event: status
+data: {"txid":"` + testTx + `","txStatus":"SEEN_ON_NETWORK"}
+
+data: {"txid":"6bdbcfab0526d30e8d68279f79dff61fb4026ace8b7b32789af016336e54f2f0","txStatus":"MINED"}
+
+data: {"txid":"` + testTx + `","txStatus":"ACCEPTED_BY_NETWORK"}
+
+data: {"txid":"` + testTx + `",
+data: "txStatus":"MINED","blockHash":"0000000000000000050a09fe90b0e8542bba9e712edb8cc9349e61888fe45ac5","blockHeight":612530}
+
+`))
+	}
+
+	// Default is valid
+	return resp, nil
+}
+
+// collectTxStatus will return the events of the subscription (until the channel is closed)
+func collectTxStatus(t *testing.T, events <-chan *TxStatusEvent) []*TxStatusEvent {
+	var received []*TxStatusEvent
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				return received
+			}
+			received = append(received, event)
+		case <-timeout:
+			t.Fatalf("subscription was not closed, received: %d events", len(received))
+		}
+	}
+}
+
+// TestClient_SubscribeTxStatus tests the method SubscribeTxStatus()
+func TestClient_SubscribeTxStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pushed by the status stream", func(t *testing.T) {
+		client := newTestClient(&mockHTTPStatusStream{})
+		miner := client.MinerByName(MinerTaal)
+		miner.StatusURL = testStatusURL
+
+		events, err := client.SubscribeTxStatus(context.Background(), miner, testTx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		received := collectTxStatus(t, events)
+		if len(received) != 2 {
+			t.Fatalf("expected 2 events, got: %d", len(received))
+		} else if received[0].Status != TxStatusMempool || received[0].Source != TxStatusSourcePush {
+			t.Fatalf("expected a pushed mempool status, got: %s %s", received[0].Source, received[0].Status)
+		} else if received[1].Status != TxStatusMined || !received[1].IsFinal() || received[1].BlockHeight != 612530 {
+			t.Fatalf("expected a mined status at 612530, got: %s %d", received[1].Status, received[1].BlockHeight)
+		} else if received[1].Miner != miner || received[1].TxID != testTx || received[1].Time.IsZero() {
+			t.Fatalf("expected the miner, txid and time of the event")
+		}
+	})
+
+	t.Run("polled without a status stream", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+
+		events, err := client.SubscribeTxStatus(context.Background(), client.MinerByName(MinerTaal), testTx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		received := collectTxStatus(t, events)
+		if len(received) != 1 {
+			t.Fatalf("expected 1 event, got: %d", len(received))
+		} else if received[0].Status != TxStatusMined || received[0].Source != TxStatusSourcePoll {
+			t.Fatalf("expected a polled mined status, got: %s %s", received[0].Source, received[0].Status)
+		} else if received[0].Confirmations != 43733 {
+			t.Fatalf("expected 43733 confirmations, got: %d", received[0].Confirmations)
+		}
+	})
+
+	t.Run("falls back to polling if the stream fails", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		miner := client.MinerByName(MinerTaal)
+		miner.StatusURL = testStatusURL
+
+		events, err := client.SubscribeTxStatus(context.Background(), miner, testTx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		received := collectTxStatus(t, events)
+		if len(received) != 1 || received[0].Source != TxStatusSourcePoll || received[0].Status != TxStatusMined {
+			t.Fatalf("expected a polled mined status, got: %d events", len(received))
+		}
+	})
+
+	t.Run("failed polls until the context is done", func(t *testing.T) {
+		client := newTestClient(&mockHTTPBadQuery{})
+		client.Options.StatusPollInterval = 5 * time.Millisecond
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		events, err := client.SubscribeTxStatus(ctx, client.MinerByName(MinerTaal), testTx)
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		received := collectTxStatus(t, events)
+		if len(received) == 0 {
+			t.Fatalf("expected failed poll events")
+		} else if len(received[0].Error) == 0 || received[0].Status != TxStatusUnknown {
+			t.Fatalf("expected an error with an unknown status, got: %s", received[0].Status)
+		}
+	})
+
+	t.Run("invalid miner or txid", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidQuery{})
+		if _, err := client.SubscribeTxStatus(context.Background(), nil, testTx); err == nil {
+			t.Fatalf("error should have occurred")
+		} else if _, err = client.SubscribeTxStatus(
+			context.Background(), client.MinerByName(MinerTaal), "invalid",
+		); !errors.Is(err, ErrInvalidTxID) {
+			t.Fatalf("expected ErrInvalidTxID, got: %v", err)
+		}
+	})
+}
+
+// TestParseStreamTxStatus tests the method parseStreamTxStatus()
+func TestParseStreamTxStatus(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected TxStatus
+	}{
+		{"MINED", TxStatusMined},
+		{"seen_on_network", TxStatusMempool},
+		{"STORED", TxStatusMempool},
+		{"REJECTED", TxStatusRejected},
+		{"DOUBLE_SPEND_ATTEMPTED", TxStatusRejected},
+		{"QUEUED", TxStatusUnknown},
+		{"", TxStatusUnknown},
+	}
+	for _, test := range tests {
+		if output := parseStreamTxStatus(test.input); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%s] expected, received: [%s]", t.Name(), test.input, test.expected, output)
+		}
+	}
+}
+
+// TestValidateMiner_StatusURL tests the method ValidateMiner() with a status url
+func TestValidateMiner_StatusURL(t *testing.T) {
+	t.Parallel()
+
+	miner := &Miner{Name: MinerTaal, URL: "merchantapi.taal.com", StatusURL: testStatusURL}
+	if err := ValidateMiner(miner); err != nil {
+		t.Fatalf("error occurred: %s", err.Error())
+	}
+	miner.StatusURL = "arc.example.com/events"
+	if err := ValidateMiner(miner); !errors.Is(err, ErrInvalidStatusURL) {
+		t.Fatalf("expected ErrInvalidStatusURL, got: %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package minercraft
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/libsv/libsv/transaction"
+)
+
+// ErrInvalidRawTx is returned when a raw transaction hex does not decode into a valid transaction
+var ErrInvalidRawTx = errors.New("invalid raw transaction")
+
+// ErrInvalidTxID is returned when a transaction id is not 64 hex characters
+var ErrInvalidTxID = errors.New("invalid transaction id")
+
+// IsValidTxID will return true if the transaction id is 64 hex characters (upper or lower case)
+func IsValidTxID(txID string) bool {
+	if len(txID) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(txID)
+	return err == nil
+}
+
+// ValidateRawTx will check that the raw transaction hex decodes into a structurally valid transaction
+//
+// This catches copy/paste errors (odd length, non-hex or truncated data) locally,
+// it does not check scripts, signatures or the inputs being spent
+func ValidateRawTx(rawTx string) error {
+
+	// Make sure we have valid hex
+	if len(rawTx) == 0 {
+		return fmt.Errorf("%w: missing raw tx", ErrInvalidRawTx)
+	}
+	txBytes, err := hex.DecodeString(rawTx)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRawTx, err.Error())
+	}
+
+	// Decode the transaction
+	var tx *transaction.Transaction
+	if tx, err = decodeTx(txBytes); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidRawTx, err.Error())
+	}
+
+	// Make sure the transaction has inputs and outputs
+	if len(tx.Inputs) == 0 {
+		return fmt.Errorf("%w: no inputs found", ErrInvalidRawTx)
+	} else if len(tx.Outputs) == 0 {
+		return fmt.Errorf("%w: no outputs found", ErrInvalidRawTx)
+	}
+
+	return nil
+}
+
+// decodeTx will decode the transaction bytes
+//
+// The libsv decoder can panic on truncated inputs/outputs, the panic is returned as an error
+func decodeTx(txBytes []byte) (tx *transaction.Transaction, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			tx, err = nil, errors.New("truncated transaction data")
+		}
+	}()
+	return transaction.NewFromBytes(txBytes)
+}
@@ -0,0 +1,125 @@
+package minercraft
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// testRawTx is a valid raw transaction (1 input, 1 output)
+const testRawTx = "01000000010000000000000000000000000000000000000000000000000000000000000000ffffffff1c03d7c6082f7376706f6f6c2e636f6d2f3edff034600055b8467f0040ffffffff01247e814a000000001976a914492558fb8ca71a3591316d095afc0f20ef7d42f788ac00000000"
+
+// TestValidateRawTx tests the method ValidateRawTx()
+func TestValidateRawTx(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input         string
+		expectedError bool
+	}{
+		{testRawTx, false},
+		{"", true},
+		{"0", true},
+		{testRawTx + "0", true},
+		{"zz" + testRawTx[2:], true},
+		{testRawTx[:len(testRawTx)-20], true},
+		{testRawTx[:100], true},
+		{testRawTx + "00", true},
+		{strings.Repeat("00", 10), true},
+		{strings.Repeat("00", 9), true},
+	}
+
+	for _, test := range tests {
+		if err := ValidateRawTx(test.input); err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%s] inputted and error was expected", t.Name(), test.input)
+		} else if err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%s] inputted and error not expected but got: %s", t.Name(), test.input, err.Error())
+		} else if err != nil && !errors.Is(err, ErrInvalidRawTx) {
+			t.Errorf("%s Failed: [%s] inputted and [%v] expected but got: %v", t.Name(), test.input, ErrInvalidRawTx, err)
+		}
+	}
+}
+
+// TestIsValidTxID tests the method IsValidTxID()
+func TestIsValidTxID(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		input    string
+		expected bool
+	}{
+		{testTx, true},
+		{strings.ToUpper(testTx), true},
+		{"", false},
+		{testTx[:63], false},
+		{testTx + "0", false},
+		{"zz" + testTx[2:], false},
+		{" " + testTx[1:], false},
+	}
+
+	for _, test := range tests {
+		if output := IsValidTxID(test.input); output != test.expected {
+			t.Errorf("%s Failed: [%s] inputted and [%v] expected, received: [%v]", t.Name(), test.input, test.expected, output)
+		}
+	}
+}
+
+// TestClient_QueryTransactionInvalidTxID tests the txid validation in QueryTransaction()
+func TestClient_QueryTransactionInvalidTxID(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCountingQuote{}
+	client := newTestClient(mock)
+	if _, err := client.QueryTransaction(client.MinerByName(MinerTaal), "not-a-txid"); !errors.Is(err, ErrInvalidTxID) {
+		t.Fatalf("expected [%v] but got: %v", ErrInvalidTxID, err)
+	}
+
+	// No requests were made
+	if mock.count() != 0 {
+		t.Fatalf("expected %d requests, got %d", 0, mock.count())
+	}
+}
+
+// TestClient_SubmitTransactionInvalidRawTx tests the raw tx validation in SubmitTransaction()
+func TestClient_SubmitTransactionInvalidRawTx(t *testing.T) {
+	t.Parallel()
+
+	mock := &mockHTTPCountingQuote{}
+	client := newTestClient(mock)
+	if _, err := client.SubmitTransaction(client.MinerByName(MinerTaal), &Transaction{RawTx: testRawTx[:100]}); !errors.Is(err, ErrInvalidRawTx) {
+		t.Fatalf("expected [%v] but got: %v", ErrInvalidRawTx, err)
+	}
+
+	// No requests were made
+	if mock.count() != 0 {
+		t.Fatalf("expected %d requests, got %d", 0, mock.count())
+	}
+}
+
+// ExampleValidateRawTx example using ValidateRawTx()
+func ExampleValidateRawTx() {
+	err := ValidateRawTx("0100")
+	fmt.Printf("error: %s", err.Error())
+	// Output:error: invalid raw transaction: too short to be a tx - even an empty tx has 10 bytes
+}
+
+// ExampleIsValidTxID example using IsValidTxID()
+func ExampleIsValidTxID() {
+	fmt.Printf("valid: %v", IsValidTxID(testTx))
+	// Output:valid: true
+}
+
+// BenchmarkIsValidTxID benchmarks the method IsValidTxID()
+func BenchmarkIsValidTxID(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = IsValidTxID(testTx)
+	}
+}
+
+// BenchmarkValidateRawTx benchmarks the method ValidateRawTx()
+func BenchmarkValidateRawTx(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ValidateRawTx(testRawTx)
+	}
+}
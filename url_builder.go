@@ -0,0 +1,27 @@
+package minercraft
+
+import (
+	"net/url"
+	"strings"
+)
+
+// buildURL will return the endpoint url for the miner and route
+//
+// Respects the miner's scheme, port and path prefix (IE: "www.ddpurse.com/openapi"), normalizes any
+// trailing slashes and escapes each path parameter (IE: tx ids) before appending it to the route
+func buildURL(miner *Miner, route string, params ...string) (string, error) {
+
+	// Parse the miner url (scheme, host:port and path prefix)
+	u, err := parseMinerURL(miner)
+	if err != nil {
+		return "", err
+	}
+
+	// Build the path (prefix + route + params)
+	path := strings.TrimRight(u.EscapedPath(), "/") + "/" + strings.Trim(route, "/")
+	for _, param := range params {
+		path += "/" + url.PathEscape(param)
+	}
+
+	return u.Scheme + "://" + u.Host + path, nil
+}
@@ -0,0 +1,59 @@
+package minercraft
+
+import (
+	"errors"
+	"testing"
+)
+
+// TestBuildURL tests the method buildURL()
+func TestBuildURL(t *testing.T) {
+	t.Parallel()
+
+	var tests = []struct {
+		inputMiner    *Miner
+		inputRoute    string
+		inputParams   []string
+		expectedURL   string
+		expectedError bool
+	}{
+		{&Miner{URL: "merchantapi.taal.com"}, routeFeeQuote, nil, "https://merchantapi.taal.com/mapi/feeQuote", false},
+		{&Miner{URL: "www.ddpurse.com/openapi"}, routeFeeQuote, nil, "https://www.ddpurse.com/openapi/mapi/feeQuote", false},
+		{&Miner{URL: "www.ddpurse.com/openapi/"}, routeSubmitTx, nil, "https://www.ddpurse.com/openapi/mapi/tx", false},
+		{&Miner{URL: "localhost:9004", Scheme: SchemeHTTP}, routeFeeQuote, nil, "http://localhost:9004/mapi/feeQuote", false},
+		{&Miner{URL: "http://localhost:9004/api/"}, routeFeeQuote, nil, "http://localhost:9004/api/mapi/feeQuote", false},
+		{&Miner{URL: "HTTPS://localhost:9004", Scheme: "HTTP"}, routeFeeQuote, nil, "http://localhost:9004/mapi/feeQuote", false},
+		{&Miner{URL: "merchantapi.taal.com"}, routeQueryTx, []string{testTx}, "https://merchantapi.taal.com/mapi/tx/" + testTx, false},
+		{&Miner{URL: "merchantapi.taal.com"}, routeQueryTx, []string{"../a b?c"}, "https://merchantapi.taal.com/mapi/tx/..%2Fa%20b%3Fc", false},
+		{&Miner{URL: "merchantapi.taal.com", Scheme: "ftp"}, routeFeeQuote, nil, "", true},
+		{&Miner{URL: "https://"}, routeFeeQuote, nil, "", true},
+	}
+
+	for _, test := range tests {
+		if output, err := buildURL(test.inputMiner, test.inputRoute, test.inputParams...); err != nil && !test.expectedError {
+			t.Errorf("%s Failed: [%v] inputted and error not expected but got: %s", t.Name(), test.inputMiner, err.Error())
+		} else if err == nil && test.expectedError {
+			t.Errorf("%s Failed: [%v] inputted and error was expected", t.Name(), test.inputMiner)
+		} else if output != test.expectedURL {
+			t.Errorf("%s Failed: [%v] inputted and [%s] expected but got: %s", t.Name(), test.inputMiner, test.expectedURL, output)
+		}
+	}
+}
+
+// TestClient_FeeQuote_InvalidScheme tests the method FeeQuote() with an unsupported miner scheme
+func TestClient_FeeQuote_InvalidScheme(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidFeeQuote{})
+	_, err := client.FeeQuote(&Miner{Name: "Test", URL: "testminer.com", Scheme: "ftp"})
+	if !errors.Is(err, ErrUnsupportedScheme) {
+		t.Fatalf("expected [%v] but got: %v", ErrUnsupportedScheme, err)
+	}
+}
+
+// BenchmarkBuildURL benchmarks the method buildURL()
+func BenchmarkBuildURL(b *testing.B) {
+	miner := &Miner{URL: "www.ddpurse.com/openapi"}
+	for i := 0; i < b.N; i++ {
+		_, _ = buildURL(miner, routeQueryTx, testTx)
+	}
+}
@@ -0,0 +1,100 @@
+package minercraft
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Operation is the operation of a request to a miner (see: UsageMetrics)
+type Operation string
+
+// Operations of the requests to miners
+const (
+	OperationCustom      Operation = "custom" // Requests to custom endpoints (see: Client.Do)
+	OperationFeeQuote    Operation = "feeQuote"
+	OperationPolicyQuote Operation = "policyQuote"
+	OperationQueryTx     Operation = "queryTx"
+	OperationSubmitTx    Operation = "submitTx"
+)
+
+// UsageStats are the requests sent to a miner for an operation (see: UsageMetrics)
+//
+// The hour and day are calendar windows in UTC (the windows of the request budgets, see: Miner.HourlyBudget)
+type UsageStats struct {
+	Day         int64     `json:"day"`           // Requests in the current day
+	DayResetAt  time.Time `json:"day_reset_at"`  // When the day count resets
+	Hour        int64     `json:"hour"`          // Requests in the current hour
+	HourResetAt time.Time `json:"hour_reset_at"` // When the hour count resets
+	Miner       string    `json:"miner"`
+	Operation   Operation `json:"operation"`
+	Total       int64     `json:"total"` // Requests since the client was created
+	day         time.Time
+	hour        time.Time
+}
+
+// usageTracker is the usage of each miner and operation
+type usageTracker struct {
+	sync.Mutex
+	stats map[string]*UsageStats
+}
+
+// newUsageTracker will return a new tracker without usage
+func newUsageTracker() *usageTracker {
+	return &usageTracker{stats: make(map[string]*UsageStats)}
+}
+
+// UsageMetrics will return the requests sent to each miner by operation (sorted by miner name and operation),
+// so usage on metered miner plans can be reconciled
+//
+// A request is counted once it is sent (cached quotes and refused requests are not counted, retries of the
+// http client are counted as one request)
+func (c *Client) UsageMetrics() []*UsageStats {
+	c.usage.Lock()
+	defer c.usage.Unlock()
+	hour, day := usageWindows(c.now())
+	metrics := make([]*UsageStats, 0, len(c.usage.stats))
+	for _, stats := range c.usage.stats {
+		copied := *stats
+		copied.rollover(hour, day)
+		copied.DayResetAt, copied.HourResetAt = copied.day.AddDate(0, 0, 1), copied.hour.Add(time.Hour)
+		metrics = append(metrics, &copied)
+	}
+	sort.Slice(metrics, func(i, j int) bool {
+		if metrics[i].Miner != metrics[j].Miner {
+			return metrics[i].Miner < metrics[j].Miner
+		}
+		return metrics[i].Operation < metrics[j].Operation
+	})
+	return metrics
+}
+
+// request will count a request sent to the miner
+func (t *usageTracker) request(miner *Miner, operation Operation, now time.Time) {
+	if miner == nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	key := strings.ToLower(miner.Name) + ":" + string(operation)
+	stats, ok := t.stats[key]
+	if !ok {
+		stats = &UsageStats{Miner: miner.Name, Operation: operation}
+		t.stats[key] = stats
+	}
+	stats.rollover(usageWindows(now))
+	stats.Day++
+	stats.Hour++
+	stats.Total++
+}
+
+// rollover will reset the counts of the windows that ended
+func (s *UsageStats) rollover(hour, day time.Time) {
+	if !s.day.Equal(day) {
+		s.day, s.Day = day, 0
+	}
+	if !s.hour.Equal(hour) {
+		s.hour, s.Hour = hour, 0
+	}
+}
@@ -0,0 +1,47 @@
+package minercraft
+
+import (
+	"testing"
+	"time"
+)
+
+// TestClient_UsageMetrics tests the method UsageMetrics()
+func TestClient_UsageMetrics(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(&mockHTTPValidQuery{})
+	miner := client.MinerByName(MinerMatterpool)
+	for i := 0; i < 2; i++ {
+		if _, err := client.QueryTransaction(miner, testTx); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+	}
+
+	metrics := client.UsageMetrics()
+	if len(metrics) != 1 {
+		t.Fatalf("expected the usage of 1 operation, got: %d", len(metrics))
+	} else if stats := metrics[0]; stats.Miner != MinerMatterpool || stats.Operation != OperationQueryTx ||
+		stats.Total != 2 || stats.Hour != 2 || stats.Day != 2 {
+		t.Fatalf("expected 2 queries of %s, got: %+v", MinerMatterpool, stats)
+	} else if !stats.HourResetAt.After(time.Now()) || stats.DayResetAt.Before(stats.HourResetAt) {
+		t.Fatalf("expected the reset windows to be in the future, got: %s %s", stats.HourResetAt, stats.DayResetAt)
+	}
+}
+
+// TestUsageTracker_Request tests the method request()
+func TestUsageTracker_Request(t *testing.T) {
+	t.Parallel()
+
+	tracker := newUsageTracker()
+	miner := &Miner{Name: "Test"}
+	now := time.Date(2021, 1, 1, 23, 30, 0, 0, time.UTC)
+	tracker.request(miner, OperationSubmitTx, now)
+	tracker.request(miner, OperationSubmitTx, now)
+	tracker.request(nil, OperationSubmitTx, now)
+
+	// The hour and day counts reset in the next windows (the total is kept)
+	tracker.request(miner, OperationSubmitTx, now.Add(time.Hour))
+	if stats := tracker.stats["test:"+string(OperationSubmitTx)]; stats.Total != 3 || stats.Hour != 1 || stats.Day != 1 {
+		t.Fatalf("expected 3 requests with 1 in the new windows, got: %+v", stats)
+	}
+}
@@ -0,0 +1,54 @@
+package minercraft
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// WarmUpSummary is the readiness summary of all miners after warming up the client
+type WarmUpSummary struct {
+	Duration time.Duration  `json:"duration"`
+	Healthy  int            `json:"healthy"` // Number of healthy miners
+	Miners   []*MinerHealth `json:"miners"`  // Same order as the client's miners
+	Ready    bool           `json:"ready"`   // True if at least one miner is healthy
+}
+
+// WarmUp will concurrently pre-fetch quotes (cached if quote caching is enabled) and run health checks for all miners,
+// returning a readiness summary. Intended to be called before a service starts accepting payment traffic.
+//
+// An error is returned (along with the summary) if no miners are healthy
+func (c *Client) WarmUp(ctx context.Context) (*WarmUpSummary, error) {
+
+	// Start the summary
+	started := time.Now()
+	summary := &WarmUpSummary{Miners: make([]*MinerHealth, len(c.Miners))}
+
+	// Loop each miner (a health check for each miner, see: ClientOptions.MaxConcurrency)
+	group, groupCtx := c.fanOut(ctx)
+	for index, miner := range c.Miners {
+		index, miner := index, miner
+		group.Go(func() error {
+			summary.Miners[index] = c.HealthCheck(groupCtx, miner)
+			return nil
+		})
+	}
+
+	// Waiting for all requests to finish
+	_ = group.Wait()
+
+	// Count the healthy miners
+	for _, health := range summary.Miners {
+		if health.Healthy {
+			summary.Healthy++
+		}
+	}
+	summary.Ready = summary.Healthy > 0
+	summary.Duration = time.Since(started)
+
+	// Not ready
+	if !summary.Ready {
+		return summary, errors.New("no miners are healthy")
+	}
+	return summary, nil
+}
@@ -0,0 +1,107 @@
+package minercraft
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+// TestClient_WarmUp tests the method WarmUp()
+func TestClient_WarmUp(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all miners ready", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidBestQuote{})
+		summary, err := client.WarmUp(context.Background())
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if !summary.Ready {
+			t.Fatalf("expected client to be ready")
+		} else if summary.Healthy != len(client.Miners) {
+			t.Fatalf("expected %d healthy miners, got %d", len(client.Miners), summary.Healthy)
+		}
+
+		// Same order as the miners
+		for index, health := range summary.Miners {
+			if health.Miner != client.Miners[index] {
+				t.Fatalf("expected %s at index %d, got %s", client.Miners[index].Name, index, health.Miner.Name)
+			}
+		}
+	})
+
+	t.Run("nil miner", func(t *testing.T) {
+		client := newTestClient(&mockHTTPValidBestQuote{})
+		client.Miners = append(client.Miners, nil)
+		summary, err := client.WarmUp(context.Background())
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if summary.Healthy != len(client.Miners)-1 {
+			t.Fatalf("expected %d healthy miners, got %d", len(client.Miners)-1, summary.Healthy)
+		} else if last := summary.Miners[len(summary.Miners)-1]; last.Healthy {
+			t.Fatalf("expected the nil miner to be unhealthy")
+		}
+
+		// Eager validation reports the nil miner
+		if err = client.validateMiners(context.Background()); err == nil {
+			t.Fatalf("error should have occurred")
+		}
+	})
+
+	t.Run("partially ready", func(t *testing.T) {
+		client := newTestClient(&mockHTTPPartialQuotes{})
+		summary, err := client.WarmUp(context.Background())
+		if err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if summary.Healthy != 1 {
+			t.Fatalf("expected %d healthy miner, got %d", 1, summary.Healthy)
+		}
+	})
+
+	t.Run("not ready", func(t *testing.T) {
+		client := newTestClient(&mockHTTPError{})
+		summary, err := client.WarmUp(context.Background())
+		if err == nil {
+			t.Fatalf("error should have occurred")
+		} else if summary.Ready {
+			t.Fatalf("expected client to not be ready")
+		}
+	})
+
+	t.Run("pre-fetches cached quotes", func(t *testing.T) {
+		mock := &mockHTTPCountingQuote{expiresIn: 10 * time.Minute}
+		client := newTestCachingClient(mock)
+		if _, err := client.WarmUp(context.Background()); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		}
+		if _, err := client.BestQuote(FeeCategoryMining, FeeTypeData); err != nil {
+			t.Fatalf("error occurred: %s", err.Error())
+		} else if mock.count() != len(client.Miners) {
+			t.Fatalf("expected %d requests, got %d", len(client.Miners), mock.count())
+		}
+	})
+}
+
+// ExampleClient_WarmUp example using WarmUp()
+func ExampleClient_WarmUp() {
+	// Create a client (using a test client vs NewClient())
+	client := newTestClient(&mockHTTPPartialQuotes{})
+
+	// Warm up before accepting traffic
+	summary, err := client.WarmUp(context.Background())
+	if err != nil {
+		fmt.Printf("error occurred: %s", err.Error())
+		return
+	}
+
+	fmt.Printf("ready: %v with %d of %d healthy miners", summary.Ready, summary.Healthy, len(summary.Miners))
+	// Output:ready: true with 1 of 3 healthy miners
+}
+
+// BenchmarkClient_WarmUp benchmarks the method WarmUp()
+func BenchmarkClient_WarmUp(b *testing.B) {
+	client := newTestClient(&mockHTTPValidBestQuote{})
+	for i := 0; i < b.N; i++ {
+		_, _ = client.WarmUp(context.Background())
+	}
+}